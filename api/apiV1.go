@@ -28,13 +28,16 @@ func initGame(request *http.Request) (store.PlayerStore, engine.EngineConfig, en
 		logger.Errorf("InitGame Error EngineID: %s - %s", gameSlug+"-engine", err)
 		return store.PlayerStore{}, engine.EngineConfig{}, engine.Gamestate{}, rgse.Create(rgse.EngineNotFoundError)
 	}
-	engineConfig := engine.BuildEngineDefs(engineID)
+	engineConfig, err := engine.LoadEngineDefs(engineID)
+	if err != nil {
+		return store.PlayerStore{}, engine.EngineConfig{}, engine.Gamestate{}, err
+	}
 	authToken, err := processAuthorization(request)
 	if err != nil {
 		return store.PlayerStore{}, engine.EngineConfig{}, engine.Gamestate{}, err
 	}
 	wallet := chi.URLParam(request, "wallet")
-	latestGamestate, player, err := store.InitPlayerGS(authToken, authToken, gameSlug, currency, wallet)
+	latestGamestate, player, err := store.InitPlayerGS(authToken, authToken, gameSlug, currency, wallet, "", true)
 	if err != nil {
 		return store.PlayerStore{}, engine.EngineConfig{}, engine.Gamestate{}, err
 	}
@@ -115,7 +118,45 @@ func getInitPlayValues(request *http.Request, clientID string, memID string, gam
 }
 
 func validateBet(data engine.GameParams, txStore store.TransactionStore, game string) (engine.GameParams, rgse.RGSErr) {
-	if data.Action != "base" {
+	// the rules of the jurisdiction of the company apply to every play of the round
+	jurisdiction, err := parameterSelector.GetJurisdiction(txStore.CompanyId)
+	if err != nil {
+		return data, err
+	}
+	if err := validateJurisdictionPlay(jurisdiction, data.Autoplay, data.Turbo); err != nil {
+		return data, err
+	}
+	if data.Action == engine.GambleAction && jurisdiction.NoGamble {
+		return data, jurisdictionError(jurisdiction, "gamble is not allowed")
+	}
+	if data.Action == engine.BuyFeatureAction {
+		// the feature is bought at the start of a round, the stake is validated like a base round stake
+		EC, err := engine.Gamestate{Game: game}.ActiveEngine()
+		if err != nil {
+			return data, err
+		}
+		if !EC.HasBuyFeature() {
+			rgserr := rgse.Create(rgse.InvalidParamsError)
+			rgserr.AppendErrorText("game has no buy feature")
+			return data, rgserr
+		}
+	}
+	if data.BetMode != "" {
+		// bet modes apply to new base rounds, the stake is validated without the cost of the mode
+		if data.Action != "base" {
+			rgserr := rgse.Create(rgse.InvalidParamsError)
+			rgserr.AppendErrorText("bet mode is not available for action " + data.Action)
+			return data, rgserr
+		}
+		EC, err := engine.Gamestate{Game: game}.ActiveEngine()
+		if err != nil {
+			return data, err
+		}
+		if _, err := EC.BetMode(data.BetMode); err != nil {
+			return data, err
+		}
+	}
+	if data.Action != "base" && data.Action != engine.BuyFeatureAction {
 		// stake value must be zero
 		// check that the round is open
 		if txStore.RoundStatus != store.RoundStatusOpen {
@@ -137,6 +178,11 @@ func validateBet(data engine.GameParams, txStore store.TransactionStore, game st
 		if err != nil {
 			return data, err
 		}
+		// the cap of the operator is fixed on the round when it starts
+		data.MaxWinMultiplier, err = parameterSelector.GetMaxWinMultiplier(txStore.CompanyId)
+		if err != nil {
+			return data, err
+		}
 
 		var sd int
 		if data.Stake > 0 {
@@ -146,20 +192,26 @@ func validateBet(data engine.GameParams, txStore store.TransactionStore, game st
 			if err != nil {
 				return engine.GameParams{}, rgse.Create(rgse.EngineConfigError)
 			}
-			engineConfig := engine.BuildEngineDefs(engineID)
+			engineConfig, err := engine.LoadEngineDefs(engineID)
+			if err != nil {
+				return engine.GameParams{}, err
+			}
 			sd = engineConfig.EngineDefs[0].StakeDivisor
 			logger.Infof("Validating a total stake of %s", data.TotalStake.ValueAsString())
 			data.Stake = data.TotalStake
 		}
 
 		EC, err := engine.Gamestate{Game: game}.Engine()
+		if err != nil {
+			return data, err
+		}
 		valid := false
 		for i := 0; i < len(stakeValues); i++ {
 			validStake := stakeValues[i].Mul(engine.NewFixedFromInt(sd))
 			if data.Stake == validStake {
 				data.Stake = stakeValues[i]
 				valid = true
-				if i == len(stakeValues)-1 && data.Action == "base" {
+				if i == len(stakeValues)-1 && data.Action == "base" && data.BetMode == "" {
 					// pass on when max bet is played, only if no action is passed already and game allows it
 					if err == nil {
 						maxDef := EC.DefIdByName("maxBase")
@@ -197,10 +249,38 @@ func validateBet(data engine.GameParams, txStore store.TransactionStore, game st
 				return data, rgserr
 			}
 		}
+		wager, err := roundWager(EC, data)
+		if err != nil {
+			return data, err
+		}
+		if err := validateJurisdictionRound(jurisdiction, txStore, engine.Money{Amount: wager, Currency: txStore.Amount.Currency}); err != nil {
+			return data, err
+		}
 	}
 	return data, nil
 }
 
+// roundWager returns the amount charged for a new round at the validated bet per line, including the cost of the bet
+// mode or the price of the bought feature
+func roundWager(EC engine.EngineConfig, data engine.GameParams) (engine.Fixed, rgse.RGSErr) {
+	wager := data.Stake.Mul(engine.NewFixedFromInt(EC.EngineDefs[0].StakeDivisor))
+	if data.BetMode != "" {
+		mode, err := EC.BetMode(data.BetMode)
+		if err != nil {
+			return 0, err
+		}
+		wager = wager.Mul(mode.CostMultiplier())
+	}
+	if data.Action == engine.BuyFeatureAction {
+		price, err := EC.BuyFeaturePrice()
+		if err != nil {
+			return 0, err
+		}
+		wager = wager.Mul(price)
+	}
+	return wager, nil
+}
+
 func play(request *http.Request, data engine.GameParams) (engine.Gamestate, store.PlayerStore, BalanceResponse, engine.EngineConfig, rgse.RGSErr) {
 	authHeader := request.Header.Get("Authorization")
 	gameSlug := chi.URLParam(request, "gameSlug")
@@ -209,14 +289,12 @@ func play(request *http.Request, data engine.GameParams) (engine.Gamestate, stor
 	clientID := chi.URLParam(request, "gamestateID")
 
 	var txStore store.TransactionStore
-	var err rgse.RGSErr
 	var previousGamestate engine.Gamestate
-	switch wallet {
-	case "demo":
-		txStore, err = store.ServLocal.TransactionByGameId(store.Token(memID), store.ModeDemo, gameSlug)
-	case "dashur":
-		txStore, err = store.Serv.TransactionByGameId(store.Token(memID), store.ModeReal, gameSlug)
+	service, mode, err := store.WalletService(wallet)
+	if err != nil {
+		return previousGamestate, store.PlayerStore{}, BalanceResponse{}, engine.EngineConfig{}, err
 	}
+	txStore, err = service.TransactionByGameId(store.Token(memID), mode, gameSlug)
 	if err != nil {
 		if err.(*rgse.RGSError).ErrCode == rgse.EntityNotFound {
 			// this is first gameplay
@@ -326,17 +404,8 @@ func play(request *http.Request, data engine.GameParams) (engine.Gamestate, stor
 			Ttl:                 gamestate.GetTtl(),
 			History:             txStore.History,
 		}
-		switch wallet {
-		case "demo":
-			tx.Mode = store.ModeDemo
-			balance, err = store.ServLocal.Transaction(token, store.ModeDemo, tx)
-		case "dashur":
-			tx.Mode = store.ModeReal
-			balance, err = store.Serv.Transaction(token, store.ModeReal, tx)
-		default:
-			return engine.Gamestate{}, store.PlayerStore{}, BalanceResponse{}, engine.EngineConfig{}, rgse.Create(rgse.InvalidWallet)
-		}
-
+		tx.Mode = mode
+		balance, err = service.Transaction(token, mode, tx)
 		if err != nil {
 			return engine.Gamestate{}, store.PlayerStore{}, BalanceResponse{}, engine.EngineConfig{}, err
 		}
@@ -69,7 +69,10 @@ func getGameHashes(request *http.Request) (GameHashResponse, rgse.RGSErr) {
 		h, ok := config.GlobalHashes[cfg]
 		if ok {
 
-			EC := engine.BuildEngineDefs(c.EngineID)
+			EC, err := engine.LoadEngineDefs(c.EngineID)
+			if err != nil {
+				return response, err
+			}
 
 			for _, g := range c.Games {
 
@@ -136,7 +139,10 @@ func initV2(request *http.Request) (GameInitResponseV2, rgse.RGSErr) {
 	if err != nil {
 		return GameInitResponseV2{}, err
 	}
-	engineConfig := engine.BuildEngineDefs(engineID)
+	engineConfig, err := engine.LoadEngineDefs(engineID)
+	if err != nil {
+		return GameInitResponseV2{}, err
+	}
 	var authToken string
 	authToken, err = getAuth(request)
 	if err != nil {
@@ -144,6 +150,10 @@ func initV2(request *http.Request) (GameInitResponseV2, rgse.RGSErr) {
 	}
 
 	// get wallet from operator config
+	operator, err := config.GetOperator(data.Operator)
+	if err != nil {
+		return GameInitResponseV2{}, err
+	}
 	wallet, err := config.GetWalletFromOperatorAndMode(data.Operator, data.Mode)
 	if err != nil {
 		return GameInitResponseV2{}, err
@@ -151,17 +161,42 @@ func initV2(request *http.Request) (GameInitResponseV2, rgse.RGSErr) {
 	var player store.PlayerStore
 	var latestGamestate engine.Gamestate
 
-	latestGamestate, player, err = store.InitPlayerGS(authToken, authToken, data.Game, data.Ccy, wallet)
+	latestGamestate, player, err = store.InitPlayerGS(authToken, authToken, data.Game, data.Ccy, wallet, operator.BetProfile, operator.FreeGames)
 
 	if err != nil {
 		logger.Debugf("error: %v", err)
 		return GameInitResponseV2{}, err
 	}
 
+	// the client is told the cap that applies to the rounds of the player
+	maxWinMultiplier, err := parameterSelector.GetMaxWinMultiplier(player.CompanyId)
+	if err != nil {
+		return GameInitResponseV2{}, err
+	}
+	engineConfig = engineConfig.WithMaxWinMultiplier(maxWinMultiplier)
+	jurisdiction, err := parameterSelector.GetJurisdiction(player.CompanyId)
+	if err != nil {
+		return GameInitResponseV2{}, err
+	}
+	if err = startRGSession(player, jurisdiction); err != nil {
+		return GameInitResponseV2{}, err
+	}
+	if jurisdiction.NoGamble {
+		engineConfig.Gamble = engine.GambleConfiguration{}
+	}
+
 	giResp := fillGameInitPreviousGameplay(latestGamestate, store.BalanceStore{Balance: player.Balance, Token: player.Token, FreeGames: player.FreeGames})
 	logger.Debugf("fillGameInitPreviousGameplay")
 	giResp.FillEngineInfo(engineConfig)
 	logger.Debugf("fillEngineInfo")
+	if jurisdiction.NoGamble {
+		for action, round := range giResp.LastRound {
+			round.GambleAvailable = false
+			giResp.LastRound[action] = round
+		}
+	}
+	giResp.Jurisdiction = newJurisdictionResponse(jurisdiction, latestGamestate.BetPerLine.Currency)
+	giResp.Operator = newOperatorInfoResponse(operator)
 	//logger.Debugf("reel response: %v", giResp.ReelSets)
 	giResp.Wallet = wallet
 	// set stakevalues, links,
@@ -171,6 +206,10 @@ func initV2(request *http.Request) (GameInitResponseV2, rgse.RGSErr) {
 		logger.Debugf("error: %v", err)
 		return GameInitResponseV2{}, err
 	}
+	stakeValues, defaultBet, err = jurisdiction.LimitStakeValues(stakeValues, defaultBet, engineConfig.EngineDefs[0].StakeDivisor, latestGamestate.BetPerLine.Currency)
+	if err != nil {
+		return GameInitResponseV2{}, err
+	}
 	sd := engine.NewFixedFromInt(engineConfig.EngineDefs[0].StakeDivisor)
 	giResp.DefaultBet = defaultBet
 	giResp.DefaultTotal = defaultBet.Mul(sd)
@@ -213,16 +252,11 @@ func playV2(request *http.Request) (GameplayResponseV2, rgse.RGSErr) {
 
 	var txStore store.TransactionStore
 	var previousGamestate engine.Gamestate
-	var err rgse.RGSErr
-	switch data.Wallet {
-	case "demo":
-		txStore, err = store.ServLocal.TransactionByGameId(token, store.ModeDemo, data.Game)
-	case "dashur":
-		txStore, err = store.Serv.TransactionByGameId(token, store.ModeReal, data.Game)
-	default:
-		logger.Debugf("No such wallet '%v': %#v", data.Wallet, request)
-		return GameplayResponseV2{}, rgse.Create(rgse.InvalidWallet)
+	service, mode, err := store.WalletService(data.Wallet)
+	if err != nil {
+		return GameplayResponseV2{}, err
 	}
+	txStore, err = service.TransactionByGameId(token, mode, data.Game)
 
 	logger.Debugf("txstore: {%v}, err: %v", txStore, err)
 	if err != nil {
@@ -259,14 +293,11 @@ func playV2(request *http.Request) (GameplayResponseV2, rgse.RGSErr) {
 }
 
 func lastTransaction(token store.Token, wallet string, game string) (store.TransactionStore, rgse.RGSErr) {
-	switch wallet {
-	case "demo":
-		return store.ServLocal.TransactionByGameId(token, store.ModeDemo, game)
-	case "dashur":
-		return store.Serv.TransactionByGameId(token, store.ModeReal, game)
-	}
-	logger.Debugf("No such wallet '%v'", wallet)
-	return store.TransactionStore{}, rgse.Create(rgse.InvalidWallet)
+	service, mode, err := store.WalletService(wallet)
+	if err != nil {
+		return store.TransactionStore{}, err
+	}
+	return service.TransactionByGameId(token, mode, game)
 }
 
 func playRound(request *http.Request) (RoundResponse, rgse.RGSErr) {
@@ -280,14 +311,9 @@ func playRound(request *http.Request) (RoundResponse, rgse.RGSErr) {
 		return RoundResponse{}, err
 	}
 
-	var mode store.Mode
-	switch data.Wallet {
-	case "demo":
-		mode = store.ModeDemo
-	case "dashur":
-		mode = store.ModeReal
-	default:
-		return RoundResponse{}, rgse.Create(rgse.InvalidWallet)
+	service, mode, err := store.WalletService(data.Wallet)
+	if err != nil {
+		return RoundResponse{}, err
 	}
 
 	txStore, err := lastTransaction(token, data.Wallet, data.Game)
@@ -313,6 +339,9 @@ func playRound(request *http.Request) (RoundResponse, rgse.RGSErr) {
 	}
 
 	nextAction := "base"
+	if data.Action == engine.BuyFeatureAction {
+		nextAction = data.Action
+	}
 	if len(previousGamestate.NextActions) > 0 && previousGamestate.NextActions[0] != "finish" {
 		logger.Debugf("completing unfinished round actions [%#v]", previousGamestate.NextActions)
 		nextAction = previousGamestate.NextActions[0]
@@ -465,9 +494,13 @@ func playRound(request *http.Request) (RoundResponse, rgse.RGSErr) {
 			Win:              win,
 			Freespins:        countFreespinsRemaining(gamestate),
 			View:             gamestate.SymbolGrid,
+			ReelHeights:      gamestate.ReelHeights,
+			BetMode:          gamestate.BetMode,
 			Prizes:           adjustPrizes(gamestate), // gamestate.Prizes),
 			Multiplier:       gamestate.Multiplier,
 			CascadePositions: getCascadePositions(gamestate),
+			Gamble:           gamestate.Gamble,
+			Capped:           gamestate.Capped,
 			Features:         gamestate.Features,
 			FeatureView:      gamestate.FeatureView,
 		})
@@ -502,7 +535,7 @@ func playRound(request *http.Request) (RoundResponse, rgse.RGSErr) {
 
 	//	logger.Debugf("playround spins: %#v", spins)
 
-	balance, err := store.GetService(mode).MultiTransaction(token, mode, transactions)
+	balance, err := service.MultiTransaction(token, mode, transactions)
 
 	if err != nil {
 		return RoundResponse{}, err
@@ -529,7 +562,8 @@ func playRound(request *http.Request) (RoundResponse, rgse.RGSErr) {
 			FreeGames:    balance.FreeGames.NoOfFreeSpins,
 			FreeSpinInfo: &fsresp,
 		},
-		Spins: spins,
+		Spins:        spins,
+		RealityCheck: store.DueRealityCheck(balance.Token),
 	}, nil
 }
 
@@ -566,17 +600,13 @@ func playFirst(request *http.Request, data engine.GameParams) (GameplayResponseV
 
 	var player store.PlayerStore
 	var latestGamestateStore store.GameStateStore
-	var err rgse.RGSErr
 	var initGS engine.Gamestate
 
-	switch data.Wallet {
-	case "dashur":
-		player, latestGamestateStore, err = store.Serv.PlayerByToken(token, store.ModeReal, data.Game)
-	case "demo":
-		player, latestGamestateStore, err = store.ServLocal.PlayerByToken(token, store.ModeDemo, data.Game)
-	default:
-		return GameplayResponseV2{}, rgse.Create(rgse.InvalidWallet)
+	service, mode, err := store.WalletService(data.Wallet)
+	if err != nil {
+		return GameplayResponseV2{}, err
 	}
+	player, latestGamestateStore, err = service.PlayerByToken(token, mode, data.Game)
 	if err != nil {
 		return GameplayResponseV2{}, err
 	}
@@ -592,6 +622,7 @@ func playFirst(request *http.Request, data engine.GameParams) (GameplayResponseV
 	txStoreInit := store.TransactionStore{
 		RoundStatus:         store.RoundStatusClose,
 		BetLimitSettingCode: player.BetLimitSettingCode,
+		CompanyId:           player.CompanyId,
 		PlayerId:            player.PlayerId,
 		FreeGames:           player.FreeGames,
 		Token:               player.Token,
@@ -692,24 +723,23 @@ func getRoundResults(data engine.GameParams, previousGamestate engine.Gamestate,
 			Ttl:                 gamestate.GetTtl(),
 			History:             txStore.History,
 		}
-		switch data.Wallet {
-		case "demo":
-			tx.Mode = store.ModeDemo
-			balance, err = store.ServLocal.Transaction(token, store.ModeDemo, tx)
-		case "dashur":
-			tx.Mode = store.ModeReal
-			balance, err = store.Serv.Transaction(token, store.ModeReal, tx)
-		default:
-			err = rgse.Create(rgse.InvalidWallet)
+		var service store.Service
+		service, tx.Mode, err = store.WalletService(data.Wallet)
+		if err != nil {
 			return
 		}
-
+		balance, err = service.Transaction(token, tx.Mode, tx)
 		if err != nil {
 			return
 		}
 		token = balance.Token
 	}
-	return fillGamestateResponseV2(gamestate, balance), nil
+	gameplay = fillGamestateResponseV2(gamestate, balance)
+	gameplay.RealityCheck = store.DueRealityCheck(balance.Token)
+	if jurisdiction, jurisdictionErr := parameterSelector.GetJurisdiction(txStore.CompanyId); jurisdictionErr == nil && jurisdiction.NoGamble {
+		gameplay.GambleAvailable = false
+	}
+	return gameplay, nil
 }
 
 func (i *CloseRoundParams) decode(request *http.Request) rgse.RGSErr {
@@ -732,16 +762,11 @@ func CloseGS(r *http.Request) (err rgse.RGSErr) {
 		return err
 	}
 	logger.Debugf("data= %#v token= %#v", data, token)
-	var txStore store.TransactionStore
-	switch data.Wallet {
-	case "demo":
-		txStore, err = store.ServLocal.TransactionByGameId(token, store.ModeDemo, data.Game)
-	case "dashur":
-		txStore, err = store.Serv.TransactionByGameId(token, store.ModeReal, data.Game)
-	default:
-		return rgse.Create(rgse.InvalidWallet)
+	service, mode, err := store.WalletService(data.Wallet)
+	if err != nil {
+		return
 	}
-
+	txStore, err := service.TransactionByGameId(token, mode, data.Game)
 	if err != nil {
 		return
 	}
@@ -768,11 +793,11 @@ func CloseGS(r *http.Request) (err rgse.RGSErr) {
 	}
 	state := store.SerializeGamestateToBytes(gamestateUnmarshalled)
 	ttl := gamestateUnmarshalled.GetTtl()
-	switch data.Wallet {
-	case "demo":
-		_, err = store.ServLocal.CloseRound(token, store.ModeDemo, data.Game, roundId, "", state, ttl, &txStore.History)
-	case "dashur":
-		_, err = store.Serv.CloseRound(token, store.ModeReal, data.Game, roundId, txStore.FreeGames.CampaignRef, state, ttl, nil)
+	if mode == store.ModeDemo {
+		// the demo wallet keeps the history of the round with the gamestate
+		_, err = service.CloseRound(token, mode, data.Game, roundId, "", state, ttl, &txStore.History)
+	} else {
+		_, err = service.CloseRound(token, mode, data.Game, roundId, txStore.FreeGames.CampaignRef, state, ttl, nil)
 	}
 	return
 }
@@ -803,7 +828,6 @@ func Feed(r *http.Request) (FeedResponse, rgse.RGSErr) {
 		return FeedResponse{}, autherr
 	}
 
-	var err rgse.RGSErr
 	var data FeedParams
 	if err := data.decode(r); err != nil {
 		return FeedResponse{}, err
@@ -821,17 +845,11 @@ func Feed(r *http.Request) (FeedResponse, rgse.RGSErr) {
 		data.PageSize = 1
 	}
 
-	var nextPage int
-	var rounds []store.FeedRound
-	switch data.Wallet {
-	case "demo":
-		rounds, nextPage, err = store.ServLocal.Feed(token, store.ModeDemo, data.Game, data.StartTime, data.EndTime, data.PageSize, data.Page)
-	case "dashur":
-		rounds, nextPage, err = store.Serv.Feed(token, store.ModeReal, data.Game, data.StartTime, data.EndTime, data.PageSize, data.Page)
-	default:
-		return FeedResponse{}, rgse.Create(rgse.InvalidWallet)
+	service, mode, err := store.WalletService(data.Wallet)
+	if err != nil {
+		return FeedResponse{}, err
 	}
-
+	rounds, nextPage, err := service.Feed(token, mode, data.Game, data.StartTime, data.EndTime, data.PageSize, data.Page)
 	if err != nil {
 		return FeedResponse{}, err
 	}
@@ -848,22 +866,16 @@ func FeedRound(r *http.Request) (FeedRoundResponse, rgse.RGSErr) {
 		return FeedRoundResponse{}, autherr
 	}
 
-	var err rgse.RGSErr
 	var data FeedRoundParams
 	if err := data.decode(r); err != nil {
 		return FeedRoundResponse{}, err
 	}
 
-	var transactions []store.FeedTransaction
-	switch data.Wallet {
-	case "demo":
-		transactions, err = store.ServLocal.FeedRound(token, store.ModeDemo, data.Game, data.RoundId)
-	case "dashur":
-		transactions, err = store.Serv.FeedRound(token, store.ModeReal, data.Game, data.RoundId)
-	default:
-		return FeedRoundResponse{}, rgse.Create(rgse.InvalidWallet)
+	service, mode, err := store.WalletService(data.Wallet)
+	if err != nil {
+		return FeedRoundResponse{}, err
 	}
-
+	transactions, err := service.FeedRound(token, mode, data.Game, data.RoundId)
 	if err != nil {
 		return FeedRoundResponse{}, err
 	}
@@ -34,6 +34,8 @@ type playParamsV3 struct {
 	Wallet     string `json:"wallet"`
 	PreviousID string `json:"previousID"`
 	AutoClose  bool   `json:"autoClose"`
+	Autoplay   bool   `json:"autoplay"`
+	Turbo      bool   `json:"turbo"`
 }
 
 type closeParamsV3 struct {
@@ -45,12 +47,14 @@ type IGameInitResponseV3 interface {
 }
 
 type GameInitResponseV3 struct {
-	Name             string         `json:"name"`
-	Version          string         `json:"version"`
-	Wallet           string         `json:"wallet"`
-	StakeValues      []engine.Fixed `json:"stakeValues"`
-	DefaultBet       engine.Fixed   `json:"defaultBet"`
-	CurrencyDecimals int            `json:"currencyDecimals"`
+	Name             string                `json:"name"`
+	Version          string                `json:"version"`
+	Wallet           string                `json:"wallet"`
+	StakeValues      []engine.Fixed        `json:"stakeValues"`
+	DefaultBet       engine.Fixed          `json:"defaultBet"`
+	CurrencyDecimals int                   `json:"currencyDecimals"`
+	Jurisdiction     *JurisdictionResponse `json:"jurisdiction,omitempty"`
+	Operator         *OperatorInfoResponse `json:"operator,omitempty"`
 }
 
 func (resp *GameInitResponseV3) Base() *GameInitResponseV3 {
@@ -69,14 +73,15 @@ type IGamePlayResponseV3 interface {
 }
 
 type GamePlayResponseV3 struct {
-	Token    store.Token       `json:"token`
-	StateId  string            `json:"stateId"`
-	RoundId  string            `json:"roundId"`
-	Bet      engine.Fixed      `json:"bet"`
-	Win      engine.Fixed      `json:"win"`
-	Balance  BalanceResponseV3 `json:"balance"`
-	Closed   bool              `json:"closed"`
-	Features []feature.Feature `json:"features,omitempty"`
+	Token        store.Token         `json:"token`
+	StateId      string              `json:"stateId"`
+	RoundId      string              `json:"roundId"`
+	Bet          engine.Fixed        `json:"bet"`
+	Win          engine.Fixed        `json:"win"`
+	Balance      BalanceResponseV3   `json:"balance"`
+	Closed       bool                `json:"closed"`
+	Features     []feature.Feature   `json:"features,omitempty"`
+	RealityCheck *store.RealityCheck `json:"realityCheck,omitempty"`
 }
 
 func (resp GamePlayResponseV3) Base() GamePlayResponseV3 {
@@ -120,8 +125,17 @@ func initV3(request *http.Request) (response IGameInitResponseV3, rgserr rgse.RG
 		return
 	}
 
-	engineConfig := engine.BuildEngineDefs(engineId)
+	var engineConfig engine.EngineConfig
+	engineConfig, rgserr = engine.LoadEngineDefs(engineId)
+	if rgserr != nil {
+		return
+	}
 
+	var operator config.Operator
+	operator, rgserr = config.GetOperator(data.Operator)
+	if rgserr != nil {
+		return
+	}
 	var wallet string
 	wallet, rgserr = config.GetWalletFromOperatorAndMode(data.Operator, data.Mode)
 	if rgserr != nil {
@@ -136,15 +150,18 @@ func initV3(request *http.Request) (response IGameInitResponseV3, rgserr rgse.RG
 	}
 	if len(state.GameState) == 0 {
 		logger.Debugf("initV3 gamestate length is zero")
-		if wallet == "demo" {
+		if store.WalletAdapter(wallet) == config.WalletAdapterDemo {
 			logger.Debugf("initV3 wallet is demo, save a player")
 			var balance engine.Money
 			var ctFS int
 			var waFS engine.Fixed
-			balance, ctFS, waFS, rgserr = parameterSelector.GetDemoWalletDefaults(data.Ccy, data.Game, "", authToken, player.BetSettingId)
+			balance, ctFS, waFS, rgserr = parameterSelector.GetDemoWalletDefaults(data.Ccy, data.Game, operator.BetProfile, authToken, player.BetSettingId)
 			if rgserr != nil {
 				return
 			}
+			if !operator.FreeGames {
+				ctFS, waFS = 0, 0
+			}
 
 			player = store.PlayerStore{
 				PlayerId:            authToken,
@@ -152,7 +169,7 @@ func initV3(request *http.Request) (response IGameInitResponseV3, rgserr rgse.RG
 				Mode:                store.ModeDemo,
 				Username:            "",
 				Balance:             balance,
-				BetLimitSettingCode: "",
+				BetLimitSettingCode: operator.BetProfile,
 				FreeGames: store.FreeGamesStore{
 					NoOfFreeSpins: ctFS,
 					CampaignRef:   authToken,
@@ -162,24 +179,32 @@ func initV3(request *http.Request) (response IGameInitResponseV3, rgserr rgse.RG
 			player, rgserr = store.ServLocal.PlayerSave(token, store.ModeDemo, player)
 		}
 	}
-	response, err = initGameV3(player, engineId, wallet, body, engineConfig, token, state.GameState)
-
+	var jurisdiction parameterSelector.Jurisdiction
+	jurisdiction, rgserr = parameterSelector.GetJurisdiction(player.CompanyId)
+	if rgserr != nil {
+		return
+	}
+	if rgserr = startRGSession(player, jurisdiction); rgserr != nil {
+		return
+	}
+	response, rgserr = initGameV3(player, engineId, wallet, body, engineConfig, token, state.GameState, jurisdiction)
+	if rgserr != nil {
+		return
+	}
+	response.Base().Operator = newOperatorInfoResponse(operator)
 	return
 }
 
 // build initial gamestate
 
-func initGameV3(player store.PlayerStore, engineId string, wallet string, body []byte, engineConf engine.EngineConfig, token store.Token, state []byte) (
+func initGameV3(player store.PlayerStore, engineId string, wallet string, body []byte, engineConf engine.EngineConfig, token store.Token, state []byte, jurisdiction parameterSelector.Jurisdiction) (
 	response IGameInitResponseV3, rgserr rgse.RGSErr) {
-	// use engine config to call dynamic init method?
-	switch engineId {
-	case "mvgEngineRoulette1":
-		return initRoulette(player, engineId, wallet, body, engineConf, token, state)
-	default:
+	gameType, rgserr := gameTypeV3ByEngine(engineId)
+	if rgserr != nil {
 		logger.Errorf("v3 api has no support for engineId %s", engineId)
-		break
+		return nil, rgserr
 	}
-	return nil, rgse.Create(rgse.EngineNotFoundError)
+	return gameType.Init(player, engineId, wallet, body, engineConf, token, state, jurisdiction)
 }
 
 func playV3(request *http.Request) (response IGamePlayResponseV3, rgserr rgse.RGSErr) {
@@ -215,38 +240,19 @@ func playV3(request *http.Request) (response IGamePlayResponseV3, rgserr rgse.RG
 	var prevStateStore store.GameStateStore
 	var prevIState engine.IGameStateV3 // GameStateRoulette
 
-	switch data.Wallet {
-	case "dashur":
-		logger.Debugf("wallet is dashur")
-		if bfirst {
-			//			player, prevStateStore, err = store.Serv.PlayerByToken(token, store.ModeReal, data.Game)
-			logger.Debugf("store.Serv.PlayerByToken token=%s, mode=%v, game=%s", string(token), store.ModeReal, data.Game)
-			player, prevStateStore, rgserr = store.Serv.PlayerByToken(token, store.ModeReal, data.Game)
-			logger.Debugf("store.Serv.PlayerByToken done. player=%#v", player)
-		} else {
-			logger.Debugf("store.Serv.TransactionByGameId token=%s, mode=%v, game=%s", string(token), store.ModeReal, data.Game)
-			txStore, rgserr = store.Serv.TransactionByGameId(token, store.ModeReal, data.Game)
-			logger.Debugf("store.Serv.TransactionByGameId done. txStore.TransactionId=%#v", txStore.TransactionId)
-		}
-		break
-	case "demo":
-		logger.Debugf("wallet is demo")
-		if bfirst {
-			//			player, prevStateStore, err = store.ServLocal.PlayerByToken(token, store.ModeDemo, data.Game)
-			logger.Debugf("store.ServLocal.PlayerByToken token=%s, mode=%v, game=%s", string(token), store.ModeReal, data.Game)
-			player, prevStateStore, rgserr = store.ServLocal.PlayerByToken(token, store.ModeDemo, data.Game)
-			logger.Debugf("store.ServLocal.PlayerByToken done. player=%#v", player)
-		} else {
-			logger.Debugf("store.ServLocal.TransactionByGameId token=%s, mode=%v, game=%s", string(token), store.ModeReal, data.Game)
-			txStore, rgserr = store.ServLocal.TransactionByGameId(token, store.ModeDemo, data.Game)
-			logger.Debugf("store.ServLocal.TransactionByGameId done. txStore.TransactionId=%#v", txStore.TransactionId)
-		}
-		break
-	default:
-		logger.Errorf("unknown wallet\n")
-		rgserr = rgse.Create(rgse.InvalidWallet)
+	service, mode, rgserr := store.WalletService(data.Wallet)
+	if rgserr != nil {
 		return
 	}
+	if bfirst {
+		logger.Debugf("PlayerByToken token=%s, mode=%v, game=%s", string(token), mode, data.Game)
+		player, prevStateStore, rgserr = service.PlayerByToken(token, mode, data.Game)
+		logger.Debugf("PlayerByToken done. player=%#v", player)
+	} else {
+		logger.Debugf("TransactionByGameId token=%s, mode=%v, game=%s", string(token), mode, data.Game)
+		txStore, rgserr = service.TransactionByGameId(token, mode, data.Game)
+		logger.Debugf("TransactionByGameId done. txStore.TransactionId=%#v", txStore.TransactionId)
+	}
 
 	if rgserr != nil {
 		if bfirst && rgserr.(*rgse.RGSError).ErrCode == rgse.NoSuchPlayer {
@@ -259,10 +265,16 @@ func playV3(request *http.Request) (response IGamePlayResponseV3, rgserr rgse.RG
 
 	var gameV3 store.IGameV3
 	gameV3, rgserr = store.CreateGameV3(data.Game)
-	gameV3.Base().Init(token, data.Wallet, player.Balance.Currency)
 	if rgserr != nil {
 		return
 	}
+	gameType, rgserr := gameTypeV3ByGame(data.Game)
+	if rgserr != nil {
+		return
+	}
+	if rgserr = gameV3.Base().Init(token, data.Wallet, player.Balance.Currency); rgserr != nil {
+		return
+	}
 	logger.Debugf("gameV3: %#v", gameV3)
 
 	if bfirst {
@@ -276,6 +288,7 @@ func playV3(request *http.Request) (response IGamePlayResponseV3, rgserr rgse.RG
 			RoundStatus:         store.RoundStatusClose,
 			BetLimitSettingCode: player.BetLimitSettingCode,
 			PlayerId:            player.PlayerId,
+			CompanyId:           player.CompanyId,
 			FreeGames:           player.FreeGames,
 			Token:               player.Token,
 			Amount:              engine.Money{0, player.Balance.Currency},
@@ -308,7 +321,7 @@ func playV3(request *http.Request) (response IGamePlayResponseV3, rgserr rgse.RG
 			rgserr = rgse.Create(rgse.SpinSequenceError)
 			return
 		}
-		if !prevState.Closed {
+		if !prevState.Closed && !gameType.MultiStep {
 			logger.Errorf("Spin sequence error. Previous gamestate was not closed")
 			rgserr = rgse.Create(rgse.SpinSequenceError)
 			return
@@ -346,22 +359,29 @@ func playV3(request *http.Request) (response IGamePlayResponseV3, rgserr rgse.RG
 		return
 	}
 
-	return playGameV3(engineId, data.Wallet, body, txStore)
+	var jurisdiction parameterSelector.Jurisdiction
+	jurisdiction, rgserr = parameterSelector.GetJurisdiction(txStore.CompanyId)
+	if rgserr != nil {
+		return
+	}
+	rgserr = validateJurisdictionPlay(jurisdiction, data.Autoplay, data.Turbo)
+	if rgserr != nil {
+		return
+	}
+
+	return playGameV3(engineId, data.Wallet, body, txStore, jurisdiction)
 }
 
 func validateState(state engine.IGameStateV3) rgse.RGSErr {
 	return nil
 }
 
-func playGameV3(engineId string, wallet string, body []byte, txStore store.TransactionStore) (response IGamePlayResponseV3, rgserr rgse.RGSErr) {
-
-	switch engineId {
-	case "mvgEngineRoulette1":
-		return playRoulette(engineId, wallet, body, txStore)
-	default:
-		break
+func playGameV3(engineId string, wallet string, body []byte, txStore store.TransactionStore, jurisdiction parameterSelector.Jurisdiction) (response IGamePlayResponseV3, rgserr rgse.RGSErr) {
+	gameType, rgserr := gameTypeV3ByEngine(engineId)
+	if rgserr != nil {
+		return nil, rgserr
 	}
-	return nil, rgse.Create(rgse.EngineNotFoundError)
+	return gameType.Play(engineId, wallet, body, txStore, jurisdiction)
 }
 
 func closeV3(request *http.Request) rgse.RGSErr {
@@ -385,6 +405,10 @@ func closeV3(request *http.Request) rgse.RGSErr {
 	if rgserr != nil {
 		return rgserr
 	}
+	gameType, rgserr := gameTypeV3ByGame(data.Game)
+	if rgserr != nil {
+		return rgserr
+	}
 
 	var txStore store.TransactionStore
 	txStore, rgserr = TransactionByWalletAndGame(token, data.Wallet, data.Game)
@@ -407,6 +431,11 @@ func closeV3(request *http.Request) rgse.RGSErr {
 		logger.Debugf("state round id %s != data round id %s", state.RoundId, data.RoundID)
 		return rgse.Create(rgse.SpinSequenceError)
 	}
+	if gameType.Close != nil {
+		if rgserr = gameType.Close(istate); rgserr != nil {
+			return rgserr
+		}
+	}
 	state.Closed = true
 	roundId := state.RoundId
 	if roundId == "" {
@@ -471,55 +500,48 @@ func (i *CloseRoundParams) deserialize(b []byte) rgse.RGSErr {
 
 func getPlayerAndState(token store.Token, wallet string, game string) (player store.PlayerStore, state store.GameStateStore, rgserr rgse.RGSErr) {
 	logger.Debugf("getPlayerAndState token=%s, wallet=%s, game=%s", string(token), wallet, game)
-	switch wallet {
-	case "dashur":
-		player, state, rgserr = store.Serv.PlayerByToken(token, store.ModeReal, game)
-	case "demo":
-		player, state, rgserr = store.ServLocal.PlayerByToken(token, store.ModeDemo, game)
-	default:
-		rgserr = rgse.Create(rgse.GenericWalletError)
+	service, mode, rgserr := store.WalletService(wallet)
+	if rgserr != nil {
+		return
 	}
+	player, state, rgserr = service.PlayerByToken(token, mode, game)
 	logger.Debugf("getPlayerAndState done. player=%#v", player)
 	return
 }
 
 func TransactionByWallet(token store.Token, wallet string, tx store.TransactionStore) (balance store.BalanceStore, err rgse.RGSErr) {
 	logger.Debugf("TransactionByWallet token:%s, wallet:%s transactionId:%s", token, wallet, tx.TransactionId)
-	switch wallet {
-	case "demo":
-		tx.Mode = store.ModeDemo
-		balance, err = store.ServLocal.Transaction(token, store.ModeDemo, tx)
-	case "dashur":
-		tx.Mode = store.ModeReal
-		balance, err = store.Serv.Transaction(token, store.ModeReal, tx)
-	default:
-		err = rgse.Create(rgse.InvalidWallet)
+	service, mode, err := store.WalletService(wallet)
+	if err != nil {
+		return
 	}
+	tx.Mode = mode
+	balance, err = service.Transaction(token, mode, tx)
 	logger.Debugf("TransactionByWallet done. balance=%#v", balance)
 	return
 }
 
 func TransactionByWalletAndGame(token store.Token, wallet string, game string) (txStore store.TransactionStore, rgserr rgse.RGSErr) {
 	logger.Debugf("TransactionByWalletAndGame token=%s, wallet=%s, game=%s", string(token), wallet, game)
-	switch wallet {
-	case "demo":
-		txStore, rgserr = store.ServLocal.TransactionByGameId(token, store.ModeDemo, game)
-	case "dashur":
-		txStore, rgserr = store.Serv.TransactionByGameId(token, store.ModeReal, game)
-	default:
-		rgserr = rgse.Create(rgse.InvalidWallet)
+	service, mode, rgserr := store.WalletService(wallet)
+	if rgserr != nil {
+		return
 	}
+	txStore, rgserr = service.TransactionByGameId(token, mode, game)
 	logger.Debugf("TransactionByWalletAndGame done.")
 	return
 }
 
 func CloseByWallet(token store.Token, wallet string, game string, roundId string, serializedState []byte, history *store.TransactionHistory) (rgserr rgse.RGSErr) {
 	logger.Debugf("CloseByWallet token=%s, wallet=%s, game=%s, serializedState len=%d", string(token), wallet, game, len(serializedState))
-	switch wallet {
-	case "demo":
-		_, rgserr = store.ServLocal.CloseRound(token, store.ModeDemo, game, roundId, "", serializedState, 3600, history)
-	case "dashur":
-		_, rgserr = store.Serv.CloseRound(token, store.ModeReal, game, roundId, "", serializedState, 3600, nil)
+	service, mode, rgserr := store.WalletService(wallet)
+	if rgserr != nil {
+		return
+	}
+	if mode == store.ModeDemo {
+		_, rgserr = service.CloseRound(token, mode, game, roundId, "", serializedState, 3600, history)
+	} else {
+		_, rgserr = service.CloseRound(token, mode, game, roundId, "", serializedState, 3600, nil)
 	}
 	logger.Debugf("CloseByWallet done")
 	return
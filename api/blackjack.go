@@ -0,0 +1,376 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/parameterSelector"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/store"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+var _ GameTypeV3 = RegisterGameTypeV3(store.GameCategoryBlackjack, GameTypeV3{
+	Init:         initBlackjack,
+	Play:         playBlackjack,
+	Close:        closeBlackjack,
+	MultiStep:    true,
+	Playcheck:    playcheckBlackjack,
+	PlaycheckExt: playcheckExtBlackjack,
+})
+
+type playParamsBlackjack struct {
+	playParamsV3
+
+	Action string       `json:"action"`
+	Bet    engine.Fixed `json:"bet"` // the bet of a deal
+}
+
+func (i *playParamsBlackjack) decode(request *http.Request) rgse.RGSErr {
+	return decodeParams(i, request)
+}
+
+func (i playParamsBlackjack) validate() rgse.RGSErr {
+	return nil
+}
+
+func (i *playParamsBlackjack) deserialize(b []byte) rgse.RGSErr {
+	return deserializeParams(i, b)
+}
+
+type RulesBlackjack struct {
+	Decks            int    `json:"decks"`
+	DealerHitsSoft17 bool   `json:"dealerHitsSoft17"`
+	BlackjackPays    string `json:"blackjackPays"`
+	DoubleAfterSplit bool   `json:"doubleAfterSplit"`
+	MaxHands         int    `json:"maxHands"`
+	Insurance        bool   `json:"insurance"`
+}
+
+type GameInitResponseBlackjack struct {
+	GameInitResponseV3
+	LastRound IGamePlayResponseV3 `json:"lastRound"`
+	MinBet    engine.Fixed        `json:"minBet"`
+	MaxBet    engine.Fixed        `json:"maxBet"`
+	Rules     RulesBlackjack      `json:"rules"`
+}
+
+func (resp *GameInitResponseBlackjack) Base() *GameInitResponseV3 {
+	return &resp.GameInitResponseV3
+}
+
+func (resp GameInitResponseBlackjack) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// HandBlackjack is a hand as the player sees it, the cards are named by rank and suit
+type HandBlackjack struct {
+	Cards   []string     `json:"cards"`
+	Value   int          `json:"value"`
+	Soft    bool         `json:"soft,omitempty"`
+	Bet     engine.Fixed `json:"bet,omitempty"`
+	Doubled bool         `json:"doubled,omitempty"`
+	Split   bool         `json:"split,omitempty"`
+	Result  string       `json:"result,omitempty"`
+	Win     engine.Fixed `json:"win,omitempty"`
+}
+
+func MakeHandBlackjack(cards []int) HandBlackjack {
+	hand := HandBlackjack{Cards: make([]string, len(cards))}
+	for i, c := range cards {
+		hand.Cards[i] = engine.BlackjackCardName(c)
+	}
+	hand.Value, hand.Soft = engine.BlackjackHandValue(cards)
+	return hand
+}
+
+type GamePlayResponseBlackjack struct {
+	GamePlayResponseV3
+
+	Action    string          `json:"action"`
+	Dealer    HandBlackjack   `json:"dealer"` // the hole card is shown once the round is settled
+	Hands     []HandBlackjack `json:"hands"`
+	Active    int             `json:"active"`
+	Actions   []string        `json:"actions"` // the actions the player can take, none once the round is settled
+	Insurance engine.Fixed    `json:"insurance,omitempty"`
+}
+
+func (resp GamePlayResponseBlackjack) Base() GamePlayResponseV3 {
+	return resp.GamePlayResponseV3
+}
+
+func (resp GamePlayResponseBlackjack) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func initBlackjack(player store.PlayerStore, engineId string, wallet string, body []byte, engineConf engine.EngineConfig, token store.Token, state []byte, jurisdiction parameterSelector.Jurisdiction) (
+	response IGameInitResponseV3, rgserr rgse.RGSErr) {
+
+	var data initParamsV3
+	if rgserr = data.deserialize(body); rgserr != nil {
+		return nil, rgse.Create(rgse.JsonError)
+	}
+
+	var game store.GameBlackjackV3
+	var gameState engine.GameStateBlackjack
+	if len(state) == 0 {
+		gameState = store.InitStateBlackjack(data.Game, data.Ccy)
+		gameState.Id = string(token) + data.Game + "GSinit"
+	} else {
+		// a round that waits for the player is recovered with the actions it offers
+		gameState, rgserr = game.DeserializeStateBlackjack(state)
+		if rgserr != nil {
+			return
+		}
+		logger.Debugf("initBlackjack state length:%d\ndeserialized:%#v", len(state), gameState)
+	}
+
+	balance := store.BalanceStore{
+		Balance:   player.Balance,
+		Token:     player.Token,
+		FreeGames: player.FreeGames,
+	}
+
+	stakeValues, defaultBet, minBet, maxBet, prmerr := parameterSelector.GetGameplayParameters(engine.Money{Currency: gameState.Currency}, player.BetLimitSettingCode, data.Game, player.BetSettingId)
+	if prmerr != nil {
+		rgserr = prmerr
+		return
+	}
+	stakeValues, defaultBet, rgserr = jurisdiction.LimitStakeValues(stakeValues, defaultBet, 1, gameState.Currency)
+	if rgserr != nil {
+		return
+	}
+	if maxStake := jurisdiction.MaxStakeFor(gameState.Currency); maxStake > 0 && (maxBet == 0 || maxBet > maxStake) {
+		maxBet = maxStake
+	}
+	mu, muerr := parameterSelector.GetCurrencyMinorUnit(gameState.Currency)
+	if muerr != nil {
+		rgserr = muerr
+		return
+	}
+
+	conf := engineConf.EngineDefs[0].BlackjackConfig
+	num, den, _ := conf.BlackjackOdds()
+	response = &GameInitResponseBlackjack{
+		GameInitResponseV3: GameInitResponseV3{
+			Name:             gameState.Game,
+			Wallet:           wallet,
+			StakeValues:      stakeValues,
+			DefaultBet:       defaultBet,
+			CurrencyDecimals: mu,
+			Jurisdiction:     newJurisdictionResponse(jurisdiction, gameState.Currency),
+		},
+		LastRound: fillBlackjackPlayResponse(gameState, balance),
+		MinBet:    minBet,
+		MaxBet:    maxBet,
+		Rules: RulesBlackjack{
+			Decks:            conf.DeckCount(),
+			DealerHitsSoft17: conf.DealerHitsSoft17,
+			BlackjackPays:    fmt.Sprintf("%d:%d", num, den),
+			DoubleAfterSplit: conf.DoubleAfterSplit,
+			MaxHands:         conf.HandLimit(),
+			Insurance:        conf.Insurance,
+		},
+	}
+	return
+}
+
+func playBlackjack(engineId string, wallet string, body []byte, txStore store.TransactionStore, jurisdiction parameterSelector.Jurisdiction) (response IGamePlayResponseV3, rgserr rgse.RGSErr) {
+	var data playParamsBlackjack
+	if rgserr = data.deserialize(body); rgserr != nil {
+		return
+	}
+	logger.Debugf("playBlackjack %#v\n", data)
+
+	engineConf, rgserr := engine.LoadEngineDefs(engineId)
+	if rgserr != nil {
+		return
+	}
+
+	var game store.GameBlackjackV3
+	var prevState engine.GameStateBlackjack
+	if len(txStore.GameState) == 0 {
+		logger.Debugf("no previous gamestate in playBlackjack")
+		prevState = store.InitStateBlackjack(data.Game, txStore.Amount.Currency)
+	} else {
+		prevState, rgserr = game.DeserializeStateBlackjack(txStore.GameState)
+		if rgserr != nil {
+			return
+		}
+	}
+
+	if data.Action == engine.BlackjackDeal {
+		if len(txStore.GameState) > 0 && !prevState.Closed {
+			logger.Errorf("Spin sequence error. Previous blackjack round was not closed")
+			return nil, rgse.Create(rgse.SpinSequenceError)
+		}
+		if rgserr = validateStakeValue(data.Game, data.Bet, txStore); rgserr != nil {
+			return
+		}
+		if rgserr = validateJurisdictionRound(jurisdiction, txStore, engine.Money{Amount: data.Bet, Currency: txStore.Amount.Currency}); rgserr != nil {
+			return
+		}
+	} else if prevState.Closed {
+		logger.Errorf("Spin sequence error. Blackjack action %s on a closed round", data.Action)
+		return nil, rgse.Create(rgse.SpinSequenceError)
+	} else if wager := prevState.ActionWager(data.Action); wager > 0 {
+		// a double, a split or an insurance adds to the wagers of the round, which are held to the max stake
+		if rgserr = validateJurisdictionStake(jurisdiction, engine.Money{Amount: prevState.Bet + wager, Currency: txStore.Amount.Currency}); rgserr != nil {
+			return
+		}
+	}
+
+	return getBlackjackResults(data, engineConf.EngineDefs[0], prevState, txStore)
+}
+
+func getBlackjackResults(
+	data playParamsBlackjack,
+	engineDef engine.EngineDef,
+	prevState engine.GameStateBlackjack,
+	txStore store.TransactionStore) (response GamePlayResponseBlackjack, err rgse.RGSErr) {
+
+	var game store.GameBlackjackV3
+	round := rng.NewRound(nil)
+	audit := round.StartAudit()
+	gameState, wager, err := engine.PlayBlackjack(round, prevState, data.Action, data.Bet, engineDef.BlackjackConfig)
+	audit.Finish(prevState.NextGamestate)
+	if err != nil {
+		return
+	}
+
+	roundId := prevState.RoundId
+	if data.Action == engine.BlackjackDeal {
+		roundId = prevState.NextGamestate
+	}
+	gameState.GameStateV3 = engine.GameStateV3{
+		Id:                prevState.NextGamestate,
+		Game:              data.Game,
+		Version:           "3",
+		Currency:          prevState.Currency,
+		RoundId:           roundId,
+		PreviousGamestate: data.PreviousID,
+		NextGamestate:     rng.Uuid(),
+		// every step draws its cards with its own seed
+		RngSeed: round.Seed,
+	}
+	logger.Debugf("getBlackjackResults gameState.Id=%s action=%s gameState.RoundId=%s", gameState.Id, data.Action, gameState.RoundId)
+
+	if wager > 0 {
+		gameState.Transactions = append(gameState.Transactions, engine.WalletTransaction{
+			Id:     gameState.Id,
+			Amount: engine.Money{Amount: wager, Currency: txStore.Amount.Currency},
+			Type:   "WAGER",
+		})
+	}
+	if !gameState.InProgress() || wager == 0 {
+		// a step that wagers nothing stores its state with an empty payout, as the feature steps of the slots do
+		txId := gameState.Id
+		if wager > 0 {
+			txId = rng.Uuid()
+		}
+		var win engine.Fixed
+		if !gameState.InProgress() {
+			win = gameState.Win
+		}
+		gameState.Transactions = append(gameState.Transactions, engine.WalletTransaction{
+			Id:     txId,
+			Amount: engine.Money{Amount: win, Currency: txStore.Amount.Currency},
+			Type:   "PAYOUT",
+		})
+	}
+
+	autoClose := data.AutoClose && !gameState.InProgress()
+	if autoClose {
+		gameState.Closed = true
+	}
+
+	var balance store.BalanceStore
+	logger.Debugf("processing state: %#v", gameState)
+	stateBytes := game.SerializeState(&gameState)
+	token := txStore.Token
+	roundStatus := store.RoundStatusOpen
+	for txIdx, transaction := range gameState.Transactions {
+		logger.Debugf("performing transaction %#v", transaction)
+		AppendHistory(&txStore, transaction)
+		if autoClose && txIdx+1 == len(gameState.Transactions) {
+			logger.Debugf("last transaction of the settled round, set RoundStatusClose")
+			roundStatus = store.RoundStatusClose
+		}
+		tx := store.TransactionStore{
+			TransactionId:       transaction.Id,
+			Token:               token,
+			Category:            store.Category(transaction.Type),
+			RoundStatus:         roundStatus,
+			PlayerId:            txStore.PlayerId,
+			GameId:              data.Game,
+			RoundId:             gameState.RoundId,
+			Amount:              transaction.Amount,
+			ParentTransactionId: "",
+			TxTime:              time.Now(),
+			GameState:           stateBytes,
+			BetLimitSettingCode: txStore.BetLimitSettingCode,
+			FreeGames:           store.FreeGamesStore{NoOfFreeSpins: 0, CampaignRef: ""},
+			Ttl:                 gameState.GetTtl(),
+			History:             txStore.History,
+		}
+		balance, err = TransactionByWallet(token, data.Wallet, tx)
+		if err != nil {
+			return
+		}
+		token = balance.Token
+	}
+
+	response = fillBlackjackPlayResponse(gameState, balance)
+	response.RealityCheck = store.DueRealityCheck(balance.Token)
+	return
+}
+
+// closeBlackjack only closes settled rounds, a round that waits for the player is finished by playing it
+func closeBlackjack(istate engine.IGameStateV3) rgse.RGSErr {
+	state := istate.(*engine.GameStateBlackjack)
+	if state.InProgress() {
+		rgserr := rgse.Create(rgse.IncompleteRoundError)
+		rgserr.AppendErrorText(fmt.Sprintf("blackjack round %s waits for one of %v", state.RoundId, state.Actions))
+		return rgserr
+	}
+	return nil
+}
+
+func fillBlackjackPlayResponse(gameState engine.GameStateBlackjack, balance store.BalanceStore) GamePlayResponseBlackjack {
+	response := GamePlayResponseBlackjack{
+		GamePlayResponseV3: GamePlayResponseV3{
+			Token:   balance.Token,
+			StateId: gameState.Id,
+			RoundId: gameState.RoundId,
+			Balance: BalanceResponseV3{
+				Amount: balance.Balance,
+			},
+			Bet:    gameState.Bet,
+			Win:    gameState.Win,
+			Closed: gameState.Closed,
+		},
+		Action:    gameState.Action,
+		Hands:     make([]HandBlackjack, len(gameState.Hands)),
+		Active:    gameState.Active,
+		Actions:   gameState.Actions,
+		Insurance: gameState.Insurance,
+	}
+	dealer := gameState.Dealer
+	if gameState.InProgress() && len(dealer) > 1 {
+		dealer = dealer[:1]
+	}
+	response.Dealer = MakeHandBlackjack(dealer)
+	for i, h := range gameState.Hands {
+		hand := MakeHandBlackjack(h.Cards)
+		hand.Bet = h.Bet
+		hand.Doubled = h.Doubled
+		hand.Split = h.Split
+		hand.Result = h.Result
+		hand.Win = h.Win
+		response.Hands[i] = hand
+	}
+	return response
+}
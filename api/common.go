@@ -16,6 +16,14 @@ type SetBalanceParams struct {
 	Balance engine.Money `json:"balance"`
 }
 
+// SetRGLimitsParams sets the responsible gaming limits of the session of a demo player
+type SetRGLimitsParams struct {
+	LossLimit    engine.Fixed `json:"lossLimit"`
+	WagerLimit   engine.Fixed `json:"wagerLimit"`
+	TimeLimit    int          `json:"timeLimit"`    // minutes
+	RealityCheck int          `json:"realityCheck"` // minutes
+}
+
 type PlayCheckExtParams struct {
 	Feeds []store.RestTransactiondata `json:"feeds"`
 }
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/parameterSelector"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/store"
+)
+
+// GameTypeV3 holds the handlers of a V3 game type. The state type of the game type is registered in the store with
+// store.RegisterGameV3 under the same category.
+type GameTypeV3 struct {
+	Init func(player store.PlayerStore, engineId string, wallet string, body []byte, engineConf engine.EngineConfig, token store.Token, state []byte, jurisdiction parameterSelector.Jurisdiction) (IGameInitResponseV3, rgse.RGSErr)
+	Play func(engineId string, wallet string, body []byte, txStore store.TransactionStore, jurisdiction parameterSelector.Jurisdiction) (IGamePlayResponseV3, rgse.RGSErr)
+	// Close checks that the round of the state can be closed and closes it, the state is only marked closed if nil
+	Close func(istate engine.IGameStateV3) rgse.RGSErr
+	// MultiStep game types play a round in several plays, a play may continue the open round of the previous state
+	MultiStep    bool
+	Playcheck    func(istate engine.IGameStateV3, w http.ResponseWriter)
+	PlaycheckExt func(r *http.Request, w http.ResponseWriter, params PlayCheckExtParams, istate engine.IGameStateV3) (PlaycheckExtResponse, error)
+}
+
+// the V3 game types by the category of their engines in the game config
+var gameTypesV3 = map[string]GameTypeV3{}
+
+// RegisterGameTypeV3 registers the handlers of the V3 game type of the category
+func RegisterGameTypeV3(category string, gameType GameTypeV3) GameTypeV3 {
+	if _, exists := gameTypesV3[category]; exists {
+		panic(fmt.Sprintf("V3 game type %s already registered", category))
+	}
+	gameTypesV3[category] = gameType
+	return gameType
+}
+
+func gameTypeV3ByEngine(engineId string) (GameTypeV3, rgse.RGSErr) {
+	gameType, ok := gameTypesV3[config.GetCategoryFromEngine(engineId)]
+	if !ok {
+		rgserr := rgse.Create(rgse.EngineNotFoundError)
+		rgserr.AppendErrorText(fmt.Sprintf("v3 api has no support for engineId %s", engineId))
+		return GameTypeV3{}, rgserr
+	}
+	return gameType, nil
+}
+
+func gameTypeV3ByGame(game string) (GameTypeV3, rgse.RGSErr) {
+	engineId, rgserr := config.GetEngineFromGame(game)
+	if rgserr != nil {
+		return GameTypeV3{}, rgserr
+	}
+	return gameTypeV3ByEngine(engineId)
+}
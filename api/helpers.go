@@ -8,6 +8,7 @@ import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
 	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/parameterSelector"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/store"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
@@ -93,8 +94,11 @@ func DefaultTitleName(name string) string {
 }
 
 func getCascadePositions(state engine.Gamestate) []int {
+	if len(state.CascadePositions) > 0 {
+		return state.CascadePositions
+	}
 	if strings.Contains(state.Action, "cascade") || state.Action == "pushreels" {
-		// this is a hack for now, needed for recovery. potentially in the future we add a proper cascade positions field to the gamestate message
+		// gamestates stored before the cascade positions field encoded the positions in the selected win lines
 		return state.SelectedWinLines
 	}
 	return nil
@@ -118,3 +122,22 @@ func adjustPrizes(gamestate engine.Gamestate) []engine.Prize {
 	}
 	return prizes
 }
+
+// validateStakeValue checks that the bet of a table or instant game is one of the stake values and within the bet limits
+func validateStakeValue(game string, bet engine.Fixed, txStore store.TransactionStore) rgse.RGSErr {
+	stakeValues, _, minBet, maxBet, prmerr := parameterSelector.GetGameplayParameters(engine.Money{Amount: 0, Currency: txStore.Amount.Currency}, txStore.BetLimitSettingCode, game, txStore.BetSettingId)
+	if prmerr != nil {
+		return prmerr
+	}
+	if (minBet > 0 && bet < minBet) || (maxBet > 0 && bet > maxBet) {
+		logger.Debugf("bet %s is outside the limits %s - %s", bet.ValueAsString(), minBet.ValueAsString(), maxBet.ValueAsString())
+		return rgse.Create(rgse.InvalidStakeError)
+	}
+	for _, stake := range stakeValues {
+		if stake == bet {
+			return nil
+		}
+	}
+	logger.Debugf("bet %s is not one of the stake values %v", bet.ValueAsString(), stakeValues)
+	return rgse.Create(rgse.InvalidStakeError)
+}
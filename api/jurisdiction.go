@@ -0,0 +1,69 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/parameterSelector"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/store"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// JurisdictionResponse tells the client the rules of the jurisdiction that the player plays under
+type JurisdictionResponse struct {
+	parameterSelector.Jurisdiction
+	MaxStake engine.Fixed `json:"maxStake,omitempty"` // max amount wagered on a round in the currency of the player
+}
+
+func newJurisdictionResponse(jurisdiction parameterSelector.Jurisdiction, currency string) *JurisdictionResponse {
+	if jurisdiction.Code == "" {
+		return nil
+	}
+	return &JurisdictionResponse{Jurisdiction: jurisdiction, MaxStake: jurisdiction.MaxStakeFor(currency)}
+}
+
+func jurisdictionError(jurisdiction parameterSelector.Jurisdiction, reason string) rgse.RGSErr {
+	logger.Debugf("jurisdiction %v: %v", jurisdiction.Code, reason)
+	rgserr := rgse.Create(rgse.JurisdictionRestricted)
+	rgserr.AppendErrorText(reason)
+	return rgserr
+}
+
+// validateJurisdictionPlay enforces the rules of the jurisdiction on the way a play is requested by the client
+func validateJurisdictionPlay(jurisdiction parameterSelector.Jurisdiction, autoplay bool, turbo bool) rgse.RGSErr {
+	switch {
+	case autoplay && jurisdiction.NoAutoplay:
+		return jurisdictionError(jurisdiction, "autoplay is not allowed")
+	case turbo && jurisdiction.NoTurbo:
+		return jurisdictionError(jurisdiction, "turbo play is not allowed")
+	}
+	return nil
+}
+
+// validateJurisdictionRound enforces the rules of the jurisdiction on a new round, the wager is the amount charged for
+// the round and txStore holds the last transaction of the player in the game
+func validateJurisdictionRound(jurisdiction parameterSelector.Jurisdiction, txStore store.TransactionStore, wager engine.Money) rgse.RGSErr {
+	if jurisdiction.MinSpinDuration > 0 && !txStore.TxTime.IsZero() {
+		elapsed := time.Since(txStore.TxTime)
+		if elapsed < time.Duration(jurisdiction.MinSpinDuration)*time.Millisecond {
+			return jurisdictionError(jurisdiction, fmt.Sprintf("round started %v after the last one, the minimum is %vms", elapsed, jurisdiction.MinSpinDuration))
+		}
+	}
+	return validateJurisdictionStake(jurisdiction, wager)
+}
+
+// validateJurisdictionStake enforces the max stake of the jurisdiction on the wagers of a round, the stake is their sum
+func validateJurisdictionStake(jurisdiction parameterSelector.Jurisdiction, stake engine.Money) rgse.RGSErr {
+	if maxStake := jurisdiction.MaxStakeFor(stake.Currency); maxStake > 0 && stake.Amount > maxStake {
+		return jurisdictionError(jurisdiction, fmt.Sprintf("wager %v is above the max stake %v", stake.Amount.ValueAsString(), maxStake.ValueAsString()))
+	}
+	return nil
+}
+
+// startRGSession starts the responsible gaming session of the player, the reality checks of the session are at least
+// as frequent as the jurisdiction requires
+func startRGSession(player store.PlayerStore, jurisdiction parameterSelector.Jurisdiction) rgse.RGSErr {
+	return store.StartRGSession(player.Token, player.PlayerId, player.Balance.Currency, time.Duration(jurisdiction.RealityCheck)*time.Minute)
+}
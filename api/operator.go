@@ -0,0 +1,24 @@
+package api
+
+import (
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+)
+
+// OperatorInfoResponse tells the client what the operator that launched the game supports
+type OperatorInfoResponse struct {
+	Code         string   `json:"code"`
+	Platforms    []string `json:"platforms,omitempty"`
+	Languages    []string `json:"languages,omitempty"`
+	FreeGames    bool     `json:"freeGames"`
+	PlaycheckUrl string   `json:"playcheckUrl,omitempty"`
+}
+
+func newOperatorInfoResponse(operator config.Operator) *OperatorInfoResponse {
+	return &OperatorInfoResponse{
+		Code:         operator.Code,
+		Platforms:    operator.Platforms,
+		Languages:    operator.Languages,
+		FreeGames:    operator.FreeGames,
+		PlaycheckUrl: operator.PlaycheckUrl,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"html/template"
 	"net/http"
 	"net/url"
+	"sort"
 
 	"github.com/go-chi/chi/v5"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
@@ -14,6 +15,9 @@ import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
+// playcheckCommonTemplate defines the head and the logo shared by the playchecks of the V3 games
+const playcheckCommonTemplate = "templates/api/playcheck/playcheckcommon.html"
+
 type PlaycheckFields struct {
 	Gamestate    engine.Gamestate
 	GameID       string
@@ -66,12 +70,12 @@ func playcheck(request *http.Request, w http.ResponseWriter) {
 	}
 	istate, rgserr := DeserializeV3Gamestate(gsbytes)
 	if rgserr == nil {
-		switch istate.Base().Game {
-		case "dragon-roulette":
-			playcheckRoulette(istate, w)
-		default:
+		gameType, gterr := gameTypeV3ByGame(istate.Base().Game)
+		if gterr != nil || gameType.Playcheck == nil {
 			logger.Infof("Can not produce playcheck for unknown V3 game \"%s\"", istate.Base().Game)
+			return
 		}
+		gameType.Playcheck(istate, w)
 		return
 	}
 
@@ -130,7 +134,10 @@ type PlaycheckRoulette struct {
 	Currency  string
 	Bets      []PlaycheckBetRoulette
 	Prizes    []PlaycheckPrizeRoulette
+	CallBets  []PlaycheckCallBetRoulette
+	Prison    []PlaycheckBetRoulette
 	Symbol    int
+	Number    string
 	ColSize   int
 }
 
@@ -143,6 +150,13 @@ type PlaycheckBetRoulette struct {
 type PlaycheckPrizeRoulette struct {
 	Index  string
 	Amount string
+	Rule   string
+}
+
+type PlaycheckCallBetRoulette struct {
+	Name   string
+	Number string
+	Amount string
 }
 
 func playcheckRoulette(istate engine.IGameStateV3, w http.ResponseWriter) {
@@ -160,26 +174,30 @@ func playcheckRoulette(istate engine.IGameStateV3, w http.ResponseWriter) {
 		},
 	}
 	tpl := template.New("playcheckroulette.html").Funcs(fm)
-	t, err := tpl.ParseFiles("templates/api/playcheck/playcheckroulette.html")
+	t, err := tpl.ParseFiles("templates/api/playcheck/playcheckroulette.html", playcheckCommonTemplate)
 	if err != nil {
 		logger.Errorf("Template parsing error: ", err)
 		fmt.Fprint(w, "<center><h1>Template parsing error </h1></center>")
 		return
 	}
 
-	bets := make([]PlaycheckBetRoulette, len(state.Bets))
-	idx := 0
-	for k, b := range state.Bets {
-		bets[idx].Index = k
-		bets[idx].Amount = b.Amount.ValueAsString() + " " + state.Currency
-		bets[idx].Symbols = b.Symbols
-		idx++
-	}
+	bets := makePlaycheckBetsRoulette(state.Bets, state.Currency)
+	prison := makePlaycheckBetsRoulette(state.Prison, state.Currency)
 
 	prizes := make([]PlaycheckPrizeRoulette, len(state.Prizes))
 	for i, p := range state.Prizes {
 		prizes[i].Index = p.Index
 		prizes[i].Amount = p.Amount.ValueAsString() + " " + state.Currency
+		prizes[i].Rule = p.Rule
+	}
+
+	callBets := make([]PlaycheckCallBetRoulette, len(state.CallBets))
+	for i, c := range state.CallBets {
+		callBets[i].Name = c.Name
+		if c.Name == engine.RouletteNeighbours {
+			callBets[i].Number = engine.RouletteSymbolName(c.Number)
+		}
+		callBets[i].Amount = c.Amount.ValueAsString() + " " + state.Currency
 	}
 
 	fields := PlaycheckRoulette{
@@ -190,7 +208,10 @@ func playcheckRoulette(istate engine.IGameStateV3, w http.ResponseWriter) {
 		Currency:  state.Currency,
 		Bets:      bets,
 		Prizes:    prizes,
+		CallBets:  callBets,
+		Prison:    prison,
 		Symbol:    state.Symbol,
+		Number:    engine.RouletteSymbolName(state.Symbol),
 		ColSize:   1,
 	}
 	err = t.Execute(w, fields)
@@ -200,3 +221,145 @@ func playcheckRoulette(istate engine.IGameStateV3, w http.ResponseWriter) {
 		return
 	}
 }
+
+type PlaycheckBlackjack struct {
+	Gamestate engine.GameStateBlackjack
+	Wager     string
+	Payout    string
+	Currency  string
+	Insurance string
+	Actions   []string
+	Dealer    PlaycheckHandBlackjack
+	Hands     []PlaycheckHandBlackjack
+}
+
+type PlaycheckHandBlackjack struct {
+	Cards  []string
+	Value  int
+	Bet    string
+	Result string
+	Win    string
+}
+
+type PlaycheckScratch struct {
+	Gamestate  engine.GameStateScratch
+	Wager      string
+	Payout     string
+	Currency   string
+	Multiplier int
+	Rows       [][]PlaycheckCellScratch
+}
+
+type PlaycheckCellScratch struct {
+	Symbol  int
+	Matched bool
+}
+
+func playcheckBlackjack(istate engine.IGameStateV3, w http.ResponseWriter) {
+	var state *engine.GameStateBlackjack = istate.(*engine.GameStateBlackjack)
+
+	logger.Debugf("creating playcheck blackjack for state %#v", state)
+
+	t, err := template.New("playcheckblackjack.html").ParseFiles("templates/api/playcheck/playcheckblackjack.html", playcheckCommonTemplate)
+	if err != nil {
+		logger.Errorf("Template parsing error: ", err)
+		fmt.Fprint(w, "<center><h1>Template parsing error </h1></center>")
+		return
+	}
+
+	// the hole card of the dealer is shown once the round is settled
+	dealer := state.Dealer
+	if state.InProgress() && len(dealer) > 1 {
+		dealer = dealer[:1]
+	}
+	dealerHand := MakeHandBlackjack(dealer)
+	hands := make([]PlaycheckHandBlackjack, len(state.Hands))
+	for i, h := range state.Hands {
+		hand := MakeHandBlackjack(h.Cards)
+		hands[i] = PlaycheckHandBlackjack{
+			Cards:  hand.Cards,
+			Value:  hand.Value,
+			Bet:    h.Bet.ValueAsString(),
+			Result: h.Result,
+			Win:    h.Win.ValueAsString(),
+		}
+	}
+	insurance := ""
+	if state.Insurance > 0 {
+		insurance = state.Insurance.ValueAsString()
+	}
+
+	fields := PlaycheckBlackjack{
+		Gamestate: *state,
+		Wager:     state.Bet.ValueAsString(),
+		Payout:    state.Win.ValueAsString(),
+		Currency:  state.Currency,
+		Insurance: insurance,
+		Actions:   state.Actions,
+		Dealer:    PlaycheckHandBlackjack{Cards: dealerHand.Cards, Value: dealerHand.Value},
+		Hands:     hands,
+	}
+	err = t.Execute(w, fields)
+	if err != nil {
+		logger.Errorf("template executing error: ", err)
+		fmt.Fprint(w, "<center><h1>Template Execution Error</h1></center>")
+		return
+	}
+}
+
+func playcheckScratch(istate engine.IGameStateV3, w http.ResponseWriter) {
+	var state *engine.GameStateScratch = istate.(*engine.GameStateScratch)
+
+	logger.Debugf("creating playcheck scratch for state %#v", state)
+
+	t, err := template.New("playcheckscratch.html").ParseFiles("templates/api/playcheck/playcheckscratch.html", playcheckCommonTemplate)
+	if err != nil {
+		logger.Errorf("Template parsing error: ", err)
+		fmt.Fprint(w, "<center><h1>Template parsing error </h1></center>")
+		return
+	}
+
+	matched := make(map[int]bool, len(state.Positions))
+	for _, p := range state.Positions {
+		matched[p] = true
+	}
+	rows := [][]PlaycheckCellScratch{}
+	for r, cells := range scratchRows(state.Layout, state.Columns) {
+		row := make([]PlaycheckCellScratch, len(cells))
+		for c, symbol := range cells {
+			row[c] = PlaycheckCellScratch{Symbol: symbol, Matched: matched[r*state.Columns+c]}
+		}
+		rows = append(rows, row)
+	}
+
+	fields := PlaycheckScratch{
+		Gamestate:  *state,
+		Wager:      state.Bet.ValueAsString(),
+		Payout:     state.Win.ValueAsString(),
+		Currency:   state.Currency,
+		Multiplier: state.Multiplier,
+		Rows:       rows,
+	}
+	err = t.Execute(w, fields)
+	if err != nil {
+		logger.Errorf("template executing error: ", err)
+		fmt.Fprint(w, "<center><h1>Template Execution Error</h1></center>")
+		return
+	}
+}
+
+// makePlaycheckBetsRoulette lists the bets in the order of their index
+func makePlaycheckBetsRoulette(bets map[string]engine.BetRoulette, currency string) []PlaycheckBetRoulette {
+	indexes := make([]string, 0, len(bets))
+	for k := range bets {
+		indexes = append(indexes, k)
+	}
+	sort.Strings(indexes)
+	list := make([]PlaycheckBetRoulette, len(indexes))
+	for i, k := range indexes {
+		list[i].Index = k
+		list[i].Amount = bets[k].Amount.ValueAsString() + " " + currency
+		list[i].Symbols = bets[k].Symbols
+	}
+	return list
+}
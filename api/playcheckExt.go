@@ -23,14 +23,43 @@ type PlaycheckExtBaseReq struct {
 
 type PlaycheckExtRequest struct {
 	PlaycheckExtBaseReq
-	Rounds [][][]int `json:"rounds"`
+	Rounds [][][]int           `json:"rounds"`
+	Gamble *engine.GambleState `json:"gamble,omitempty"`
+	Capped bool                `json:"capped,omitempty"`
 }
 
 type PlaycheckExtRouletteRequest struct {
 	PlaycheckExtBaseReq
-	Symbol int               `json:"symbol"`
-	Bets   map[string]string `json:"bets"`
-	Prizes map[string]string `json:"prizes"`
+	Symbol   int                      `json:"symbol"`
+	Bets     map[string]string        `json:"bets"`
+	Prizes   map[string]string        `json:"prizes"`
+	CallBets []engine.RouletteCallBet `json:"callBets,omitempty"`
+	Prison   map[string]string        `json:"prison,omitempty"`
+}
+
+type PlaycheckExtBlackjackRequest struct {
+	PlaycheckExtBaseReq
+	Dealer    []string                    `json:"dealer"`
+	Hands     []PlaycheckExtHandBlackjack `json:"hands"`
+	Insurance float64                     `json:"insurance,omitempty"`
+	Settled   bool                        `json:"settled"`
+}
+
+type PlaycheckExtHandBlackjack struct {
+	Cards  []string `json:"cards"`
+	Bet    float64  `json:"bet"`
+	Result string   `json:"result,omitempty"`
+	Win    float64  `json:"win"`
+}
+
+type PlaycheckExtScratchRequest struct {
+	PlaycheckExtBaseReq
+	Multiplier int     `json:"multiplier"`
+	Symbol     int     `json:"symbol,omitempty"`
+	Layout     [][]int `json:"layout"`
+	Positions  []int   `json:"positions,omitempty"`
+	Pool       string  `json:"pool,omitempty"`
+	Ticket     int     `json:"ticket,omitempty"`
 }
 
 type PlaycheckExtResponse struct {
@@ -56,13 +85,11 @@ func playcheckExt(r *http.Request, w http.ResponseWriter, params PlayCheckExtPar
 
 	istate, rgserr := DeserializeV3Gamestate(gsbytes)
 	if rgserr == nil {
-		switch istate.Base().Game {
-		case "dragon-roulette":
-			return playcheckExtRoulette(r, w, params, istate)
-		default:
-			err = fmt.Errorf("Can not produce playcheckExt for unknown V3 game \"%s\"", istate.Base().Game)
+		gameType, gterr := gameTypeV3ByGame(istate.Base().Game)
+		if gterr != nil || gameType.PlaycheckExt == nil {
+			return PlaycheckExtResponse{}, fmt.Errorf("Can not produce playcheckExt for unknown V3 game \"%s\"", istate.Base().Game)
 		}
-		return PlaycheckExtResponse{}, err
+		return gameType.PlaycheckExt(r, w, params, istate)
 	}
 
 	state := store.DeserializeGamestateFromBytes(gsbytes)
@@ -116,6 +143,8 @@ func playcheckExt(r *http.Request, w http.ResponseWriter, params PlayCheckExtPar
 			Currency:  tx.CurrencyUnit,
 		},
 		Rounds: rounds,
+		Gamble: tx.Metadata.Vendor.State.Gamble,
+		Capped: tx.Metadata.Vendor.State.Capped,
 	}
 
 	js, err := json.Marshal(req)
@@ -174,9 +203,21 @@ func playcheckExtRoulette(r *http.Request, w http.ResponseWriter, params PlayChe
 	for k, v := range state.Bets {
 		bets[k] = v.Amount.ValueAsString()
 	}
-	prizes := make(map[string]string)
+	// a bet held en prison from the previous spin can win on the index of a bet of this spin
+	prizeAmounts := make(map[string]engine.Fixed)
 	for _, p := range state.Prizes {
-		prizes[p.Index] = p.Amount.ValueAsString()
+		prizeAmounts[p.Index] += p.Amount
+	}
+	prizes := make(map[string]string)
+	for k, v := range prizeAmounts {
+		prizes[k] = v.ValueAsString()
+	}
+	var prison map[string]string
+	for k, v := range state.Prison {
+		if prison == nil {
+			prison = make(map[string]string)
+		}
+		prison[k] = v.Amount.ValueAsString()
 	}
 
 	gameId := tx.Metadata.ExtItemId
@@ -190,9 +231,11 @@ func playcheckExtRoulette(r *http.Request, w http.ResponseWriter, params PlayChe
 			WinAmount: win,
 			Currency:  tx.CurrencyUnit,
 		},
-		Symbol: state.Symbol,
-		Bets:   bets,
-		Prizes: prizes,
+		Symbol:   state.Symbol,
+		Bets:     bets,
+		Prizes:   prizes,
+		CallBets: state.CallBets,
+		Prison:   prison,
 	}
 
 	js, err := json.Marshal(req)
@@ -208,3 +251,99 @@ func playcheckExtRoulette(r *http.Request, w http.ResponseWriter, params PlayChe
 		Url: url,
 	}, nil
 }
+
+func playcheckExtBlackjack(r *http.Request, w http.ResponseWriter, params PlayCheckExtParams, istate engine.IGameStateV3) (PlaycheckExtResponse, error) {
+	if len(params.Feeds) == 0 {
+		return PlaycheckExtResponse{}, fmt.Errorf("empty feeds")
+	}
+
+	var txdata store.RestTransactiondata = params.Feeds[0]
+
+	var state *engine.GameStateBlackjack = istate.(*engine.GameStateBlackjack)
+	logger.Debugf("gamestate: %#v", state)
+
+	// the state of a step holds the wagers and the win of the whole round
+	settled := !state.InProgress()
+	dealer := state.Dealer
+	if !settled && len(dealer) > 1 {
+		dealer = dealer[:1]
+	}
+	hands := make([]PlaycheckExtHandBlackjack, len(state.Hands))
+	for i, h := range state.Hands {
+		hands[i] = PlaycheckExtHandBlackjack{
+			Cards:  MakeHandBlackjack(h.Cards).Cards,
+			Bet:    h.Bet.ValueAsFloat64(),
+			Result: h.Result,
+			Win:    h.Win.ValueAsFloat64(),
+		}
+	}
+
+	req := PlaycheckExtBlackjackRequest{
+		PlaycheckExtBaseReq: PlaycheckExtBaseReq{
+			Id:        txdata.Metadata.RoundId,
+			Start:     txdata.TxTime,
+			End:       txdata.TxTime,
+			BetAmount: state.Bet.ValueAsFloat64(),
+			WinAmount: state.Win.ValueAsFloat64(),
+			Currency:  txdata.CurrencyUnit,
+		},
+		Dealer:    MakeHandBlackjack(dealer).Cards,
+		Hands:     hands,
+		Insurance: state.Insurance.ValueAsFloat64(),
+		Settled:   settled,
+	}
+
+	js, err := json.Marshal(req)
+	if err != nil {
+		return PlaycheckExtResponse{}, err
+	}
+	data := base64.StdEncoding.EncodeToString(js)
+
+	url := fmt.Sprintf(config.GlobalConfig.ExtPlaycheck+"?game=%s&d=%s", txdata.Metadata.ExtItemId, data)
+	logger.Debugf("%s", url)
+
+	return PlaycheckExtResponse{
+		Url: url,
+	}, nil
+}
+
+func playcheckExtScratch(r *http.Request, w http.ResponseWriter, params PlayCheckExtParams, istate engine.IGameStateV3) (PlaycheckExtResponse, error) {
+	if len(params.Feeds) == 0 {
+		return PlaycheckExtResponse{}, fmt.Errorf("empty feeds")
+	}
+
+	var txdata store.RestTransactiondata = params.Feeds[0]
+
+	var state *engine.GameStateScratch = istate.(*engine.GameStateScratch)
+	logger.Debugf("gamestate: %#v", state)
+
+	req := PlaycheckExtScratchRequest{
+		PlaycheckExtBaseReq: PlaycheckExtBaseReq{
+			Id:        txdata.Metadata.RoundId,
+			Start:     txdata.TxTime,
+			End:       txdata.TxTime,
+			BetAmount: state.Bet.ValueAsFloat64(),
+			WinAmount: state.Win.ValueAsFloat64(),
+			Currency:  txdata.CurrencyUnit,
+		},
+		Multiplier: state.Multiplier,
+		Symbol:     state.Symbol,
+		Layout:     scratchRows(state.Layout, state.Columns),
+		Positions:  state.Positions,
+		Pool:       state.Pool,
+		Ticket:     state.Ticket,
+	}
+
+	js, err := json.Marshal(req)
+	if err != nil {
+		return PlaycheckExtResponse{}, err
+	}
+	data := base64.StdEncoding.EncodeToString(js)
+
+	url := fmt.Sprintf(config.GlobalConfig.ExtPlaycheck+"?game=%s&d=%s", txdata.Metadata.ExtItemId, data)
+	logger.Debugf("%s", url)
+
+	return PlaycheckExtResponse{
+		Url: url,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/store"
+)
+
+// PendingTransactionResponse is a transaction of the reconciliation queue
+type PendingTransactionResponse struct {
+	Id                  string       `json:"id"`
+	Wallet              string       `json:"wallet"`
+	Action              string       `json:"action"`
+	Status              string       `json:"status"`
+	Category            string       `json:"category"`
+	ParentTransactionId string       `json:"parentTransactionId,omitempty"`
+	PlayerId            string       `json:"playerId,omitempty"`
+	Game                string       `json:"game"`
+	RoundId             string       `json:"roundId"`
+	Amount              engine.Money `json:"amount"`
+	Attempts            int          `json:"attempts"`
+	LastError           string       `json:"lastError,omitempty"`
+	Note                string       `json:"note,omitempty"`
+	Created             time.Time    `json:"created"`
+	Updated             time.Time    `json:"updated"`
+	NextAttempt         time.Time    `json:"nextAttempt"`
+}
+
+func (resp PendingTransactionResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// PendingTransactionsResponse lists the transactions of the reconciliation queue
+type PendingTransactionsResponse struct {
+	Transactions []PendingTransactionResponse `json:"transactions"`
+}
+
+func (resp PendingTransactionsResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// ResolveParams is the note of a transaction that was settled by hand
+type ResolveParams struct {
+	Note string `json:"note"`
+}
+
+func newPendingTransactionResponse(p store.PendingTransaction) PendingTransactionResponse {
+	return PendingTransactionResponse{
+		Id:                  p.Id,
+		Wallet:              p.Wallet,
+		Action:              string(p.Action),
+		Status:              string(p.Status),
+		Category:            string(p.Transaction.Category),
+		ParentTransactionId: p.Transaction.ParentTransactionId,
+		PlayerId:            p.Transaction.PlayerId,
+		Game:                p.Transaction.GameId,
+		RoundId:             p.Transaction.RoundId,
+		Amount:              p.Transaction.Amount,
+		Attempts:            p.Attempts,
+		LastError:           p.LastError,
+		Note:                p.Note,
+		Created:             p.Created,
+		Updated:             p.Updated,
+		NextAttempt:         p.NextAttempt,
+	}
+}
+
+// processAdminAuthorization checks the admin token of the request, without a configured token the admin endpoints
+// are only open in devmode
+func processAdminAuthorization(request *http.Request) rgse.RGSErr {
+	adminToken := config.GlobalConfig.Reconcile.AdminToken
+	if adminToken == "" {
+		if config.GlobalConfig.DevMode {
+			return nil
+		}
+		return rgse.Create(rgse.InvalidCredentials)
+	}
+	tokenInfo := strings.Split(request.Header.Get("Authorization"), " ")
+	if len(tokenInfo) != 2 || tokenInfo[0] != "MAVERICK-Admin-Token" || tokenInfo[1] != adminToken {
+		return rgse.Create(rgse.InvalidCredentials)
+	}
+	return nil
+}
+
+func pendingTransactions(request *http.Request) (PendingTransactionsResponse, rgse.RGSErr) {
+	if err := processAdminAuthorization(request); err != nil {
+		return PendingTransactionsResponse{}, err
+	}
+	status := store.ReconcileStatus(strings.ToUpper(request.URL.Query().Get("status")))
+	pending := store.PendingTransactions(status)
+	resp := PendingTransactionsResponse{Transactions: make([]PendingTransactionResponse, len(pending))}
+	for i, p := range pending {
+		resp.Transactions[i] = newPendingTransactionResponse(p)
+	}
+	return resp, nil
+}
+
+func retryPendingTransaction(request *http.Request, id string) (PendingTransactionResponse, rgse.RGSErr) {
+	if err := processAdminAuthorization(request); err != nil {
+		return PendingTransactionResponse{}, err
+	}
+	// a failed attempt is recorded on the transaction and is not an error of the request
+	p, err := store.RetryPendingTransaction(id)
+	if err != nil && p.Id == "" {
+		return PendingTransactionResponse{}, err
+	}
+	return newPendingTransactionResponse(p), nil
+}
+
+func resolvePendingTransaction(request *http.Request, id string, note string) (PendingTransactionResponse, rgse.RGSErr) {
+	if err := processAdminAuthorization(request); err != nil {
+		return PendingTransactionResponse{}, err
+	}
+	p, err := store.ResolvePendingTransaction(id, note)
+	if err != nil {
+		return PendingTransactionResponse{}, err
+	}
+	return newPendingTransactionResponse(p), nil
+}
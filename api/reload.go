@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// ReloadEnginesResponse lists the result of reloading the config of every loaded engine
+type ReloadEnginesResponse struct {
+	Engines []engine.EngineReload `json:"engines"`
+}
+
+func (resp ReloadEnginesResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// reloadEngines activates the changed engine configs, rounds in progress finish on the config they began with
+func reloadEngines() ReloadEnginesResponse {
+	reloads := engine.ReloadEngineConfigs()
+	for _, reload := range reloads {
+		if reload.Error != "" {
+			logger.Errorf("reloading engine %s: %s", reload.EngineID, reload.Error)
+		}
+	}
+	return ReloadEnginesResponse{Engines: reloads}
+}
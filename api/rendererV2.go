@@ -28,6 +28,34 @@ type GameInitResponseV2 struct {
 	ReelSets         map[string]ReelResponse       `json:"reelSets,omitempty"` // base, freeSpin, etc. as keys  might want to have this as ReelSetResponse
 	BetMult          int                           `json:"betMultiplier"`
 	Features         []feature.Feature             `json:"featureConfigs,omitempty"`
+	BuyFeature       *BuyFeatureResponse           `json:"buyFeature,omitempty"`
+	BetModes         []BetModeResponse             `json:"betModes,omitempty"`
+	Gamble           *GambleResponse               `json:"gamble,omitempty"`
+	MaxWinMultiplier int                           `json:"maxWinMultiplier,omitempty"` // cap of the round win as a multiple of the wager of the round
+	Jurisdiction     *JurisdictionResponse         `json:"jurisdiction,omitempty"`
+	Operator         *OperatorInfoResponse         `json:"operator,omitempty"`
+}
+
+// BuyFeatureResponse describes the feature that can be bought, the price is a multiple of the total stake
+type BuyFeatureResponse struct {
+	Award           string       `json:"award"`
+	PriceMultiplier engine.Fixed `json:"priceMultiplier"`
+}
+
+// BetModeResponse describes a bet mode that can be selected for base rounds, the cost is a multiple of the total stake
+type BetModeResponse struct {
+	Name           string       `json:"name"`
+	CostMultiplier engine.Fixed `json:"costMultiplier"`
+}
+
+// GambleResponse describes the gamble that round wins can be staked on
+type GambleResponse struct {
+	Mode        string   `json:"mode"`
+	Choices     []string `json:"choices,omitempty"`
+	MaxSteps    int      `json:"maxSteps,omitempty"`
+	MaxWin      int      `json:"maxWin,omitempty"` // multiple of the round stake
+	CollectHalf bool     `json:"collectHalf"`
+	Ladder      []int    `json:"ladder,omitempty"`
 }
 
 func (gi GameInitResponseV2) Render(w http.ResponseWriter, r *http.Request) error {
@@ -109,6 +137,8 @@ type GameplayResponseV2 struct {
 	FSRemaining      *int                `json:"freeSpinsRemaining,omitempty"`
 	Balance          BalanceResponseV2   `json:"balance"`
 	View             [][]int             `json:"view"` // includes row above and below
+	ReelHeights      []int               `json:"reelHeights,omitempty"`
+	BetMode          string              `json:"betMode,omitempty"`
 	Prizes           []engine.Prize      `json:"wins"` // []WinResponseV2
 	NextAction       string              `json:"nextAction"`
 	Closed           bool                `json:"closed"`
@@ -116,35 +146,44 @@ type GameplayResponseV2 struct {
 	Gamification     *GamificationRespV2 `json:"gamification,omitempty"`
 	CascadePositions []int               `json:"cascadePositions,omitempty"`
 	RespinPrices     []engine.Fixed      `json:"respinPrices,omitempty"`
+	Gamble           *engine.GambleState `json:"gamble,omitempty"`          // the gamble step played by this state
+	GambleAvailable  bool                `json:"gambleAvailable,omitempty"` // the win of this state can be gambled
+	Capped           bool                `json:"capped,omitempty"`          // the round win reached the max win and the round was finished
+	RealityCheck     *store.RealityCheck `json:"realityCheck,omitempty"`    // the play of the session, returned at the reality check interval
 	Choices          []string            `json:"choices,omitempty"`
 	Features         []feature.Feature   `json:"features,omitempty"`
 	FeatureView      [][]int             `json:"featureview,omitempty"`
 }
 
 type RoundResponse struct {
-	MetaData  MetaResponse      `json:"meta"'`
-	SessionID store.Token       `json:"host/verified-token"`
-	RoundID   string            `json:"roundID"`
-	Stake     engine.Fixed      `json:"totalStake"`
-	LineBet   engine.Fixed      `json:"lineBet,omitempty"`
-	Win       engine.Fixed      `json:"win"`
-	Balance   BalanceResponseV2 `json:"balance"`
-	Spins     []SpinResponse    `json:"spins"`
+	MetaData     MetaResponse        `json:"meta"'`
+	SessionID    store.Token         `json:"host/verified-token"`
+	RoundID      string              `json:"roundID"`
+	Stake        engine.Fixed        `json:"totalStake"`
+	LineBet      engine.Fixed        `json:"lineBet,omitempty"`
+	Win          engine.Fixed        `json:"win"`
+	Balance      BalanceResponseV2   `json:"balance"`
+	Spins        []SpinResponse      `json:"spins"`
+	RealityCheck *store.RealityCheck `json:"realityCheck,omitempty"`
 }
 
 type SpinResponse struct {
-	Action           string            `json:"action"`
-	StateID          string            `json:"stateID"`
-	DefID            int               `json:"reelset"`
-	ReelsetID        string            `json:"reelsetId,omitempty"`
-	Win              engine.Fixed      `json:"win"`
-	Freespins        int               `json:"freespins"`
-	View             [][]int           `json:"view"`
-	Prizes           []engine.Prize    `json:"wins"`
-	Multiplier       int               `json:"multiplier"`
-	CascadePositions []int             `json:"cascadePositions,omitempty"`
-	Features         []feature.Feature `json:"features,omitempty"`
-	FeatureView      [][]int           `json:"featureview,omitempty"`
+	Action           string              `json:"action"`
+	StateID          string              `json:"stateID"`
+	DefID            int                 `json:"reelset"`
+	ReelsetID        string              `json:"reelsetId,omitempty"`
+	Win              engine.Fixed        `json:"win"`
+	Freespins        int                 `json:"freespins"`
+	View             [][]int             `json:"view"`
+	ReelHeights      []int               `json:"reelHeights,omitempty"`
+	BetMode          string              `json:"betMode,omitempty"`
+	Prizes           []engine.Prize      `json:"wins"`
+	Multiplier       int                 `json:"multiplier"`
+	CascadePositions []int               `json:"cascadePositions,omitempty"`
+	Gamble           *engine.GambleState `json:"gamble,omitempty"`
+	Capped           bool                `json:"capped,omitempty"`
+	Features         []feature.Feature   `json:"features,omitempty"`
+	FeatureView      [][]int             `json:"featureview,omitempty"`
 }
 
 type GamificationRespV2 struct {
@@ -237,6 +276,12 @@ func fillGamestateResponseV2(gamestate engine.Gamestate, balance store.BalanceSt
 		}
 	}
 
+	var gambleAvailable bool
+	if EC, err := gamestate.Engine(); err == nil && EC.HasGamble() {
+		_, err = EC.GambleOffer(gamestate)
+		gambleAvailable = err == nil
+	}
+
 	level, stage := gamestate.Gamification.GetLevelAndStage()
 	/*
 		for p := 0; p < len(gamestate.Prizes); p++ {
@@ -283,6 +328,8 @@ func fillGamestateResponseV2(gamestate engine.Gamestate, balance store.BalanceSt
 			FreeSpinInfo: &fsresp,
 		},
 		View:            gamestate.SymbolGrid,
+		ReelHeights:     gamestate.ReelHeights,
+		BetMode:         gamestate.BetMode,
 		Prizes:          adjustPrizes(gamestate), // gamestate.Prizes),
 		RoundMultiplier: gamestate.Multiplier,
 		Closed:          gamestate.Closed,
@@ -295,6 +342,9 @@ func fillGamestateResponseV2(gamestate engine.Gamestate, balance store.BalanceSt
 		},
 		CascadePositions: cascadePositions,
 		RespinPrices:     respinPrices,
+		Gamble:           gamestate.Gamble,
+		GambleAvailable:  gambleAvailable,
+		Capped:           gamestate.Capped,
 		Choices:          gamestate.GetChoices(),
 		Features:         gamestate.Features,
 		FeatureView:      gamestate.FeatureView,
@@ -398,6 +448,23 @@ func (initResp *GameInitResponseV2) FillEngineInfo(enginecfg engine.EngineConfig
 	if !config.GlobalConfig.IsV3() {
 		initResp.ReelSets = reelResp
 	}
+	if enginecfg.HasBuyFeature() {
+		price, err := enginecfg.BuyFeaturePrice()
+		if err == nil {
+			def := enginecfg.EngineDefs[enginecfg.DefIdByName(engine.BuyFeatureAction)]
+			initResp.BuyFeature = &BuyFeatureResponse{Award: def.BuyFeature.Award, PriceMultiplier: price}
+		} else {
+			logger.Errorf("buy feature price: %v", err.Error())
+		}
+	}
+	for _, mode := range enginecfg.BetModes {
+		initResp.BetModes = append(initResp.BetModes, BetModeResponse{Name: mode.Name, CostMultiplier: mode.CostMultiplier()})
+	}
+	initResp.MaxWinMultiplier = enginecfg.MaxWinMultiplier
+	if enginecfg.HasGamble() {
+		gamble := enginecfg.Gamble
+		initResp.Gamble = &GambleResponse{Mode: gamble.Mode, Choices: gamble.Choices(), MaxSteps: gamble.MaxSteps, MaxWin: gamble.MaxWin, CollectHalf: gamble.CollectHalf, Ladder: gamble.Ladder}
+	}
 	for k := range reelResp {
 		// per reel bet settings has been disabled, use first definition
 		initResp.BetMult = reelResp[k].BetMult
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/store"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// ReplayRoundParams identifies the gamestate to replay, either as base64 encoded gamestates or by the ids
+// of gamestates in the local store. The previous gamestate is optional for the first play of a round.
+type ReplayRoundParams struct {
+	State         string `json:"state"`
+	PreviousState string `json:"previousState"`
+	GameplayID    string `json:"gameplayID"`
+	PreviousID    string `json:"previousID"`
+}
+
+type ReplayRoundResponse struct {
+	Match    bool             `json:"match"`
+	Diff     []string         `json:"diff,omitempty"`
+	Stored   engine.Gamestate `json:"stored"`
+	Replayed engine.Gamestate `json:"replayed"`
+}
+
+func (resp ReplayRoundResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func replayRound(param ReplayRoundParams) (response ReplayRoundResponse, rgserr rgse.RGSErr) {
+	var stored, previous engine.Gamestate
+	stored, rgserr = replayGamestate(param.State, param.GameplayID)
+	if rgserr != nil {
+		return
+	}
+	if param.PreviousState != "" || param.PreviousID != "" {
+		previous, rgserr = replayGamestate(param.PreviousState, param.PreviousID)
+		if rgserr != nil {
+			return
+		}
+	}
+
+	var replayed engine.Gamestate
+	replayed, _, rgserr = engine.ReplayRound(previous, stored)
+	if rgserr != nil {
+		return
+	}
+	// compare after the same serialization round trip the stored gamestate went through
+	replayed = store.DeserializeGamestateFromBytes(store.SerializeGamestateToBytes(replayed))
+	diff := engine.DiffGamestates(stored, replayed)
+	logger.Infof("replayed gamestate %s with %d differences", stored.Id, len(diff))
+
+	response = ReplayRoundResponse{
+		Match:    len(diff) == 0,
+		Diff:     diff,
+		Stored:   stored,
+		Replayed: replayed,
+	}
+	return
+}
+
+func replayGamestate(encoded string, id string) (engine.Gamestate, rgse.RGSErr) {
+	var gsbytes []byte
+	if encoded != "" {
+		var err error
+		gsbytes, err = base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return engine.Gamestate{}, rgse.Create(rgse.B64Error)
+		}
+	} else if id != "" {
+		gamestateStore, rgserr := store.ServLocal.GamestateById(id)
+		if rgserr != nil {
+			return engine.Gamestate{}, rgserr
+		}
+		gsbytes = gamestateStore.GameState
+	} else {
+		rgserr := rgse.Create(rgse.InvalidParamsError)
+		rgserr.AppendErrorText(": no gamestate to replay")
+		return engine.Gamestate{}, rgserr
+	}
+	return store.DeserializeGamestateFromBytes(gsbytes), nil
+}
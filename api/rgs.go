@@ -452,15 +452,13 @@ func Routes() *chi.Mux {
 			gameSlug := chi.URLParam(r, "gameSlug")
 			wallet := chi.URLParam(r, "wallet")
 			var txStore store.TransactionStore
-			var err rgserror.RGSErr
-			switch wallet {
-			case "demo":
-				txStore, err = store.ServLocal.TransactionByGameId(store.Token(token), store.ModeDemo, gameSlug)
-			case "dashur":
-				txStore, err = store.Serv.TransactionByGameId(store.Token(token), store.ModeReal, gameSlug)
+			service, mode, err := store.WalletService(wallet)
+			if err == nil {
+				txStore, err = service.TransactionByGameId(store.Token(token), mode, gameSlug)
 			}
 			if txStore.WalletStatus != 1 {
 				// if this is zero, the tx is pending and shouldn't be resent, if it is -1, the tx is failed and an error should be sent to reload the client
+				// a tx that failed without an answer is settled by the reconciliation worker, see /reconcile
 				logger.Debugf("STATUS: %v", txStore.WalletStatus)
 				fmt.Fprint(w, []byte("ERROR"))
 				return
@@ -478,14 +476,16 @@ func Routes() *chi.Mux {
 			}
 			state := store.SerializeGamestateToBytes(gamestateUnmarshalled)
 			ttl := gamestateUnmarshalled.GetTtl()
-			switch wallet {
-			case "demo":
-				_, err = store.ServLocal.CloseRound(store.Token(token), store.ModeDemo, gameSlug, roundId, "", state, ttl, &store.TransactionHistory{})
-			case "dashur":
-				_, err = store.Serv.CloseRound(store.Token(token), store.ModeReal, gameSlug, roundId, txStore.FreeGames.CampaignRef, state, ttl, nil)
+			if mode == store.ModeDemo {
+				_, err = service.CloseRound(store.Token(token), mode, gameSlug, roundId, "", state, ttl, &store.TransactionHistory{})
+			} else {
+				_, err = service.CloseRound(store.Token(token), mode, gameSlug, roundId, txStore.FreeGames.CampaignRef, state, ttl, nil)
 			}
 			if err != nil {
+				// a close that was not answered is resent by the reconciliation worker
+				logger.Warnf("clientstate close of round %v failed: %v", roundId, err.Error())
 				fmt.Fprint(w, []byte("ERROR"))
+				return
 			}
 			fmt.Fprint(w, []byte("OK"))
 		})
@@ -568,31 +568,12 @@ func Routes() *chi.Mux {
 				return
 			}
 			logger.Debugf("gamestate: %#v", gamestate)
-			switch wallet {
-			case "demo":
-				_, err = store.ServLocal.Transaction(player.Token, store.ModeDemo, store.TransactionStore{
-					TransactionId:       gamestate.Transactions[0].Id,
-					Token:               "",
-					Mode:                store.ModeDemo,
-					Category:            store.CategoryPayout,
-					RoundStatus:         store.RoundStatusOpen,
-					PlayerId:            player.PlayerId,
-					GameId:              gamestate.Game,
-					RoundId:             gamestate.RoundID,
-					Amount:              gamestate.Transactions[0].Amount,
-					ParentTransactionId: "",
-					TxTime:              time.Now(),
-					BetLimitSettingCode: "",
-					GameState:           gsbytes,
-					FreeGames:           store.FreeGamesStore{},
-					WalletStatus:        0,
-					Ttl:                 gamestate.GetTtl(),
-				})
-			case "dashur":
-				_, err = store.Serv.Transaction(player.Token, store.ModeReal, store.TransactionStore{
+			service, mode, err := store.WalletService(wallet)
+			if err == nil {
+				_, err = service.Transaction(player.Token, mode, store.TransactionStore{
 					TransactionId:       gamestate.Transactions[0].Id,
 					Token:               "",
-					Mode:                store.ModeReal,
+					Mode:                mode,
 					Category:            store.CategoryPayout,
 					RoundStatus:         store.RoundStatusOpen,
 					PlayerId:            player.PlayerId,
@@ -714,6 +695,41 @@ func Routes() *chi.Mux {
 				return
 			}
 		})
+		r.Get("/reconcile", func(w http.ResponseWriter, r *http.Request) {
+			resp, err := pendingTransactions(r)
+			if err != nil {
+				_ = render.Render(w, r, ErrBadRequestRender(err.(*rgserror.RGSError)))
+				return
+			}
+			if err := render.Render(w, r, resp); err != nil {
+				_ = render.Render(w, r, ErrRender(err))
+			}
+		})
+		r.Post("/reconcile/{id:"+RegexId+"}/retry", func(w http.ResponseWriter, r *http.Request) {
+			resp, err := retryPendingTransaction(r, chi.URLParam(r, "id"))
+			if err != nil {
+				_ = render.Render(w, r, ErrBadRequestRender(err.(*rgserror.RGSError)))
+				return
+			}
+			if err := render.Render(w, r, resp); err != nil {
+				_ = render.Render(w, r, ErrRender(err))
+			}
+		})
+		r.Post("/reconcile/{id:"+RegexId+"}/resolve", func(w http.ResponseWriter, r *http.Request) {
+			var param ResolveParams
+			if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+				_ = render.Render(w, r, ErrRender(err))
+				return
+			}
+			resp, err := resolvePendingTransaction(r, chi.URLParam(r, "id"), param.Note)
+			if err != nil {
+				_ = render.Render(w, r, ErrBadRequestRender(err.(*rgserror.RGSError)))
+				return
+			}
+			if err := render.Render(w, r, resp); err != nil {
+				_ = render.Render(w, r, ErrRender(err))
+			}
+		})
 		r.Get("/stakes", func(w http.ResponseWriter, r *http.Request) {
 			stakeInfo(r, w)
 		})
@@ -757,6 +773,52 @@ func Routes() *chi.Mux {
 		})
 
 		if config.GlobalConfig.DevMode {
+			// the limits of a session can be set to test them, outside devmode the configured limits apply
+			r.Post("/setrglimits/demo", func(w http.ResponseWriter, r *http.Request) {
+				var param SetRGLimitsParams
+				err := json.NewDecoder(r.Body).Decode(&param)
+				if err != nil {
+					_ = render.Render(w, r, ErrRender(err))
+					return
+				}
+				token, err := processAuthorization(r)
+				if err != nil {
+					_ = render.Render(w, r, ErrRender(err))
+					return
+				}
+				memID := parseMemID(token)
+				err = store.SetRGLimits(store.Token(memID), "", "", store.RGLimits{
+					LossLimit:    param.LossLimit,
+					WagerLimit:   param.WagerLimit,
+					TimeLimit:    time.Duration(param.TimeLimit) * time.Minute,
+					RealityCheck: time.Duration(param.RealityCheck) * time.Minute,
+				})
+				if err != nil {
+					_ = render.Render(w, r, ErrRender(err))
+					return
+				}
+			})
+			r.Post("/replayround", func(w http.ResponseWriter, r *http.Request) {
+				var param ReplayRoundParams
+				err := json.NewDecoder(r.Body).Decode(&param)
+				if err != nil {
+					_ = render.Render(w, r, ErrRender(err))
+					return
+				}
+				replayResp, rgserr := replayRound(param)
+				if rgserr != nil {
+					_ = render.Render(w, r, ErrBadRequestRender(rgserr.(*rgserror.RGSError)))
+					return
+				}
+				if err := render.Render(w, r, replayResp); err != nil {
+					_ = render.Render(w, r, ErrRender(err))
+				}
+			})
+			r.Post("/reloadengines", func(w http.ResponseWriter, r *http.Request) {
+				if err := render.Render(w, r, reloadEngines()); err != nil {
+					_ = render.Render(w, r, ErrRender(err))
+				}
+			})
 			r.Get("/debug/pprof/profile", pprof.Profile)
 			r.Mount("/debug/pprof/heap", pprof.Handler("heap"))
 			r.Mount("/debug/pprof/block", pprof.Handler("block"))
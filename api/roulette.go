@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"sort"
 	"time"
 
 	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
@@ -12,6 +13,13 @@ import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
+var _ GameTypeV3 = RegisterGameTypeV3(store.GameCategoryRoulette, GameTypeV3{
+	Init:         initRoulette,
+	Play:         playRoulette,
+	Playcheck:    playcheckRoulette,
+	PlaycheckExt: playcheckExtRoulette,
+})
+
 type initParamsRoulette struct {
 	initParamsV3
 	//	Bets map[string]BetRoulette `json:"bets"`
@@ -32,7 +40,8 @@ func (i *initParamsRoulette) deserialize(b []byte) rgse.RGSErr {
 type playParamsRoulette struct {
 	playParamsV3
 
-	Bets map[string]engine.BetRoulette `json:"bets"`
+	Bets     map[string]engine.BetRoulette `json:"bets"`
+	CallBets []engine.RouletteCallBet      `json:"callBets"`
 }
 
 func (i *playParamsRoulette) decode(request *http.Request) rgse.RGSErr {
@@ -67,6 +76,23 @@ type PrizeRoulette struct {
 }
 */
 
+// RulesRoulette are the table rules of a roulette engine that sets them
+type RulesRoulette struct {
+	Wheel      string                    `json:"wheel"`
+	Zeros      []int                     `json:"zeros"`
+	EvenMoney  string                    `json:"evenMoney,omitempty"`
+	CallBets   []string                  `json:"callBets,omitempty"`
+	Neighbours int                       `json:"neighbours,omitempty"`
+	Limits     map[string]LimitRoulette  `json:"limits,omitempty"`
+	CallChips  map[string]map[string]int `json:"callChips,omitempty"`
+}
+
+// LimitRoulette is the range of the stake of one bet of a bet type
+type LimitRoulette struct {
+	Min engine.Fixed `json:"min"`
+	Max engine.Fixed `json:"max"`
+}
+
 type GameInitResponseRoulette struct {
 	GameInitResponseV3
 	LastRound IGamePlayResponseV3       `json:"lastRound"`
@@ -74,6 +100,7 @@ type GameInitResponseRoulette struct {
 	MinBet    engine.Fixed              `json:"minBet"`
 	MaxBet    engine.Fixed              `json:"maxBet"`
 	Bets      map[string]PayoutRoulette `json:"bets"`
+	Rules     *RulesRoulette            `json:"rules,omitempty"`
 }
 
 func (resp *GameInitResponseRoulette) Base() *GameInitResponseV3 {
@@ -91,6 +118,9 @@ type GamePlayResponseRoulette struct {
 	Position int                           `json:"position"`
 	Bets     map[string]engine.BetRoulette `json:"bets"`
 	Prizes   []engine.PrizeRoulette        `json:"wins"`
+	CallBets []engine.RouletteCallBet      `json:"callBets,omitempty"`
+	Prison   map[string]engine.BetRoulette `json:"prison,omitempty"`
+	Stats    *engine.RouletteStats         `json:"stats,omitempty"`
 }
 
 func (resp GamePlayResponseRoulette) Base() GamePlayResponseV3 {
@@ -101,7 +131,7 @@ func (resp GamePlayResponseRoulette) Render(w http.ResponseWriter, r *http.Reque
 	return nil
 }
 
-func initRoulette(player store.PlayerStore, engineId string, wallet string, body []byte, engineConf engine.EngineConfig, token store.Token, state []byte) (
+func initRoulette(player store.PlayerStore, engineId string, wallet string, body []byte, engineConf engine.EngineConfig, token store.Token, state []byte, jurisdiction parameterSelector.Jurisdiction) (
 	response IGameInitResponseV3, rgserr rgse.RGSErr) {
 
 	var data initParamsRoulette
@@ -127,13 +157,21 @@ func initRoulette(player store.PlayerStore, engineId string, wallet string, body
 		FreeGames: player.FreeGames,
 	}
 
-	playResponse := fillRoulettePlayResponse(gameState, balance)
+	playResponse := fillRoulettePlayResponse(gameState, engineDef, balance)
 
 	stakeValues, defaultBet, minBet, maxBet, prmerr := parameterSelector.GetGameplayParameters(engine.Money{Currency: gameState.Currency}, player.BetLimitSettingCode, data.Game, player.BetSettingId)
 	if prmerr != nil {
 		rgserr = prmerr
 		return
 	}
+	// chips above the max stake of the jurisdiction can not be placed, the total bet is limited to it
+	stakeValues, defaultBet, rgserr = jurisdiction.LimitStakeValues(stakeValues, defaultBet, 1, gameState.Currency)
+	if rgserr != nil {
+		return
+	}
+	if maxStake := jurisdiction.MaxStakeFor(gameState.Currency); maxStake > 0 && (maxBet == 0 || maxBet > maxStake) {
+		maxBet = maxStake
+	}
 	mu, muerr := parameterSelector.GetCurrencyMinorUnit(gameState.Currency)
 	if muerr != nil {
 		rgserr = muerr
@@ -151,30 +189,75 @@ func initRoulette(player store.PlayerStore, engineId string, wallet string, body
 			StakeValues:      stakeValues,
 			DefaultBet:       defaultBet,
 			CurrencyDecimals: mu,
+			Jurisdiction:     newJurisdictionResponse(jurisdiction, gameState.Currency),
 		},
 		LastRound: playResponse,
 		Reel:      engineDef.Reels[0],
 		Bets:      bets,
 		MinBet:    minBet,
 		MaxBet:    maxBet,
+		Rules:     makeRulesRoulette(engineDef.RouletteConfig, stakeValues),
 	}
 	return
 }
 
-func playRoulette(engineId string, wallet string, body []byte, txStore store.TransactionStore) (response IGamePlayResponseV3, rgserr rgse.RGSErr) {
+func makeRulesRoulette(conf engine.RouletteConfiguration, stakeValues []engine.Fixed) *RulesRoulette {
+	if conf.Wheel == "" {
+		return nil
+	}
+	rules := &RulesRoulette{
+		Wheel:     conf.WheelName(),
+		Zeros:     conf.Zeros(),
+		EvenMoney: conf.EvenMoneyRule(),
+		CallBets:  conf.CallBets,
+	}
+	if conf.OffersCallBet(engine.RouletteNeighbours) {
+		rules.Neighbours = conf.NeighbourCount()
+	}
+	wheel, _ := engine.RouletteWheel(conf.WheelName())
+	for _, name := range conf.CallBets {
+		if chips, ok := engine.RouletteCallBetChips(name, 0, wheel, conf.NeighbourCount()); ok && name != engine.RouletteNeighbours {
+			if rules.CallChips == nil {
+				rules.CallChips = make(map[string]map[string]int)
+			}
+			rules.CallChips[name] = chips
+		}
+	}
+	if len(conf.Limits) > 0 && len(stakeValues) > 0 {
+		rules.Limits = make(map[string]LimitRoulette, len(conf.Limits))
+		for betType, limit := range conf.Limits {
+			rules.Limits[betType] = LimitRoulette{
+				Min: stakeValues[0].MulInt(int64(limit.Min)),
+				Max: stakeValues[0].MulInt(int64(limit.Max)),
+			}
+		}
+	}
+	return rules
+}
+
+func playRoulette(engineId string, wallet string, body []byte, txStore store.TransactionStore, jurisdiction parameterSelector.Jurisdiction) (response IGamePlayResponseV3, rgserr rgse.RGSErr) {
 	var data playParamsRoulette
 	rgserr = data.deserialize(body)
 
 	logger.Debugf("playRoulette %#v\n", data)
 
-	engineConf := engine.BuildEngineDefs(engineId)
+	engineConf, rgserr := engine.LoadEngineDefs(engineId)
+	if rgserr != nil {
+		return
+	}
 
 	stakeValues, _, minBet, maxBet, prmerr := parameterSelector.GetGameplayParameters(engine.Money{0, txStore.Amount.Currency}, txStore.BetLimitSettingCode, data.Game, txStore.BetSettingId)
 	if prmerr != nil {
 		return nil, prmerr
 	}
 
-	valid, stake := validateRouletteBets(data.Bets, engineConf.EngineDefs[0].RoulettePayouts, stakeValues)
+	bets, valid := expandRouletteCallBets(data.Bets, data.CallBets, engineConf.EngineDefs[0])
+	if !valid {
+		return nil, rgse.Create(rgse.InvalidStakeError)
+	}
+	data.Bets = bets
+
+	valid, stake := validateRouletteBets(data.Bets, engineConf.EngineDefs[0].RoulettePayouts, stakeValues, engineConf.EngineDefs[0].RouletteConfig)
 	if minBet > 0 && stake < minBet {
 		logger.Debugf("Total roulette bet %s is lower than limit %s", stake.ValueAsString(), minBet.ValueAsString())
 		valid = false
@@ -186,6 +269,9 @@ func playRoulette(engineId string, wallet string, body []byte, txStore store.Tra
 	if !valid || len(data.Bets) == 0 {
 		return nil, rgse.Create(rgse.InvalidStakeError)
 	}
+	if rgserr = validateJurisdictionRound(jurisdiction, txStore, engine.Money{Amount: stake, Currency: txStore.Amount.Currency}); rgserr != nil {
+		return
+	}
 
 	var game store.GameRouletteV3
 	var prevState engine.GameStateRoulette
@@ -211,11 +297,55 @@ func playRoulette(engineId string, wallet string, body []byte, txStore store.Tra
 	return getRouletteResults(data, engineDef, stake, prevState, txStore)
 }
 
-func validateRouletteBets(bets map[string]engine.BetRoulette, validBets map[string]engine.RoulettePayout, validStakes []engine.Fixed) (bool, engine.Fixed) {
+// expandRouletteCallBets places the chips of the call bets on the bets of the table, the chips of a call bet add up
+// with a bet placed on the same index
+func expandRouletteCallBets(bets map[string]engine.BetRoulette, callBets []engine.RouletteCallBet, engineDef engine.EngineDef) (map[string]engine.BetRoulette, bool) {
+	if len(callBets) == 0 {
+		return bets, true
+	}
+	conf := engineDef.RouletteConfig
+	wheel, _ := engine.RouletteWheel(conf.WheelName())
+	expanded := make(map[string]engine.BetRoulette, len(bets))
+	for k, v := range bets {
+		expanded[k] = v
+	}
+	for _, callBet := range callBets {
+		if !conf.OffersCallBet(callBet.Name) {
+			logger.Debugf("roulette call bet %s is not offered", callBet.Name)
+			return nil, false
+		}
+		if callBet.Amount <= 0 {
+			logger.Warnf("roulette call bet %s is zero or negative", callBet.Name)
+			return nil, false
+		}
+		chips, ok := engine.RouletteCallBetChips(callBet.Name, callBet.Number, wheel, conf.NeighbourCount())
+		if !ok {
+			logger.Debugf("roulette call bet %s on number %d can not be placed", callBet.Name, callBet.Number)
+			return nil, false
+		}
+		for index, num := range chips {
+			payout, ok := engineDef.RoulettePayouts[index]
+			if !ok {
+				logger.Debugf("roulette call bet %s places unknown bet %s", callBet.Name, index)
+				return nil, false
+			}
+			bet, exists := expanded[index]
+			if !exists {
+				bet.Symbols = payout.Symbols
+			}
+			bet.Amount += callBet.Amount.MulInt(int64(num))
+			expanded[index] = bet
+		}
+	}
+	return expanded, true
+}
+
+func validateRouletteBets(bets map[string]engine.BetRoulette, validBets map[string]engine.RoulettePayout, validStakes []engine.Fixed, conf engine.RouletteConfiguration) (bool, engine.Fixed) {
 	sum := engine.NewFixedFromInt(0)
 	for k, v := range bets {
 		logger.Debugf("validating bet %#v", v)
-		if !validateRouletteBet(k, v, validBets) || !validateRouletteStake(v, validStakes) {
+		if !validateRouletteBet(k, v, validBets) || !validateRouletteStake(v, validStakes) ||
+			!validateRouletteLimit(k, v, validBets[k], validStakes, conf) {
 			return false, engine.NewFixedFromInt(0)
 		}
 		sum += v.Amount
@@ -245,6 +375,24 @@ func validateRouletteBet(index string, bet engine.BetRoulette, payouts map[strin
 	return false
 }
 
+// validateRouletteLimit checks the stake of the bet against the table limit of its bet type, the limits are multiples
+// of the smallest stake value
+func validateRouletteLimit(index string, bet engine.BetRoulette, payout engine.RoulettePayout, stakes []engine.Fixed, conf engine.RouletteConfiguration) bool {
+	limit, ok := conf.Limits[engine.RouletteBetType(payout)]
+	if !ok || len(stakes) == 0 {
+		return true
+	}
+	if limit.Min > 0 && bet.Amount < stakes[0].MulInt(int64(limit.Min)) {
+		logger.Debugf("roulette bet %s of %s is lower than the table limit", index, bet.Amount.ValueAsString())
+		return false
+	}
+	if limit.Max > 0 && bet.Amount > stakes[0].MulInt(int64(limit.Max)) {
+		logger.Debugf("roulette bet %s of %s is higher than the table limit", index, bet.Amount.ValueAsString())
+		return false
+	}
+	return true
+}
+
 func validateRouletteStake(bet engine.BetRoulette, stakes []engine.Fixed) bool {
 	logger.Debugf("validating stake in bet %#v", bet)
 	if bet.Amount <= 0 {
@@ -273,19 +421,60 @@ func validateRouletteStake(bet engine.BetRoulette, stakes []engine.Fixed) bool {
 	return true
 }
 
-func processRouletteBets(symbol int, bets map[string]engine.BetRoulette, payouts map[string]engine.RoulettePayout) (engine.Fixed, []engine.PrizeRoulette) {
+// processRouletteBets settles the bets on the symbol. When a zero wins the even money bets are settled by the rule of
+// the engine, la partage returns half of the bet and en prison holds the bet for the next spin. The bets held from the
+// previous spin return their stake if they win and are lost otherwise.
+func processRouletteBets(symbol int, bets map[string]engine.BetRoulette, payouts map[string]engine.RoulettePayout,
+	conf engine.RouletteConfiguration, prison map[string]engine.BetRoulette) (engine.Fixed, []engine.PrizeRoulette, map[string]engine.BetRoulette) {
+
 	sum, prizes := engine.NewFixedFromInt(0), []engine.PrizeRoulette{}
+	var held map[string]engine.BetRoulette
+	zero := conf.IsZero(symbol)
+	rule := conf.EvenMoneyRule()
 	for k, v := range bets {
-		for _, s := range v.Symbols {
-			if s == symbol {
-				win := v.Amount.Mul(engine.NewFixedFromInt(payouts[k].Multiplier))
-				sum += win
-				prizes = append(prizes, engine.PrizeRoulette{Index: k, Amount: win})
-				break
+		if containsSymbol(v.Symbols, symbol) {
+			win := v.Amount.Mul(engine.NewFixedFromInt(payouts[k].Multiplier))
+			sum += win
+			prizes = append(prizes, engine.PrizeRoulette{Index: k, Amount: win})
+			continue
+		}
+		if !zero || !conf.IsEvenMoney(payouts[k]) {
+			continue
+		}
+		switch rule {
+		case engine.RouletteLaPartage:
+			win := v.Amount.Div(engine.NewFixedFromInt(2))
+			sum += win
+			prizes = append(prizes, engine.PrizeRoulette{Index: k, Amount: win, Rule: rule})
+		case engine.RouletteEnPrison:
+			if held == nil {
+				held = make(map[string]engine.BetRoulette)
 			}
+			held[k] = v
 		}
 	}
-	return sum, prizes
+	for k, v := range prison {
+		if containsSymbol(v.Symbols, symbol) {
+			sum += v.Amount
+			prizes = append(prizes, engine.PrizeRoulette{Index: k, Amount: v.Amount, Rule: engine.RouletteEnPrison})
+		}
+	}
+	sort.Slice(prizes, func(i, j int) bool {
+		if prizes[i].Index != prizes[j].Index {
+			return prizes[i].Index < prizes[j].Index
+		}
+		return prizes[i].Rule < prizes[j].Rule
+	})
+	return sum, prizes, held
+}
+
+func containsSymbol(symbols []int, symbol int) bool {
+	for _, s := range symbols {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
 }
 
 func getRouletteResults(
@@ -309,8 +498,9 @@ func getRouletteResults(
 		}}
 	gameState.GameStateV3.Transactions = append(bets, gameState.GameStateV3.Transactions...)
 
-	win, prizes := processRouletteBets(gameState.Symbol, data.Bets, engineDef.RoulettePayouts)
+	win, prizes, prison := processRouletteBets(gameState.Symbol, data.Bets, engineDef.RoulettePayouts, engineDef.RouletteConfig, prevState.Prison)
 	gameState.Prizes = prizes
+	gameState.Prison = prison
 	if len(prizes) > 0 {
 		gameState.GameStateV3.Transactions = append(gameState.GameStateV3.Transactions, engine.WalletTransaction{
 			Id:     rng.Uuid(), // prevState.NextGamestate,
@@ -371,17 +561,21 @@ func getRouletteResults(
 		//		gameState.Id = string(token)
 	}
 
-	response = fillRoulettePlayResponse(gameState, balance)
+	response = fillRoulettePlayResponse(gameState, engineDef, balance)
+	response.RealityCheck = store.DueRealityCheck(balance.Token)
 
 	return
 }
 
 func rouletteRound(data playParamsRoulette, engineDef engine.EngineDef, prevState engine.GameStateRoulette) engine.GameStateRoulette {
+	round := rng.NewRound(nil)
+	audit := round.StartAudit()
 	reel := engineDef.Reels[0]
-	position := rng.RandFromRange(len(reel))
+	position := round.RandFromRange(len(reel))
 	symbol := reel[position]
 
 	id := prevState.NextGamestate
+	audit.Finish(id)
 	roundId := id
 	nextid := rng.Uuid()
 	gameState := engine.GameStateRoulette{
@@ -393,16 +587,25 @@ func rouletteRound(data playParamsRoulette, engineDef engine.EngineDef, prevStat
 			RoundId:           roundId,
 			PreviousGamestate: data.PreviousID,
 			NextGamestate:     nextid,
+			RngSeed:           round.Seed,
 		},
 		Position: position,
 		Symbol:   symbol,
 		Bets:     data.Bets,
+		CallBets: data.CallBets,
+	}
+	if keep := engineDef.RouletteConfig.History; keep > 0 {
+		history := append(append([]int{}, prevState.History...), symbol)
+		if len(history) > keep {
+			history = history[len(history)-keep:]
+		}
+		gameState.History = history
 	}
 	return gameState
 }
 
-func fillRoulettePlayResponse(gameState engine.GameStateRoulette, balance store.BalanceStore) GamePlayResponseRoulette {
-	return GamePlayResponseRoulette{
+func fillRoulettePlayResponse(gameState engine.GameStateRoulette, engineDef engine.EngineDef, balance store.BalanceStore) GamePlayResponseRoulette {
+	response := GamePlayResponseRoulette{
 		GamePlayResponseV3: GamePlayResponseV3{
 			Token:   balance.Token,
 			StateId: gameState.Id,
@@ -418,5 +621,12 @@ func fillRoulettePlayResponse(gameState engine.GameStateRoulette, balance store.
 		Position: gameState.Position,
 		Bets:     gameState.Bets,
 		Prizes:   gameState.Prizes,
+		CallBets: gameState.CallBets,
+		Prison:   gameState.Prison,
+	}
+	if engineDef.RouletteConfig.History > 0 {
+		stats := engine.RouletteHotCold(gameState.History, engineDef.Reels[0])
+		response.Stats = &stats
 	}
+	return response
 }
@@ -0,0 +1,376 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/parameterSelector"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/store"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+var _ GameTypeV3 = RegisterGameTypeV3(store.GameCategoryScratch, GameTypeV3{
+	Init:         initScratch,
+	Play:         playScratch,
+	Playcheck:    playcheckScratch,
+	PlaycheckExt: playcheckExtScratch,
+})
+
+type playParamsScratch struct {
+	playParamsV3
+
+	Bet engine.Fixed `json:"bet"` // the price of the ticket
+}
+
+func (i *playParamsScratch) decode(request *http.Request) rgse.RGSErr {
+	return decodeParams(i, request)
+}
+
+func (i playParamsScratch) validate() rgse.RGSErr {
+	return nil
+}
+
+func (i *playParamsScratch) deserialize(b []byte) rgse.RGSErr {
+	return deserializeParams(i, b)
+}
+
+type PrizeScratch struct {
+	Multiplier int `json:"multiplier"`
+	Symbol     int `json:"symbol"`
+}
+
+type RulesScratch struct {
+	Mode    string         `json:"mode"`
+	Rows    int            `json:"rows"`
+	Columns int            `json:"columns"`
+	Match   int            `json:"match"`
+	Symbols []int          `json:"symbols"`
+	Prizes  []PrizeScratch `json:"prizes"` // the winning prizes
+}
+
+// PoolScratch is the ticket pool that the next ticket is sold from
+type PoolScratch struct {
+	Id        string `json:"id"`
+	Size      int    `json:"size"`
+	Remaining int    `json:"remaining"`
+}
+
+type GameInitResponseScratch struct {
+	GameInitResponseV3
+	LastRound IGamePlayResponseV3 `json:"lastRound"`
+	MinBet    engine.Fixed        `json:"minBet"`
+	MaxBet    engine.Fixed        `json:"maxBet"`
+	Rules     RulesScratch        `json:"rules"`
+	Pool      *PoolScratch        `json:"pool,omitempty"`
+}
+
+func (resp *GameInitResponseScratch) Base() *GameInitResponseV3 {
+	return &resp.GameInitResponseV3
+}
+
+func (resp GameInitResponseScratch) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type GamePlayResponseScratch struct {
+	GamePlayResponseV3
+
+	Multiplier int     `json:"multiplier"`
+	Symbol     int     `json:"symbol,omitempty"`
+	Layout     [][]int `json:"layout"`              // the symbols of the cells by row
+	Positions  []int   `json:"positions,omitempty"` // the matched cells counted row by row
+	Pool       string  `json:"pool,omitempty"`
+	Ticket     int     `json:"ticket,omitempty"`
+}
+
+func (resp GamePlayResponseScratch) Base() GamePlayResponseV3 {
+	return resp.GamePlayResponseV3
+}
+
+func (resp GamePlayResponseScratch) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func initScratch(player store.PlayerStore, engineId string, wallet string, body []byte, engineConf engine.EngineConfig, token store.Token, state []byte, jurisdiction parameterSelector.Jurisdiction) (
+	response IGameInitResponseV3, rgserr rgse.RGSErr) {
+
+	var data initParamsV3
+	if rgserr = data.deserialize(body); rgserr != nil {
+		return nil, rgse.Create(rgse.JsonError)
+	}
+
+	var game store.GameScratchV3
+	var gameState engine.GameStateScratch
+	if len(state) == 0 {
+		gameState = store.InitStateScratch(data.Game, data.Ccy)
+		gameState.Id = string(token) + data.Game + "GSinit"
+	} else {
+		gameState, rgserr = game.DeserializeStateScratch(state)
+		if rgserr != nil {
+			return
+		}
+		logger.Debugf("initScratch state length:%d\ndeserialized:%#v", len(state), gameState)
+	}
+
+	balance := store.BalanceStore{
+		Balance:   player.Balance,
+		Token:     player.Token,
+		FreeGames: player.FreeGames,
+	}
+
+	stakeValues, defaultBet, minBet, maxBet, prmerr := parameterSelector.GetGameplayParameters(engine.Money{Currency: gameState.Currency}, player.BetLimitSettingCode, data.Game, player.BetSettingId)
+	if prmerr != nil {
+		rgserr = prmerr
+		return
+	}
+	stakeValues, defaultBet, rgserr = jurisdiction.LimitStakeValues(stakeValues, defaultBet, 1, gameState.Currency)
+	if rgserr != nil {
+		return
+	}
+	if maxStake := jurisdiction.MaxStakeFor(gameState.Currency); maxStake > 0 && (maxBet == 0 || maxBet > maxStake) {
+		maxBet = maxStake
+	}
+	mu, muerr := parameterSelector.GetCurrencyMinorUnit(gameState.Currency)
+	if muerr != nil {
+		rgserr = muerr
+		return
+	}
+
+	conf := engineConf.EngineDefs[0].ScratchConfig
+	rules := RulesScratch{
+		Mode:    conf.Mode,
+		Rows:    conf.Layout.Rows,
+		Columns: conf.Layout.Columns,
+		Match:   conf.MatchCount(),
+		Symbols: conf.Layout.Symbols,
+		Prizes:  []PrizeScratch{},
+	}
+	if rules.Mode == "" {
+		rules.Mode = engine.ScratchModeTable
+	}
+	for _, p := range conf.Prizes {
+		if p.Multiplier > 0 {
+			rules.Prizes = append(rules.Prizes, PrizeScratch{Multiplier: p.Multiplier, Symbol: p.Symbol})
+		}
+	}
+	initResponse := &GameInitResponseScratch{
+		GameInitResponseV3: GameInitResponseV3{
+			Name:             gameState.Game,
+			Wallet:           wallet,
+			StakeValues:      stakeValues,
+			DefaultBet:       defaultBet,
+			CurrencyDecimals: mu,
+			Jurisdiction:     newJurisdictionResponse(jurisdiction, gameState.Currency),
+		},
+		LastRound: fillScratchPlayResponse(gameState, balance),
+		MinBet:    minBet,
+		MaxBet:    maxBet,
+		Rules:     rules,
+	}
+	if conf.PoolMode() {
+		id, size, remaining, poolerr := store.ScratchPoolStatus(data.Game, conf)
+		if poolerr != nil {
+			rgserr = poolerr
+			return
+		}
+		initResponse.Pool = &PoolScratch{Id: id, Size: size, Remaining: remaining}
+	}
+	response = initResponse
+	return
+}
+
+func playScratch(engineId string, wallet string, body []byte, txStore store.TransactionStore, jurisdiction parameterSelector.Jurisdiction) (response IGamePlayResponseV3, rgserr rgse.RGSErr) {
+	var data playParamsScratch
+	if rgserr = data.deserialize(body); rgserr != nil {
+		return
+	}
+	logger.Debugf("playScratch %#v\n", data)
+
+	engineConf, rgserr := engine.LoadEngineDefs(engineId)
+	if rgserr != nil {
+		return
+	}
+	if rgserr = validateStakeValue(data.Game, data.Bet, txStore); rgserr != nil {
+		return
+	}
+	if rgserr = validateJurisdictionRound(jurisdiction, txStore, engine.Money{Amount: data.Bet, Currency: txStore.Amount.Currency}); rgserr != nil {
+		return
+	}
+
+	var game store.GameScratchV3
+	var prevState engine.GameStateScratch
+	if len(txStore.GameState) == 0 {
+		logger.Debugf("no previous gamestate in playScratch")
+		prevState = store.InitStateScratch(data.Game, txStore.Amount.Currency)
+	} else {
+		prevState, rgserr = game.DeserializeStateScratch(txStore.GameState)
+		if rgserr != nil {
+			return
+		}
+	}
+
+	return getScratchResults(data, engineConf.EngineDefs[0], prevState, txStore)
+}
+
+func getScratchResults(
+	data playParamsScratch,
+	engineDef engine.EngineDef,
+	prevState engine.GameStateScratch,
+	txStore store.TransactionStore) (response GamePlayResponseScratch, err rgse.RGSErr) {
+
+	var game store.GameScratchV3
+	conf := engineDef.ScratchConfig
+	var prize, ticket int
+	var pool string
+	var reserved store.ScratchTicket
+	if conf.PoolMode() {
+		// the ticket is reserved before the round starts and sold once its wager is taken, a new pool is shuffled in a
+		// round of its own
+		reserved, err = store.ReserveScratchTicket(data.Game, conf)
+		if err != nil {
+			return
+		}
+		prize, pool, ticket = reserved.Prize, reserved.Pool, reserved.Number
+	}
+	round := rng.NewRound(nil)
+	audit := round.StartAudit()
+	if !conf.PoolMode() {
+		prize = conf.DrawPrize(round)
+	}
+	gameState := engine.PlayScratch(round, conf, prize, data.Bet)
+	audit.Finish(prevState.NextGamestate)
+
+	gameState.GameStateV3 = engine.GameStateV3{
+		Id:                prevState.NextGamestate,
+		Game:              data.Game,
+		Version:           "3",
+		Currency:          prevState.Currency,
+		RoundId:           prevState.NextGamestate,
+		PreviousGamestate: data.PreviousID,
+		NextGamestate:     rng.Uuid(),
+		RngSeed:           round.Seed,
+	}
+	gameState.Pool = pool
+	gameState.Ticket = ticket
+	logger.Debugf("getScratchResults gameState.Id=%s prize=%d pool=%s ticket=%d", gameState.Id, prize, pool, ticket)
+
+	gameState.Transactions = append(gameState.Transactions, engine.WalletTransaction{
+		Id:     gameState.Id,
+		Amount: engine.Money{Amount: data.Bet, Currency: txStore.Amount.Currency},
+		Type:   "WAGER",
+	})
+	if gameState.Win > 0 {
+		gameState.Transactions = append(gameState.Transactions, engine.WalletTransaction{
+			Id:     rng.Uuid(),
+			Amount: engine.Money{Amount: gameState.Win, Currency: txStore.Amount.Currency},
+			Type:   "PAYOUT",
+		})
+	}
+
+	autoClose := data.AutoClose
+	if autoClose {
+		gameState.Closed = true
+	}
+
+	var balance store.BalanceStore
+	logger.Debugf("processing state: %#v", gameState)
+	stateBytes := game.SerializeState(&gameState)
+	token := txStore.Token
+	roundStatus := store.RoundStatusOpen
+	for txIdx, transaction := range gameState.Transactions {
+		logger.Debugf("performing transaction %#v", transaction)
+		AppendHistory(&txStore, transaction)
+		if autoClose && txIdx+1 == len(gameState.Transactions) {
+			logger.Debugf("last transaction, set RoundStatusClose")
+			roundStatus = store.RoundStatusClose
+		}
+		tx := store.TransactionStore{
+			TransactionId:       transaction.Id,
+			Token:               token,
+			Category:            store.Category(transaction.Type),
+			RoundStatus:         roundStatus,
+			PlayerId:            txStore.PlayerId,
+			GameId:              data.Game,
+			RoundId:             gameState.RoundId,
+			Amount:              transaction.Amount,
+			ParentTransactionId: "",
+			TxTime:              time.Now(),
+			GameState:           stateBytes,
+			BetLimitSettingCode: txStore.BetLimitSettingCode,
+			FreeGames:           store.FreeGamesStore{NoOfFreeSpins: 0, CampaignRef: ""},
+			Ttl:                 gameState.GetTtl(),
+			History:             txStore.History,
+		}
+		balance, err = TransactionByWallet(token, data.Wallet, tx)
+		if txIdx == 0 && pool != "" {
+			settleScratchTicket(reserved, err)
+		}
+		if err != nil {
+			return
+		}
+		token = balance.Token
+	}
+
+	response = fillScratchPlayResponse(gameState, balance)
+	response.RealityCheck = store.DueRealityCheck(balance.Token)
+	return
+}
+
+// settleScratchTicket sells the ticket of a round whose wager was taken and returns it to its pool if the wallet
+// refused the wager. The ticket of a wager that was not answered stays reserved as the wager may still be applied, it
+// is settled once the reconciliation worker resolves the wager.
+func settleScratchTicket(ticket store.ScratchTicket, wagerErr rgse.RGSErr) {
+	var err rgse.RGSErr
+	switch {
+	case wagerErr == nil:
+		err = store.SellScratchTicket(ticket)
+	case store.Unanswered(wagerErr):
+		logger.Warnf("wager of ticket %d of pool %s was not answered, the ticket stays reserved", ticket.Number, ticket.Pool)
+	default:
+		err = store.ReturnScratchTicket(ticket)
+	}
+	if err != nil {
+		logger.Errorf("ticket %d of pool %s was not settled: %v", ticket.Number, ticket.Pool, err.Error())
+	}
+}
+
+func fillScratchPlayResponse(gameState engine.GameStateScratch, balance store.BalanceStore) GamePlayResponseScratch {
+	return GamePlayResponseScratch{
+		GamePlayResponseV3: GamePlayResponseV3{
+			Token:   balance.Token,
+			StateId: gameState.Id,
+			RoundId: gameState.RoundId,
+			Balance: BalanceResponseV3{
+				Amount: balance.Balance,
+			},
+			Bet:    gameState.Bet,
+			Win:    gameState.Win,
+			Closed: gameState.Closed,
+		},
+		Multiplier: gameState.Multiplier,
+		Symbol:     gameState.Symbol,
+		Layout:     scratchRows(gameState.Layout, gameState.Columns),
+		Positions:  gameState.Positions,
+		Pool:       gameState.Pool,
+		Ticket:     gameState.Ticket,
+	}
+}
+
+// scratchRows splits the cells of a ticket into its rows
+func scratchRows(cells []int, columns int) [][]int {
+	rows := [][]int{}
+	if columns < 1 {
+		return rows
+	}
+	for i := 0; i < len(cells); i += columns {
+		end := i + columns
+		if end > len(cells) {
+			end = len(cells)
+		}
+		rows = append(rows, cells[i:end])
+	}
+	return rows
+}
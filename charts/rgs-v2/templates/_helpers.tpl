@@ -60,4 +60,10 @@ Create the name of the service account to use
 {{- else -}}
     {{ default "default" .Values.serviceAccount.name }}
 {{- end -}}
-{{- end -}}
\ No newline at end of file
+{{- end -}}
+{{/*
+Name of the claim of the data volume that the instances share
+*/}}
+{{- define "rgs-v2.sharedDataClaim" -}}
+{{- default (printf "%s-data" (include "rgs-v2.fullname" .)) .Values.sharedData.existingClaim -}}
+{{- end -}}
@@ -8,12 +8,15 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"runtime"
 	"strconv"
+	"time"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/go-chi/chi/v5"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/api"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureTriggers"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
@@ -30,6 +33,10 @@ var (
 	perSpin    bool
 	maxes      bool
 	getHashes  bool
+	workers    int
+	vtReport   string
+	exactRTP   bool
+	lint       bool
 	memProfile string
 	gameState  string
 )
@@ -42,10 +49,27 @@ func main() {
 	flag.IntVar(&chunks, "chunks", 10, "number of chunks to run (default 10)")
 	flag.BoolVar(&perSpin, "perspin", false, "show results per spin")
 	flag.BoolVar(&maxes, "maxes", false, "get max theoretical values per engine")
+	flag.IntVar(&workers, "workers", runtime.NumCPU(), "number of goroutines the volume tester spreads the spins over (defaults to the number of cpus)")
+	flag.StringVar(&vtReport, "vtreport", "", "write the volume test report as json and csv to this path (without extension)")
+	flag.BoolVar(&exactRTP, "exactrtp", false, "calculate the exact base rtp of the engine (all engines if blank) by enumerating the reels and exit")
+	flag.BoolVar(&lint, "lintengines", false, "check all engine configs, print the diagnostics and exit")
 	flag.BoolVar(&getHashes, "gethashes", true, "get hashes of engine files")
 	flag.StringVar(&gameState, "decodestate", "", "decode the base64 encoded gamestate to json")
 
 	config.InitConfig()
+	// features must be registered before the engine configs that use them are checked
+	featureProducts.Register()
+	featureTriggers.Register()
+	if lint == true {
+		diagnostics := engine.LintEngineConfigs()
+		for _, d := range diagnostics {
+			fmt.Println(d.String())
+		}
+		if engine.CountLintErrors(diagnostics) > 0 {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 	initerr := store.Init(getHashes)
 	if initerr != nil {
 		logger.Errorf("Error initializing store %s", initerr)
@@ -53,14 +77,22 @@ func main() {
 	}
 
 	rng.Init()
-	featureProducts.Register()
-	featureTriggers.Register()
+	rng.EnableAudit(config.GlobalConfig.RngAudit)
+	if config.GlobalConfig.EngineReload > 0 {
+		go engine.WatchEngineConfigs(time.Duration(config.GlobalConfig.EngineReload)*time.Second, nil)
+	}
 	logger.Infof("API INIT: OK")
 	//
 	// initial serve web
+	if exactRTP == true {
+		if volumeTester.RunExactRTP(engineID, vtReport) {
+			os.Exit(5)
+		}
+		os.Exit(0)
+	}
 	if runVT == true {
-		logger.Errorf("Running VT : spins %v  chunks %v engine %v", spins, chunks, engineID)
-		failed := volumeTester.RunVT(engineID, spins, chunks, perSpin, maxes)
+		logger.Errorf("Running VT : spins %v  chunks %v engine %v workers %v", spins, chunks, engineID, workers)
+		failed := volumeTester.RunVT(engineID, spins, chunks, perSpin, maxes, workers, vtReport)
 		if failed == true {
 			logger.Errorf("VT Failed, not starting server")
 			os.Exit(5)
@@ -41,25 +41,55 @@ type StoreConfig struct {
 	StoreTimeoutMs  int64  `yaml:"storetimeoutms" cfg:"storetimeoutms" cfgDefault:3000`
 }
 
+// RGSessionConfig holds the responsible gaming limits of a player session, a zero or missing limit is not enforced.
+// The sessions are held in memory by the instance that the player plays on, they start over when the instance
+// restarts and the player must be routed to the same instance for the limits to hold across a session.
+type RGSessionConfig struct {
+	LossLimit    map[string]float64 `yaml:"losslimit" cfg:"-"`                              // max net loss of a session by currency
+	WagerLimit   map[string]float64 `yaml:"wagerlimit" cfg:"-"`                             // max amount wagered in a session by currency
+	TimeLimit    int                `yaml:"timelimit" cfg:"timelimit" cfgDefault:"0"`       // minutes after the start of a session in which wagers are accepted
+	RealityCheck int                `yaml:"realitycheck" cfg:"realitycheck" cfgDefault:"0"` // minutes between the reality checks returned in play responses
+	Idle         int                `yaml:"idle" cfg:"idle" cfgDefault:"30"`                // minutes without transactions after which a session ends
+}
+
+// ReconcileConfig holds the retries of the wallet transactions that failed without an answer
+type ReconcileConfig struct {
+	Path        string `yaml:"path" cfg:"path" cfgDefault:""`                 // file that the instances share the pending transactions in, in memory if empty in devmode
+	Interval    int    `yaml:"interval" cfg:"interval" cfgDefault:"10"`       // seconds between the runs of the worker
+	Backoff     int    `yaml:"backoff" cfg:"backoff" cfgDefault:"10"`         // seconds before the first retry, doubled on every retry
+	MaxBackoff  int    `yaml:"maxbackoff" cfg:"maxbackoff" cfgDefault:"600"`  // max seconds between retries
+	MaxAttempts int    `yaml:"maxattempts" cfg:"maxattempts" cfgDefault:"10"` // attempts after which a transaction is left to be resolved by hand
+	Retention   int    `yaml:"retention" cfg:"retention" cfgDefault:"24"`     // hours that resolved transactions are kept
+	AdminToken  string `yaml:"admintoken" cfg:"admintoken" cfgDefault:""`     // token of the admin endpoints, they are only open in devmode without one
+}
+
 // Config structure
 type Config struct {
 	DevMode         bool   `yaml:"devmode" cfg:"devmode" cfgDefault:"false"`
 	MCRouter        string `yaml:"mcrouter" cfg:"mcrouter" cfgDefault:"10.42.0.86:5000"`
 	Server          `yaml:"server"`
-	Local           bool        `yaml:"local" cfg:"local" cfgDefault:"false"`
-	Logging         string      `yaml:"logging" cfg:"logging" cfgDefault:"debug"`
-	DashurConfig    StoreConfig `yaml:"dashurconf"`
-	DefaultPlatform string      `yaml:"defaultplatform" cfg:"defaultplatform" cfgDefault:"html5"`
-	DefaultLanguage string      `yaml:"defaultlanguage" cfg:"defaultlanguage" cfgDefault:"en"`
-	DemoTokenPrefix string      `yaml:"demotokenprefix" cfg:"demotokenprefix" cfgDefault:"demo-token"`
-	DemoCurrency    string      `yaml:"democurrency" cfg:"democurrency" cfgDefault:"USD"`
-	LogAccount      string      `yaml:"logaccount" cfg:"logaccount" cfgDefault:"145472021_144443389"`
-	SentryDsn       string      `yaml:"sentryDsn" cfg:"sentryDsn" cfgDefault:""`
-	Environment     string      `yaml:"environment" cfg:"environment" cfgDefault:"local"`
-	DataLimit       int         `yaml:"datalimit" cfg:"datalimit" cfgDefault:"800"`
-	LocalDataTtl    int64       `yaml:"localdatattl" cfg:"localdatattl" cfgDefault:"0"`
-	ExtPlaycheck    string      `yaml:"extplaycheck" cfg:"extplaycheck" cfgDefault:"https://dev.elysiumstudios.se/game-history"`
-	ExtParamService string      `yaml:"extparamservice" ctg:"extparamservice" cfgDefault:""`
+	Local           bool            `yaml:"local" cfg:"local" cfgDefault:"false"`
+	Logging         string          `yaml:"logging" cfg:"logging" cfgDefault:"debug"`
+	DashurConfig    StoreConfig     `yaml:"dashurconf"`
+	DefaultPlatform string          `yaml:"defaultplatform" cfg:"defaultplatform" cfgDefault:"html5"`
+	DefaultLanguage string          `yaml:"defaultlanguage" cfg:"defaultlanguage" cfgDefault:"en"`
+	DemoTokenPrefix string          `yaml:"demotokenprefix" cfg:"demotokenprefix" cfgDefault:"demo-token"`
+	DemoCurrency    string          `yaml:"democurrency" cfg:"democurrency" cfgDefault:"USD"`
+	LogAccount      string          `yaml:"logaccount" cfg:"logaccount" cfgDefault:"145472021_144443389"`
+	SentryDsn       string          `yaml:"sentryDsn" cfg:"sentryDsn" cfgDefault:""`
+	Environment     string          `yaml:"environment" cfg:"environment" cfgDefault:"local"`
+	DataLimit       int             `yaml:"datalimit" cfg:"datalimit" cfgDefault:"800"`
+	LocalDataTtl    int64           `yaml:"localdatattl" cfg:"localdatattl" cfgDefault:"0"`
+	LocalStore      string          `yaml:"localstore" cfg:"localstore" cfgDefault:"memory"`
+	LocalStorePath  string          `yaml:"localstorepath" cfg:"localstorepath" cfgDefault:"data/localstore.gob"`
+	RngAudit        bool            `yaml:"rngaudit" cfg:"rngaudit" cfgDefault:"false"`
+	EngineReload    int             `yaml:"enginereload" cfg:"enginereload" cfgDefault:"1000"`                       // seconds between checks of the engine config files for changes, 0 disables the watcher
+	EngineSnapshots string          `yaml:"enginesnapshots" cfg:"enginesnapshots" cfgDefault:"data/engineSnapshots"` // directory the activated engine configs are kept in by hash, the instances that share it continue the rounds of one another
+	ScratchPools    string          `yaml:"scratchpools" cfg:"scratchpools" cfgDefault:"data/scratchPools"`          // directory the ticket pools are kept in by game, the instances that mount it share the pools, in memory by instance if empty
+	ExtPlaycheck    string          `yaml:"extplaycheck" cfg:"extplaycheck" cfgDefault:"https://dev.elysiumstudios.se/game-history"`
+	ExtParamService string          `yaml:"extparamservice" ctg:"extparamservice" cfgDefault:""`
+	RGSession       RGSessionConfig `yaml:"rgsession"`
+	Reconcile       ReconcileConfig `yaml:"reconcile"`
 }
 
 // Game config structure
@@ -168,3 +198,13 @@ func GetEngineFromGame(gameName string) (engineID string, err rgse.RGSErr) {
 	err.AppendErrorText(fmt.Sprintf(" for %s", gameName))
 	return
 }
+
+// GetCategoryFromEngine returns the game category of the engine, the slot engines have no category
+func GetCategoryFromEngine(engineID string) string {
+	for i := 0; i < len(GlobalGameConfig); i++ {
+		if GlobalGameConfig[i].EngineID == engineID {
+			return GlobalGameConfig[i].Category
+		}
+	}
+	return ""
+}
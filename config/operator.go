@@ -1,19 +1,240 @@
 package config
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
 	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+	"gopkg.in/yaml.v3"
 )
 
+const operatorConfigFile = "config/operatorConfig.yml"
+
+// the registry is reread when it is older, the same as the bet config
+const operatorConfigRefresh = 10 * time.Second
+
+const (
+	WalletAdapterDemo     = "demo"     // in memory demo wallet of the rgs
+	WalletAdapterDashur   = "dashur"   // dashur gnrc wallet api
+	WalletAdapterSeamless = "seamless" // generic seamless wallet json api with hmac signed requests
+)
+
+// Wallet is a wallet of the registry and the adapter that its player sessions and transactions go through
+type Wallet struct {
+	Name       string `yaml:"-"`
+	Mode       string `yaml:"mode"`       // mode of the sessions and transactions, DEMO or REAL
+	Adapter    string `yaml:"adapter"`    // protocol of the wallet, demo, dashur or seamless
+	Url        string `yaml:"url"`        // base url of the wallet api of a seamless wallet
+	Secret     string `yaml:"secret"`     // key of the hmac signatures of a seamless wallet
+	MaxRetries int    `yaml:"maxRetries"` // retries of a seamless wallet call that failed without an answer
+	TimeoutMs  int64  `yaml:"timeoutMs"`  // time after which a seamless wallet call is given up
+}
+
+// Operator is the registry entry of an operator that launches games
+type Operator struct {
+	Code         string            `yaml:"-"`
+	Wallets      map[string]string `yaml:"wallets"`      // wallet by the mode that the game is launched in, e.g. real or demo
+	Companies    []string          `yaml:"companies"`    // ids of the companies of the operator in the wallet
+	Platforms    []string          `yaml:"platforms"`    // platforms that the games are launched on
+	Languages    []string          `yaml:"languages"`    // languages that the games are launched in
+	BetProfile   string            `yaml:"betProfile"`   // bet settings code of demo players
+	Jurisdiction string            `yaml:"jurisdiction"` // jurisdiction of the companies of the operator that have none of their own
+	FreeGames    bool              `yaml:"freeGames"`    // the operator awards free games
+	PlaycheckUrl string            `yaml:"playcheckUrl"` // page where players review their rounds
+}
+
+type operatorConfig struct {
+	Wallets   map[string]Wallet   `yaml:"wallets"`
+	Operators map[string]Operator `yaml:"operators"`
+}
+
+var operatorCache struct {
+	sync.Mutex
+	conf *operatorConfig
+	read time.Time
+}
+
+// GetOperator returns the registry entry of the operator
+func GetOperator(code string) (Operator, rgse.RGSErr) {
+	conf, err := parseOperatorConfig()
+	if err != nil {
+		return Operator{}, err
+	}
+	operator, ok := conf.Operators[code]
+	if !ok {
+		rgserr := rgse.Create(rgse.BadOperatorConfig)
+		rgserr.AppendErrorText(fmt.Sprintf("unknown operator %v", code))
+		return Operator{}, rgserr
+	}
+	operator.Code = code
+	return operator, nil
+}
+
+// GetOperatorByCompany returns the registry entry of the operator of the company, false if no operator lists it
+func GetOperatorByCompany(companyId string) (Operator, bool) {
+	if companyId == "" {
+		return Operator{}, false
+	}
+	conf, err := parseOperatorConfig()
+	if err != nil {
+		return Operator{}, false
+	}
+	for code, operator := range conf.Operators {
+		for _, id := range operator.Companies {
+			if id == companyId {
+				operator.Code = code
+				return operator, true
+			}
+		}
+	}
+	return Operator{}, false
+}
+
+// GetWallet returns the wallet adapter
+func GetWallet(name string) (Wallet, rgse.RGSErr) {
+	conf, err := parseOperatorConfig()
+	if err != nil {
+		return Wallet{}, err
+	}
+	wallet, ok := conf.Wallets[name]
+	if !ok {
+		rgserr := rgse.Create(rgse.InvalidWallet)
+		rgserr.AppendErrorText(fmt.Sprintf("unknown wallet %v", name))
+		return Wallet{}, rgserr
+	}
+	wallet.Name = name
+	return wallet, nil
+}
+
+// GetModeWallet returns the default wallet of the mode, the first one by name
+func GetModeWallet(mode string) (Wallet, rgse.RGSErr) {
+	conf, err := parseOperatorConfig()
+	if err != nil {
+		return Wallet{}, err
+	}
+	names := make([]string, 0, len(conf.Wallets))
+	for name, wallet := range conf.Wallets {
+		if wallet.Mode == mode {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		rgserr := rgse.Create(rgse.InvalidWallet)
+		rgserr.AppendErrorText(fmt.Sprintf("no wallet of mode %v", mode))
+		return Wallet{}, rgserr
+	}
+	sort.Strings(names)
+	wallet := conf.Wallets[names[0]]
+	wallet.Name = names[0]
+	return wallet, nil
+}
+
+// GetWalletFromOperatorAndMode returns the wallet that the games of the operator are played with in the mode
 func GetWalletFromOperatorAndMode(operator string, mode string) (string, rgse.RGSErr) {
-	//todo make this method use a config parser, store info in yaml file
-	//todo return interface
-	if operator == "mav" {
-		switch mode {
-		case "real":
-			return "dashur", nil
-		case "demo":
-			return "demo", nil
+	op, err := GetOperator(operator)
+	if err != nil {
+		return "", err
+	}
+	wallet, ok := op.Wallets[mode]
+	if !ok {
+		rgserr := rgse.Create(rgse.BadOperatorConfig)
+		rgserr.AppendErrorText(fmt.Sprintf("operator %v has no %v wallet", operator, mode))
+		return "", rgserr
+	}
+	return wallet, nil
+}
+
+func parseOperatorConfig() (*operatorConfig, rgse.RGSErr) {
+	operatorCache.Lock()
+	defer operatorCache.Unlock()
+	now := time.Now()
+	if operatorCache.conf != nil && now.Sub(operatorCache.read) <= operatorConfigRefresh {
+		return operatorCache.conf, nil
+	}
+	conf, err := readOperatorConfig()
+	if err != nil {
+		if operatorCache.conf != nil {
+			// keep serving the last good registry until the file is fixed
+			logger.Errorf("Failed reloading operator config, keeping the cached one")
+			operatorCache.read = now
+			return operatorCache.conf, nil
+		}
+		return nil, err
+	}
+	if operatorCache.conf == nil {
+		logger.Infof("Loaded and cached operator config")
+	}
+	operatorCache.conf = conf
+	operatorCache.read = now
+	return conf, nil
+}
+
+func readOperatorConfig() (*operatorConfig, rgse.RGSErr) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		logger.Errorf("Failed opening current directory")
+		return nil, rgse.Create(rgse.BadConfigError)
+	}
+	yamlFile, err := ioutil.ReadFile(filepath.Join(currentDir, operatorConfigFile))
+	if err != nil {
+		logger.Errorf("Error reading operator config file: %v", err)
+		return nil, rgse.Create(rgse.YamlError)
+	}
+	var conf operatorConfig
+	if err = yaml.Unmarshal(yamlFile, &conf); err != nil {
+		logger.Errorf("Error unmarshaling operator config %v", err)
+		return nil, rgse.Create(rgse.YamlError)
+	}
+	if rgserr := validateOperatorConfig(conf); rgserr != nil {
+		logger.Errorf("Error validating operator config: %v", rgserr.Error())
+		return nil, rgserr
+	}
+	return &conf, nil
+}
+
+func validateOperatorConfig(conf operatorConfig) rgse.RGSErr {
+	invalid := func(format string, a ...interface{}) rgse.RGSErr {
+		rgserr := rgse.Create(rgse.BadOperatorConfig)
+		rgserr.AppendErrorText(fmt.Sprintf(format, a...))
+		return rgserr
+	}
+	for name, wallet := range conf.Wallets {
+		if wallet.Mode != "DEMO" && wallet.Mode != "REAL" {
+			return invalid("wallet %v has unknown mode %v", name, wallet.Mode)
+		}
+		switch wallet.Adapter {
+		case WalletAdapterDemo:
+			if wallet.Mode != "DEMO" {
+				return invalid("wallet %v uses the demo adapter in mode %v", name, wallet.Mode)
+			}
+		case WalletAdapterDashur:
+		case WalletAdapterSeamless:
+			if wallet.Url == "" || wallet.Secret == "" {
+				return invalid("seamless wallet %v needs a url and a secret", name)
+			}
+		default:
+			return invalid("wallet %v has unknown adapter %v", name, wallet.Adapter)
+		}
+	}
+	companies := map[string]string{}
+	for code, operator := range conf.Operators {
+		for mode, wallet := range operator.Wallets {
+			if _, ok := conf.Wallets[wallet]; !ok {
+				return invalid("operator %v has unknown %v wallet %v", code, mode, wallet)
+			}
+		}
+		for _, id := range operator.Companies {
+			if other, ok := companies[id]; ok {
+				return invalid("company %v is listed by operators %v and %v", id, other, code)
+			}
+			companies[id] = code
 		}
 	}
-	return "", rgse.Create(rgse.BadOperatorConfig)
+	return nil
 }
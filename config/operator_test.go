@@ -0,0 +1,144 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+const testOperatorConfig = `
+wallets:
+  demo:
+    mode: DEMO
+    adapter: demo
+  dashur:
+    mode: REAL
+    adapter: dashur
+  seamless:
+    mode: REAL
+    adapter: seamless
+    url: https://wallet.example.com/rgs
+    secret: shared-secret
+operators:
+  mav:
+    wallets:
+      real: dashur
+      demo: demo
+    companies: ["1"]
+  ext:
+    wallets:
+      real: seamless
+    companies: ["2", "3"]
+`
+
+func TestValidateOperatorConfig(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	tests := []struct {
+		name   string
+		config string
+		error  string // part of the error text, the config is valid if empty
+	}{
+		{"valid", testOperatorConfig, ""},
+		{"unknown adapter", strings.Replace(testOperatorConfig, "adapter: dashur", "adapter: paypal", 1), "unknown adapter paypal"},
+		{"unknown mode", strings.Replace(testOperatorConfig, "mode: REAL\n    adapter: dashur", "mode: TEST\n    adapter: dashur", 1), "unknown mode TEST"},
+		{"demo adapter in real mode", strings.Replace(testOperatorConfig, "mode: DEMO", "mode: REAL", 1), "uses the demo adapter"},
+		{"duplicate company", strings.Replace(testOperatorConfig, `["2", "3"]`, `["2", "1"]`, 1), "company 1 is listed by operators"},
+		{"missing seamless url", strings.Replace(testOperatorConfig, "url: https://wallet.example.com/rgs", "", 1), "needs a url and a secret"},
+		{"missing seamless secret", strings.Replace(testOperatorConfig, "secret: shared-secret", "", 1), "needs a url and a secret"},
+		{"unknown operator wallet", strings.Replace(testOperatorConfig, "real: seamless", "real: other", 1), "unknown real wallet other"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restore := writeOperatorConfig(t, test.config)
+			defer restore()
+			_, err := readOperatorConfig()
+			switch {
+			case test.error == "" && err != nil:
+				t.Errorf("valid config rejected: %v", err.Error())
+			case test.error != "" && err == nil:
+				t.Errorf("invalid config accepted")
+			case test.error != "" && (err.(*rgse.RGSError).ErrCode != rgse.BadOperatorConfig || !strings.Contains(err.Error(), test.error)):
+				t.Errorf("error %v, expected %v", err.Error(), test.error)
+			}
+		})
+	}
+}
+
+func TestOperatorConfigReload(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	restore := writeOperatorConfig(t, testOperatorConfig)
+	defer restore()
+
+	operator, ok := GetOperatorByCompany("3")
+	if !ok || operator.Code != "ext" {
+		t.Fatalf("company 3 has operator %v", operator.Code)
+	}
+	if wallet, err := GetWalletFromOperatorAndMode("ext", "real"); err != nil || wallet != "seamless" {
+		t.Fatalf("real wallet of ext is %v: %v", wallet, err)
+	}
+	if _, err := GetWalletFromOperatorAndMode("ext", "demo"); err == nil {
+		t.Errorf("ext has no demo wallet")
+	}
+	if _, err := GetOperator("unknown"); err == nil {
+		t.Errorf("unknown operator found")
+	}
+	if wallet, err := GetModeWallet("REAL"); err != nil || wallet.Name != "dashur" {
+		t.Errorf("default real wallet %v: %v", wallet.Name, err)
+	}
+
+	// a broken registry is not loaded, the cached one is kept until the file is fixed
+	broken := strings.Replace(testOperatorConfig, "adapter: dashur", "adapter: paypal", 1)
+	if err := ioutil.WriteFile(operatorConfigFile, []byte(broken), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expireOperatorConfig()
+	if wallet, err := GetWallet("dashur"); err != nil || wallet.Adapter != WalletAdapterDashur {
+		t.Errorf("cached registry not kept after a failed reload: %v %v", wallet.Adapter, err)
+	}
+
+	// a fixed registry is loaded on the next refresh
+	fixed := strings.Replace(testOperatorConfig, `["2", "3"]`, `["2"]`, 1)
+	if err := ioutil.WriteFile(operatorConfigFile, []byte(fixed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expireOperatorConfig()
+	if _, ok := GetOperatorByCompany("3"); ok {
+		t.Errorf("changed registry not reloaded")
+	}
+}
+
+// writeOperatorConfig runs the test in a directory with the registry and without a cached registry
+func writeOperatorConfig(t *testing.T, config string) func() {
+	wd, _ := os.Getwd()
+	dir, err := ioutil.TempDir("", "operatorconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(dir, "config"), 0755)
+	if err := ioutil.WriteFile(filepath.Join(dir, operatorConfigFile), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chdir(dir)
+	operatorCache.Lock()
+	operatorCache.conf = nil
+	operatorCache.Unlock()
+	return func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+		operatorCache.Lock()
+		operatorCache.conf = nil
+		operatorCache.Unlock()
+	}
+}
+
+func expireOperatorConfig() {
+	operatorCache.Lock()
+	operatorCache.read = time.Now().Add(-2 * operatorConfigRefresh)
+	operatorCache.Unlock()
+}
@@ -13,6 +13,7 @@ const (
 	BadConfigError       = 10
 	EngineHashError      = 11
 	EngineConfigError    = 12
+	EngineSnapshotError  = 13
 	GenericEngineError   = 100
 	SpinSequenceError    = 101
 	EngineNotFoundError  = 102
@@ -20,6 +21,7 @@ const (
 	InvalidStakeError    = 104
 	IncompleteRoundError = 105
 	InvalidParamsError   = 106
+	TicketPoolSoldOut    = 107
 
 	// Gamestate JSON marshalling
 	GamestateStringSerializerError   = 110
@@ -61,6 +63,9 @@ const (
 	UnexpectedWalletStatus = 462
 	YamlError              = 463
 	RequestTimeout         = 464
+	InvalidSignature       = 465
+	WalletNotSupported     = 466
+	TransactionRolledBack  = 467
 
 	// System Error
 	InternalServerError = 500
@@ -76,6 +81,8 @@ const (
 	MontlyTimeLimit        = 610
 	WeeklyTimeLimit        = 611
 	DailyTimeLimit         = 612
+	JurisdictionRestricted = 613
+	SessionTimeLimit       = 614
 
 	// Session Error
 	CreateSessionError  = 700
@@ -101,6 +108,8 @@ var sentryIgnoreList = []int{
 	SpinSequenceError,
 	InvalidStakeError,
 	TokenExpired,
+	JurisdictionRestricted,
+	TicketPoolSoldOut,
 }
 
 // ErrMsg Error message key value map
@@ -109,12 +118,14 @@ var ErrMsg = map[int]string{
 	BadConfigError:                   "Bad configuration",
 	EngineHashError:                  "Could not generate hashes of engine files",
 	EngineConfigError:                "No game config",
+	EngineSnapshotError:              "Engine config of the round not found",
 	GenericEngineError:               "Engine error",
 	SpinSequenceError:                "Spin request out of sequence, please reload",
 	EngineNotFoundError:              "Engine not found",
 	BetConfigError:                   "Bet configuration error",
 	InvalidStakeError:                "Invalid stake error",
 	InvalidParamsError:               "Invalid params error",
+	TicketPoolSoldOut:                "Ticket pool sold out",
 	RgsInitError:                     "RGS Initialization error",
 	StoreInitError:                   "RGS Storage Initialization error",
 	GamestateStringSerializerError:   "Failure serializing Gamestate to string",
@@ -161,6 +172,9 @@ var ErrMsg = map[int]string{
 	YamlError:                        "Error encoding/decoding yaml",
 	BadFSWagerAmt:                    "Bad freespin wager amount",
 	RequestTimeout:                   "Request took too long",
+	InvalidSignature:                 "Invalid wallet signature",
+	WalletNotSupported:               "Not supported by the wallet",
+	TransactionRolledBack:            "Transaction was rolled back by the wallet",
 	CustomOperatorError:              "Custom operator error",
 	SpendingBudgetExceeded:           "Spending budged exceeded",
 	BlockedFromProduct:               "Player blocked",
@@ -168,6 +182,8 @@ var ErrMsg = map[int]string{
 	MontlyTimeLimit:                  "Monthly time limit exceeded",
 	WeeklyTimeLimit:                  "Weekly time limit exceeded",
 	DailyTimeLimit:                   "Daily time limit exceeded",
+	JurisdictionRestricted:           "Not allowed by the rules of the jurisdiction",
+	SessionTimeLimit:                 "Session time limit exceeded",
 }
 
 type RGSErr interface {
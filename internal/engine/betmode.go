@@ -0,0 +1,32 @@
+package engine
+
+import (
+	"fmt"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+)
+
+// BetMode is a player selectable mode of base rounds, e.g. an ante bet. A round in the mode costs a multiple of the
+// total stake and is played on its own engine def, the wins are still calculated from the bet per line.
+type BetMode struct {
+	Name string  `yaml:"name"`
+	Cost float64 `yaml:"cost"` // price of a round as a multiple of the total stake, e.g. 1.25
+	Def  string  `yaml:"def"`  // name of the engine def that base rounds in this mode are played on
+}
+
+// BetMode returns the bet mode with the given name
+func (config EngineConfig) BetMode(name string) (BetMode, rgse.RGSErr) {
+	for _, mode := range config.BetModes {
+		if mode.Name == name {
+			return mode, nil
+		}
+	}
+	err := rgse.Create(rgse.InvalidParamsError)
+	err.AppendErrorText(fmt.Sprintf("engine has no bet mode %v", name))
+	return BetMode{}, err
+}
+
+// CostMultiplier returns the cost of the mode as a multiple of the total stake
+func (mode BetMode) CostMultiplier() Fixed {
+	return NewFixedFromFloat64(mode.Cost)
+}
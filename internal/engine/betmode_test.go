@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+)
+
+func TestBetMode(t *testing.T) {
+	rng.Init()
+	EC := BuildEngineDefs("testAnteBet")
+	mode, err := EC.BetMode("ante")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode.CostMultiplier() != NewFixedFromFloat64(1.25) {
+		t.Errorf("cost multiplier %v", mode.CostMultiplier())
+	}
+	if _, err := EC.BetMode("super"); err == nil {
+		t.Errorf("expected an error for an unknown bet mode")
+	}
+
+	// both modes return the engine rtp of their cost, the freespins are worth 20 spins paying 1.0
+	freespin := float64(EC.EngineDefs[EC.DefIdByName("freespin")].ExpectedPayout) / float64(fixedExp)
+	for name, cost := range map[string]float64{"base": 1, "ante": mode.Cost} {
+		result, err := EC.EngineDefs[EC.DefIdByName(name)].ExactRTP()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rtp := (result.RTP + result.Triggers["freespin:20"]*20*freespin) / cost
+		if math.Abs(rtp-float64(EC.RTP)) > 1e-6 {
+			t.Errorf("%v rtp %v, expected %v", name, rtp, EC.RTP)
+		}
+	}
+
+	previous := Gamestate{Game: "test-ante-bet", NextGamestate: "ante", NextActions: []string{"finish"}}
+	params := GameParams{Game: "test-ante-bet", Stake: NewFixedFromInt(5), Action: "base", BetMode: "ante"}
+	// the round played with this seed shows three 0s and triggers the freespins
+	gamestate, _, err := PlayWithSeed(previous, NewFixedFromInt(1), "USD", params, betModeFreespinSeed)
+	if err != nil {
+		t.Fatalf("play: %v", err.Error())
+	}
+	if gamestate.BetMode != "ante" || gamestate.DefID != EC.DefIdByName("ante") || gamestate.Action != "base" {
+		t.Fatalf("round played in mode %v on def %v with action %v", gamestate.BetMode, gamestate.DefID, gamestate.Action)
+	}
+	if len(gamestate.Transactions) == 0 || gamestate.Transactions[0].Type != "WAGER" || gamestate.Transactions[0].Amount.Amount != NewFixedFromFloat64(6.25) {
+		t.Fatalf("unexpected transactions %#v", gamestate.Transactions)
+	}
+	if len(gamestate.NextActions) < 2 || gamestate.NextActions[0] != "freespin" {
+		t.Fatalf("no freespins triggered in ante mode: %v", gamestate.NextActions)
+	}
+	// the freespins of the round keep its bet mode and are not charged
+	next, _, err := Play(gamestate, NewFixedFromInt(1), "USD", GameParams{Game: "test-ante-bet", Action: gamestate.NextActions[0]})
+	if err != nil {
+		t.Fatalf("play: %v", err.Error())
+	}
+	if next.BetMode != "ante" || next.Action != "freespin" {
+		t.Errorf("freespin played in mode %v with action %v", next.BetMode, next.Action)
+	}
+	for _, tx := range next.Transactions {
+		if tx.Type == "WAGER" {
+			t.Errorf("wager charged in freespin")
+		}
+	}
+	if decoded := next.Convert().Convert(); decoded.BetMode != "ante" {
+		t.Errorf("bet mode lost in serialization")
+	}
+}
+
+// the seed of an ante round of test-ante-bet that triggers the freespins
+var betModeFreespinSeed = []uint64{97}
+
+func TestBetModeInvalid(t *testing.T) {
+	rng.Init()
+	previous := Gamestate{Game: "test-ante-bet", NextGamestate: "ante", NextActions: []string{"finish"}}
+	if _, _, err := Play(previous, NewFixedFromInt(1), "USD", GameParams{Game: "test-ante-bet", Action: "base", BetMode: "super"}); err == nil {
+		t.Errorf("expected an error for an unknown bet mode")
+	}
+	if _, _, err := Play(previous, NewFixedFromInt(1), "USD", GameParams{Game: "test-ante-bet", Action: "respin", RespinReel: 0, BetMode: "ante"}); err == nil {
+		t.Errorf("expected an error for a bet mode on a respin")
+	}
+}
@@ -0,0 +1,396 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+const (
+	BlackjackDeal        = "deal"
+	BlackjackHit         = "hit"
+	BlackjackStand       = "stand"
+	BlackjackDouble      = "double"
+	BlackjackSplit       = "split"
+	BlackjackInsurance   = "insurance"
+	BlackjackNoInsurance = "noInsurance"
+
+	// the results of the hands
+	BlackjackResultBlackjack = "blackjack"
+	BlackjackResultWin       = "win"
+	BlackjackResultPush      = "push"
+	BlackjackResultLose      = "lose"
+	BlackjackResultBust      = "bust"
+
+	blackjackDefaultDecks    = 6
+	blackjackDefaultMaxHands = 4
+	blackjackDefaultPays     = "3:2"
+	blackjackMaxDecks        = 8
+)
+
+// BlackjackConfiguration holds the rules of the table of a blackjack engine
+type BlackjackConfiguration struct {
+	Decks            int    `yaml:"Decks"`            // the decks in the shoe, 6 if not set
+	DealerHitsSoft17 bool   `yaml:"DealerHitsSoft17"` // the dealer draws to a soft 17 instead of standing
+	BlackjackPays    string `yaml:"BlackjackPays"`    // the odds of a blackjack, 3:2 if not set or 6:5
+	DoubleAfterSplit bool   `yaml:"DoubleAfterSplit"` // split hands may be doubled
+	MaxHands         int    `yaml:"MaxHands"`         // the hands a player can split to, 4 if not set
+	Insurance        bool   `yaml:"Insurance"`        // insurance is offered when the dealer shows an ace
+}
+
+func (c BlackjackConfiguration) DeckCount() int {
+	if c.Decks == 0 {
+		return blackjackDefaultDecks
+	}
+	return c.Decks
+}
+
+func (c BlackjackConfiguration) HandLimit() int {
+	if c.MaxHands == 0 {
+		return blackjackDefaultMaxHands
+	}
+	return c.MaxHands
+}
+
+// BlackjackOdds returns the odds that a blackjack pays
+func (c BlackjackConfiguration) BlackjackOdds() (int, int, error) {
+	pays := c.BlackjackPays
+	if pays == "" {
+		pays = blackjackDefaultPays
+	}
+	parts := strings.Split(pays, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("blackjack pays %v is not of the form 3:2", pays)
+	}
+	num, err1 := strconv.Atoi(parts[0])
+	den, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || num <= 0 || den <= 0 {
+		return 0, 0, fmt.Errorf("blackjack pays %v is not of the form 3:2", pays)
+	}
+	return num, den, nil
+}
+
+// GameStateBlackjack is one step of a blackjack round, the round is settled when no actions are left
+type GameStateBlackjack struct {
+	GameStateV3
+
+	Action    string          `json:"action"`  // the action that led to the state
+	Shoe      []int           `json:"shoe"`    // the cards left in the shoe
+	Dealer    []int           `json:"dealer"`  // the cards of the dealer, the second is the hole card
+	Hands     []HandBlackjack `json:"hands"`   // the hands of the player, split hands follow the hand they were split from
+	Active    int             `json:"active"`  // the hand the actions apply to
+	Actions   []string        `json:"actions"` // the actions the player can take
+	Insurance Fixed           `json:"insurance"`
+	Bet       Fixed           `json:"bet"` // the wagers of the round
+	Win       Fixed           `json:"win"` // the payout of the round once it is settled
+	rng       *rng.Round      // the rng of the step, set by PlayBlackjack
+}
+
+type HandBlackjack struct {
+	Cards   []int  `json:"cards"`
+	Bet     Fixed  `json:"bet"`
+	Doubled bool   `json:"doubled,omitempty"`
+	Split   bool   `json:"split,omitempty"` // the hand was split and can not be a blackjack
+	Done    bool   `json:"done,omitempty"`
+	Result  string `json:"result,omitempty"`
+	Win     Fixed  `json:"win"`
+}
+
+func (g *GameStateBlackjack) Base() *GameStateV3 {
+	return &g.GameStateV3
+}
+
+func (s GameStateBlackjack) Serialize() []byte {
+	b, _ := json.Marshal(s)
+	logger.Debugf("GameStateBlackjack.Serialize %s", string(b))
+	return b
+}
+
+func (s *GameStateBlackjack) Deserialize(serialized []byte) rgse.RGSErr {
+	err := json.Unmarshal(serialized, s)
+	if err != nil {
+		logger.Debugf("unmarshal json failed with error %s", err.Error())
+		return rgse.Create(rgse.GamestateByteDeserializerError)
+	}
+	return nil
+}
+
+// GetTtl keeps a round that waits for the player open as long as the pending features of the slots
+func (s GameStateBlackjack) GetTtl() int64 {
+	if s.InProgress() {
+		return 3600 * 24 * 2
+	}
+	return 3600
+}
+
+// InProgress reports whether the round waits for an action of the player
+func (s GameStateBlackjack) InProgress() bool {
+	return len(s.Actions) > 0
+}
+
+// BlackjackCardValue returns the value of the card, an ace counts 1
+func BlackjackCardValue(card int) int {
+	rank := card%13 + 1
+	if rank > 10 {
+		return 10
+	}
+	return rank
+}
+
+// BlackjackCardName returns the rank and the suit of the card, As for the ace of spades
+func BlackjackCardName(card int) string {
+	return string("A23456789TJQK"[card%13]) + string("shdc"[(card/13)%4])
+}
+
+// BlackjackHandValue returns the best value of the cards and whether an ace counts 11 in it
+func BlackjackHandValue(cards []int) (int, bool) {
+	value, aces := 0, false
+	for _, c := range cards {
+		v := BlackjackCardValue(c)
+		value += v
+		if v == 1 {
+			aces = true
+		}
+	}
+	if aces && value+10 <= 21 {
+		return value + 10, true
+	}
+	return value, false
+}
+
+func isBlackjack(cards []int) bool {
+	value, _ := BlackjackHandValue(cards)
+	return len(cards) == 2 && value == 21
+}
+
+// NewBlackjackShoe returns the cards of the decks, the cards are drawn at random from the shoe as they are dealt so
+// that the state of a round in progress does not hold the order of the cards to come
+func NewBlackjackShoe(decks int) []int {
+	shoe := make([]int, decks*52)
+	for i := range shoe {
+		shoe[i] = i % 52
+	}
+	return shoe
+}
+
+// draw takes a card from the shoe with the rng of the step
+func (s *GameStateBlackjack) draw() int {
+	i := s.rng.RandFromRangeTagged(len(s.Shoe), "blackjackCard")
+	card := s.Shoe[i]
+	s.Shoe = append(s.Shoe[:i], s.Shoe[i+1:]...)
+	return card
+}
+
+// copyRound copies the cards and the hands so that the step does not change the state it continues
+func (s GameStateBlackjack) copyRound() GameStateBlackjack {
+	s.Shoe = append([]int{}, s.Shoe...)
+	s.Dealer = append([]int{}, s.Dealer...)
+	hands := make([]HandBlackjack, len(s.Hands))
+	for i, h := range s.Hands {
+		h.Cards = append([]int{}, h.Cards...)
+		hands[i] = h
+	}
+	s.Hands = hands
+	s.Actions = nil
+	return s
+}
+
+// PlayBlackjack plays the action of the player on the round of the previous state and returns the state of the step
+// and the amount that the step wagers. A deal starts a round with the bet on a new shoe, the other actions must be
+// offered by the previous state. The cards are drawn with the rng of the round.
+func PlayBlackjack(round *rng.Round, previous GameStateBlackjack, action string, bet Fixed, conf BlackjackConfiguration) (GameStateBlackjack, Fixed, rgse.RGSErr) {
+	var state GameStateBlackjack
+	var wager Fixed
+	if action == BlackjackDeal {
+		if previous.InProgress() {
+			rgserr := rgse.Create(rgse.SpinSequenceError)
+			rgserr.AppendErrorText("the round of the previous state is not settled")
+			return state, 0, rgserr
+		}
+		if bet <= 0 {
+			return state, 0, rgse.Create(rgse.InvalidStakeError)
+		}
+		state = GameStateBlackjack{Shoe: NewBlackjackShoe(conf.DeckCount()), rng: round}
+		hand := HandBlackjack{Bet: bet}
+		hand.Cards = append(hand.Cards, state.draw())
+		state.Dealer = append(state.Dealer, state.draw())
+		hand.Cards = append(hand.Cards, state.draw())
+		state.Dealer = append(state.Dealer, state.draw())
+		state.Hands = []HandBlackjack{hand}
+		wager = bet
+		if conf.Insurance && BlackjackCardValue(state.Dealer[0]) == 1 {
+			state.Actions = []string{BlackjackInsurance, BlackjackNoInsurance}
+		} else {
+			state.checkBlackjacks(conf)
+		}
+	} else {
+		if !previous.offers(action) {
+			rgserr := rgse.Create(rgse.SpinSequenceError)
+			rgserr.AppendErrorText(fmt.Sprintf("action %v is not offered, the round offers %v", action, previous.Actions))
+			return state, 0, rgserr
+		}
+		state = previous.copyRound()
+		state.rng = round
+		hand := &state.Hands[state.Active]
+		switch action {
+		case BlackjackInsurance:
+			wager = previous.ActionWager(action)
+			state.Insurance = wager
+			state.checkBlackjacks(conf)
+		case BlackjackNoInsurance:
+			state.checkBlackjacks(conf)
+		case BlackjackHit:
+			hand.Cards = append(hand.Cards, state.draw())
+			if value, _ := BlackjackHandValue(hand.Cards); value >= 21 {
+				hand.Done = true
+			}
+			state.next(conf)
+		case BlackjackStand:
+			hand.Done = true
+			state.next(conf)
+		case BlackjackDouble:
+			wager = previous.ActionWager(action)
+			hand.Bet += wager
+			hand.Doubled = true
+			hand.Cards = append(hand.Cards, state.draw())
+			hand.Done = true
+			state.next(conf)
+		case BlackjackSplit:
+			wager = previous.ActionWager(action)
+			aces := BlackjackCardValue(hand.Cards[0]) == 1
+			second := HandBlackjack{Cards: []int{hand.Cards[1]}, Bet: hand.Bet, Split: true, Done: aces}
+			hand.Cards = []int{hand.Cards[0], state.draw()}
+			second.Cards = append(second.Cards, state.draw())
+			hand.Split = true
+			// split aces take one card each
+			hand.Done = aces
+			state.Hands = append(state.Hands[:state.Active+1], append([]HandBlackjack{second}, state.Hands[state.Active+1:]...)...)
+			state.next(conf)
+		}
+	}
+	state.Action = action
+	state.Bet += wager
+	return state, wager, nil
+}
+
+// ActionWager returns the amount that the action wagers on the round of the state, the deal wagers the bet
+func (s GameStateBlackjack) ActionWager(action string) Fixed {
+	if s.Active >= len(s.Hands) {
+		return 0
+	}
+	switch action {
+	case BlackjackInsurance:
+		return s.Hands[s.Active].Bet.Div(NewFixedFromInt(2))
+	case BlackjackDouble, BlackjackSplit:
+		return s.Hands[s.Active].Bet
+	}
+	return 0
+}
+
+func (s GameStateBlackjack) offers(action string) bool {
+	for _, a := range s.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBlackjacks settles the round when the dealer peeks a blackjack or the player has one, the player plays the
+// hand otherwise
+func (s *GameStateBlackjack) checkBlackjacks(conf BlackjackConfiguration) {
+	if isBlackjack(s.Dealer) || isBlackjack(s.Hands[0].Cards) {
+		s.Hands[0].Done = true
+		s.settle(conf)
+		return
+	}
+	s.next(conf)
+}
+
+// next moves to the first hand that is not done and offers its actions, the dealer plays once every hand is done
+func (s *GameStateBlackjack) next(conf BlackjackConfiguration) {
+	for s.Active < len(s.Hands) {
+		hand := &s.Hands[s.Active]
+		if value, _ := BlackjackHandValue(hand.Cards); value >= 21 {
+			hand.Done = true
+		}
+		if !hand.Done {
+			break
+		}
+		s.Active++
+	}
+	if s.Active >= len(s.Hands) {
+		s.Active = len(s.Hands) - 1
+		s.playDealer(conf)
+		s.settle(conf)
+		return
+	}
+	hand := s.Hands[s.Active]
+	s.Actions = []string{BlackjackHit, BlackjackStand}
+	if len(hand.Cards) == 2 && (!hand.Split || conf.DoubleAfterSplit) {
+		s.Actions = append(s.Actions, BlackjackDouble)
+	}
+	if len(hand.Cards) == 2 && BlackjackCardValue(hand.Cards[0]) == BlackjackCardValue(hand.Cards[1]) && len(s.Hands) < conf.HandLimit() {
+		s.Actions = append(s.Actions, BlackjackSplit)
+	}
+}
+
+// playDealer draws the cards of the dealer unless every hand is bust
+func (s *GameStateBlackjack) playDealer(conf BlackjackConfiguration) {
+	for _, hand := range s.Hands {
+		if value, _ := BlackjackHandValue(hand.Cards); value <= 21 {
+			for {
+				value, soft := BlackjackHandValue(s.Dealer)
+				if value > 17 || (value == 17 && !(soft && conf.DealerHitsSoft17)) {
+					return
+				}
+				s.Dealer = append(s.Dealer, s.draw())
+			}
+		}
+	}
+}
+
+// settle pays the hands and the insurance, the wins include the stakes
+func (s *GameStateBlackjack) settle(conf BlackjackConfiguration) {
+	num, den, err := conf.BlackjackOdds()
+	if err != nil {
+		logger.Warnf("%v, paying 3:2", err.Error())
+		num, den = 3, 2
+	}
+	dealer, _ := BlackjackHandValue(s.Dealer)
+	dealerBlackjack := isBlackjack(s.Dealer)
+	s.Win = 0
+	for i := range s.Hands {
+		hand := &s.Hands[i]
+		value, _ := BlackjackHandValue(hand.Cards)
+		playerBlackjack := !hand.Split && isBlackjack(hand.Cards)
+		switch {
+		case value > 21:
+			hand.Result, hand.Win = BlackjackResultBust, 0
+		case playerBlackjack && dealerBlackjack:
+			hand.Result, hand.Win = BlackjackResultPush, hand.Bet
+		case playerBlackjack:
+			hand.Result, hand.Win = BlackjackResultBlackjack, hand.Bet+hand.Bet.Mul(NewFixedFromInt(num)).Div(NewFixedFromInt(den))
+		case dealerBlackjack:
+			hand.Result, hand.Win = BlackjackResultLose, 0
+		case dealer > 21 || value > dealer:
+			hand.Result, hand.Win = BlackjackResultWin, hand.Bet.Mul(NewFixedFromInt(2))
+		case value == dealer:
+			hand.Result, hand.Win = BlackjackResultPush, hand.Bet
+		default:
+			hand.Result, hand.Win = BlackjackResultLose, 0
+		}
+		hand.Done = true
+		s.Win += hand.Win
+	}
+	if dealerBlackjack && s.Insurance > 0 {
+		// insurance pays 2:1
+		s.Win += s.Insurance.Mul(NewFixedFromInt(3))
+	}
+	s.Actions = nil
+}
@@ -0,0 +1,225 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+)
+
+// the cards of the spades by rank, an ace is 0 and a ten 9
+const (
+	bjAce   = 0
+	bjTwo   = 1
+	bjThree = 2
+	bjSix   = 5
+	bjSeven = 6
+	bjEight = 7
+	bjNine  = 8
+	bjTen   = 9
+	bjKing  = 12
+)
+
+func TestBlackjackHandValue(t *testing.T) {
+	for _, c := range []struct {
+		cards []int
+		value int
+		soft  bool
+	}{
+		{[]int{bjAce, bjKing}, 21, true},
+		{[]int{bjAce, bjAce + 13, bjNine}, 21, true},
+		{[]int{bjAce, bjNine, bjSix}, 16, false},
+		{[]int{bjKing, bjTen + 26, bjTwo}, 22, false},
+		{[]int{bjSeven, bjSeven + 39}, 14, false},
+	} {
+		if value, soft := BlackjackHandValue(c.cards); value != c.value || soft != c.soft {
+			t.Errorf("cards %v have value %v soft %v, expected %v %v", c.cards, value, soft, c.value, c.soft)
+		}
+	}
+	if BlackjackCardName(bjAce) != "As" || BlackjackCardName(bjTen+13) != "Th" || BlackjackCardName(51) != "Kc" {
+		t.Errorf("unexpected card names %v %v %v", BlackjackCardName(bjAce), BlackjackCardName(bjTen+13), BlackjackCardName(51))
+	}
+}
+
+func TestBlackjackOdds(t *testing.T) {
+	if num, den, err := (BlackjackConfiguration{}).BlackjackOdds(); err != nil || num != 3 || den != 2 {
+		t.Errorf("default odds %v:%v %v", num, den, err)
+	}
+	if num, den, err := (BlackjackConfiguration{BlackjackPays: "6:5"}).BlackjackOdds(); err != nil || num != 6 || den != 5 {
+		t.Errorf("odds %v:%v %v", num, den, err)
+	}
+	for _, pays := range []string{"3", "3:0", "a:b", "3:2:1"} {
+		if _, _, err := (BlackjackConfiguration{BlackjackPays: pays}).BlackjackOdds(); err == nil {
+			t.Errorf("odds %v parsed", pays)
+		}
+	}
+}
+
+func TestNewBlackjackShoe(t *testing.T) {
+	counts := make([]int, 52)
+	for _, c := range NewBlackjackShoe(2) {
+		counts[c]++
+	}
+	for c, n := range counts {
+		if n != 2 {
+			t.Errorf("card %v is %v times in the shoe", BlackjackCardName(c), n)
+		}
+	}
+
+	// the cards of a step are drawn with the seed of its round
+	rng.Init()
+	round := rng.NewRound(nil)
+	dealt, _, _ := PlayBlackjack(round, GameStateBlackjack{}, BlackjackDeal, NewFixedFromInt(1), BlackjackConfiguration{})
+	replayed, _, _ := PlayBlackjack(rng.NewRound(round.Seed), GameStateBlackjack{}, BlackjackDeal, NewFixedFromInt(1), BlackjackConfiguration{})
+	if !reflect.DeepEqual(dealt, replayed) {
+		t.Errorf("deal of the seed differs")
+	}
+}
+
+func TestPlayBlackjack_Deal(t *testing.T) {
+	rng.Init()
+	conf := BlackjackConfiguration{Insurance: true}
+	for i := 0; i < 50; i++ {
+		state, wager, err := PlayBlackjack(nil, GameStateBlackjack{}, BlackjackDeal, NewFixedFromInt(2), conf)
+		if err != nil {
+			t.Fatalf("deal: %v", err.Error())
+		}
+		if wager != NewFixedFromInt(2) || state.Bet != wager || len(state.Hands) != 1 || len(state.Hands[0].Cards) != 2 || len(state.Dealer) < 2 {
+			t.Fatalf("unexpected deal %#v", state)
+		}
+		if len(state.Shoe)+len(state.Dealer)+2 != 6*52 {
+			t.Errorf("%v cards left in the shoe", len(state.Shoe))
+		}
+		if BlackjackCardValue(state.Dealer[0]) == 1 {
+			if !reflect.DeepEqual(state.Actions, []string{BlackjackInsurance, BlackjackNoInsurance}) {
+				t.Errorf("insurance not offered: %v", state.Actions)
+			}
+		} else if isBlackjack(state.Dealer) || isBlackjack(state.Hands[0].Cards) {
+			if state.InProgress() || state.Hands[0].Result == "" {
+				t.Errorf("blackjack not settled %#v", state)
+			}
+		} else if !state.InProgress() {
+			t.Errorf("round settled without a blackjack %#v", state)
+		}
+	}
+
+	if _, _, err := PlayBlackjack(nil, GameStateBlackjack{}, BlackjackDeal, 0, conf); err == nil {
+		t.Errorf("deal without a bet")
+	}
+	if _, _, err := PlayBlackjack(nil, GameStateBlackjack{Actions: []string{BlackjackHit}}, BlackjackDeal, NewFixedFromInt(1), conf); err == nil {
+		t.Errorf("deal on a round in progress")
+	}
+	if _, _, err := PlayBlackjack(nil, GameStateBlackjack{}, BlackjackHit, 0, conf); err == nil {
+		t.Errorf("hit on a settled round")
+	}
+}
+
+func TestPlayBlackjack_SplitDouble(t *testing.T) {
+	conf := BlackjackConfiguration{DoubleAfterSplit: true}
+	state := GameStateBlackjack{
+		Shoe:    []int{bjThree, bjThree + 13},
+		Dealer:  []int{bjSix, bjTen},
+		Hands:   []HandBlackjack{{Cards: []int{bjEight, bjEight + 13}, Bet: NewFixedFromInt(1)}},
+		Actions: []string{BlackjackHit, BlackjackStand, BlackjackDouble, BlackjackSplit},
+		Bet:     NewFixedFromInt(1),
+	}
+	state, wager, err := PlayBlackjack(nil, state, BlackjackSplit, 0, conf)
+	if err != nil || wager != NewFixedFromInt(1) {
+		t.Fatalf("split: %v %v", wager, err)
+	}
+	if len(state.Shoe) != 0 || len(state.Hands) != 2 || len(state.Hands[0].Cards) != 2 || len(state.Hands[1].Cards) != 2 ||
+		state.Hands[0].Cards[0] != bjEight || state.Hands[1].Cards[0] != bjEight+13 {
+		t.Errorf("unexpected split hands %v", state.Hands)
+	}
+	if state.Active != 0 || !reflect.DeepEqual(state.Actions, []string{BlackjackHit, BlackjackStand, BlackjackDouble}) {
+		t.Errorf("unexpected actions %v on hand %v", state.Actions, state.Active)
+	}
+
+	// every step draws at random from the shoe, so the shoe holds the cards of the next step
+	state.Shoe = []int{bjTen}
+	state, wager, err = PlayBlackjack(nil, state, BlackjackDouble, 0, conf)
+	if err != nil || wager != NewFixedFromInt(1) {
+		t.Fatalf("double: %v %v", wager, err)
+	}
+	if state.Active != 1 || !state.Hands[0].Done || len(state.Hands[0].Cards) != 3 {
+		t.Errorf("doubled hand is not done %#v", state.Hands[0])
+	}
+	// the second hand may be doubled after the split
+	if !reflect.DeepEqual(state.Actions, []string{BlackjackHit, BlackjackStand, BlackjackDouble}) {
+		t.Errorf("unexpected actions %v", state.Actions)
+	}
+
+	// the dealer draws to 16 and busts
+	state.Shoe = []int{bjKing}
+	state, _, err = PlayBlackjack(nil, state, BlackjackStand, 0, conf)
+	if err != nil {
+		t.Fatalf("stand: %v", err.Error())
+	}
+	if state.InProgress() || len(state.Dealer) != 3 {
+		t.Errorf("round not settled %#v", state)
+	}
+	if state.Hands[0].Result != BlackjackResultWin || state.Hands[0].Win != NewFixedFromInt(4) || state.Hands[1].Win != NewFixedFromInt(2) {
+		t.Errorf("unexpected hands %#v", state.Hands)
+	}
+	if state.Bet != NewFixedFromInt(3) || state.Win != NewFixedFromInt(6) {
+		t.Errorf("round wagered %v and paid %v", state.Bet, state.Win)
+	}
+}
+
+func TestPlayBlackjack_Insurance(t *testing.T) {
+	offered := GameStateBlackjack{
+		Dealer:  []int{bjAce, bjKing},
+		Hands:   []HandBlackjack{{Cards: []int{bjTen, bjNine}, Bet: NewFixedFromInt(2)}},
+		Actions: []string{BlackjackInsurance, BlackjackNoInsurance},
+		Bet:     NewFixedFromInt(2),
+	}
+	state, wager, err := PlayBlackjack(nil, offered, BlackjackInsurance, 0, BlackjackConfiguration{})
+	if err != nil || wager != NewFixedFromInt(1) || state.Insurance != wager {
+		t.Fatalf("insurance: %v %v", wager, err)
+	}
+	if state.InProgress() || state.Hands[0].Result != BlackjackResultLose || state.Win != NewFixedFromInt(3) {
+		t.Errorf("insurance did not pay 2:1 %#v", state)
+	}
+	state, _, _ = PlayBlackjack(nil, offered, BlackjackNoInsurance, 0, BlackjackConfiguration{})
+	if state.InProgress() || state.Win != 0 {
+		t.Errorf("unexpected win %v", state.Win)
+	}
+
+	// the player has a blackjack and the dealer has none
+	offered.Dealer = []int{bjAce, bjNine}
+	offered.Hands[0].Cards = []int{bjAce + 13, bjKing}
+	for pays, win := range map[string]Fixed{"3:2": NewFixedFromInt(5), "6:5": Fixed(4400000)} {
+		state, _, _ = PlayBlackjack(nil, offered, BlackjackNoInsurance, 0, BlackjackConfiguration{BlackjackPays: pays})
+		if state.Hands[0].Result != BlackjackResultBlackjack || state.Win != win || len(state.Dealer) != 2 {
+			t.Errorf("blackjack paying %v won %v", pays, state.Win)
+		}
+	}
+	// the original state is not changed by the steps
+	if len(offered.Actions) != 2 || offered.Hands[0].Result != "" {
+		t.Errorf("previous state changed %#v", offered)
+	}
+}
+
+func TestPlayBlackjack_DealerSoft17(t *testing.T) {
+	state := GameStateBlackjack{
+		Shoe:    []int{bjTwo},
+		Dealer:  []int{bjAce, bjSix},
+		Hands:   []HandBlackjack{{Cards: []int{bjTen, bjEight}, Bet: NewFixedFromInt(1)}},
+		Actions: []string{BlackjackHit, BlackjackStand},
+	}
+	stands, _, _ := PlayBlackjack(nil, state, BlackjackStand, 0, BlackjackConfiguration{})
+	if len(stands.Dealer) != 2 || stands.Hands[0].Result != BlackjackResultWin {
+		t.Errorf("dealer drew to soft 17 %v", stands.Dealer)
+	}
+	hits, _, _ := PlayBlackjack(nil, state, BlackjackStand, 0, BlackjackConfiguration{DealerHitsSoft17: true})
+	if len(hits.Dealer) != 3 || hits.Hands[0].Result != BlackjackResultLose {
+		t.Errorf("dealer stood on soft 17 %v", hits.Dealer)
+	}
+
+	// the dealer does not draw when the player is bust
+	state.Shoe = []int{bjKing}
+	bust, _, _ := PlayBlackjack(nil, state, BlackjackHit, 0, BlackjackConfiguration{DealerHitsSoft17: true})
+	if bust.InProgress() || len(bust.Dealer) != 2 || bust.Hands[0].Result != BlackjackResultBust {
+		t.Errorf("unexpected bust %#v", bust)
+	}
+}
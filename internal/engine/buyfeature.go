@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// BuyFeatureAction is the action that buys the feature configured on the def with the same name
+const BuyFeatureAction = "buyFeature"
+
+// buy feature configuration of the def named buyFeature
+type buyFeature struct {
+	Award string `yaml:"award"` // special win index of the rounds that are bought, e.g. freespin:10
+}
+
+// HasBuyFeature reports whether the feature of the engine can be bought
+func (config EngineConfig) HasBuyFeature() bool {
+	defID := config.DefIdByName(BuyFeatureAction)
+	return defID >= 0 && config.EngineDefs[defID].BuyFeature.Award != ""
+}
+
+// BuyFeaturePrice returns the price of the buy feature as a multiple of the total stake. The price keeps the RTP of
+// the engine: the expected payout of the awarded rounds divided by the RTP.
+func (config EngineConfig) BuyFeaturePrice() (Fixed, rgse.RGSErr) {
+	defID := config.DefIdByName(BuyFeatureAction)
+	if defID < 0 || config.EngineDefs[defID].BuyFeature.Award == "" {
+		err := rgse.Create(rgse.InvalidParamsError)
+		err.AppendErrorText("engine has no buy feature")
+		return 0, err
+	}
+	award := config.EngineDefs[defID].BuyFeature.Award
+	awardInfo := strings.Split(award, ":")
+	count := 0
+	if len(awardInfo) == 2 {
+		count, _ = strconv.Atoi(awardInfo[1])
+	}
+	targetID := config.DefIdByName(awardInfo[0])
+	if count < 1 || targetID < 0 {
+		err := rgse.Create(rgse.EngineConfigError)
+		err.AppendErrorText(fmt.Sprintf("buy feature award %v does not match an engine def", award))
+		return 0, err
+	}
+	// expectedPayout is relative to the total stake, it must be set on the first def with the target name
+	expectedPayout := config.EngineDefs[targetID].ExpectedPayout
+	if expectedPayout <= 0 || config.RTP <= 0 {
+		err := rgse.Create(rgse.EngineConfigError)
+		err.AppendErrorText(fmt.Sprintf("buy feature award %v needs an expected payout and an engine rtp", award))
+		return 0, err
+	}
+	return expectedPayout.Mul(NewFixedFromInt(count)).Div(NewFixedFromFloat(config.RTP)), nil
+}
+
+// BuyFeatureRound awards the bought rounds without spinning, the price is charged as the wager of the round
+func (engine EngineDef) BuyFeatureRound(parameters GameParams) Gamestate {
+	// the award is not added as a prize, prizes are matched to special payouts by their payout when they are read back
+	award := Prize{Index: engine.BuyFeature.Award}
+	_, nextActions := engine.CalculatePayoutSpecialWin(&award)
+	logger.Debugf("bought feature %v", award.Index)
+	return Gamestate{DefID: engine.Index, Multiplier: 1, NextActions: nextActions}
+}
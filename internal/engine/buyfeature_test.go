@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+)
+
+func TestBuyFeature(t *testing.T) {
+	rng.Init()
+	EC := BuildEngineDefs("testBuyFeature")
+	if !EC.HasBuyFeature() {
+		t.Fatalf("testBuyFeature has no buy feature")
+	}
+
+	// the base round returns the rtp of the engine including the freespins it triggers
+	result, err := EC.EngineDefs[0].ExactRTP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	freespins := result.Triggers["freespin:10"] * 10 * float64(EC.EngineDefs[1].ExpectedPayout) / float64(fixedExp)
+	if math.Abs(result.RTP+freespins-float64(EC.RTP)) > 1e-6 {
+		t.Errorf("base rtp %v with freespins %v, expected %v", result.RTP, freespins, EC.RTP)
+	}
+
+	// ten freespins paying 1.0 each are priced at 10/.95 total stakes
+	price, err := EC.BuyFeaturePrice()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(float64(price)-10/.95*float64(fixedExp)) > 1 {
+		t.Errorf("price %v, expected %v", price.StringFmt(6), 10/.95)
+	}
+
+	previous := Gamestate{Game: "test-buy-feature", NextGamestate: "buy", NextActions: []string{"finish"}}
+	gamestate, _, err := Play(previous, NewFixedFromInt(1), "USD", GameParams{Game: "test-buy-feature", Stake: NewFixedFromInt(5), Action: BuyFeatureAction})
+	if err != nil {
+		t.Fatalf("play: %v", err.Error())
+	}
+	if len(gamestate.Transactions) != 1 || gamestate.Transactions[0].Type != "WAGER" {
+		t.Fatalf("unexpected transactions %#v", gamestate.Transactions)
+	}
+	expectedWager := RoundUpToNearestCCYUnit(Money{NewFixedFromInt(5).Mul(price), "USD"})
+	if gamestate.Transactions[0].Amount != expectedWager {
+		t.Errorf("wager %v, expected %v", gamestate.Transactions[0].Amount, expectedWager)
+	}
+	if gamestate.Action != BuyFeatureAction || len(gamestate.Prizes) != 0 {
+		t.Errorf("unexpected bought round %v with prizes %v", gamestate.Action, gamestate.Prizes)
+	}
+	if len(gamestate.NextActions) != 11 || gamestate.NextActions[0] != "freespin" || gamestate.NextActions[10] != "finish" {
+		t.Errorf("unexpected next actions %v", gamestate.NextActions)
+	}
+
+	// the bought freespins are played without a wager
+	for len(gamestate.NextActions) > 1 {
+		gamestate, _, err = Play(gamestate, NewFixedFromInt(1), "USD", GameParams{Game: "test-buy-feature", Action: gamestate.NextActions[0]})
+		if err != nil {
+			t.Fatalf("play: %v", err.Error())
+		}
+		for _, tx := range gamestate.Transactions {
+			if tx.Type == "WAGER" {
+				t.Errorf("wager charged in freespin %v", gamestate.Id)
+			}
+		}
+	}
+
+	mvg := BuildEngineDefs("mvgEngineI")
+	if mvg.HasBuyFeature() {
+		t.Errorf("mvgEngineI has a buy feature")
+	}
+	if _, err := mvg.BuyFeaturePrice(); err == nil {
+		t.Errorf("expected an error for an engine without a buy feature")
+	}
+}
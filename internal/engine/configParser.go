@@ -1,16 +1,19 @@
 package engine
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"math"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	sync "sync"
-	"sync/atomic"
 	"time"
 
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
 	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
@@ -28,92 +31,385 @@ import (
 
 // EngineConfig ...
 type EngineConfig struct {
-	RTP        float32     `yaml:"rtp"`
-	Volatility float64     `yaml:"volatility"`
-	Version    string      `yaml:"version"`
-	EngineDefs []EngineDef `yaml:"EngineDefs,flow"`
+	RTP              float32             `yaml:"rtp"`
+	Volatility       float64             `yaml:"volatility"`
+	Version          string              `yaml:"version"`
+	MaxWinMultiplier int                 `yaml:"maxWinMultiplier"` // cap of the round win as a multiple of the wager of the round, uncapped if zero
+	EngineDefs       []EngineDef         `yaml:"EngineDefs,flow"`
+	BetModes         []BetMode           `yaml:"BetModes"`
+	Gamble           GambleConfiguration `yaml:"Gamble"`
+	Hash             string              `yaml:"-"` // sha1 of the config file, identifies the snapshot
 }
 
-type cachedEngineConfig struct {
-	engineConfig atomic.Value
-	loaded       bool
-	cacheTime    time.Time
-	semaphore    int32
+// the snapshots of an engine that are kept in memory besides the active config, the least recently used snapshot is
+// dropped when more are loaded and is read again from the snapshot directory when a round needs it
+const maxEngineSnapshots = 8
+
+// engineSnapshots holds the active config of an engine and the configs that rounds in progress were played on, rounds
+// that are in progress keep playing on the snapshot they began with
+type engineSnapshots struct {
+	active    *EngineConfig
+	snapshots map[string]*EngineConfig
+	used      []string // the hashes of the snapshots, least recently used first
+}
+
+// add keeps the snapshot in memory as the most recently used one and drops the least recently used snapshots that
+// are not active
+func (e *engineSnapshots) add(cfg *EngineConfig) *EngineConfig {
+	if existing, ok := e.snapshots[cfg.Hash]; ok {
+		cfg = existing
+	}
+	e.snapshots[cfg.Hash] = cfg
+	e.touch(cfg.Hash)
+	for i := 0; len(e.snapshots) > maxEngineSnapshots+1 && i < len(e.used); {
+		hash := e.used[i]
+		if e.active != nil && hash == e.active.Hash {
+			i++
+			continue
+		}
+		delete(e.snapshots, hash)
+		e.used = append(e.used[:i], e.used[i+1:]...)
+	}
+	return cfg
 }
 
-var configCache map[string]*cachedEngineConfig = nil
+func (e *engineSnapshots) touch(hash string) {
+	for i, h := range e.used {
+		if h == hash {
+			e.used = append(e.used[:i], e.used[i+1:]...)
+			break
+		}
+	}
+	e.used = append(e.used, hash)
+}
 
-const cacheRefresh time.Duration = time.Duration(1000000000000) // 10 seconds
-var cacheLock sync.Mutex
+var registry = struct {
+	sync.RWMutex
+	engines map[string]*engineSnapshots
+}{engines: map[string]*engineSnapshots{}}
 
-// BuildEngineDefs wrapper to reuse cached versions of the configs
+// BuildEngineDefs returns the active config snapshot of the engine, the config is read on first use. It panics if the
+// config cannot be read, it is meant for the tools that run on the linted configs, requests use LoadEngineDefs.
 func BuildEngineDefs(engineID string) EngineConfig {
-	//	return ReadEngineDefs(engineID)
+	cfg, rgserr := LoadEngineDefs(engineID)
+	if rgserr != nil {
+		panic(rgserr.Error())
+	}
+	return cfg
+}
+
+// LoadEngineDefs returns the active config snapshot of the engine, the config is read on first use. A config that
+// cannot be read is not cached so that it is read again once it is fixed.
+func LoadEngineDefs(engineID string) (EngineConfig, rgse.RGSErr) {
+	registry.RLock()
+	engine, ok := registry.engines[engineID]
+	registry.RUnlock()
+	if ok {
+		return *engine.active, nil
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	engine, ok = registry.engines[engineID]
+	if !ok {
+		cfg, yamlFile, rgserr := readEngineConfig(engineID)
+		if rgserr != nil {
+			return EngineConfig{}, rgserr
+		}
+		engine = &engineSnapshots{snapshots: map[string]*EngineConfig{}}
+		engine.active = engine.add(&cfg)
+		registry.engines[engineID] = engine
+		saveEngineSnapshot(engineID, cfg.Hash, yamlFile)
+		logger.Infof("read and cached config for engine %s (%s)", engineID, cfg.Hash)
+	}
+	return *engine.active, nil
+}
+
+// EngineSnapshot returns the config snapshot of the engine with the given hash. A snapshot that is not in memory is
+// read from the snapshot directory or else from the config file of the engine if the file still holds that config,
+// ok is false if the snapshot can be found in neither.
+func EngineSnapshot(engineID string, hash string) (EngineConfig, bool) {
+	registry.Lock()
+	engine, ok := registry.engines[engineID]
+	if ok {
+		if cfg, found := engine.snapshots[hash]; found {
+			engine.touch(hash)
+			registry.Unlock()
+			return *cfg, true
+		}
+	}
+	registry.Unlock()
+
+	cfg, ok := loadEngineSnapshot(engineID, hash)
+	if !ok {
+		// an instance that does not share the snapshot directory, the config file is the same on every instance
+		cfg, ok = loadEngineConfigFile(engineID, hash)
+	}
+	if !ok {
+		return EngineConfig{}, false
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	engine, ok = registry.engines[engineID]
+	if !ok {
+		// the active config is read on first use
+		return cfg, true
+	}
+	return *engine.add(&cfg), true
+}
 
-	var config *cachedEngineConfig = nil
-	var ok bool = false
+// engineSnapshotPath returns the file of the snapshot in the snapshot directory, the snapshots are named by the hash
+// of their content so that every instance writes the same file for a config
+func engineSnapshotPath(engineID string, hash string) (string, bool) {
+	dir := config.GlobalConfig.EngineSnapshots
+	if dir == "" {
+		return "", false
+	}
+	if b, err := hex.DecodeString(hash); err != nil || len(b) != sha1.Size {
+		return "", false
+	}
+	return filepath.Join(dir, engineID, hash+".yml"), true
+}
 
-	if configCache != nil {
-		config, ok = configCache[engineID]
+// saveEngineSnapshot writes the activated config to the snapshot directory if it is not there yet
+func saveEngineSnapshot(engineID string, hash string, yamlFile []byte) {
+	path, ok := engineSnapshotPath(engineID, hash)
+	if !ok {
+		return
+	}
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		logger.Errorf("could not create snapshot directory of engine %s: %v", engineID, err)
+		return
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), hash)
+	if err != nil {
+		logger.Errorf("could not write snapshot %s of engine %s: %v", hash, engineID, err)
+		return
+	}
+	_, err = tmp.Write(yamlFile)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
 	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		logger.Errorf("could not write snapshot %s of engine %s: %v", hash, engineID, err)
+	}
+}
 
-	now := time.Now()
+// loadEngineSnapshot reads the snapshot of the engine from the snapshot directory, a file whose content does not
+// match its hash is rejected
+func loadEngineSnapshot(engineID string, hash string) (EngineConfig, bool) {
+	path, ok := engineSnapshotPath(engineID, hash)
 	if !ok {
-		cacheLock.Lock()
-		if configCache == nil {
-			configCache = make(map[string]*cachedEngineConfig)
-		}
-		config, ok = configCache[engineID]
-		if !ok {
-			config = &cachedEngineConfig{
-				engineConfig: atomic.Value{},
-				loaded:       false,
-				cacheTime:    now,
-				semaphore:    0,
+		return EngineConfig{}, false
+	}
+	yamlFile, err := ioutil.ReadFile(path)
+	if err != nil {
+		return EngineConfig{}, false
+	}
+	if engineConfigHash(yamlFile) != hash {
+		logger.Errorf("snapshot %s of engine %s does not match its hash", hash, engineID)
+		return EngineConfig{}, false
+	}
+	cfg, rgserr := parseEngineConfig(engineID, yamlFile)
+	if rgserr != nil {
+		logger.Errorf("snapshot %s of engine %s: %v", hash, engineID, rgserr.Error())
+		return EngineConfig{}, false
+	}
+	logger.Infof("read config snapshot %s of engine %s", hash, engineID)
+	return cfg, true
+}
+
+// loadEngineConfigFile reads the config file of the engine if it holds the config with the given hash
+func loadEngineConfigFile(engineID string, hash string) (EngineConfig, bool) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return EngineConfig{}, false
+	}
+	yamlFile, err := ioutil.ReadFile(engineConfigPath(currentDir, engineID))
+	if err != nil || engineConfigHash(yamlFile) != hash {
+		return EngineConfig{}, false
+	}
+	cfg, rgserr := parseEngineConfig(engineID, yamlFile)
+	if rgserr != nil {
+		logger.Errorf("config %s of engine %s: %v", hash, engineID, rgserr.Error())
+		return EngineConfig{}, false
+	}
+	saveEngineSnapshot(engineID, hash, yamlFile)
+	logger.Infof("read config %s of engine %s from its config file", hash, engineID)
+	return cfg, true
+}
+
+// EngineReload is the result of reloading the config of one engine
+type EngineReload struct {
+	EngineID string `json:"engineId"`
+	Hash     string `json:"hash"`
+	Previous string `json:"previous,omitempty"`
+	Changed  bool   `json:"changed"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ReloadEngineConfig reads the config file of the engine again and activates it as a new snapshot if it changed.
+// A config with lint errors is not activated.
+func ReloadEngineConfig(engineID string) (reload EngineReload, rgserr rgse.RGSErr) {
+	reload.EngineID = engineID
+	currentDir, err := os.Getwd()
+	if err != nil {
+		rgserr = rgse.Create(rgse.BadConfigError)
+		rgserr.AppendErrorText("failed opening current directory")
+		return
+	}
+	yamlFile, err := ioutil.ReadFile(engineConfigPath(currentDir, engineID))
+	if err != nil {
+		rgserr = rgse.Create(rgse.BadConfigError)
+		rgserr.AppendErrorText(fmt.Sprintf("no config found for engine %v", engineID))
+		return
+	}
+	reload.Hash = engineConfigHash(yamlFile)
+
+	registry.RLock()
+	engine, loaded := registry.engines[engineID]
+	if loaded {
+		reload.Previous = engine.active.Hash
+	}
+	registry.RUnlock()
+	if loaded && reload.Previous == reload.Hash {
+		return
+	}
+
+	l := newEngineLinter(engineID)
+	l.lint(yamlFile)
+	if n := CountLintErrors(l.diagnostics); n > 0 {
+		for _, d := range l.diagnostics {
+			if d.Severity == LintError {
+				logger.Errorf("%v", d.String())
 			}
-			cfg := EngineConfig{}
-			config.engineConfig.Store(&cfg)
-			configCache[engineID] = config
 		}
-		cacheLock.Unlock()
+		rgserr = rgse.Create(rgse.BadConfigError)
+		rgserr.AppendErrorText(fmt.Sprintf("%v errors in config of engine %v, not activated", n, engineID))
+		return
+	}
+	cfg, rgserr := parseEngineConfig(engineID, yamlFile)
+	if rgserr != nil {
+		return
 	}
 
-	if !config.loaded || now.Sub(config.cacheTime) > cacheRefresh {
-		if atomic.CompareAndSwapInt32(&config.semaphore, 0, 1) {
-			cfg := ReadEngineDefs(engineID)
-			config.engineConfig.Store(&cfg)
-			config.cacheTime = now
-			config.loaded = true
-			atomic.StoreInt32(&config.semaphore, 0)
-			logger.Infof("read and cached config for engine %s", engineID)
-		} else {
-			for atomic.LoadInt32(&config.semaphore) != 0 {
-				time.Sleep(1000000) // 1ms
+	registry.Lock()
+	defer registry.Unlock()
+	engine, loaded = registry.engines[engineID]
+	if !loaded {
+		engine = &engineSnapshots{snapshots: map[string]*EngineConfig{}}
+		registry.engines[engineID] = engine
+	}
+	// switching back to an earlier version keeps using the same snapshot
+	engine.active = engine.add(&cfg)
+	saveEngineSnapshot(engineID, cfg.Hash, yamlFile)
+	reload.Changed = true
+	logger.Warnf("activated config %s for engine %s (was %s)", reload.Hash, engineID, reload.Previous)
+	return
+}
+
+// ReloadEngineConfigs reloads every engine that has been loaded
+func ReloadEngineConfigs() []EngineReload {
+	registry.RLock()
+	engineIDs := make([]string, 0, len(registry.engines))
+	for engineID := range registry.engines {
+		engineIDs = append(engineIDs, engineID)
+	}
+	registry.RUnlock()
+	sort.Strings(engineIDs)
+
+	reloads := make([]EngineReload, len(engineIDs))
+	for i, engineID := range engineIDs {
+		reload, err := ReloadEngineConfig(engineID)
+		if err != nil {
+			reload.Error = err.Error()
+		}
+		reloads[i] = reload
+	}
+	return reloads
+}
+
+// WatchEngineConfigs reloads the loaded engine configs every interval until stop is closed
+func WatchEngineConfigs(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			for _, reload := range ReloadEngineConfigs() {
+				if reload.Error != "" {
+					logger.Errorf("reloading engine %s: %s", reload.EngineID, reload.Error)
+				}
 			}
 		}
 	}
+}
+
+func engineConfigHash(yamlFile []byte) string {
+	hash := sha1.Sum(yamlFile)
+	return hex.EncodeToString(hash[:])
+}
 
-	return *config.engineConfig.Load().(*EngineConfig)
+func engineConfigPath(dir string, engineID string) string {
+	return filepath.Join(dir, "internal/engine/engineConfigs", engineID+".yml")
 }
 
-// BuildEngineDefs reads engine definition from yml
-func ReadEngineDefs(engineID string) EngineConfig {
+// ReadEngineDefs reads engine definition from yml
+func ReadEngineDefs(engineID string) (EngineConfig, rgse.RGSErr) {
+	c, _, rgserr := readEngineConfig(engineID)
+	return c, rgserr
+}
+
+func readEngineConfig(engineID string) (EngineConfig, []byte, rgse.RGSErr) {
 	// takes an engineId string and parses the corresponding yaml file into an EngineConfig
 	logger.Debugf("reading engine config %s", engineID)
 	currentDir, err := os.Getwd()
 	if err != nil {
-		panic("Failed opening current directory")
+		rgserr := rgse.Create(rgse.EngineConfigError)
+		rgserr.AppendErrorText(fmt.Sprintf("could not open current directory: %v", err))
+		return EngineConfig{}, nil, rgserr
 	}
-	engineDef := filepath.Join(currentDir, "internal/engine/engineConfigs", engineID+".yml")
-	yamlFile, err := ioutil.ReadFile(engineDef)
+	yamlFile, err := ioutil.ReadFile(engineConfigPath(currentDir, engineID))
 	if err != nil {
 		logger.Errorf("No config found for engine %v  %v ", engineID, err)
+		rgserr := rgse.Create(rgse.EngineConfigError)
+		rgserr.AppendErrorText(fmt.Sprintf("no config found for engine %v: %v", engineID, err))
+		return EngineConfig{}, nil, rgserr
+	}
+	c, rgserr := parseEngineConfig(engineID, yamlFile)
+	if rgserr != nil {
+		return EngineConfig{}, nil, rgserr
 	}
+	return c, yamlFile, nil
+}
+
+func parseEngineConfig(engineID string, yamlFile []byte) (EngineConfig, rgse.RGSErr) {
 	c := EngineConfig{}
-	err = yaml.Unmarshal(yamlFile, &c)
+	err := yaml.Unmarshal(yamlFile, &c)
+	if err == nil && len(c.EngineDefs) == 0 {
+		err = fmt.Errorf("no engine defs")
+	}
 	if err != nil {
-		logger.Errorf("Unmarshal: %v", err)
+		rgserr := rgse.Create(rgse.YamlError)
+		rgserr.AppendErrorText(fmt.Sprintf("could not parse config of engine %v: %v", engineID, err))
+		return EngineConfig{}, rgserr
 	}
+	c = fillEngineDefs(c)
+	c.Hash = engineConfigHash(yamlFile)
+	return c, nil
+}
+
+// fillEngineDefs completes every engine def with the values it inherits from the first or previous def
+func fillEngineDefs(c EngineConfig) EngineConfig {
 	// take values from default wherever available
 	filledEngineDefs := []EngineDef{c.EngineDefs[0]}
 	completeDef := c.EngineDefs[0]
@@ -183,6 +479,21 @@ func ReadEngineDefs(engineID string) EngineConfig {
 		if c.EngineDefs[i].WinConfig.Flags != "" {
 			completeDef.WinConfig = c.EngineDefs[i].WinConfig
 		}
+		if !reflect.DeepEqual(c.EngineDefs[i].ClusterConfig, ClusterConfiguration{}) {
+			completeDef.ClusterConfig = c.EngineDefs[i].ClusterConfig
+		}
+		if !reflect.DeepEqual(c.EngineDefs[i].CascadeConfig, CascadeConfiguration{}) {
+			completeDef.CascadeConfig = c.EngineDefs[i].CascadeConfig
+		}
+		if !reflect.DeepEqual(c.EngineDefs[i].RouletteConfig, RouletteConfiguration{}) {
+			completeDef.RouletteConfig = c.EngineDefs[i].RouletteConfig
+		}
+		if c.EngineDefs[i].BlackjackConfig != (BlackjackConfiguration{}) {
+			completeDef.BlackjackConfig = c.EngineDefs[i].BlackjackConfig
+		}
+		if !reflect.DeepEqual(c.EngineDefs[i].ScratchConfig, ScratchConfiguration{}) {
+			completeDef.ScratchConfig = c.EngineDefs[i].ScratchConfig
+		}
 		if c.EngineDefs[i].ReelsetId != "" {
 			completeDef.ReelsetId = c.EngineDefs[i].ReelsetId
 		}
@@ -205,11 +516,14 @@ func ReadEngineDefs(engineID string) EngineConfig {
 		completeDef.VariableWL = c.EngineDefs[i].VariableWL
 		completeDef.Compounding = c.EngineDefs[i].Compounding
 		completeDef.ExpectedPayout = c.EngineDefs[i].ExpectedPayout
+		completeDef.BuyFeature = c.EngineDefs[i].BuyFeature
 		filledEngineDefs = append(filledEngineDefs, completeDef)
 	}
+	for i := range filledEngineDefs {
+		filledEngineDefs[i] = fillRouletteDef(filledEngineDefs[i])
+	}
 	c.EngineDefs = filledEngineDefs
 	return c
-
 }
 
 func (config EngineConfig) DefIdByName(action string) int {
@@ -222,7 +536,7 @@ func (config EngineConfig) DefIdByName(action string) int {
 	return -1
 }
 
-func (config EngineConfig) getEngineAndMethodInternal(action string, exception bool) (reflect.Value, int, rgse.RGSErr) {
+func (config EngineConfig) getEngineAndMethodInternal(action string, exception bool, round *rng.Round) (reflect.Value, int, rgse.RGSErr) {
 	//log.Printf("Retrieving method: %v", action)
 	var matchedEngines []EngineDef
 	sumEngineProbabilities := 0
@@ -249,7 +563,7 @@ func (config EngineConfig) getEngineAndMethodInternal(action string, exception b
 	if len(matchedEngines) == 1 {
 		selectedEngine = matchedEngines[0]
 	} else {
-		engineDefThreshold := rng.RandFromRange(sumEngineProbabilities)
+		engineDefThreshold := round.RandFromRange(sumEngineProbabilities)
 		logger.Debugf("Using Probabilities to Select Engine -- Probability Sum: %v; Threshold: %v", sumEngineProbabilities, engineDefThreshold)
 		engineDefCurrent := -1
 		for idx, engine := range matchedEngines {
@@ -262,11 +576,12 @@ func (config EngineConfig) getEngineAndMethodInternal(action string, exception b
 		}
 	}
 	logger.Debugf("method selected: %s engine index: %d", selectedEngine.Function, selectedEngine.Index)
+	selectedEngine.rng = round
 	return reflect.ValueOf(selectedEngine).MethodByName(selectedEngine.Function), selectedEngine.Index, nil
 }
 
-func (config EngineConfig) getEngineAndMethod(action string) (reflect.Value, int, rgse.RGSErr) {
-	return config.getEngineAndMethodInternal(action, true)
+func (config EngineConfig) getEngineAndMethod(action string, round *rng.Round) (reflect.Value, int, rgse.RGSErr) {
+	return config.getEngineAndMethodInternal(action, true, round)
 }
 
 func (engine EngineConfig) NumSpinsStat() int {
@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+func TestReloadEngineConfig(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	wd, _ := os.Getwd()
+	root := wd
+	for dir := wd; dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "config", "gameConfig.yml")); err == nil {
+			root = dir
+			break
+		}
+	}
+	os.Chdir(root)
+	defer os.Chdir(wd)
+	if err := config.InitGameConfig(); err != nil {
+		t.Fatalf("game config: %v", err)
+	}
+	rng.Init()
+	original, err := ioutil.ReadFile(engineConfigPath(root, "mvgEngineI"))
+	if err != nil {
+		t.Fatalf("engine config: %v", err)
+	}
+
+	// play from a copy of the engine configs so that the config file can be changed
+	tmp, err := ioutil.TempDir("", "engineconfigs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	path := engineConfigPath(tmp, "mvgEngineI")
+	os.MkdirAll(filepath.Dir(path), 0755)
+	if err := ioutil.WriteFile(path, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chdir(tmp)
+	config.GlobalConfig.EngineSnapshots = filepath.Join(tmp, "snapshots")
+	defer func() { config.GlobalConfig.EngineSnapshots = "" }()
+	registry.Lock()
+	delete(registry.engines, "mvgEngineI")
+	registry.Unlock()
+	first := BuildEngineDefs("mvgEngineI")
+	defer func() {
+		ioutil.WriteFile(path, original, 0644)
+		ReloadEngineConfig("mvgEngineI")
+	}()
+
+	reload, rgserr := ReloadEngineConfig("mvgEngineI")
+	if rgserr != nil || reload.Changed {
+		t.Fatalf("unchanged config reloaded: %#v %v", reload, rgserr)
+	}
+
+	changed := strings.Replace(string(original), "rtp: .96038", "rtp: .95", 1)
+	ioutil.WriteFile(path, []byte(changed), 0644)
+	reload, rgserr = ReloadEngineConfig("mvgEngineI")
+	if rgserr != nil {
+		t.Fatalf("reload: %v", rgserr.Error())
+	}
+	if !reload.Changed || reload.Previous != first.Hash || reload.Hash == first.Hash {
+		t.Fatalf("unexpected reload %#v", reload)
+	}
+	if active := BuildEngineDefs("mvgEngineI"); active.Hash != reload.Hash || active.RTP != .95 {
+		t.Errorf("active config %s rtp %v, expected %s", active.Hash, active.RTP, reload.Hash)
+	}
+	if snapshot, ok := EngineSnapshot("mvgEngineI", first.Hash); !ok || snapshot.RTP != first.RTP {
+		t.Errorf("first snapshot not retained")
+	}
+
+	// after a restart or on another instance the snapshot is read from the snapshot directory
+	registry.Lock()
+	delete(registry.engines, "mvgEngineI")
+	registry.Unlock()
+	if active := BuildEngineDefs("mvgEngineI"); active.Hash != reload.Hash {
+		t.Errorf("active config %s after restart, expected %s", active.Hash, reload.Hash)
+	}
+	if snapshot, ok := EngineSnapshot("mvgEngineI", first.Hash); !ok || snapshot.RTP != first.RTP {
+		t.Errorf("first snapshot not read from the snapshot directory")
+	}
+
+	// an instance without the snapshot directory reads the config of the round from the config file while it holds it
+	config.GlobalConfig.EngineSnapshots = ""
+	registry.Lock()
+	delete(registry.engines, "mvgEngineI")
+	registry.Unlock()
+	if snapshot, ok := EngineSnapshot("mvgEngineI", reload.Hash); !ok || snapshot.RTP != .95 {
+		t.Errorf("config of the config file not read without a snapshot directory")
+	}
+	if _, ok := EngineSnapshot("mvgEngineI", first.Hash); ok {
+		t.Errorf("snapshot that is not in the config file read without a snapshot directory")
+	}
+	config.GlobalConfig.EngineSnapshots = filepath.Join(tmp, "snapshots")
+
+	// a continuing round keeps the snapshot it began with, a new round uses the active config
+	inProgress := Gamestate{
+		Id:            "prev",
+		Game:          "the-year-of-zhu",
+		NextGamestate: "played",
+		NextActions:   []string{"freespin", "finish"},
+		EngineHash:    first.Hash,
+		BetPerLine:    Money{NewFixedFromInt(1), "USD"},
+	}
+	if engine, _ := inProgress.Engine(); engine.Hash != first.Hash {
+		t.Errorf("in progress round uses %s, expected %s", engine.Hash, first.Hash)
+	}
+	// a round is not continued on a config that cannot be found
+	unknown := inProgress
+	unknown.EngineHash = engineConfigHash([]byte("unknown"))
+	if _, rgserr := unknown.Engine(); rgserr == nil || rgserr.(*rgse.RGSError).ErrCode != rgse.EngineSnapshotError {
+		t.Errorf("round on unknown snapshot continued: %v", rgserr)
+	}
+	finished := inProgress
+	finished.NextActions = []string{"finish"}
+	played, _, rgserr := Play(finished, NewFixedFromInt(1), "USD", GameParams{Game: "the-year-of-zhu", Stake: NewFixedFromInt(1), Action: "base"})
+	if rgserr != nil {
+		t.Fatalf("play: %v", rgserr.Error())
+	}
+	if played.EngineHash != reload.Hash {
+		t.Errorf("new round played on %s, expected %s", played.EngineHash, reload.Hash)
+	}
+
+	// a config with errors is not activated
+	ioutil.WriteFile(path, []byte(strings.Replace(changed, "function: BaseRound", "function: NoRound", 1)), 0644)
+	if _, rgserr = ReloadEngineConfig("mvgEngineI"); rgserr == nil {
+		t.Errorf("config with errors activated")
+	}
+	if active := BuildEngineDefs("mvgEngineI"); active.Hash != reload.Hash {
+		t.Errorf("active config changed to %s", active.Hash)
+	}
+
+	// a missing or empty config is an error of the request, it is read again once it is there
+	registry.Lock()
+	delete(registry.engines, "mvgEngineII")
+	registry.Unlock()
+	defer func() {
+		registry.Lock()
+		delete(registry.engines, "mvgEngineII")
+		registry.Unlock()
+	}()
+	if _, rgserr := LoadEngineDefs("mvgEngineII"); rgserr == nil || rgserr.(*rgse.RGSError).ErrCode != rgse.EngineConfigError {
+		t.Errorf("missing config loaded: %v", rgserr)
+	}
+	ioutil.WriteFile(engineConfigPath(tmp, "mvgEngineII"), []byte("rtp: .95\n"), 0644)
+	if _, rgserr := LoadEngineDefs("mvgEngineII"); rgserr == nil || rgserr.(*rgse.RGSError).ErrCode != rgse.YamlError {
+		t.Errorf("config without engine defs loaded: %v", rgserr)
+	}
+	ioutil.WriteFile(engineConfigPath(tmp, "mvgEngineII"), original, 0644)
+	if loaded, rgserr := LoadEngineDefs("mvgEngineII"); rgserr != nil || loaded.Hash != first.Hash {
+		t.Errorf("fixed config not loaded: %s %v", loaded.Hash, rgserr)
+	}
+}
+
+func TestEngineSnapshotsBounded(t *testing.T) {
+	e := &engineSnapshots{snapshots: map[string]*EngineConfig{}}
+	e.active = e.add(&EngineConfig{Hash: "active"})
+	for i := 0; i < 2*maxEngineSnapshots; i++ {
+		e.add(&EngineConfig{Hash: fmt.Sprintf("snapshot%v", i)})
+	}
+	if len(e.snapshots) != maxEngineSnapshots+1 || len(e.used) != len(e.snapshots) {
+		t.Fatalf("%v snapshots kept, expected %v", len(e.snapshots), maxEngineSnapshots+1)
+	}
+	if _, ok := e.snapshots["active"]; !ok {
+		t.Errorf("active config dropped")
+	}
+	if _, ok := e.snapshots["snapshot0"]; ok {
+		t.Errorf("least recently used snapshot kept")
+	}
+	if _, ok := e.snapshots[fmt.Sprintf("snapshot%v", 2*maxEngineSnapshots-1)]; !ok {
+		t.Errorf("most recently used snapshot dropped")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
 	rgserror "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 	"gopkg.in/yaml.v3"
 )
@@ -29,6 +30,12 @@ type wild struct {
 	Sticky     bool               `yaml:"sticky"`
 }
 
+// distribution of the visible height of a reel
+type reelHeight struct {
+	Heights       []int `yaml:"heights"`
+	Probabilities []int `yaml:"probabilities"`
+}
+
 // bar symbols
 type bar struct {
 	PayoutID int   `yaml:"payoutId"`
@@ -59,6 +66,39 @@ type WinConfiguration struct {
 	Flags string `yaml:"Flags"` // concatenation of winconf_ flag strings, if empty string then configuration is ignored(set to none then)
 }
 
+const (
+	clusterwilds_shared    = "shared"    // a wild counts for every cluster or symbol it is adjacent to (default)
+	clusterwilds_exclusive = "exclusive" // a wild counts only for the first paying cluster, or the best paying symbol
+	clusterwilds_none      = "none"      // wilds do not take part in wins
+)
+
+// ClusterConfiguration configures the cluster and scatterpays win types
+type ClusterConfiguration struct {
+	MinSize  int      `yaml:"MinSize"`  // the smallest cluster size or symbol count that pays
+	Diagonal bool     `yaml:"Diagonal"` // diagonally adjacent positions are connected, cluster only
+	Wilds    string   `yaml:"Wilds"`    // one of the clusterwilds_ values
+	Payouts  []Payout `yaml:"Payouts"`  // payouts by size, Count is the smallest size for the payout. Payouts is used if empty
+}
+
+const (
+	cascaderefill_reel    = "reel"   // refill from the reel strip beyond the view, as if the reel kept spinning (default)
+	cascaderefill_strip   = "strip"  // refill from RefillStrips, each cascade continues where the previous one stopped
+	cascaderefill_random  = "random" // refill with symbols drawn from RefillSymbols by RefillWeights
+	cascadedirection_down = "down"   // remaining symbols fall to the bottom and new symbols enter at the top (default)
+	cascadedirection_up   = "up"     // remaining symbols rise to the top and new symbols enter at the bottom
+)
+
+// CascadeConfiguration configures how the Cascade round removes the winning symbols and refills the view
+type CascadeConfiguration struct {
+	Refill        string  `yaml:"Refill"`        // one of the cascaderefill_ values
+	RefillStrips  [][]int `yaml:"RefillStrips"`  // one strip per reel for the strip refill
+	RefillSymbols []int   `yaml:"RefillSymbols"` // symbols of the random refill
+	RefillWeights []int   `yaml:"RefillWeights"` // weights of RefillSymbols
+	Direction     string  `yaml:"Direction"`     // one of the cascadedirection_ values
+	Sticky        []int   `yaml:"Sticky"`        // symbols that stay in the view when they are part of a win, e.g. wilds
+	Multipliers   []int   `yaml:"Multipliers"`   // multiplier of the spin and of each cascade that follows, the last one holds
+}
+
 type RoulettePayout struct {
 	Multiplier int   `yaml:"Multiplier"`
 	Symbols    []int `yaml:"Symbols"`
@@ -79,6 +119,10 @@ type EngineDef struct {
 	SpecialPayouts        []Prize                   `yaml:"SpecialPayouts"`
 	WinLines              [][]int                   `yaml:"WinLines,flow"`
 	WinConfig             WinConfiguration          `yaml:"WinConfig"`
+	ClusterConfig         ClusterConfiguration      `yaml:"ClusterConfig"`
+	CascadeConfig         CascadeConfiguration      `yaml:"CascadeConfig"`
+	BuyFeature            buyFeature                `yaml:"BuyFeature"`
+	ReelHeights           []reelHeight              `yaml:"ReelHeights"` // draws the visible height of each reel per spin, ViewSize holds the largest heights
 	Wilds                 []wild                    `yaml:"wilds"`
 	Bars                  []bar                     `yaml:"bars"`
 	Multiplier            weightedMultiplier        `yaml:"multiplier"`
@@ -91,8 +135,13 @@ type EngineDef struct {
 	VariableWL            bool                      `yaml:"variableWinLines"` // will be false by default
 	Compounding           bool                      `yaml:"compoundingWilds"` // will be false by default
 	force                 []int                     // may not be set via yaml
+	forceHeights          []int                     // may not be set via yaml
+	rng                   *rng.Round                // the rng of the round the def is played in, set when the def is selected
 	Features              []feature.FeatureDef      `yaml:"Features"`
 	RoulettePayouts       map[string]RoulettePayout `yaml:"RoulettePayouts"`
+	RouletteConfig        RouletteConfiguration     `yaml:"RouletteConfig"`
+	BlackjackConfig       BlackjackConfiguration    `yaml:"BlackjackConfig"`
+	ScratchConfig         ScratchConfiguration      `yaml:"ScratchConfig"`
 	NextMultiplierActions []string                  `yaml:"NextMultiplierActions"` // actions that selects the next multiplier, default ["cascade"]
 	HoldMultiplierActions []string                  `yaml:"HoldMultiplierActions"` // actions that keeps the current multiplier, default ["freespin"]
 	FeatureStages         []string                  `yaml:"FeatureStages"`         // execution stages ("reelupdate")
@@ -108,6 +157,17 @@ func (engine EngineDef) SetForce(force []int) (forcedengine EngineDef, err rgser
 	return
 }
 
+// SetForceHeights forces the reel heights of the next spin of a def with variable reel heights
+func (engine EngineDef) SetForceHeights(heights []int) (forcedengine EngineDef, err rgserror.RGSErr) {
+	forcedengine = engine
+	if config.GlobalConfig.DevMode == true {
+		forcedengine.forceHeights = heights
+		return
+	}
+	err = rgserror.Create(rgserror.ForceProhibited)
+	return
+}
+
 type Fixed int64
 
 const fixedExp Fixed = 1000000
@@ -226,18 +286,50 @@ type Gamestate struct {
 	RoundID           string                    `json:"round_id"`
 	Features          []feature.Feature         `json:"features,omitempty"`
 	FeatureView       [][]int                   `json:"feature_view,omitempty"`
+	RngSeed           []uint64                  `json:"rng_seed,omitempty"`
+	EngineHash        string                    `json:"engine_hash,omitempty"`       // the engine config snapshot the gamestate was played on
+	ReelHeights       []int                     `json:"reel_heights,omitempty"`      // visible height of each reel, set for defs with variable reel heights
+	BetMode           string                    `json:"bet_mode,omitempty"`          // bet mode the round was started in
+	CascadePositions  []int                     `json:"cascade_positions,omitempty"` // number of symbols the cascade added to each reel
+	CascadeIndex      int                       `json:"cascade_index,omitempty"`     // number of cascades since the spin
+	Gamble            *GambleState              `json:"gamble,omitempty"`            // the step of the configured gamble played by a gamble action
+	MaxWin            Fixed                     `json:"max_win,omitempty"`           // cap of the round win, fixed when the round starts
+	Capped            bool                      `json:"capped,omitempty"`            // the round win reached the max win and the round was finished
 	Replay            bool
 	ReplayParams      feature.FeatureParams
 }
 
+// Engine returns the engine config snapshot the gamestate was played on, an error if that snapshot is not found
 func (gamestate Gamestate) Engine() (engine EngineConfig, err rgserror.RGSErr) {
+	return gamestate.engineSnapshot(gamestate.EngineHash)
+}
+
+// ActiveEngine returns the active engine config of the game, new rounds are played on it
+func (gamestate Gamestate) ActiveEngine() (engine EngineConfig, err rgserror.RGSErr) {
+	return gamestate.engineSnapshot("")
+}
+
+func (gamestate Gamestate) engineSnapshot(hash string) (engine EngineConfig, err rgserror.RGSErr) {
 	var engineID string
 	engineID, err = config.GetEngineFromGame(gamestate.Game)
 	if err != nil {
 		logger.Errorf("error parsing game name: %v", gamestate.Game)
 		return
 	}
-	engine = BuildEngineDefs(engineID)
+	engine, err = LoadEngineDefs(engineID)
+	if err != nil {
+		return
+	}
+	if hash != "" && hash != engine.Hash {
+		snapshot, ok := EngineSnapshot(engineID, hash)
+		if !ok {
+			// the round is not continued on a config it was not played on
+			err = rgserror.Create(rgserror.EngineSnapshotError)
+			err.AppendErrorText(fmt.Sprintf("config snapshot %s of engine %s not found", hash, engineID))
+			return EngineConfig{}, err
+		}
+		engine = snapshot
+	}
 	return
 }
 
@@ -262,19 +354,29 @@ type WalletTransaction struct {
 }
 
 func (transactionPB WalletTransactionPB) Convert() WalletTransaction {
+	// transactions written before the string fields only have the enums
+	currency, txType := transactionPB.CurrencyCode, transactionPB.TypeName
+	if currency == "" {
+		currency = transactionPB.Currency.String()
+	}
+	if txType == "" {
+		txType = transactionPB.Type.String()
+	}
 	return WalletTransaction{
 		Id:     transactionPB.Id,
-		Amount: Money{Amount: Fixed(transactionPB.Amount), Currency: transactionPB.Currency.String()},
-		Type:   transactionPB.Type.String(),
+		Amount: Money{Amount: Fixed(transactionPB.Amount), Currency: currency},
+		Type:   txType,
 	}
 }
 
 func (transaction WalletTransaction) Convert() *WalletTransactionPB {
 	return &WalletTransactionPB{
-		Id:       transaction.Id,
-		Amount:   transaction.Amount.Amount.ValueRaw(),
-		Currency: Ccy(Ccy_value[transaction.Amount.Currency]),
-		Type:     WalletTransactionPB_Type(WalletTransactionPB_Type_value[transaction.Type]),
+		Id:           transaction.Id,
+		Amount:       transaction.Amount.Amount.ValueRaw(),
+		Currency:     Ccy(Ccy_value[transaction.Amount.Currency]),
+		Type:         WalletTransactionPB_Type(WalletTransactionPB_Type_value[transaction.Type]),
+		CurrencyCode: transaction.Amount.Currency,
+		TypeName:     transaction.Type,
 	}
 }
 
@@ -438,26 +540,73 @@ func convertFeaturesToPB(unconverted []feature.Feature) []*FeaturePB {
 	return converted
 }
 
-func (gamestatePB GamestatePB) Convert() Gamestate {
+// wire format versions of GamestatePB. version 1 stores the game, currency and actions in the GameID, Ccy and Action
+// enums, which must be extended for every new game. version 2 stores them as strings and still fills the enums where
+// a value exists so that a version 1 reader can read the gamestates of a version 2 writer
+const (
+	GamestateVersionEnum   = 1
+	GamestateVersionString = 2
+	GamestateVersion       = GamestateVersionString
+)
+
+// WireVersion returns the wire format version of the gamestate, gamestates written before the version marker are
+// version 1
+func (gamestatePB *GamestatePB) WireVersion() int32 {
+	if gamestatePB.Version == 0 {
+		return GamestateVersionEnum
+	}
+	return gamestatePB.Version
+}
+
+func (gamestatePB *GamestatePB) gameID() string {
+	if gamestatePB.WireVersion() >= GamestateVersionString {
+		return gamestatePB.Game
+	}
+	return GetGameIDFromPB(gamestatePB.GameId.String())
+}
+
+func (gamestatePB *GamestatePB) currency() string {
+	if gamestatePB.WireVersion() >= GamestateVersionString {
+		return gamestatePB.CurrencyCode
+	}
+	return gamestatePB.Currency.String()
+}
+
+func (gamestatePB *GamestatePB) action() string {
+	if gamestatePB.WireVersion() >= GamestateVersionString {
+		return gamestatePB.ActionName
+	}
+	return gamestatePB.Action.String()
+}
+
+func (gamestatePB *GamestatePB) nextActions() []string {
+	if gamestatePB.WireVersion() >= GamestateVersionString {
+		return append(make([]string, 0, len(gamestatePB.NextActionNames)), gamestatePB.NextActionNames...)
+	}
 	nextActions := make([]string, len(gamestatePB.NextActions))
 	for i, action := range gamestatePB.NextActions {
 		nextActions[i] = action.String()
 	}
+	return nextActions
+}
+
+func (gamestatePB GamestatePB) Convert() Gamestate {
+	nextActions := gamestatePB.nextActions()
 	// every set of transactions should begin with a WAGER. this ID is also the gamestate ID
 	if len(gamestatePB.Transactions) == 0 {
 		logger.Errorf("NO TX associated")
 		return Gamestate{}
 	}
-	gameID := GetGameIDFromPB(gamestatePB.GameId.String())
+	gameID := gamestatePB.gameID()
 	return Gamestate{
 		Id:                gamestatePB.Transactions[0].Id,
 		Game:              gameID,
 		DefID:             int(gamestatePB.EngineDef),
-		BetPerLine:        Money{Amount: Fixed(gamestatePB.BetPerLine), Currency: gamestatePB.Currency.String()},
+		BetPerLine:        Money{Amount: Fixed(gamestatePB.BetPerLine), Currency: gamestatePB.currency()},
 		Transactions:      convertTransactionsFromPB(gamestatePB.Transactions),
 		PreviousGamestate: string(gamestatePB.PreviousGamestate),
 		NextGamestate:     string(gamestatePB.NextGamestate),
-		Action:            gamestatePB.Action.String(),
+		Action:            gamestatePB.action(),
 		SymbolGrid:        convertSymbolGridFromPB(gamestatePB.SymbolGrid),
 		Prizes:            convertPrizesFromPB(gamestatePB.Prizes, Fixed(gamestatePB.BetPerLine), gameID, gamestatePB.EngineDef),
 		SelectedWinLines:  convertInt32Int(gamestatePB.SelectedWinLines),
@@ -477,27 +626,33 @@ func (gamestatePB GamestatePB) Convert() Gamestate {
 		Features:          convertFeaturesFromPB(gamestatePB.Features),
 		FeatureView:       convertSymbolGridFromPB(gamestatePB.FeatureView),
 		ReelsetID:         gamestatePB.ReelsetId,
+		RngSeed:           gamestatePB.RngSeed,
+		EngineHash:        gamestatePB.EngineHash,
+		ReelHeights:       convertInt32Int(gamestatePB.ReelHeights),
+		BetMode:           gamestatePB.BetMode,
+		CascadePositions:  convertInt32Int(gamestatePB.CascadePositions),
+		CascadeIndex:      int(gamestatePB.CascadeIndex),
+		Gamble:            convertGambleFromPB(gamestatePB.GambleState),
+		MaxWin:            Fixed(gamestatePB.MaxWin),
+		Capped:            gamestatePB.Capped,
 	}
 }
 
 func (gamestatePB GamestatePB) ConvertLegacy(transactions []*WalletTransactionPB) Gamestate {
-	nextActions := make([]string, len(gamestatePB.NextActions))
-	for i, action := range gamestatePB.NextActions {
-		nextActions[i] = action.String()
-	}
+	nextActions := gamestatePB.nextActions()
 	// every set of transactions should begin with a WAGER. this ID is also the gamestate ID
 
 	// get Game ID
-	gameID := GetGameIDFromPB(gamestatePB.GameId.String())
+	gameID := gamestatePB.gameID()
 	return Gamestate{
 		Id:                transactions[0].Id,
 		Game:              gameID,
 		DefID:             int(gamestatePB.EngineDef),
-		BetPerLine:        Money{Amount: Fixed(gamestatePB.BetPerLine), Currency: gamestatePB.Currency.String()},
+		BetPerLine:        Money{Amount: Fixed(gamestatePB.BetPerLine), Currency: gamestatePB.currency()},
 		Transactions:      convertTransactionsFromPB(transactions),
 		PreviousGamestate: string(gamestatePB.PreviousGamestate),
 		NextGamestate:     string(gamestatePB.NextGamestate),
-		Action:            gamestatePB.Action.String(),
+		Action:            gamestatePB.action(),
 		SymbolGrid:        convertSymbolGridFromPB(gamestatePB.SymbolGrid),
 		Prizes:            convertPrizesFromPB(gamestatePB.Prizes, Fixed(gamestatePB.BetPerLine), gameID, gamestatePB.EngineDef),
 		SelectedWinLines:  convertInt32Int(gamestatePB.SelectedWinLines),
@@ -516,7 +671,7 @@ func GetEngineDefFromGame(gameID string) (EngineConfig, rgserror.RGSErr) {
 	if err != nil {
 		return EngineConfig{}, err
 	}
-	return BuildEngineDefs(engineID), nil
+	return LoadEngineDefs(engineID)
 }
 
 func (gamestate Gamestate) Convert() GamestatePB {
@@ -526,6 +681,11 @@ func (gamestate Gamestate) Convert() GamestatePB {
 	}
 	// every set of transactions should begin with a WAGER. this ID is also the gamestate ID
 	return GamestatePB{
+		Version:           GamestateVersion,
+		Game:              gamestate.Game,
+		CurrencyCode:      gamestate.BetPerLine.Currency,
+		ActionName:        gamestate.Action,
+		NextActionNames:   gamestate.NextActions,
 		GameId:            GamestatePB_GameID(GamestatePB_GameID_value[GetPBFromGameID(gamestate.Game)]),
 		EngineDef:         int32(gamestate.DefID),
 		BetPerLine:        gamestate.BetPerLine.Amount.ValueRaw(),
@@ -553,6 +713,15 @@ func (gamestate Gamestate) Convert() GamestatePB {
 		Features:          convertFeaturesToPB(gamestate.Features),
 		FeatureView:       convertSymbolGridToPB(gamestate.FeatureView),
 		ReelsetId:         gamestate.ReelsetID,
+		RngSeed:           gamestate.RngSeed,
+		EngineHash:        gamestate.EngineHash,
+		ReelHeights:       convertIntInt32(gamestate.ReelHeights),
+		BetMode:           gamestate.BetMode,
+		CascadePositions:  convertIntInt32(gamestate.CascadePositions),
+		CascadeIndex:      int32(gamestate.CascadeIndex),
+		GambleState:       convertGambleToPB(gamestate.Gamble),
+		MaxWin:            gamestate.MaxWin.ValueRaw(),
+		Capped:            gamestate.Capped,
 	}
 }
 
@@ -565,6 +734,11 @@ func (gamestate Gamestate) ConvertLegacy() (GamestatePB, []*WalletTransactionPB)
 	}
 	// every set of transactions should begin with a WAGER. this ID is also the gamestate ID
 	return GamestatePB{
+		Version:           GamestateVersion,
+		Game:              gamestate.Game,
+		CurrencyCode:      gamestate.BetPerLine.Currency,
+		ActionName:        gamestate.Action,
+		NextActionNames:   gamestate.NextActions,
 		GameId:            GamestatePB_GameID(GamestatePB_GameID_value[GetPBFromGameID(gamestate.Game)]),
 		EngineDef:         int32(gamestate.DefID),
 		BetPerLine:        gamestate.BetPerLine.Amount.ValueRaw(),
@@ -603,6 +777,7 @@ type GameStateV3 struct {
 	Closed            bool                `json:"closed"`
 	RoundId           string              `json:"roundId"`
 	Features          []feature.Feature   `json:"features"`
+	RngSeed           []uint64            `json:"rngSeed,omitempty"`
 }
 
 func (s *GameStateV3) Base() *GameStateV3 {
@@ -811,6 +811,8 @@ const (
 	GamestatePB_cascade7     GamestatePB_Action = 57
 	GamestatePB_cascade8     GamestatePB_Action = 58
 	GamestatePB_cascade0     GamestatePB_Action = 59
+	GamestatePB_buyFeature   GamestatePB_Action = 60
+	GamestatePB_gamble       GamestatePB_Action = 61
 )
 
 // Enum value maps for GamestatePB_Action.
@@ -875,6 +877,8 @@ var (
 		57: "cascade7",
 		58: "cascade8",
 		59: "cascade0",
+		60: "buyFeature",
+		61: "gamble",
 	}
 	GamestatePB_Action_value = map[string]int32{
 		"base":         0,
@@ -936,6 +940,8 @@ var (
 		"cascade7":     57,
 		"cascade8":     58,
 		"cascade0":     59,
+		"buyFeature":   60,
+		"gamble":       61,
 	}
 )
 
@@ -1105,10 +1111,12 @@ type WalletTransactionPB struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id       string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Amount   int64                    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	Type     WalletTransactionPB_Type `protobuf:"varint,3,opt,name=type,proto3,enum=engine.WalletTransactionPB_Type" json:"type,omitempty"`
-	Currency Ccy                      `protobuf:"varint,4,opt,name=currency,proto3,enum=engine.Ccy" json:"currency,omitempty"`
+	Id           string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount       int64                    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Type         WalletTransactionPB_Type `protobuf:"varint,3,opt,name=type,proto3,enum=engine.WalletTransactionPB_Type" json:"type,omitempty"`
+	Currency     Ccy                      `protobuf:"varint,4,opt,name=currency,proto3,enum=engine.Ccy" json:"currency,omitempty"`
+	CurrencyCode string                   `protobuf:"bytes,5,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	TypeName     string                   `protobuf:"bytes,6,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
 }
 
 func (x *WalletTransactionPB) Reset() {
@@ -1171,6 +1179,20 @@ func (x *WalletTransactionPB) GetCurrency() Ccy {
 	return Ccy_DEFAULT
 }
 
+func (x *WalletTransactionPB) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *WalletTransactionPB) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
 type FeaturePB struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1266,6 +1288,20 @@ type GamestatePB struct {
 	ReelsetId         string                    `protobuf:"bytes,25,opt,name=reelset_id,json=reelsetId,proto3" json:"reelset_id,omitempty"`
 	CampaignWin       int64                     `protobuf:"varint,26,opt,name=campaign_win,json=campaignWin,proto3" json:"campaign_win,omitempty"`
 	CampaignRef       string                    `protobuf:"bytes,27,opt,name=campaign_ref,json=campaignRef,proto3" json:"campaign_ref,omitempty"`
+	RngSeed           []uint64                  `protobuf:"varint,28,rep,packed,name=rng_seed,json=rngSeed,proto3" json:"rng_seed,omitempty"`
+	EngineHash        string                    `protobuf:"bytes,29,opt,name=engine_hash,json=engineHash,proto3" json:"engine_hash,omitempty"`
+	ReelHeights       []int32                   `protobuf:"varint,30,rep,packed,name=reel_heights,json=reelHeights,proto3" json:"reel_heights,omitempty"`
+	BetMode           string                    `protobuf:"bytes,31,opt,name=bet_mode,json=betMode,proto3" json:"bet_mode,omitempty"`
+	CascadePositions  []int32                   `protobuf:"varint,32,rep,packed,name=cascade_positions,json=cascadePositions,proto3" json:"cascade_positions,omitempty"`
+	CascadeIndex      int32                     `protobuf:"varint,33,opt,name=cascade_index,json=cascadeIndex,proto3" json:"cascade_index,omitempty"`
+	GambleState       *GamestatePB_Gamble       `protobuf:"bytes,34,opt,name=gamble_state,json=gambleState,proto3" json:"gamble_state,omitempty"`
+	MaxWin            int64                     `protobuf:"varint,35,opt,name=max_win,json=maxWin,proto3" json:"max_win,omitempty"`
+	Capped            bool                      `protobuf:"varint,36,opt,name=capped,proto3" json:"capped,omitempty"`
+	Version           int32                     `protobuf:"varint,37,opt,name=version,proto3" json:"version,omitempty"`
+	Game              string                    `protobuf:"bytes,38,opt,name=game,proto3" json:"game,omitempty"`
+	CurrencyCode      string                    `protobuf:"bytes,39,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	ActionName        string                    `protobuf:"bytes,40,opt,name=action_name,json=actionName,proto3" json:"action_name,omitempty"`
+	NextActionNames   []string                  `protobuf:"bytes,41,rep,name=next_action_names,json=nextActionNames,proto3" json:"next_action_names,omitempty"`
 }
 
 func (x *GamestatePB) Reset() {
@@ -1489,6 +1525,104 @@ func (x *GamestatePB) GetCampaignRef() string {
 	return ""
 }
 
+func (x *GamestatePB) GetRngSeed() []uint64 {
+	if x != nil {
+		return x.RngSeed
+	}
+	return nil
+}
+
+func (x *GamestatePB) GetEngineHash() string {
+	if x != nil {
+		return x.EngineHash
+	}
+	return ""
+}
+
+func (x *GamestatePB) GetReelHeights() []int32 {
+	if x != nil {
+		return x.ReelHeights
+	}
+	return nil
+}
+
+func (x *GamestatePB) GetBetMode() string {
+	if x != nil {
+		return x.BetMode
+	}
+	return ""
+}
+
+func (x *GamestatePB) GetCascadePositions() []int32 {
+	if x != nil {
+		return x.CascadePositions
+	}
+	return nil
+}
+
+func (x *GamestatePB) GetCascadeIndex() int32 {
+	if x != nil {
+		return x.CascadeIndex
+	}
+	return 0
+}
+
+func (x *GamestatePB) GetGambleState() *GamestatePB_Gamble {
+	if x != nil {
+		return x.GambleState
+	}
+	return nil
+}
+
+func (x *GamestatePB) GetMaxWin() int64 {
+	if x != nil {
+		return x.MaxWin
+	}
+	return 0
+}
+
+func (x *GamestatePB) GetCapped() bool {
+	if x != nil {
+		return x.Capped
+	}
+	return false
+}
+
+func (x *GamestatePB) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GamestatePB) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *GamestatePB) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *GamestatePB) GetActionName() string {
+	if x != nil {
+		return x.ActionName
+	}
+	return ""
+}
+
+func (x *GamestatePB) GetNextActionNames() []string {
+	if x != nil {
+		return x.NextActionNames
+	}
+	return nil
+}
+
 type GamestatePB_Reel struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1615,6 +1749,109 @@ func (x *GamestatePB_Gamification) GetTotalSpins() int32 {
 	return 0
 }
 
+type GamestatePB_Gamble struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Step       int32  `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"`
+	Stake      int64  `protobuf:"varint,2,opt,name=stake,proto3" json:"stake,omitempty"`
+	Collected  int64  `protobuf:"varint,3,opt,name=collected,proto3" json:"collected,omitempty"`
+	Banked     int64  `protobuf:"varint,4,opt,name=banked,proto3" json:"banked,omitempty"`
+	Win        int64  `protobuf:"varint,5,opt,name=win,proto3" json:"win,omitempty"`
+	RoundStake int64  `protobuf:"varint,6,opt,name=round_stake,json=roundStake,proto3" json:"round_stake,omitempty"`
+	Choice     string `protobuf:"bytes,7,opt,name=choice,proto3" json:"choice,omitempty"`
+	Card       string `protobuf:"bytes,8,opt,name=card,proto3" json:"card,omitempty"`
+}
+
+func (x *GamestatePB_Gamble) Reset() {
+	*x = GamestatePB_Gamble{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_engine_datatypes_v1_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GamestatePB_Gamble) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GamestatePB_Gamble) ProtoMessage() {}
+
+func (x *GamestatePB_Gamble) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_engine_datatypes_v1_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GamestatePB_Gamble.ProtoReflect.Descriptor instead.
+func (*GamestatePB_Gamble) Descriptor() ([]byte, []int) {
+	return file_internal_engine_datatypes_v1_proto_rawDescGZIP(), []int{4, 2}
+}
+
+func (x *GamestatePB_Gamble) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *GamestatePB_Gamble) GetStake() int64 {
+	if x != nil {
+		return x.Stake
+	}
+	return 0
+}
+
+func (x *GamestatePB_Gamble) GetCollected() int64 {
+	if x != nil {
+		return x.Collected
+	}
+	return 0
+}
+
+func (x *GamestatePB_Gamble) GetBanked() int64 {
+	if x != nil {
+		return x.Banked
+	}
+	return 0
+}
+
+func (x *GamestatePB_Gamble) GetWin() int64 {
+	if x != nil {
+		return x.Win
+	}
+	return 0
+}
+
+func (x *GamestatePB_Gamble) GetRoundStake() int64 {
+	if x != nil {
+		return x.RoundStake
+	}
+	return 0
+}
+
+func (x *GamestatePB_Gamble) GetChoice() string {
+	if x != nil {
+		return x.Choice
+	}
+	return ""
+}
+
+func (x *GamestatePB_Gamble) GetCard() string {
+	if x != nil {
+		return x.Card
+	}
+	return ""
+}
+
 var File_internal_engine_datatypes_v1_proto protoreflect.FileDescriptor
 
 var file_internal_engine_datatypes_v1_proto_rawDesc = []byte{
@@ -1636,7 +1873,7 @@ var file_internal_engine_datatypes_v1_proto_rawDesc = []byte{
 	0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x50, 0x6f,
 	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x69, 0x6e, 0x6c, 0x69,
 	0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x6c, 0x69, 0x6e,
-	0x65, 0x22, 0xd6, 0x01, 0x0a, 0x13, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e,
+	0x65, 0x22, 0x98, 0x02, 0x0a, 0x13, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e,
 	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x42, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
 	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
@@ -1646,313 +1883,362 @@ var file_internal_engine_datatypes_v1_proto_rawDesc = []byte{
 	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65,
 	0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x65, 0x6e, 0x67, 0x69,
 	0x6e, 0x65, 0x2e, 0x43, 0x63, 0x79, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
-	0x22, 0x38, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41,
-	0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x57, 0x41, 0x47, 0x45, 0x52, 0x10, 0x01,
-	0x12, 0x0a, 0x0a, 0x06, 0x50, 0x41, 0x59, 0x4f, 0x55, 0x54, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08,
-	0x45, 0x4e, 0x44, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x03, 0x22, 0x43, 0x0a, 0x09, 0x46, 0x65,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x42, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0xaf, 0x1b, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x12,
-	0x33, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x44, 0x52, 0x06, 0x67, 0x61,
-	0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x64,
-	0x65, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
-	0x44, 0x65, 0x66, 0x12, 0x20, 0x0a, 0x0c, 0x62, 0x65, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6c,
-	0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x62, 0x65, 0x74, 0x50, 0x65,
-	0x72, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x27, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
-	0x2e, 0x43, 0x63, 0x79, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2d,
-	0x0a, 0x12, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76,
-	0x69, 0x6f, 0x75, 0x73, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a,
-	0x0e, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x74, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x61,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0b, 0x73, 0x79, 0x6d, 0x62,
-	0x6f, 0x6c, 0x5f, 0x67, 0x72, 0x69, 0x64, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x50, 0x42, 0x2e, 0x52, 0x65, 0x65, 0x6c, 0x52, 0x0a, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x47,
-	0x72, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x73, 0x18, 0x09, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x50, 0x72, 0x69,
-	0x7a, 0x65, 0x50, 0x42, 0x52, 0x06, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12,
-	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x69, 0x6e,
-	0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
-	0x65, 0x64, 0x57, 0x69, 0x6e, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65,
-	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x50, 0x61, 0x79,
-	0x6f, 0x75, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65,
-	0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c,
-	0x69, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x69, 0x73, 0x74,
-	0x18, 0x0d, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x73, 0x74,
-	0x12, 0x3d, 0x0a, 0x0c, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x0e, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
+	0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x22, 0x38, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45,
+	0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x57, 0x41, 0x47, 0x45, 0x52,
+	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x41, 0x59, 0x4f, 0x55, 0x54, 0x10, 0x02, 0x12, 0x0c,
+	0x0a, 0x08, 0x45, 0x4e, 0x44, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x03, 0x22, 0x43, 0x0a, 0x09,
+	0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x42, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0xf1, 0x20, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50,
+	0x42, 0x12, 0x33, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x44, 0x52, 0x06,
+	0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x5f, 0x64, 0x65, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x44, 0x65, 0x66, 0x12, 0x20, 0x0a, 0x0c, 0x62, 0x65, 0x74, 0x5f, 0x70, 0x65, 0x72,
+	0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x62, 0x65, 0x74,
+	0x50, 0x65, 0x72, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x27, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x43, 0x63, 0x79, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x67, 0x61, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x72,
+	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x47, 0x61, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
 	0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x44, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47,
-	0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x47, 0x61, 0x6d, 0x69, 0x66,
-	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x67, 0x61, 0x6d, 0x69, 0x66, 0x69, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74,
-	0x69, 0x76, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63,
-	0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x57, 0x69, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
-	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x11, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
-	0x65, 0x12, 0x3f, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
-	0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x50, 0x42, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x13, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x6f,
-	0x75, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x6f,
-	0x75, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x70, 0x69, 0x6e, 0x5f, 0x77, 0x69,
-	0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x57, 0x69, 0x6e,
-	0x12, 0x3d, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x67, 0x72, 0x69,
-	0x64, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
-	0x2e, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x52, 0x65, 0x65,
-	0x6c, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x47, 0x72, 0x69, 0x64, 0x12,
-	0x2d, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x17, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x50, 0x42, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x3b,
-	0x0a, 0x0c, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x18, 0x18,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x61,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x52, 0x65, 0x65, 0x6c, 0x52, 0x0b,
-	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x69, 0x65, 0x77, 0x12, 0x1d, 0x0a, 0x0a, 0x72,
-	0x65, 0x65, 0x6c, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x72, 0x65, 0x65, 0x6c, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61,
-	0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x77, 0x69, 0x6e, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x57, 0x69, 0x6e, 0x12, 0x21, 0x0a,
-	0x0c, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x1b, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x66,
-	0x1a, 0x20, 0x0a, 0x04, 0x52, 0x65, 0x65, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62,
-	0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f,
-	0x6c, 0x73, 0x1a, 0xaf, 0x01, 0x0a, 0x0c, 0x47, 0x61, 0x6d, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
-	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12,
-	0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x70, 0x69,
-	0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e,
-	0x69, 0x6e, 0x67, 0x53, 0x70, 0x69, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x11, 0x73, 0x70, 0x69, 0x6e,
-	0x73, 0x5f, 0x74, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x70, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0e, 0x73, 0x70, 0x69, 0x6e, 0x73, 0x54, 0x6f, 0x53, 0x74, 0x61, 0x67,
-	0x65, 0x55, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x70, 0x69,
-	0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53,
-	0x70, 0x69, 0x6e, 0x73, 0x22, 0xe0, 0x09, 0x0a, 0x06, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x44, 0x12,
-	0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f,
-	0x54, 0x48, 0x45, 0x5f, 0x59, 0x45, 0x41, 0x52, 0x5f, 0x4f, 0x46, 0x5f, 0x5a, 0x48, 0x55, 0x10,
-	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x5a, 0x4f, 0x44, 0x49, 0x41, 0x43, 0x10, 0x02, 0x12, 0x0d, 0x0a,
-	0x09, 0x43, 0x41, 0x54, 0x5f, 0x54, 0x48, 0x49, 0x45, 0x46, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11,
-	0x54, 0x48, 0x52, 0x45, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x44, 0x4f, 0x4d, 0x5f, 0x53, 0x48,
-	0x55, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x48, 0x52, 0x45, 0x45, 0x5f, 0x4b, 0x49, 0x4e,
-	0x47, 0x44, 0x4f, 0x4d, 0x5f, 0x57, 0x45, 0x49, 0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x48,
-	0x52, 0x45, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x44, 0x4f, 0x4d, 0x5f, 0x57, 0x55, 0x10, 0x06,
-	0x12, 0x16, 0x0a, 0x12, 0x43, 0x52, 0x49, 0x4d, 0x53, 0x4f, 0x4e, 0x5f, 0x4d, 0x41, 0x53, 0x51,
-	0x55, 0x45, 0x52, 0x41, 0x44, 0x45, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x55, 0x4e, 0x47,
-	0x4c, 0x45, 0x5f, 0x53, 0x41, 0x47, 0x41, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x41, 0x4e,
-	0x44, 0x59, 0x5f, 0x47, 0x49, 0x52, 0x4c, 0x53, 0x10, 0x09, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x55,
-	0x4b, 0x4f, 0x4e, 0x47, 0x5f, 0x54, 0x52, 0x45, 0x41, 0x53, 0x55, 0x52, 0x45, 0x53, 0x10, 0x0a,
-	0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x52, 0x45, 0x45, 0x54, 0x5f, 0x52, 0x41, 0x43, 0x45, 0x52,
-	0x10, 0x0b, 0x12, 0x10, 0x0a, 0x0c, 0x42, 0x41, 0x42, 0x41, 0x4b, 0x53, 0x5f, 0x51, 0x55, 0x45,
-	0x53, 0x54, 0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x53, 0x54, 0x52, 0x4f, 0x5f, 0x47, 0x45,
-	0x4d, 0x53, 0x10, 0x0d, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x4e, 0x44, 0x41, 0x10, 0x0e, 0x12,
-	0x14, 0x0a, 0x10, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x4f, 0x46, 0x5f, 0x47, 0x41, 0x4d, 0x42, 0x4c,
-	0x45, 0x52, 0x53, 0x10, 0x0f, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x52, 0x54, 0x5f, 0x4f, 0x46, 0x5f,
-	0x54, 0x48, 0x45, 0x5f, 0x46, 0x49, 0x53, 0x54, 0x10, 0x10, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45,
-	0x41, 0x53, 0x4f, 0x4e, 0x53, 0x5f, 0x57, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x11, 0x12, 0x12,
-	0x0a, 0x0e, 0x53, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x53, 0x5f, 0x53, 0x50, 0x52, 0x49, 0x4e, 0x47,
-	0x10, 0x12, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x53, 0x5f, 0x53, 0x55,
-	0x4d, 0x4d, 0x45, 0x52, 0x10, 0x13, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x41, 0x53, 0x4f, 0x4e,
-	0x53, 0x5f, 0x41, 0x55, 0x54, 0x55, 0x4d, 0x4e, 0x10, 0x14, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x45,
-	0x41, 0x53, 0x4f, 0x4e, 0x53, 0x10, 0x15, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x52, 0x55, 0x49, 0x54,
-	0x59, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x45, 0x10, 0x16, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x5f, 0x46,
-	0x41, 0x49, 0x52, 0x59, 0x5f, 0x54, 0x41, 0x4c, 0x45, 0x10, 0x17, 0x12, 0x13, 0x0a, 0x0f, 0x41,
-	0x5f, 0x48, 0x49, 0x44, 0x44, 0x45, 0x4e, 0x5f, 0x46, 0x4f, 0x52, 0x45, 0x53, 0x54, 0x10, 0x18,
-	0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x41, 0x54, 0x54, 0x4c, 0x45, 0x4d, 0x45, 0x43, 0x48, 0x10, 0x19,
-	0x12, 0x14, 0x0a, 0x10, 0x41, 0x5f, 0x4d, 0x49, 0x4c, 0x4c, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49,
-	0x47, 0x48, 0x54, 0x53, 0x10, 0x1a, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x49, 0x53, 0x54, 0x52, 0x4f,
-	0x10, 0x1b, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4c, 0x4f, 0x55, 0x44, 0x39, 0x10, 0x1c, 0x12, 0x14,
-	0x0a, 0x10, 0x4c, 0x41, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x5f, 0x46, 0x45, 0x53, 0x54, 0x49, 0x56,
-	0x41, 0x4c, 0x10, 0x1d, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x5f, 0x43, 0x41, 0x4e, 0x44, 0x59, 0x5f,
-	0x47, 0x49, 0x52, 0x4c, 0x53, 0x5f, 0x43, 0x48, 0x52, 0x49, 0x53, 0x54, 0x4d, 0x41, 0x53, 0x10,
-	0x1e, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x4b, 0x59, 0x5f, 0x4a, 0x45, 0x57, 0x45, 0x4c, 0x53, 0x10,
-	0x1f, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x45, 0x41, 0x52, 0x4c, 0x5f, 0x46, 0x49, 0x53, 0x48, 0x45,
-	0x52, 0x10, 0x20, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x4f, 0x41, 0x4c, 0x10, 0x21, 0x12, 0x0b, 0x0a,
-	0x07, 0x44, 0x41, 0x59, 0x54, 0x4f, 0x4e, 0x41, 0x10, 0x22, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x5f,
-	0x59, 0x45, 0x41, 0x52, 0x5f, 0x4f, 0x46, 0x5f, 0x4c, 0x41, 0x4f, 0x53, 0x48, 0x55, 0x10, 0x23,
-	0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x52, 0x41, 0x47, 0x4f, 0x4e, 0x5f, 0x4d, 0x59, 0x53, 0x54, 0x10,
-	0x24, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4f, 0x4b, 0x4f, 0x46, 0x46, 0x5f, 0x43, 0x48, 0x41,
-	0x4d, 0x50, 0x49, 0x4f, 0x4e, 0x10, 0x25, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x41, 0x4e, 0x44, 0x59,
-	0x5f, 0x53, 0x4d, 0x41, 0x53, 0x48, 0x10, 0x26, 0x12, 0x13, 0x0a, 0x0f, 0x56, 0x41, 0x4c, 0x4c,
-	0x45, 0x59, 0x5f, 0x4f, 0x46, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x53, 0x10, 0x27, 0x12, 0x15, 0x0a,
-	0x11, 0x43, 0x52, 0x41, 0x5a, 0x59, 0x5f, 0x45, 0x41, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x45, 0x47,
-	0x47, 0x53, 0x10, 0x28, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x52, 0x49, 0x46, 0x54, 0x10, 0x29, 0x12,
-	0x0c, 0x0a, 0x08, 0x4d, 0x41, 0x48, 0x5f, 0x4a, 0x4f, 0x4e, 0x47, 0x10, 0x2a, 0x12, 0x0a, 0x0a,
-	0x06, 0x4d, 0x55, 0x53, 0x45, 0x55, 0x4d, 0x10, 0x2b, 0x12, 0x13, 0x0a, 0x0f, 0x42, 0x41, 0x4e,
-	0x47, 0x4b, 0x4f, 0x4b, 0x5f, 0x46, 0x49, 0x47, 0x48, 0x54, 0x45, 0x52, 0x10, 0x2c, 0x12, 0x13,
-	0x0a, 0x0f, 0x41, 0x53, 0x55, 0x4b, 0x41, 0x5f, 0x58, 0x5f, 0x53, 0x41, 0x4d, 0x55, 0x52, 0x41,
-	0x49, 0x10, 0x2d, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x50, 0x41, 0x52, 0x54, 0x41, 0x10, 0x2e, 0x12,
-	0x13, 0x0a, 0x0f, 0x42, 0x41, 0x54, 0x54, 0x4c, 0x45, 0x4d, 0x45, 0x43, 0x48, 0x5f, 0x4d, 0x41,
-	0x52, 0x53, 0x10, 0x2f, 0x12, 0x13, 0x0a, 0x0f, 0x5a, 0x4f, 0x44, 0x49, 0x41, 0x43, 0x5f, 0x45,
-	0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x53, 0x10, 0x30, 0x12, 0x12, 0x0a, 0x0e, 0x42, 0x4f, 0x4f,
-	0x4b, 0x5f, 0x4f, 0x46, 0x5f, 0x4c, 0x49, 0x56, 0x49, 0x4e, 0x47, 0x10, 0x31, 0x12, 0x0c, 0x0a,
-	0x08, 0x50, 0x52, 0x49, 0x4e, 0x43, 0x45, 0x53, 0x53, 0x10, 0x32, 0x12, 0x0d, 0x0a, 0x09, 0x53,
-	0x55, 0x50, 0x41, 0x5f, 0x43, 0x52, 0x45, 0x57, 0x10, 0x33, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x4f,
-	0x58, 0x5f, 0x54, 0x41, 0x4c, 0x45, 0x10, 0x34, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x52, 0x41, 0x47,
-	0x4f, 0x4e, 0x5f, 0x52, 0x4f, 0x55, 0x4c, 0x45, 0x54, 0x54, 0x45, 0x10, 0x35, 0x12, 0x12, 0x0a,
-	0x0e, 0x53, 0x50, 0x49, 0x52, 0x49, 0x54, 0x5f, 0x48, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x53, 0x10,
-	0x36, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x49, 0x5a, 0x41, 0x52, 0x44, 0x5a, 0x5f, 0x57, 0x4f, 0x52,
-	0x4c, 0x44, 0x10, 0x37, 0x12, 0x13, 0x0a, 0x0f, 0x42, 0x41, 0x54, 0x54, 0x4c, 0x45, 0x5f, 0x4f,
-	0x46, 0x5f, 0x4d, 0x59, 0x54, 0x48, 0x53, 0x10, 0x38, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x57, 0x4f,
-	0x52, 0x44, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x10, 0x39, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4c, 0x41,
-	0x53, 0x48, 0x5f, 0x4f, 0x46, 0x5f, 0x48, 0x45, 0x52, 0x4f, 0x45, 0x53, 0x10, 0x3a, 0x12, 0x0f,
-	0x0a, 0x0b, 0x45, 0x4c, 0x59, 0x53, 0x49, 0x55, 0x4d, 0x5f, 0x56, 0x49, 0x50, 0x10, 0x3b, 0x12,
-	0x12, 0x0a, 0x0e, 0x45, 0x4c, 0x59, 0x53, 0x49, 0x55, 0x4d, 0x5f, 0x56, 0x49, 0x50, 0x5f, 0x39,
-	0x34, 0x10, 0x3c, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x41, 0x57, 0x5f, 0x4f, 0x46, 0x5f, 0x47, 0x49,
-	0x4c, 0x47, 0x41, 0x4d, 0x45, 0x53, 0x48, 0x10, 0x3e, 0x12, 0x17, 0x0a, 0x13, 0x4c, 0x41, 0x57,
-	0x5f, 0x4f, 0x46, 0x5f, 0x47, 0x49, 0x4c, 0x47, 0x41, 0x4d, 0x45, 0x53, 0x48, 0x5f, 0x39, 0x34,
-	0x10, 0x3f, 0x12, 0x17, 0x0a, 0x13, 0x4c, 0x41, 0x57, 0x5f, 0x4f, 0x46, 0x5f, 0x47, 0x49, 0x4c,
-	0x47, 0x41, 0x4d, 0x45, 0x53, 0x48, 0x5f, 0x39, 0x30, 0x10, 0x40, 0x12, 0x10, 0x0a, 0x0c, 0x54,
-	0x49, 0x50, 0x53, 0x59, 0x5f, 0x43, 0x48, 0x41, 0x52, 0x4d, 0x53, 0x10, 0x41, 0x12, 0x13, 0x0a,
-	0x0f, 0x54, 0x49, 0x50, 0x53, 0x59, 0x5f, 0x43, 0x48, 0x41, 0x52, 0x4d, 0x53, 0x5f, 0x39, 0x34,
-	0x10, 0x42, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x49, 0x50, 0x53, 0x59, 0x5f, 0x43, 0x48, 0x41, 0x52,
-	0x4d, 0x53, 0x5f, 0x39, 0x30, 0x10, 0x43, 0x22, 0xd7, 0x06, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
-	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x66, 0x72, 0x65, 0x65,
-	0x73, 0x70, 0x69, 0x6e, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x70, 0x69, 0x63, 0x6b, 0x53, 0x70,
-	0x69, 0x6e, 0x73, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x10,
-	0x04, 0x12, 0x0d, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x32, 0x10, 0x05,
-	0x12, 0x0d, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x33, 0x10, 0x06, 0x12,
-	0x0d, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x34, 0x10, 0x07, 0x12, 0x0d,
-	0x0a, 0x09, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x35, 0x10, 0x08, 0x12, 0x0e, 0x0a,
-	0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x31, 0x30, 0x10, 0x09, 0x12, 0x0e, 0x0a,
-	0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x32, 0x35, 0x10, 0x0a, 0x12, 0x0b, 0x0a,
-	0x07, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x10, 0x0b, 0x12, 0x0f, 0x0a, 0x0b, 0x73, 0x68,
-	0x75, 0x66, 0x66, 0x6c, 0x65, 0x66, 0x6c, 0x6f, 0x70, 0x10, 0x0c, 0x12, 0x10, 0x0a, 0x0c, 0x73,
-	0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x70, 0x72, 0x69, 0x6d, 0x65, 0x10, 0x0d, 0x12, 0x0b, 0x0a,
-	0x07, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x73, 0x65, 0x10, 0x0e, 0x12, 0x0f, 0x0a, 0x0b, 0x72, 0x61,
-	0x6e, 0x64, 0x6f, 0x6d, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x10, 0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x67,
-	0x61, 0x6d, 0x62, 0x6c, 0x65, 0x31, 0x10, 0x10, 0x12, 0x0b, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x62,
-	0x6c, 0x65, 0x32, 0x10, 0x11, 0x12, 0x0b, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x62, 0x6c, 0x65, 0x33,
-	0x10, 0x12, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x31,
-	0x10, 0x13, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x32,
-	0x10, 0x14, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x33,
-	0x10, 0x15, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x34,
-	0x10, 0x16, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x35,
-	0x10, 0x17, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x36,
-	0x10, 0x18, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x37,
-	0x10, 0x19, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x38,
-	0x10, 0x1a, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x30,
-	0x10, 0x1b, 0x12, 0x0b, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x62, 0x6c, 0x65, 0x30, 0x10, 0x1c, 0x12,
-	0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x31, 0x10, 0x1d, 0x12, 0x0a, 0x0a, 0x06, 0x62,
-	0x6f, 0x6e, 0x75, 0x73, 0x32, 0x10, 0x1e, 0x12, 0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73,
-	0x33, 0x10, 0x1f, 0x12, 0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x34, 0x10, 0x20, 0x12,
-	0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x35, 0x10, 0x21, 0x12, 0x0a, 0x0a, 0x06, 0x62,
-	0x6f, 0x6e, 0x75, 0x73, 0x36, 0x10, 0x22, 0x12, 0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73,
-	0x37, 0x10, 0x23, 0x12, 0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x38, 0x10, 0x24, 0x12,
-	0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x30, 0x10, 0x25, 0x12, 0x0e, 0x0a, 0x0a, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x31, 0x10, 0x26, 0x12, 0x0e, 0x0a, 0x0a, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x32, 0x10, 0x27, 0x12, 0x0e, 0x0a, 0x0a, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x33, 0x10, 0x28, 0x12, 0x0e, 0x0a, 0x0a, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x34, 0x10, 0x29, 0x12, 0x0e, 0x0a, 0x0a, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x35, 0x10, 0x2a, 0x12, 0x0e, 0x0a, 0x0a, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x36, 0x10, 0x2b, 0x12, 0x0e, 0x0a, 0x0a, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x37, 0x10, 0x2c, 0x12, 0x0e, 0x0a, 0x0a, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x38, 0x10, 0x2d, 0x12, 0x0e, 0x0a, 0x0a, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x30, 0x10, 0x2e, 0x12, 0x0d, 0x0a, 0x09, 0x72,
-	0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x10, 0x2f, 0x12, 0x0d, 0x0a, 0x09, 0x70, 0x75,
-	0x73, 0x68, 0x72, 0x65, 0x65, 0x6c, 0x73, 0x10, 0x30, 0x12, 0x08, 0x0a, 0x04, 0x69, 0x6e, 0x69,
-	0x74, 0x10, 0x32, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x31, 0x10,
-	0x33, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x32, 0x10, 0x34, 0x12,
-	0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x33, 0x10, 0x35, 0x12, 0x0c, 0x0a,
-	0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x34, 0x10, 0x36, 0x12, 0x0c, 0x0a, 0x08, 0x63,
-	0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x35, 0x10, 0x37, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73,
-	0x63, 0x61, 0x64, 0x65, 0x36, 0x10, 0x38, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61,
-	0x64, 0x65, 0x37, 0x10, 0x39, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65,
-	0x38, 0x10, 0x3a, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x30, 0x10,
-	0x3b, 0x2a, 0xb2, 0x09, 0x0a, 0x03, 0x43, 0x63, 0x79, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46,
-	0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x4e, 0x59, 0x10, 0x01, 0x12,
-	0x07, 0x0a, 0x03, 0x55, 0x53, 0x44, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x42, 0x50, 0x10,
-	0x03, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x55, 0x52, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x4a, 0x50,
-	0x59, 0x10, 0x06, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x48, 0x42, 0x10, 0x07, 0x12, 0x07, 0x0a, 0x03,
-	0x4d, 0x59, 0x52, 0x10, 0x08, 0x12, 0x07, 0x0a, 0x03, 0x56, 0x4e, 0x44, 0x10, 0x09, 0x12, 0x07,
-	0x0a, 0x03, 0x4b, 0x52, 0x57, 0x10, 0x0a, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x44, 0x52, 0x10, 0x0b,
-	0x12, 0x07, 0x0a, 0x03, 0x5a, 0x41, 0x52, 0x10, 0x0c, 0x12, 0x07, 0x0a, 0x03, 0x58, 0x42, 0x54,
-	0x10, 0x0d, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x52, 0x59, 0x10, 0x0e, 0x12, 0x07, 0x0a, 0x03, 0x41,
-	0x4d, 0x44, 0x10, 0x0f, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x4f, 0x4b, 0x10, 0x10, 0x12, 0x07, 0x0a,
-	0x03, 0x53, 0x45, 0x4b, 0x10, 0x11, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x55, 0x42, 0x10, 0x12, 0x12,
-	0x07, 0x0a, 0x03, 0x4b, 0x5a, 0x54, 0x10, 0x13, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x4d, 0x4b, 0x10,
-	0x14, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x52, 0x4c, 0x10, 0x15, 0x12, 0x07, 0x0a, 0x03, 0x56, 0x45,
-	0x53, 0x10, 0x16, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x58, 0x4e, 0x10, 0x17, 0x12, 0x07, 0x0a, 0x03,
-	0x42, 0x4f, 0x42, 0x10, 0x18, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x59, 0x47, 0x10, 0x19, 0x12, 0x07,
-	0x0a, 0x03, 0x50, 0x45, 0x4e, 0x10, 0x1a, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x4c, 0x50, 0x10, 0x1b,
-	0x12, 0x07, 0x0a, 0x03, 0x55, 0x59, 0x55, 0x10, 0x1c, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x41, 0x44,
-	0x10, 0x1d, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x45, 0x44, 0x10, 0x1e, 0x12, 0x07, 0x0a, 0x03, 0x41,
-	0x4c, 0x4c, 0x10, 0x1f, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4f, 0x41, 0x10, 0x20, 0x12, 0x07, 0x0a,
-	0x03, 0x41, 0x52, 0x53, 0x10, 0x22, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x5a, 0x4e, 0x10, 0x21, 0x12,
-	0x07, 0x0a, 0x03, 0x42, 0x41, 0x4d, 0x10, 0x23, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x44, 0x54, 0x10,
-	0x24, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x47, 0x4e, 0x10, 0x25, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x48,
-	0x44, 0x10, 0x26, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x4e, 0x44, 0x10, 0x27, 0x12, 0x07, 0x0a, 0x03,
-	0x42, 0x57, 0x50, 0x10, 0x28, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x44, 0x46, 0x10, 0x29, 0x12, 0x07,
-	0x0a, 0x03, 0x43, 0x48, 0x46, 0x10, 0x2a, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x52, 0x43, 0x10, 0x2b,
-	0x12, 0x07, 0x0a, 0x03, 0x43, 0x4f, 0x50, 0x10, 0x2c, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x5a, 0x4b,
-	0x10, 0x2d, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x4b, 0x4b, 0x10, 0x2e, 0x12, 0x07, 0x0a, 0x03, 0x44,
-	0x4f, 0x50, 0x10, 0x2f, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x47, 0x50, 0x10, 0x30, 0x12, 0x07, 0x0a,
-	0x03, 0x47, 0x45, 0x4c, 0x10, 0x31, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x48, 0x53, 0x10, 0x32, 0x12,
-	0x07, 0x0a, 0x03, 0x47, 0x49, 0x50, 0x10, 0x33, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x4e, 0x46, 0x10,
-	0x34, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x54, 0x51, 0x10, 0x35, 0x12, 0x07, 0x0a, 0x03, 0x48, 0x4b,
-	0x44, 0x10, 0x36, 0x12, 0x07, 0x0a, 0x03, 0x48, 0x52, 0x4b, 0x10, 0x37, 0x12, 0x07, 0x0a, 0x03,
-	0x48, 0x54, 0x47, 0x10, 0x38, 0x12, 0x07, 0x0a, 0x03, 0x48, 0x55, 0x46, 0x10, 0x39, 0x12, 0x07,
-	0x0a, 0x03, 0x49, 0x4c, 0x53, 0x10, 0x3a, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x4e, 0x52, 0x10, 0x3b,
-	0x12, 0x07, 0x0a, 0x03, 0x49, 0x51, 0x44, 0x10, 0x3c, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x52, 0x52,
-	0x10, 0x3d, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x53, 0x4b, 0x10, 0x3e, 0x12, 0x07, 0x0a, 0x03, 0x4a,
-	0x4f, 0x44, 0x10, 0x3f, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x45, 0x53, 0x10, 0x40, 0x12, 0x07, 0x0a,
-	0x03, 0x4b, 0x57, 0x44, 0x10, 0x41, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x42, 0x50, 0x10, 0x42, 0x12,
-	0x07, 0x0a, 0x03, 0x4c, 0x53, 0x4c, 0x10, 0x43, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x41, 0x44, 0x10,
-	0x44, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x42, 0x43, 0x10, 0x45, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x4b,
-	0x44, 0x10, 0x46, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x57, 0x4b, 0x10, 0x47, 0x12, 0x07, 0x0a, 0x03,
-	0x4e, 0x41, 0x44, 0x10, 0x48, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x47, 0x4e, 0x10, 0x49, 0x12, 0x07,
-	0x0a, 0x03, 0x4e, 0x5a, 0x44, 0x10, 0x4a, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x4d, 0x52, 0x10, 0x4b,
-	0x12, 0x07, 0x0a, 0x03, 0x5a, 0x4d, 0x57, 0x10, 0x4c, 0x12, 0x07, 0x0a, 0x03, 0x5a, 0x4d, 0x4b,
-	0x10, 0x4d, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4c, 0x4e, 0x10, 0x4e, 0x12, 0x07, 0x0a, 0x03, 0x58,
-	0x4f, 0x46, 0x10, 0x4f, 0x12, 0x07, 0x0a, 0x03, 0x51, 0x41, 0x52, 0x10, 0x50, 0x12, 0x07, 0x0a,
-	0x03, 0x52, 0x4f, 0x4e, 0x10, 0x51, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x53, 0x44, 0x10, 0x52, 0x12,
-	0x07, 0x0a, 0x03, 0x52, 0x57, 0x46, 0x10, 0x53, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x41, 0x52, 0x10,
-	0x54, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x4d, 0x54, 0x10, 0x55, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x4e,
-	0x44, 0x10, 0x56, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x5a, 0x53, 0x10, 0x57, 0x12, 0x07, 0x0a, 0x03,
-	0x55, 0x41, 0x48, 0x10, 0x58, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x42, 0x43, 0x10, 0x59, 0x12, 0x07,
-	0x0a, 0x03, 0x55, 0x47, 0x58, 0x10, 0x5a, 0x12, 0x07, 0x0a, 0x03, 0x58, 0x41, 0x46, 0x10, 0x5b,
-	0x12, 0x07, 0x0a, 0x03, 0x42, 0x54, 0x43, 0x10, 0x5c, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x52, 0x58,
-	0x10, 0x5d, 0x12, 0x07, 0x0a, 0x03, 0x56, 0x45, 0x58, 0x10, 0x5e, 0x12, 0x07, 0x0a, 0x03, 0x55,
-	0x53, 0x54, 0x10, 0x5f, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x54, 0x48, 0x10, 0x60, 0x12, 0x07, 0x0a,
-	0x03, 0x42, 0x43, 0x48, 0x10, 0x61, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x43, 0x10, 0x62, 0x12,
-	0x07, 0x0a, 0x03, 0x42, 0x59, 0x4e, 0x10, 0x63, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x5a, 0x53, 0x10,
-	0x64, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x4c, 0x10, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4b,
-	0x52, 0x10, 0x66, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x47, 0x41, 0x10, 0x67, 0x12, 0x07, 0x0a, 0x03,
-	0x54, 0x4a, 0x53, 0x10, 0x68, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x47, 0x53, 0x10, 0x69, 0x12, 0x07,
-	0x0a, 0x03, 0x4d, 0x5a, 0x4e, 0x10, 0x6a, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x59, 0x44, 0x10, 0x6b,
-	0x12, 0x07, 0x0a, 0x03, 0x44, 0x5a, 0x44, 0x10, 0x6c, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x41, 0x42,
-	0x10, 0x6d, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x54, 0x42, 0x10, 0x6e, 0x12, 0x07, 0x0a, 0x03, 0x49,
-	0x52, 0x54, 0x10, 0x6f, 0x12, 0x07, 0x0a, 0x03, 0x48, 0x4e, 0x4c, 0x10, 0x70, 0x12, 0x07, 0x0a,
-	0x03, 0x53, 0x53, 0x50, 0x10, 0x71, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x4c, 0x4c, 0x10, 0x72, 0x12,
-	0x07, 0x0a, 0x03, 0x4d, 0x4e, 0x54, 0x10, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x55, 0x44, 0x10,
-	0x74, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x55, 0x50, 0x10, 0x75, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x48,
-	0x52, 0x10, 0x76, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x50, 0x52, 0x10, 0x77, 0x12, 0x07, 0x0a, 0x03,
-	0x50, 0x4b, 0x52, 0x10, 0x78, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x4f, 0x53, 0x10, 0x79, 0x12, 0x07,
-	0x0a, 0x03, 0x58, 0x44, 0x52, 0x10, 0x7a, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x45, 0x54, 0x10, 0x7b,
-	0x12, 0x07, 0x0a, 0x03, 0x47, 0x47, 0x50, 0x10, 0x7c, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x4d, 0x50,
-	0x10, 0x7d, 0x12, 0x07, 0x0a, 0x03, 0x4a, 0x45, 0x50, 0x10, 0x7e, 0x12, 0x07, 0x0a, 0x03, 0x44,
-	0x4f, 0x47, 0x10, 0x7f, 0x12, 0x08, 0x0a, 0x03, 0x54, 0x52, 0x58, 0x10, 0x80, 0x01, 0x12, 0x08,
-	0x0a, 0x03, 0x4c, 0x52, 0x44, 0x10, 0x81, 0x01, 0x12, 0x08, 0x0a, 0x03, 0x55, 0x45, 0x54, 0x10,
-	0x82, 0x01, 0x12, 0x08, 0x0a, 0x03, 0x46, 0x54, 0x4e, 0x10, 0x83, 0x01, 0x12, 0x08, 0x0a, 0x03,
-	0x54, 0x54, 0x48, 0x10, 0x84, 0x01, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62,
-	0x2e, 0x6d, 0x61, 0x76, 0x65, 0x72, 0x69, 0x63, 0x6b, 0x2d, 0x6f, 0x70, 0x73, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x6d, 0x61, 0x76, 0x65, 0x72, 0x69, 0x63, 0x6b, 0x2f, 0x72, 0x67, 0x73, 0x2d, 0x63,
-	0x6f, 0x72, 0x65, 0x2d, 0x76, 0x32, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
-	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0b, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x67, 0x72, 0x69, 0x64, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x50, 0x42, 0x2e, 0x52, 0x65, 0x65, 0x6c, 0x52, 0x0a, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x47, 0x72, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x50,
+	0x72, 0x69, 0x7a, 0x65, 0x50, 0x42, 0x52, 0x06, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x73, 0x12, 0x2c,
+	0x0a, 0x12, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x77, 0x69, 0x6e, 0x5f, 0x6c,
+	0x69, 0x6e, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x57, 0x69, 0x6e, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x50,
+	0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c,
+	0x69, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x70, 0x6c, 0x69, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e,
+	0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x44, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x2e, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x47, 0x61, 0x6d,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x67, 0x61, 0x6d, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x75, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x57, 0x69, 0x6e, 0x12, 0x23,
+	0x0a, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x42, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x70, 0x69, 0x6e, 0x5f,
+	0x77, 0x69, 0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x70, 0x69, 0x6e, 0x57,
+	0x69, 0x6e, 0x12, 0x3d, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x67,
+	0x72, 0x69, 0x64, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x52,
+	0x65, 0x65, 0x6c, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x47, 0x72, 0x69,
+	0x64, 0x12, 0x2d, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x17, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x46, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x50, 0x42, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x12, 0x3b, 0x0a, 0x0c, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77,
+	0x18, 0x18, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e,
+	0x47, 0x61, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x52, 0x65, 0x65, 0x6c,
+	0x52, 0x0b, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x69, 0x65, 0x77, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x65, 0x65, 0x6c, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x19, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x72, 0x65, 0x65, 0x6c, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x77, 0x69, 0x6e, 0x18, 0x1a, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x57, 0x69, 0x6e, 0x12,
+	0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x52,
+	0x65, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x18, 0x1c,
+	0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x72, 0x6e, 0x67, 0x53, 0x65, 0x65, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x1d, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x65, 0x6c, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x1e,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x65, 0x6c, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x65, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x1f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x2b, 0x0a, 0x11,
+	0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x73,
+	0x63, 0x61, 0x64, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x21, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3d,
+	0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x22,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x47, 0x61,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x74, 0x65, 0x50, 0x42, 0x2e, 0x47, 0x61, 0x6d, 0x62, 0x6c, 0x65,
+	0x52, 0x0b, 0x67, 0x61, 0x6d, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x6d, 0x61, 0x78, 0x5f, 0x77, 0x69, 0x6e, 0x18, 0x23, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x6d, 0x61, 0x78, 0x57, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x24, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x25, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x6d, 0x65,
+	0x18, 0x26, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x27, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x28, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x29, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6e,
+	0x65, 0x78, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x20,
+	0x0a, 0x04, 0x52, 0x65, 0x65, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73,
+	0x1a, 0xaf, 0x01, 0x0a, 0x0c, 0x47, 0x61, 0x6d, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a,
+	0x0f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x70, 0x69, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x53, 0x70, 0x69, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x11, 0x73, 0x70, 0x69, 0x6e, 0x73, 0x5f,
+	0x74, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0e, 0x73, 0x70, 0x69, 0x6e, 0x73, 0x54, 0x6f, 0x53, 0x74, 0x61, 0x67, 0x65, 0x55,
+	0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x70, 0x69, 0x6e, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x70, 0x69,
+	0x6e, 0x73, 0x1a, 0xc7, 0x01, 0x0a, 0x06, 0x47, 0x61, 0x6d, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x65,
+	0x70, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x61, 0x6e, 0x6b, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x62, 0x61, 0x6e, 0x6b, 0x65, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x77, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x77, 0x69, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x6b, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x22, 0xe0, 0x09, 0x0a,
+	0x06, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x44, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55,
+	0x4c, 0x54, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x48, 0x45, 0x5f, 0x59, 0x45, 0x41, 0x52,
+	0x5f, 0x4f, 0x46, 0x5f, 0x5a, 0x48, 0x55, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x5a, 0x4f, 0x44,
+	0x49, 0x41, 0x43, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x41, 0x54, 0x5f, 0x54, 0x48, 0x49,
+	0x45, 0x46, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x48, 0x52, 0x45, 0x45, 0x5f, 0x4b, 0x49,
+	0x4e, 0x47, 0x44, 0x4f, 0x4d, 0x5f, 0x53, 0x48, 0x55, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x54,
+	0x48, 0x52, 0x45, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x44, 0x4f, 0x4d, 0x5f, 0x57, 0x45, 0x49,
+	0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x48, 0x52, 0x45, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x47,
+	0x44, 0x4f, 0x4d, 0x5f, 0x57, 0x55, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x52, 0x49, 0x4d,
+	0x53, 0x4f, 0x4e, 0x5f, 0x4d, 0x41, 0x53, 0x51, 0x55, 0x45, 0x52, 0x41, 0x44, 0x45, 0x10, 0x07,
+	0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x55, 0x4e, 0x47, 0x4c, 0x45, 0x5f, 0x53, 0x41, 0x47, 0x41, 0x10,
+	0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x41, 0x4e, 0x44, 0x59, 0x5f, 0x47, 0x49, 0x52, 0x4c, 0x53,
+	0x10, 0x09, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x55, 0x4b, 0x4f, 0x4e, 0x47, 0x5f, 0x54, 0x52, 0x45,
+	0x41, 0x53, 0x55, 0x52, 0x45, 0x53, 0x10, 0x0a, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x52, 0x45,
+	0x45, 0x54, 0x5f, 0x52, 0x41, 0x43, 0x45, 0x52, 0x10, 0x0b, 0x12, 0x10, 0x0a, 0x0c, 0x42, 0x41,
+	0x42, 0x41, 0x4b, 0x53, 0x5f, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a,
+	0x41, 0x53, 0x54, 0x52, 0x4f, 0x5f, 0x47, 0x45, 0x4d, 0x53, 0x10, 0x0d, 0x12, 0x09, 0x0a, 0x05,
+	0x50, 0x41, 0x4e, 0x44, 0x41, 0x10, 0x0e, 0x12, 0x14, 0x0a, 0x10, 0x4b, 0x49, 0x4e, 0x47, 0x5f,
+	0x4f, 0x46, 0x5f, 0x47, 0x41, 0x4d, 0x42, 0x4c, 0x45, 0x52, 0x53, 0x10, 0x0f, 0x12, 0x13, 0x0a,
+	0x0f, 0x41, 0x52, 0x54, 0x5f, 0x4f, 0x46, 0x5f, 0x54, 0x48, 0x45, 0x5f, 0x46, 0x49, 0x53, 0x54,
+	0x10, 0x10, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x53, 0x5f, 0x57, 0x49,
+	0x4e, 0x54, 0x45, 0x52, 0x10, 0x11, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x53, 0x5f, 0x53, 0x50, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x12, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45,
+	0x41, 0x53, 0x4f, 0x4e, 0x53, 0x5f, 0x53, 0x55, 0x4d, 0x4d, 0x45, 0x52, 0x10, 0x13, 0x12, 0x12,
+	0x0a, 0x0e, 0x53, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x53, 0x5f, 0x41, 0x55, 0x54, 0x55, 0x4d, 0x4e,
+	0x10, 0x14, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x53, 0x10, 0x15, 0x12,
+	0x10, 0x0a, 0x0c, 0x46, 0x52, 0x55, 0x49, 0x54, 0x59, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x45, 0x10,
+	0x16, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x5f, 0x46, 0x41, 0x49, 0x52, 0x59, 0x5f, 0x54, 0x41, 0x4c,
+	0x45, 0x10, 0x17, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x5f, 0x48, 0x49, 0x44, 0x44, 0x45, 0x4e, 0x5f,
+	0x46, 0x4f, 0x52, 0x45, 0x53, 0x54, 0x10, 0x18, 0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x41, 0x54, 0x54,
+	0x4c, 0x45, 0x4d, 0x45, 0x43, 0x48, 0x10, 0x19, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x5f, 0x4d, 0x49,
+	0x4c, 0x4c, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x47, 0x48, 0x54, 0x53, 0x10, 0x1a, 0x12, 0x0a,
+	0x0a, 0x06, 0x42, 0x49, 0x53, 0x54, 0x52, 0x4f, 0x10, 0x1b, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4c,
+	0x4f, 0x55, 0x44, 0x39, 0x10, 0x1c, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x41, 0x4e, 0x54, 0x45, 0x52,
+	0x4e, 0x5f, 0x46, 0x45, 0x53, 0x54, 0x49, 0x56, 0x41, 0x4c, 0x10, 0x1d, 0x12, 0x1b, 0x0a, 0x17,
+	0x41, 0x5f, 0x43, 0x41, 0x4e, 0x44, 0x59, 0x5f, 0x47, 0x49, 0x52, 0x4c, 0x53, 0x5f, 0x43, 0x48,
+	0x52, 0x49, 0x53, 0x54, 0x4d, 0x41, 0x53, 0x10, 0x1e, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x4b, 0x59,
+	0x5f, 0x4a, 0x45, 0x57, 0x45, 0x4c, 0x53, 0x10, 0x1f, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x45, 0x41,
+	0x52, 0x4c, 0x5f, 0x46, 0x49, 0x53, 0x48, 0x45, 0x52, 0x10, 0x20, 0x12, 0x08, 0x0a, 0x04, 0x47,
+	0x4f, 0x41, 0x4c, 0x10, 0x21, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x41, 0x59, 0x54, 0x4f, 0x4e, 0x41,
+	0x10, 0x22, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x5f, 0x59, 0x45, 0x41, 0x52, 0x5f, 0x4f, 0x46, 0x5f,
+	0x4c, 0x41, 0x4f, 0x53, 0x48, 0x55, 0x10, 0x23, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x52, 0x41, 0x47,
+	0x4f, 0x4e, 0x5f, 0x4d, 0x59, 0x53, 0x54, 0x10, 0x24, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4f,
+	0x4b, 0x4f, 0x46, 0x46, 0x5f, 0x43, 0x48, 0x41, 0x4d, 0x50, 0x49, 0x4f, 0x4e, 0x10, 0x25, 0x12,
+	0x0f, 0x0a, 0x0b, 0x43, 0x41, 0x4e, 0x44, 0x59, 0x5f, 0x53, 0x4d, 0x41, 0x53, 0x48, 0x10, 0x26,
+	0x12, 0x13, 0x0a, 0x0f, 0x56, 0x41, 0x4c, 0x4c, 0x45, 0x59, 0x5f, 0x4f, 0x46, 0x5f, 0x4b, 0x49,
+	0x4e, 0x47, 0x53, 0x10, 0x27, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x52, 0x41, 0x5a, 0x59, 0x5f, 0x45,
+	0x41, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x45, 0x47, 0x47, 0x53, 0x10, 0x28, 0x12, 0x09, 0x0a, 0x05,
+	0x44, 0x52, 0x49, 0x46, 0x54, 0x10, 0x29, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x41, 0x48, 0x5f, 0x4a,
+	0x4f, 0x4e, 0x47, 0x10, 0x2a, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x55, 0x53, 0x45, 0x55, 0x4d, 0x10,
+	0x2b, 0x12, 0x13, 0x0a, 0x0f, 0x42, 0x41, 0x4e, 0x47, 0x4b, 0x4f, 0x4b, 0x5f, 0x46, 0x49, 0x47,
+	0x48, 0x54, 0x45, 0x52, 0x10, 0x2c, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x53, 0x55, 0x4b, 0x41, 0x5f,
+	0x58, 0x5f, 0x53, 0x41, 0x4d, 0x55, 0x52, 0x41, 0x49, 0x10, 0x2d, 0x12, 0x0a, 0x0a, 0x06, 0x53,
+	0x50, 0x41, 0x52, 0x54, 0x41, 0x10, 0x2e, 0x12, 0x13, 0x0a, 0x0f, 0x42, 0x41, 0x54, 0x54, 0x4c,
+	0x45, 0x4d, 0x45, 0x43, 0x48, 0x5f, 0x4d, 0x41, 0x52, 0x53, 0x10, 0x2f, 0x12, 0x13, 0x0a, 0x0f,
+	0x5a, 0x4f, 0x44, 0x49, 0x41, 0x43, 0x5f, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x53, 0x10,
+	0x30, 0x12, 0x12, 0x0a, 0x0e, 0x42, 0x4f, 0x4f, 0x4b, 0x5f, 0x4f, 0x46, 0x5f, 0x4c, 0x49, 0x56,
+	0x49, 0x4e, 0x47, 0x10, 0x31, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x49, 0x4e, 0x43, 0x45, 0x53,
+	0x53, 0x10, 0x32, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x50, 0x41, 0x5f, 0x43, 0x52, 0x45, 0x57,
+	0x10, 0x33, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x4f, 0x58, 0x5f, 0x54, 0x41, 0x4c, 0x45, 0x10, 0x34,
+	0x12, 0x13, 0x0a, 0x0f, 0x44, 0x52, 0x41, 0x47, 0x4f, 0x4e, 0x5f, 0x52, 0x4f, 0x55, 0x4c, 0x45,
+	0x54, 0x54, 0x45, 0x10, 0x35, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x50, 0x49, 0x52, 0x49, 0x54, 0x5f,
+	0x48, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x53, 0x10, 0x36, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x49, 0x5a,
+	0x41, 0x52, 0x44, 0x5a, 0x5f, 0x57, 0x4f, 0x52, 0x4c, 0x44, 0x10, 0x37, 0x12, 0x13, 0x0a, 0x0f,
+	0x42, 0x41, 0x54, 0x54, 0x4c, 0x45, 0x5f, 0x4f, 0x46, 0x5f, 0x4d, 0x59, 0x54, 0x48, 0x53, 0x10,
+	0x38, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x4b, 0x49, 0x4e, 0x47, 0x10,
+	0x39, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4c, 0x41, 0x53, 0x48, 0x5f, 0x4f, 0x46, 0x5f, 0x48, 0x45,
+	0x52, 0x4f, 0x45, 0x53, 0x10, 0x3a, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x4c, 0x59, 0x53, 0x49, 0x55,
+	0x4d, 0x5f, 0x56, 0x49, 0x50, 0x10, 0x3b, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x4c, 0x59, 0x53, 0x49,
+	0x55, 0x4d, 0x5f, 0x56, 0x49, 0x50, 0x5f, 0x39, 0x34, 0x10, 0x3c, 0x12, 0x14, 0x0a, 0x10, 0x4c,
+	0x41, 0x57, 0x5f, 0x4f, 0x46, 0x5f, 0x47, 0x49, 0x4c, 0x47, 0x41, 0x4d, 0x45, 0x53, 0x48, 0x10,
+	0x3e, 0x12, 0x17, 0x0a, 0x13, 0x4c, 0x41, 0x57, 0x5f, 0x4f, 0x46, 0x5f, 0x47, 0x49, 0x4c, 0x47,
+	0x41, 0x4d, 0x45, 0x53, 0x48, 0x5f, 0x39, 0x34, 0x10, 0x3f, 0x12, 0x17, 0x0a, 0x13, 0x4c, 0x41,
+	0x57, 0x5f, 0x4f, 0x46, 0x5f, 0x47, 0x49, 0x4c, 0x47, 0x41, 0x4d, 0x45, 0x53, 0x48, 0x5f, 0x39,
+	0x30, 0x10, 0x40, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x49, 0x50, 0x53, 0x59, 0x5f, 0x43, 0x48, 0x41,
+	0x52, 0x4d, 0x53, 0x10, 0x41, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x49, 0x50, 0x53, 0x59, 0x5f, 0x43,
+	0x48, 0x41, 0x52, 0x4d, 0x53, 0x5f, 0x39, 0x34, 0x10, 0x42, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x49,
+	0x50, 0x53, 0x59, 0x5f, 0x43, 0x48, 0x41, 0x52, 0x4d, 0x53, 0x5f, 0x39, 0x30, 0x10, 0x43, 0x22,
+	0xf3, 0x06, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x62, 0x61,
+	0x73, 0x65, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x10, 0x01,
+	0x12, 0x0c, 0x0a, 0x08, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x10, 0x02, 0x12, 0x0d,
+	0x0a, 0x09, 0x70, 0x69, 0x63, 0x6b, 0x53, 0x70, 0x69, 0x6e, 0x73, 0x10, 0x03, 0x12, 0x0a, 0x0a,
+	0x06, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x66, 0x72, 0x65,
+	0x65, 0x73, 0x70, 0x69, 0x6e, 0x32, 0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x65,
+	0x73, 0x70, 0x69, 0x6e, 0x33, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x65, 0x73,
+	0x70, 0x69, 0x6e, 0x34, 0x10, 0x07, 0x12, 0x0d, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70,
+	0x69, 0x6e, 0x35, 0x10, 0x08, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69,
+	0x6e, 0x31, 0x30, 0x10, 0x09, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x73, 0x70, 0x69,
+	0x6e, 0x32, 0x35, 0x10, 0x0a, 0x12, 0x0b, 0x0a, 0x07, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65,
+	0x10, 0x0b, 0x12, 0x0f, 0x0a, 0x0b, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x66, 0x6c, 0x6f,
+	0x70, 0x10, 0x0c, 0x12, 0x10, 0x0a, 0x0c, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x70, 0x72,
+	0x69, 0x6d, 0x65, 0x10, 0x0d, 0x12, 0x0b, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x73, 0x65,
+	0x10, 0x0e, 0x12, 0x0f, 0x0a, 0x0b, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x50, 0x72, 0x69, 0x7a,
+	0x65, 0x10, 0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x62, 0x6c, 0x65, 0x31, 0x10, 0x10,
+	0x12, 0x0b, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x62, 0x6c, 0x65, 0x32, 0x10, 0x11, 0x12, 0x0b, 0x0a,
+	0x07, 0x67, 0x61, 0x6d, 0x62, 0x6c, 0x65, 0x33, 0x10, 0x12, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x31, 0x10, 0x13, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x32, 0x10, 0x14, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x33, 0x10, 0x15, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x34, 0x10, 0x16, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x35, 0x10, 0x17, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x36, 0x10, 0x18, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x37, 0x10, 0x19, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x38, 0x10, 0x1a, 0x12, 0x0e, 0x0a, 0x0a, 0x66, 0x72,
+	0x65, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x45, 0x30, 0x10, 0x1b, 0x12, 0x0b, 0x0a, 0x07, 0x67, 0x61,
+	0x6d, 0x62, 0x6c, 0x65, 0x30, 0x10, 0x1c, 0x12, 0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73,
+	0x31, 0x10, 0x1d, 0x12, 0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x32, 0x10, 0x1e, 0x12,
+	0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x33, 0x10, 0x1f, 0x12, 0x0a, 0x0a, 0x06, 0x62,
+	0x6f, 0x6e, 0x75, 0x73, 0x34, 0x10, 0x20, 0x12, 0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73,
+	0x35, 0x10, 0x21, 0x12, 0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x36, 0x10, 0x22, 0x12,
+	0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x37, 0x10, 0x23, 0x12, 0x0a, 0x0a, 0x06, 0x62,
+	0x6f, 0x6e, 0x75, 0x73, 0x38, 0x10, 0x24, 0x12, 0x0a, 0x0a, 0x06, 0x62, 0x6f, 0x6e, 0x75, 0x73,
+	0x30, 0x10, 0x25, 0x12, 0x0e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x31, 0x10, 0x26, 0x12, 0x0e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x32, 0x10, 0x27, 0x12, 0x0e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x33, 0x10, 0x28, 0x12, 0x0e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x34, 0x10, 0x29, 0x12, 0x0e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x35, 0x10, 0x2a, 0x12, 0x0e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x36, 0x10, 0x2b, 0x12, 0x0e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x37, 0x10, 0x2c, 0x12, 0x0e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x38, 0x10, 0x2d, 0x12, 0x0e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x30, 0x10, 0x2e, 0x12, 0x0d, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x69, 0x6e, 0x61, 0x6c, 0x6c,
+	0x10, 0x2f, 0x12, 0x0d, 0x0a, 0x09, 0x70, 0x75, 0x73, 0x68, 0x72, 0x65, 0x65, 0x6c, 0x73, 0x10,
+	0x30, 0x12, 0x08, 0x0a, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x10, 0x32, 0x12, 0x0c, 0x0a, 0x08, 0x63,
+	0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x31, 0x10, 0x33, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73,
+	0x63, 0x61, 0x64, 0x65, 0x32, 0x10, 0x34, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61,
+	0x64, 0x65, 0x33, 0x10, 0x35, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65,
+	0x34, 0x10, 0x36, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x35, 0x10,
+	0x37, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x36, 0x10, 0x38, 0x12,
+	0x0c, 0x0a, 0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x37, 0x10, 0x39, 0x12, 0x0c, 0x0a,
+	0x08, 0x63, 0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x38, 0x10, 0x3a, 0x12, 0x0c, 0x0a, 0x08, 0x63,
+	0x61, 0x73, 0x63, 0x61, 0x64, 0x65, 0x30, 0x10, 0x3b, 0x12, 0x0e, 0x0a, 0x0a, 0x62, 0x75, 0x79,
+	0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x10, 0x3c, 0x12, 0x0a, 0x0a, 0x06, 0x67, 0x61, 0x6d,
+	0x62, 0x6c, 0x65, 0x10, 0x3d, 0x2a, 0xb2, 0x09, 0x0a, 0x03, 0x43, 0x63, 0x79, 0x12, 0x0b, 0x0a,
+	0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x4e,
+	0x59, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x53, 0x44, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03,
+	0x47, 0x42, 0x50, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x55, 0x52, 0x10, 0x04, 0x12, 0x07,
+	0x0a, 0x03, 0x4a, 0x50, 0x59, 0x10, 0x06, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x48, 0x42, 0x10, 0x07,
+	0x12, 0x07, 0x0a, 0x03, 0x4d, 0x59, 0x52, 0x10, 0x08, 0x12, 0x07, 0x0a, 0x03, 0x56, 0x4e, 0x44,
+	0x10, 0x09, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x52, 0x57, 0x10, 0x0a, 0x12, 0x07, 0x0a, 0x03, 0x49,
+	0x44, 0x52, 0x10, 0x0b, 0x12, 0x07, 0x0a, 0x03, 0x5a, 0x41, 0x52, 0x10, 0x0c, 0x12, 0x07, 0x0a,
+	0x03, 0x58, 0x42, 0x54, 0x10, 0x0d, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x52, 0x59, 0x10, 0x0e, 0x12,
+	0x07, 0x0a, 0x03, 0x41, 0x4d, 0x44, 0x10, 0x0f, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x4f, 0x4b, 0x10,
+	0x10, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x45, 0x4b, 0x10, 0x11, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x55,
+	0x42, 0x10, 0x12, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x5a, 0x54, 0x10, 0x13, 0x12, 0x07, 0x0a, 0x03,
+	0x4d, 0x4d, 0x4b, 0x10, 0x14, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x52, 0x4c, 0x10, 0x15, 0x12, 0x07,
+	0x0a, 0x03, 0x56, 0x45, 0x53, 0x10, 0x16, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x58, 0x4e, 0x10, 0x17,
+	0x12, 0x07, 0x0a, 0x03, 0x42, 0x4f, 0x42, 0x10, 0x18, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x59, 0x47,
+	0x10, 0x19, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x45, 0x4e, 0x10, 0x1a, 0x12, 0x07, 0x0a, 0x03, 0x43,
+	0x4c, 0x50, 0x10, 0x1b, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x59, 0x55, 0x10, 0x1c, 0x12, 0x07, 0x0a,
+	0x03, 0x43, 0x41, 0x44, 0x10, 0x1d, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x45, 0x44, 0x10, 0x1e, 0x12,
+	0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c, 0x10, 0x1f, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4f, 0x41, 0x10,
+	0x20, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x52, 0x53, 0x10, 0x22, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x5a,
+	0x4e, 0x10, 0x21, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x41, 0x4d, 0x10, 0x23, 0x12, 0x07, 0x0a, 0x03,
+	0x42, 0x44, 0x54, 0x10, 0x24, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x47, 0x4e, 0x10, 0x25, 0x12, 0x07,
+	0x0a, 0x03, 0x42, 0x48, 0x44, 0x10, 0x26, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x4e, 0x44, 0x10, 0x27,
+	0x12, 0x07, 0x0a, 0x03, 0x42, 0x57, 0x50, 0x10, 0x28, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x44, 0x46,
+	0x10, 0x29, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x48, 0x46, 0x10, 0x2a, 0x12, 0x07, 0x0a, 0x03, 0x43,
+	0x52, 0x43, 0x10, 0x2b, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x4f, 0x50, 0x10, 0x2c, 0x12, 0x07, 0x0a,
+	0x03, 0x43, 0x5a, 0x4b, 0x10, 0x2d, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x4b, 0x4b, 0x10, 0x2e, 0x12,
+	0x07, 0x0a, 0x03, 0x44, 0x4f, 0x50, 0x10, 0x2f, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x47, 0x50, 0x10,
+	0x30, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x4c, 0x10, 0x31, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x48,
+	0x53, 0x10, 0x32, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x49, 0x50, 0x10, 0x33, 0x12, 0x07, 0x0a, 0x03,
+	0x47, 0x4e, 0x46, 0x10, 0x34, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x54, 0x51, 0x10, 0x35, 0x12, 0x07,
+	0x0a, 0x03, 0x48, 0x4b, 0x44, 0x10, 0x36, 0x12, 0x07, 0x0a, 0x03, 0x48, 0x52, 0x4b, 0x10, 0x37,
+	0x12, 0x07, 0x0a, 0x03, 0x48, 0x54, 0x47, 0x10, 0x38, 0x12, 0x07, 0x0a, 0x03, 0x48, 0x55, 0x46,
+	0x10, 0x39, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x4c, 0x53, 0x10, 0x3a, 0x12, 0x07, 0x0a, 0x03, 0x49,
+	0x4e, 0x52, 0x10, 0x3b, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x51, 0x44, 0x10, 0x3c, 0x12, 0x07, 0x0a,
+	0x03, 0x49, 0x52, 0x52, 0x10, 0x3d, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x53, 0x4b, 0x10, 0x3e, 0x12,
+	0x07, 0x0a, 0x03, 0x4a, 0x4f, 0x44, 0x10, 0x3f, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x45, 0x53, 0x10,
+	0x40, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x57, 0x44, 0x10, 0x41, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x42,
+	0x50, 0x10, 0x42, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x53, 0x4c, 0x10, 0x43, 0x12, 0x07, 0x0a, 0x03,
+	0x4d, 0x41, 0x44, 0x10, 0x44, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x42, 0x43, 0x10, 0x45, 0x12, 0x07,
+	0x0a, 0x03, 0x4d, 0x4b, 0x44, 0x10, 0x46, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x57, 0x4b, 0x10, 0x47,
+	0x12, 0x07, 0x0a, 0x03, 0x4e, 0x41, 0x44, 0x10, 0x48, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x47, 0x4e,
+	0x10, 0x49, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x5a, 0x44, 0x10, 0x4a, 0x12, 0x07, 0x0a, 0x03, 0x4f,
+	0x4d, 0x52, 0x10, 0x4b, 0x12, 0x07, 0x0a, 0x03, 0x5a, 0x4d, 0x57, 0x10, 0x4c, 0x12, 0x07, 0x0a,
+	0x03, 0x5a, 0x4d, 0x4b, 0x10, 0x4d, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4c, 0x4e, 0x10, 0x4e, 0x12,
+	0x07, 0x0a, 0x03, 0x58, 0x4f, 0x46, 0x10, 0x4f, 0x12, 0x07, 0x0a, 0x03, 0x51, 0x41, 0x52, 0x10,
+	0x50, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x4f, 0x4e, 0x10, 0x51, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x53,
+	0x44, 0x10, 0x52, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x57, 0x46, 0x10, 0x53, 0x12, 0x07, 0x0a, 0x03,
+	0x53, 0x41, 0x52, 0x10, 0x54, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x4d, 0x54, 0x10, 0x55, 0x12, 0x07,
+	0x0a, 0x03, 0x54, 0x4e, 0x44, 0x10, 0x56, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x5a, 0x53, 0x10, 0x57,
+	0x12, 0x07, 0x0a, 0x03, 0x55, 0x41, 0x48, 0x10, 0x58, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x42, 0x43,
+	0x10, 0x59, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x47, 0x58, 0x10, 0x5a, 0x12, 0x07, 0x0a, 0x03, 0x58,
+	0x41, 0x46, 0x10, 0x5b, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x54, 0x43, 0x10, 0x5c, 0x12, 0x07, 0x0a,
+	0x03, 0x41, 0x52, 0x58, 0x10, 0x5d, 0x12, 0x07, 0x0a, 0x03, 0x56, 0x45, 0x58, 0x10, 0x5e, 0x12,
+	0x07, 0x0a, 0x03, 0x55, 0x53, 0x54, 0x10, 0x5f, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x54, 0x48, 0x10,
+	0x60, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x43, 0x48, 0x10, 0x61, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54,
+	0x43, 0x10, 0x62, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x59, 0x4e, 0x10, 0x63, 0x12, 0x07, 0x0a, 0x03,
+	0x55, 0x5a, 0x53, 0x10, 0x64, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x4c, 0x10, 0x65, 0x12, 0x07,
+	0x0a, 0x03, 0x4c, 0x4b, 0x52, 0x10, 0x66, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x47, 0x41, 0x10, 0x67,
+	0x12, 0x07, 0x0a, 0x03, 0x54, 0x4a, 0x53, 0x10, 0x68, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x47, 0x53,
+	0x10, 0x69, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x5a, 0x4e, 0x10, 0x6a, 0x12, 0x07, 0x0a, 0x03, 0x4c,
+	0x59, 0x44, 0x10, 0x6b, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x5a, 0x44, 0x10, 0x6c, 0x12, 0x07, 0x0a,
+	0x03, 0x50, 0x41, 0x42, 0x10, 0x6d, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x54, 0x42, 0x10, 0x6e, 0x12,
+	0x07, 0x0a, 0x03, 0x49, 0x52, 0x54, 0x10, 0x6f, 0x12, 0x07, 0x0a, 0x03, 0x48, 0x4e, 0x4c, 0x10,
+	0x70, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x53, 0x50, 0x10, 0x71, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x4c,
+	0x4c, 0x10, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x4e, 0x54, 0x10, 0x73, 0x12, 0x07, 0x0a, 0x03,
+	0x41, 0x55, 0x44, 0x10, 0x74, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x55, 0x50, 0x10, 0x75, 0x12, 0x07,
+	0x0a, 0x03, 0x4b, 0x48, 0x52, 0x10, 0x76, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x50, 0x52, 0x10, 0x77,
+	0x12, 0x07, 0x0a, 0x03, 0x50, 0x4b, 0x52, 0x10, 0x78, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x4f, 0x53,
+	0x10, 0x79, 0x12, 0x07, 0x0a, 0x03, 0x58, 0x44, 0x52, 0x10, 0x7a, 0x12, 0x07, 0x0a, 0x03, 0x4d,
+	0x45, 0x54, 0x10, 0x7b, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x47, 0x50, 0x10, 0x7c, 0x12, 0x07, 0x0a,
+	0x03, 0x49, 0x4d, 0x50, 0x10, 0x7d, 0x12, 0x07, 0x0a, 0x03, 0x4a, 0x45, 0x50, 0x10, 0x7e, 0x12,
+	0x07, 0x0a, 0x03, 0x44, 0x4f, 0x47, 0x10, 0x7f, 0x12, 0x08, 0x0a, 0x03, 0x54, 0x52, 0x58, 0x10,
+	0x80, 0x01, 0x12, 0x08, 0x0a, 0x03, 0x4c, 0x52, 0x44, 0x10, 0x81, 0x01, 0x12, 0x08, 0x0a, 0x03,
+	0x55, 0x45, 0x54, 0x10, 0x82, 0x01, 0x12, 0x08, 0x0a, 0x03, 0x46, 0x54, 0x4e, 0x10, 0x83, 0x01,
+	0x12, 0x08, 0x0a, 0x03, 0x54, 0x54, 0x48, 0x10, 0x84, 0x01, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69,
+	0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6d, 0x61, 0x76, 0x65, 0x72, 0x69, 0x63, 0x6b, 0x2d, 0x6f, 0x70,
+	0x73, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61, 0x76, 0x65, 0x72, 0x69, 0x63, 0x6b, 0x2f, 0x72,
+	0x67, 0x73, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2d, 0x76, 0x32, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1968,7 +2254,7 @@ func file_internal_engine_datatypes_v1_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_engine_datatypes_v1_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_internal_engine_datatypes_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_internal_engine_datatypes_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_internal_engine_datatypes_v1_proto_goTypes = []interface{}{
 	(Ccy)(0),                         // 0: engine.Ccy
 	(WalletTransactionPB_Type)(0),    // 1: engine.WalletTransactionPB.Type
@@ -1981,6 +2267,7 @@ var file_internal_engine_datatypes_v1_proto_goTypes = []interface{}{
 	(*GamestatePB)(nil),              // 8: engine.GamestatePB
 	(*GamestatePB_Reel)(nil),         // 9: engine.GamestatePB.Reel
 	(*GamestatePB_Gamification)(nil), // 10: engine.GamestatePB.Gamification
+	(*GamestatePB_Gamble)(nil),       // 11: engine.GamestatePB.Gamble
 }
 var file_internal_engine_datatypes_v1_proto_depIdxs = []int32{
 	4,  // 0: engine.PrizePB.payout:type_name -> engine.PayoutPB
@@ -1997,11 +2284,12 @@ var file_internal_engine_datatypes_v1_proto_depIdxs = []int32{
 	9,  // 11: engine.GamestatePB.recovery_grid:type_name -> engine.GamestatePB.Reel
 	7,  // 12: engine.GamestatePB.features:type_name -> engine.FeaturePB
 	9,  // 13: engine.GamestatePB.feature_view:type_name -> engine.GamestatePB.Reel
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	11, // 14: engine.GamestatePB.gamble_state:type_name -> engine.GamestatePB.Gamble
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_internal_engine_datatypes_v1_proto_init() }
@@ -2094,6 +2382,18 @@ func file_internal_engine_datatypes_v1_proto_init() {
 				return nil
 			}
 		}
+		file_internal_engine_datatypes_v1_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GamestatePB_Gamble); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2101,7 +2401,7 @@ func file_internal_engine_datatypes_v1_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_engine_datatypes_v1_proto_rawDesc,
 			NumEnums:      4,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
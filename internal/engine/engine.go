@@ -25,7 +25,7 @@ func (engine EngineDef) Spin() ([][]int, []int) {
 
 	for index, reel := range engine.Reels {
 		// choose a random index on the reel
-		reelIndex := rng.RandFromRange(len(reel))
+		reelIndex := engine.rng.RandFromRange(len(reel))
 		stopList[index] = reelIndex
 	}
 	if config.GlobalConfig.DevMode == true && len(engine.force) == len(engine.ViewSize) {
@@ -34,10 +34,30 @@ func (engine EngineDef) Spin() ([][]int, []int) {
 		//rgse.Create(rgse.Forcing)
 		//logger.Warnf("forcing engine %v", engine.ID)
 	}
-	symbolGrid := GetSymbolGridFromStopList(engine.Reels, engine.ViewSize, stopList)
+	symbolGrid := GetSymbolGridFromStopList(engine.Reels, engine.SpinHeights(), stopList)
 	return symbolGrid, stopList
 }
 
+// SpinHeights returns the visible height of each reel for a spin. Defs with ReelHeights draw the heights from
+// the configured distributions, all other defs show the ViewSize.
+func (engine EngineDef) SpinHeights() []int {
+	if len(engine.ReelHeights) == 0 {
+		return engine.ViewSize
+	}
+	if config.GlobalConfig.DevMode == true && len(engine.forceHeights) == len(engine.ViewSize) {
+		logger.Infof("spin using forced reel heights: %v", engine.forceHeights)
+		return engine.forceHeights
+	}
+	heights := make([]int, len(engine.ViewSize))
+	for i := range heights {
+		heights[i] = engine.ViewSize[i]
+		if i < len(engine.ReelHeights) && len(engine.ReelHeights[i].Heights) > 0 {
+			heights[i] = SelectFromWeightedOptions(engine.rng, engine.ReelHeights[i].Heights, engine.ReelHeights[i].Probabilities)
+		}
+	}
+	return heights
+}
+
 func GetSymbolGridFromStopList(reels [][]int, viewSize []int, stopList []int) [][]int {
 	symbolGrid := make([][]int, len(viewSize))
 	for i, reel := range reels {
@@ -62,7 +82,7 @@ func getNewWildMultiplier(previousMultiplier int, newMultiplier int, compounding
 	}
 }
 
-func DetermineLineWinsAnywhere(symbolGrid [][]int, WinLines [][]int, linePayouts []Payout, wilds []wild, compounding int) (lineWins []Prize) {
+func DetermineLineWinsAnywhere(round *rng.Round, symbolGrid [][]int, WinLines [][]int, linePayouts []Payout, wilds []wild, compounding int) (lineWins []Prize) {
 	// this function determines line wins not necessarily starting at the first symbol
 	// only one win per symbol per line is permitted
 
@@ -97,7 +117,7 @@ func DetermineLineWinsAnywhere(symbolGrid [][]int, WinLines [][]int, linePayouts
 					adjustedPayouts = append(adjustedPayouts, linePayouts[j])
 				}
 			}
-			win := GetWinInLine(lineContent[i:], wilds, adjustedPayouts, compounding, wildMultipliers)
+			win := GetWinInLine(round, lineContent[i:], wilds, adjustedPayouts, compounding, wildMultipliers)
 			if win.Index == "" {
 				continue
 			}
@@ -111,7 +131,7 @@ func DetermineLineWinsAnywhere(symbolGrid [][]int, WinLines [][]int, linePayouts
 	return lineWins
 }
 
-func GetWinInLine(lineContent []int, wilds []wild, linePayouts []Payout, compounding int, wildMultipliers map[int]int) (prize Prize) {
+func GetWinInLine(round *rng.Round, lineContent []int, wilds []wild, linePayouts []Payout, compounding int, wildMultipliers map[int]int) (prize Prize) {
 	// wildMultipliers is the list of multipliers already defined for variable wilds. if each wild multiplier is meant
 	// to be determined independently regardless of previous setting, wildMultipliers should be empty
 
@@ -136,7 +156,7 @@ func GetWinInLine(lineContent []int, wilds []wild, linePayouts []Payout, compoun
 					// multipliers do not compound, there may be only one line multiplier
 					engineWildMultiplier, ok := wildMultipliers[engineWild.Symbol]
 					if !ok {
-						engineWildMultiplier = SelectFromWeightedOptions(engineWild.Multiplier.Multipliers, engineWild.Multiplier.Probabilities)
+						engineWildMultiplier = SelectFromWeightedOptions(round, engineWild.Multiplier.Multipliers, engineWild.Multiplier.Probabilities)
 						wildMultipliers[engineWild.Symbol] = engineWildMultiplier
 					}
 
@@ -151,7 +171,7 @@ func GetWinInLine(lineContent []int, wilds []wild, linePayouts []Payout, compoun
 					numMatch++
 					engineWildMultiplier, ok := wildMultipliers[engineWild.Symbol]
 					if !ok {
-						engineWildMultiplier = SelectFromWeightedOptions(engineWild.Multiplier.Multipliers, engineWild.Multiplier.Probabilities)
+						engineWildMultiplier = SelectFromWeightedOptions(round, engineWild.Multiplier.Multipliers, engineWild.Multiplier.Probabilities)
 						wildMultipliers[engineWild.Symbol] = engineWildMultiplier
 					}
 
@@ -213,8 +233,8 @@ func GetWinInLineKeepWilds(lineContent []int, wilds []wild, linePayouts []Payout
    then wilds should not be converted to regular symbols when it will generate a smaller win. To avoid changing the legacy
    way to count, this function can be used with game requiering this.
 */
-func GetHighestWinInLine(lineContent []int, wilds []wild, linePayouts []Payout, compounding int, wildMultipliers map[int]int) (prize Prize) {
-	prize = GetWinInLine(lineContent, wilds, linePayouts, compounding, wildMultipliers)
+func GetHighestWinInLine(round *rng.Round, lineContent []int, wilds []wild, linePayouts []Payout, compounding int, wildMultipliers map[int]int) (prize Prize) {
+	prize = GetWinInLine(round, lineContent, wilds, linePayouts, compounding, wildMultipliers)
 	prizeWilds := GetWinInLineKeepWilds(lineContent, wilds, linePayouts)
 	if prizeWilds.Payout.Multiplier > prize.Payout.Multiplier {
 		prize = prizeWilds
@@ -222,7 +242,7 @@ func GetHighestWinInLine(lineContent []int, wilds []wild, linePayouts []Payout,
 	return
 }
 
-func DetermineLineWins(symbolGrid [][]int, WinLines [][]int, linePayouts []Payout, wilds []wild, compounding int, keepWilds bool) (lineWins []Prize) {
+func DetermineLineWins(round *rng.Round, symbolGrid [][]int, WinLines [][]int, linePayouts []Payout, wilds []wild, compounding int, keepWilds bool) (lineWins []Prize) {
 	// determines prizes from line wins including wilds with multipliers
 	// highest wild multiplier takes precedence for multiple wilds on the same line (i.e. wild multipliers do not compound)
 
@@ -248,9 +268,9 @@ func DetermineLineWins(symbolGrid [][]int, WinLines [][]int, linePayouts []Payou
 		// wildMultipliers is passed in and modulated, we get to keep the results of the modulation in the next rounds of the for loop
 		var win Prize
 		if keepWilds {
-			win = GetHighestWinInLine(lineContent, wilds, linePayouts, compounding, wildMultipliers)
+			win = GetHighestWinInLine(round, lineContent, wilds, linePayouts, compounding, wildMultipliers)
 		} else {
-			win = GetWinInLine(lineContent, wilds, linePayouts, compounding, wildMultipliers)
+			win = GetWinInLine(round, lineContent, wilds, linePayouts, compounding, wildMultipliers)
 		}
 		if win.Index == "" {
 			continue
@@ -264,7 +284,7 @@ func DetermineLineWins(symbolGrid [][]int, WinLines [][]int, linePayouts []Payou
 	return lineWins
 }
 
-func determineBarLineWins(symbolGrid [][]int, winLines [][]int, payouts []Payout, bars []bar, wilds []wild, compoundingMultipliers bool) []Prize {
+func determineBarLineWins(round *rng.Round, symbolGrid [][]int, winLines [][]int, payouts []Payout, bars []bar, wilds []wild, compoundingMultipliers bool) []Prize {
 	// assume no symbol is included in two bar types
 	adjustedSymbolGrid := make([][]int, len(symbolGrid))
 	for _, bar := range bars {
@@ -286,8 +306,8 @@ func determineBarLineWins(symbolGrid [][]int, winLines [][]int, payouts []Payout
 	if compoundingMultipliers {
 		compounding = compounding_multiplication
 	}
-	lineWinsWithBar := DetermineLineWins(adjustedSymbolGrid, winLines, payouts, wilds, compounding, false)
-	lineWinsWithoutBar := DetermineLineWins(symbolGrid, winLines, payouts, wilds, compounding, false)
+	lineWinsWithBar := DetermineLineWins(round, adjustedSymbolGrid, winLines, payouts, wilds, compounding, false)
+	lineWinsWithoutBar := DetermineLineWins(round, symbolGrid, winLines, payouts, wilds, compounding, false)
 	var highestWinsPerLine []Prize
 
 	for _, barWin := range lineWinsWithBar {
@@ -330,7 +350,7 @@ func updateSymbols(variation wayWin, newSymbol int) (symbols []int) {
 }
 
 // DetermineWaysWins ...
-func DetermineWaysWins(symbolGrid [][]int, waysPayouts []Payout, wilds []wild) []Prize {
+func DetermineWaysWins(round *rng.Round, symbolGrid [][]int, waysPayouts []Payout, wilds []wild) []Prize {
 	// Input :: symbolGrid 2d 2dslice
 	// Output :: slice of prize structs
 	var waysWins []Prize
@@ -374,7 +394,7 @@ func DetermineWaysWins(symbolGrid [][]int, waysPayouts []Payout, wilds []wild) [
 					for _, engineWild := range wilds {
 						if symbol == engineWild.Symbol {
 							match = true
-							engineWildMultiplier := SelectFromWeightedOptions(engineWild.Multiplier.Multipliers, engineWild.Multiplier.Probabilities)
+							engineWildMultiplier := SelectFromWeightedOptions(round, engineWild.Multiplier.Multipliers, engineWild.Multiplier.Probabilities)
 							if len(variations) == 0 {
 								// on first round, we need to add an initial variation
 								newVariation := wayWin{engineWildMultiplier, []int{symbolIndex}, true}
@@ -475,7 +495,7 @@ func DetermineSpecialWins(symbolGrid [][]int, specialPayouts []Prize) Prize {
 	return Prize{}
 }
 
-func determinePrimeAndFlopWins(symbolGrid [][]int, payouts []Payout, wilds []wild) []Prize {
+func determinePrimeAndFlopWins(round *rng.Round, symbolGrid [][]int, payouts []Payout, wilds []wild) []Prize {
 	// by default, prime is first reel
 	// check if symbol grid is more than one row, if so throw error
 	// win only if any symbols on flop match the prime symbol
@@ -514,7 +534,7 @@ func determinePrimeAndFlopWins(symbolGrid [][]int, payouts []Payout, wilds []wil
 					numMatch++
 					logger.Debugf("got a wild win")
 					winLocations = append(winLocations, i)
-					mulW := SelectFromWeightedOptions(wilds[w].Multiplier.Multipliers, wilds[w].Multiplier.Probabilities)
+					mulW := SelectFromWeightedOptions(round, wilds[w].Multiplier.Multipliers, wilds[w].Multiplier.Probabilities)
 					if multiplier < mulW {
 						multiplier = mulW
 					}
@@ -594,125 +614,255 @@ func DetermineElysiumLineWins(symbolGrid [][]int, WinLines [][]int, linePayouts
 	return wins
 }
 
+// DetermineClusterWins pays clusters of orthogonally adjacent symbols, wilds join every cluster they touch
 func DetermineClusterWins(symbolGrid [][]int, clusterPayouts []Payout, wilds []wild) []Prize {
+	return determineClusterWins(symbolGrid, ClusterConfiguration{Payouts: clusterPayouts}, wilds)
+}
 
-	logger.Debugf("DetermineClusterWins")
+type gridLoc struct {
+	col int
+	row int
+	pos int
+}
 
-	grid := make([][]int, len(symbolGrid))
-	for ir, r := range symbolGrid {
-		grid[ir] = make([]int, len(r))
-		for is, s := range r {
-			grid[ir][is] = s
+func isWildSymbol(sym int, wilds []wild) bool {
+	for _, w := range wilds {
+		if w.Symbol == sym {
+			return true
 		}
 	}
+	return false
+}
 
-	type loc struct {
-		col int
-		row int
-		pos int
+// clusterPayout returns the index of the payout with the largest count not above size, or -1 if the size does not pay
+func clusterPayout(payouts []Payout, symbol int, size int, minSize int) int {
+	payout := -1
+	if size < minSize {
+		return payout
 	}
-
-	isWild := func(sym int) bool {
-		for _, s := range wilds {
-			if s.Symbol == sym {
-				return true
+	for ipo, po := range payouts {
+		if po.Symbol == symbol && po.Count <= size {
+			if payout < 0 || po.Count > payouts[payout].Count {
+				payout = ipo
 			}
 		}
-		return false
-	}
-
-	fillChunk := func(l loc) []loc {
-		pos := []loc{}
-		sym := grid[l.col][l.row]
-		mem := []loc{l}
-		res := []loc{}
-		for len(mem) > 0 {
-			lc := mem[0]
-			mem = mem[1:]
-			s := grid[lc.col][lc.row]
-			iswild := isWild(s)
-			if s == sym || iswild {
-				pos = append(pos, lc)
-				if iswild {
-					res = append(res, lc)
-				}
-				grid[lc.col][lc.row] = -grid[lc.col][lc.row]
-				if lc.row > 0 {
-					mem = append(mem, loc{col: lc.col, row: lc.row - 1, pos: lc.pos - 1})
-				}
-				if lc.row < len(grid[lc.col])-1 {
-					mem = append(mem, loc{col: lc.col, row: lc.row + 1, pos: lc.pos + 1})
-				}
-				if lc.col > 0 {
-					mem = append(mem, loc{col: lc.col - 1, row: lc.row, pos: lc.pos - len(grid[lc.col-1])})
-				}
-				if lc.col < len(grid)-1 {
-					mem = append(mem, loc{col: lc.col + 1, row: lc.row, pos: lc.pos + len(grid[lc.col])})
+	}
+	return payout
+}
+
+func clusterPrize(payout Payout, locs []gridLoc) Prize {
+	sympositions := make([]int, len(locs))
+	for ip, p := range locs {
+		sympositions[ip] = p.pos
+	}
+	return Prize{
+		Payout:          payout,
+		Index:           fmt.Sprintf("%v:%v", payout.Symbol, payout.Count),
+		Multiplier:      1,
+		SymbolPositions: sympositions,
+		Winline:         -1,
+	}
+}
+
+// gridPositions returns the position of the first symbol of each reel, reels may differ in size
+func gridPositions(symbolGrid [][]int) []int {
+	offsets := make([]int, len(symbolGrid))
+	pos := 0
+	for i, r := range symbolGrid {
+		offsets[i] = pos
+		pos += len(r)
+	}
+	return offsets
+}
+
+func determineClusterWins(symbolGrid [][]int, conf ClusterConfiguration, wilds []wild) []Prize {
+	logger.Debugf("DetermineClusterWins")
+	payouts := conf.Payouts
+	if conf.Wilds == clusterwilds_none {
+		wilds = nil
+	}
+	offsets := gridPositions(symbolGrid)
+	// symbols that are part of a cluster, wilds are marked only when they are used up by an exclusive cluster
+	used := make([][]bool, len(symbolGrid))
+	for i, r := range symbolGrid {
+		used[i] = make([]bool, len(r))
+	}
+
+	directions := [][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}}
+	if conf.Diagonal {
+		directions = append(directions, [2]int{-1, -1}, [2]int{-1, 1}, [2]int{1, -1}, [2]int{1, 1})
+	}
+
+	fillCluster := func(start gridLoc) []gridLoc {
+		sym := symbolGrid[start.col][start.row]
+		visited := map[int]bool{}
+		cluster := []gridLoc{}
+		queue := []gridLoc{start}
+		for len(queue) > 0 {
+			l := queue[0]
+			queue = queue[1:]
+			if visited[l.pos] || used[l.col][l.row] {
+				continue
+			}
+			s := symbolGrid[l.col][l.row]
+			if s != sym && !isWildSymbol(s, wilds) {
+				continue
+			}
+			visited[l.pos] = true
+			cluster = append(cluster, l)
+			for _, d := range directions {
+				col, row := l.col+d[0], l.row+d[1]
+				if col < 0 || col >= len(symbolGrid) || row < 0 || row >= len(symbolGrid[col]) {
+					continue
 				}
+				queue = append(queue, gridLoc{col: col, row: row, pos: offsets[col] + row})
 			}
 		}
-		for _, r := range res {
-			grid[r.col][r.row] = -grid[r.col][r.row]
-		}
-		return pos
+		return cluster
 	}
 
 	prizes := []Prize{}
-
-	pos := 0
-	for ir, r := range grid {
+	for ir, r := range symbolGrid {
 		for is, s := range r {
-			if s >= 0 && !isWild(s) {
-				positions := fillChunk(loc{col: ir, row: is, pos: pos})
-
-				// logger.Debugf("symbol %d cluster size %d", s, len(positions))
-
-				clusterSize := len(positions)
-				payout := -1
-				for ipo, po := range clusterPayouts {
-					if po.Symbol == s && po.Count <= clusterSize {
-						logger.Debugf("checking payout %#v", po)
-						if payout < 0 || po.Count > clusterPayouts[payout].Count {
-							payout = ipo
-							logger.Debugf("using payout %d from %#v", payout, po)
-						}
-					}
+			if used[ir][is] || isWildSymbol(s, wilds) {
+				continue
+			}
+			cluster := fillCluster(gridLoc{col: ir, row: is, pos: offsets[ir] + is})
+			for _, l := range cluster {
+				if symbolGrid[l.col][l.row] == s {
+					used[l.col][l.row] = true
 				}
-
-				if payout >= 0 {
-					logger.Debugf("payout %v:%v", clusterPayouts[payout].Symbol, clusterPayouts[payout].Count)
-					sympositions := make([]int, len(positions))
-					for ip, p := range positions {
-						sympositions[ip] = p.pos
-					}
-					prizes = append(prizes, Prize{
-						Payout:          clusterPayouts[payout],
-						Index:           fmt.Sprintf("%v:%v", clusterPayouts[payout].Symbol, clusterPayouts[payout].Count),
-						Multiplier:      1,
-						SymbolPositions: sympositions,
-						Winline:         -1})
+			}
+			payout := clusterPayout(payouts, s, len(cluster), conf.MinSize)
+			if payout < 0 {
+				continue
+			}
+			logger.Debugf("payout %v:%v", payouts[payout].Symbol, payouts[payout].Count)
+			if conf.Wilds == clusterwilds_exclusive {
+				for _, l := range cluster {
+					used[l.col][l.row] = true
 				}
 			}
-			pos++
+			prizes = append(prizes, clusterPrize(payouts[payout], cluster))
 		}
 	}
 	return prizes
 }
 
-// Play ...
+// DetermineScatterWins pays every symbol by the number of times it appears anywhere on the grid
+func DetermineScatterWins(symbolGrid [][]int, conf ClusterConfiguration, wilds []wild) []Prize {
+	logger.Debugf("DetermineScatterWins")
+	payouts := conf.Payouts
+	if conf.Wilds == clusterwilds_none {
+		wilds = nil
+	}
+	offsets := gridPositions(symbolGrid)
+	symbols := []int{}
+	locs := map[int][]gridLoc{}
+	wildLocs := []gridLoc{}
+	for ir, r := range symbolGrid {
+		for is, s := range r {
+			l := gridLoc{col: ir, row: is, pos: offsets[ir] + is}
+			if isWildSymbol(s, wilds) {
+				wildLocs = append(wildLocs, l)
+				continue
+			}
+			if _, ok := locs[s]; !ok {
+				symbols = append(symbols, s)
+			}
+			locs[s] = append(locs[s], l)
+		}
+	}
+
+	// exclusive wilds count only for the symbol whose payout they raise the most
+	exclusiveSymbol := -1
+	if conf.Wilds == clusterwilds_exclusive && len(wildLocs) > 0 {
+		bestGain := 0
+		for _, s := range symbols {
+			gain := 0
+			if with := clusterPayout(payouts, s, len(locs[s])+len(wildLocs), conf.MinSize); with >= 0 {
+				gain = payouts[with].Multiplier
+			}
+			if without := clusterPayout(payouts, s, len(locs[s]), conf.MinSize); without >= 0 {
+				gain -= payouts[without].Multiplier
+			}
+			if gain > bestGain {
+				bestGain = gain
+				exclusiveSymbol = s
+			}
+		}
+	}
+
+	prizes := []Prize{}
+	for _, s := range symbols {
+		symbolLocs := locs[s]
+		if conf.Wilds != clusterwilds_exclusive || s == exclusiveSymbol {
+			symbolLocs = append(append([]gridLoc{}, symbolLocs...), wildLocs...)
+		}
+		payout := clusterPayout(payouts, s, len(symbolLocs), conf.MinSize)
+		if payout < 0 {
+			continue
+		}
+		prizes = append(prizes, clusterPrize(payouts[payout], symbolLocs))
+	}
+	return prizes
+}
+
+// Play plays the round on the rng in parameters.Rng, a round with a new seed is started when it is nil.
+// The seed is stored in the gamestate so that the round can be replayed.
 func Play(previousGamestate Gamestate, betPerLine Fixed, currency string, parameters GameParams) (Gamestate, EngineConfig, rgserror.RGSErr) {
+	if parameters.Rng == nil {
+		parameters.Rng = rng.NewRound(nil)
+	}
+	// when the rng audit is enabled all draws used to derive the gamestate are recorded under its id
+	audit := parameters.Rng.StartAudit()
+	gamestate, engineConf, err := play(previousGamestate, betPerLine, currency, parameters)
+	audit.Finish(gamestate.Id)
+	if err == nil {
+		gamestate.RngSeed = parameters.Rng.Seed
+	}
+	return gamestate, engineConf, err
+}
+
+// PlayWithSeed plays the round on a rng stream seeded with seed, a new seed is drawn when it is empty.
+func PlayWithSeed(previousGamestate Gamestate, betPerLine Fixed, currency string, parameters GameParams, seed []uint64) (Gamestate, EngineConfig, rgserror.RGSErr) {
+	parameters.Rng = rng.NewRound(seed)
+	return Play(previousGamestate, betPerLine, currency, parameters)
+}
+
+func play(previousGamestate Gamestate, betPerLine Fixed, currency string, parameters GameParams) (Gamestate, EngineConfig, rgserror.RGSErr) {
 	logger.Debugf("Playing round with parameters: %#v", parameters)
 
-	engineConf, err := previousGamestate.Engine()
+	// a round is played to the end on the config snapshot it began with, new rounds use the active config.
+	// respin and gamble act on the outcome of the previous round.
+	newRound := len(previousGamestate.NextActions) == 1 && previousGamestate.NextActions[0] == "finish" &&
+		parameters.Action != "respin" && parameters.Action != "gamble"
+	var engineConf EngineConfig
+	var err rgserror.RGSErr
+	switch {
+	case parameters.engineHash != "":
+		engineConf, err = previousGamestate.engineSnapshot(parameters.engineHash)
+	case newRound:
+		engineConf, err = previousGamestate.ActiveEngine()
+	default:
+		engineConf, err = previousGamestate.Engine()
+	}
 	if err != nil {
 		return Gamestate{}, EngineConfig{}, err
 	}
+	engineConf = engineConf.WithMaxWinMultiplier(parameters.MaxWinMultiplier)
 	var totalBet Money
 	chargeWager := true
 	var actions []string
+	var betMode BetMode
 
 	if len(previousGamestate.NextActions) == 1 && previousGamestate.NextActions[0] == "finish" {
 		// the old game round should be closed and a new round started
+		if parameters.BetMode != "" && parameters.Action != "base" {
+			err = rgserror.Create(rgserror.InvalidParamsError)
+			err.AppendErrorText(fmt.Sprintf("bet mode %v is not available for action %v", parameters.BetMode, parameters.Action))
+			return Gamestate{}, EngineConfig{}, err
+		}
 
 		// if this is a respin, special case:
 		if parameters.Action == "respin" {
@@ -728,6 +878,9 @@ func Play(previousGamestate Gamestate, betPerLine Fixed, currency string, parame
 			betPerLine = previousGamestate.BetPerLine.Amount
 			totalBet = RoundUpToNearestCCYUnit(Money{previousGamestate.RespinPriceReel(parameters.RespinReel), currency})
 			parameters.previousGamestate = previousGamestate
+		} else if parameters.Action == GambleAction && engineConf.HasGamble() {
+			// the configured gamble stakes the win of the previous round or gamble step
+			return engineConf.playGamble(previousGamestate, currency, parameters)
 		} else if parameters.Action == "gamble" {
 			// verify that the previous action was freespin and nextaction is finish
 			if !(strings.Contains(previousGamestate.Action, "freespin") && len(previousGamestate.NextActions) == 1 && previousGamestate.NextActions[0] == "finish") {
@@ -748,12 +901,33 @@ func Play(previousGamestate Gamestate, betPerLine Fixed, currency string, parame
 			betPerLine = previousGamestate.CumulativeWin
 			totalBet = Money{previousGamestate.CumulativeWin, currency}
 
+		} else if parameters.Action == BuyFeatureAction {
+			// the price of the feature is a multiple of the total stake
+			var price Fixed
+			price, err = engineConf.BuyFeaturePrice()
+			if err != nil {
+				return Gamestate{}, EngineConfig{}, err
+			}
+			actions = []string{parameters.Action, "finish"}
+			totalStake := betPerLine.Mul(NewFixedFromInt(engineConf.EngineDefs[0].StakeDivisor))
+			totalBet = RoundUpToNearestCCYUnit(Money{totalStake.Mul(price), currency})
+		} else if parameters.BetMode != "" {
+			// a round in a bet mode costs a multiple of the total stake and is played on the def of the mode
+			betMode, err = engineConf.BetMode(parameters.BetMode)
+			if err != nil {
+				return Gamestate{}, EngineConfig{}, err
+			}
+			actions = []string{parameters.Action, "finish"}
+			totalStake := betPerLine.Mul(NewFixedFromInt(engineConf.EngineDefs[0].StakeDivisor))
+			totalBet = RoundUpToNearestCCYUnit(Money{totalStake.Mul(betMode.CostMultiplier()), currency})
 		} else {
 			// new gameplay round
 			// totalbet is set after gameplay
 			actions = []string{parameters.Action, "finish"}
 		}
 	} else {
+		// the rounds that follow keep the bet mode the round was started in
+		betMode.Name = previousGamestate.BetMode
 		chargeWager = false
 		logger.Debugf("Continuing game round, no WAGER charged")
 		actions = previousGamestate.NextActions
@@ -769,12 +943,16 @@ func Play(previousGamestate Gamestate, betPerLine Fixed, currency string, parame
 	switch parameters.Action {
 	case "cascade":
 		// action must be performed on the same engine as previous round
-		logger.Debugf("cascade method selected: %s", engineConf.EngineDefs[previousGamestate.DefID].Function)
-		method = reflect.ValueOf(engineConf.EngineDefs[previousGamestate.DefID]).MethodByName(engineConf.EngineDefs[previousGamestate.DefID].Function)
+		def := engineConf.EngineDefs[previousGamestate.DefID]
+		def.rng = parameters.Rng
+		logger.Debugf("cascade method selected: %s", def.Function)
+		method = reflect.ValueOf(def).MethodByName(def.Function)
 	case "respin":
 		// action must be performed on the same engine as previous round, but method will always be respin
-		logger.Debugf("respin method selected: %s", engineConf.EngineDefs[previousGamestate.DefID].Respin)
-		method = reflect.ValueOf(engineConf.EngineDefs[previousGamestate.DefID].Respin)
+		def := engineConf.EngineDefs[previousGamestate.DefID]
+		def.rng = parameters.Rng
+		logger.Debugf("respin method selected: %s", def.Respin)
+		method = reflect.ValueOf(def.Respin)
 	//case "gamble":
 	//	// action must be performed on the gamble engine
 	//	method = reflect.ValueOf(engineConf.EngineDefs[previousGamestate.DefID]).MethodByName(engineConf.EngineDefs[previousGamestate.DefID].Function)
@@ -785,7 +963,11 @@ func Play(previousGamestate Gamestate, betPerLine Fixed, currency string, parame
 		}
 		logger.Debugf("getting engine for method %s (prev.NextAction=%s)",
 			parameters.Action, prevNextAction)
-		method, _, err = engineConf.getEngineAndMethod(parameters.Action)
+		defName := parameters.Action
+		if betMode.Def != "" {
+			defName = betMode.Def
+		}
+		method, _, err = engineConf.getEngineAndMethod(defName, parameters.Rng)
 	}
 
 	if err != nil {
@@ -799,6 +981,8 @@ func Play(previousGamestate Gamestate, betPerLine Fixed, currency string, parame
 	if !ok {
 		panic("value not a gamestate")
 	}
+	gamestate.BetMode = betMode.Name
+	gamestate.MaxWin = parameters.maxWin
 	gamestate.PostProcess(previousGamestate, chargeWager, totalBet, engineConf, betPerLine, actions, currency)
 	return gamestate, engineConf, nil
 }
@@ -828,12 +1012,20 @@ func (gamestate *Gamestate) PostProcess(previousGamestate Gamestate, chargeWager
 	}
 	gamestate.BetPerLine = Money{betPerLine, currency}
 	gamestate.PrepareActions(actions)
+	gamestate.setMaxWin(previousGamestate, chargeWager, engineConf)
 	gamestate.Gamification = previousGamestate.Gamification
 	gamestate.Game = previousGamestate.Game
 	gamestate.UpdateGamification(previousGamestate)
 
 	gamestate.Id = previousGamestate.NextGamestate
 	gamestate.PreviousGamestate = previousGamestate.Id
+	gamestate.EngineHash = engineConf.Hash
+	if gamestate.DefID >= 0 && gamestate.DefID < len(engineConf.EngineDefs) && len(engineConf.EngineDefs[gamestate.DefID].ReelHeights) > 0 {
+		gamestate.ReelHeights = make([]int, len(gamestate.SymbolGrid))
+		for i, reel := range gamestate.SymbolGrid {
+			gamestate.ReelHeights[i] = len(reel)
+		}
+	}
 
 	nextID := rng.Uuid()
 	gamestate.NextGamestate = nextID
@@ -872,11 +1064,17 @@ func (gamestate *Gamestate) PrepareTransactions(previousGamestate Gamestate) {
 	var gamestateWin Money
 	if relativePayout != 0 || gamestate.RoundID != gamestate.Id {
 		txID := gamestate.Id
-		if gamestate.RoundID == gamestate.Id {
+		if gamestate.RoundID == gamestate.Id || len(gamestate.Transactions) > 0 {
+			// the id of the gamestate is taken by its wager
 			txID = rng.Uuid()
 		}
 		// add win transaction
 		gamestateWin = Money{Amount: relativePayout.Mul(gamestate.BetPerLine.Amount), Currency: gamestate.BetPerLine.Currency} // this is in fixed notation i.e. 1.00 == 1000000
+		var roundWin Fixed
+		if gamestate.RoundID != gamestate.Id {
+			roundWin = previousGamestate.CumulativeWin
+		}
+		gamestateWin.Amount = gamestate.capWin(roundWin, gamestateWin.Amount)
 		gamestate.Transactions = append(gamestate.Transactions, WalletTransaction{Id: txID, Amount: gamestateWin, Type: "PAYOUT"})
 	}
 
@@ -939,7 +1137,7 @@ func (engine EngineDef) DetermineWins(symbolGrid [][]int) ([]Prize, int) {
 	}
 	switch engine.WinType {
 	case "ways":
-		wins = DetermineWaysWins(symbolGrid, engine.Payouts, engine.Wilds)
+		wins = DetermineWaysWins(engine.rng, symbolGrid, engine.Payouts, engine.Wilds)
 	case "lines":
 		keepWilds := strings.Contains(engine.WinConfig.Flags, "keep_wilds")
 		compounding := compounding_none
@@ -950,9 +1148,9 @@ func (engine EngineDef) DetermineWins(symbolGrid [][]int) ([]Prize, int) {
 				compounding = compounding_multiplication
 			}
 		}
-		wins = DetermineLineWins(symbolGrid, engine.WinLines, engine.Payouts, engine.Wilds, compounding, keepWilds)
+		wins = DetermineLineWins(engine.rng, symbolGrid, engine.WinLines, engine.Payouts, engine.Wilds, compounding, keepWilds)
 	case "barLines":
-		wins = determineBarLineWins(symbolGrid, engine.WinLines, engine.Payouts, engine.Bars, engine.Wilds, engine.Compounding)
+		wins = determineBarLineWins(engine.rng, symbolGrid, engine.WinLines, engine.Payouts, engine.Bars, engine.Wilds, engine.Compounding)
 	case "blazeLines":
 		// this is a special kind of line win defined for blaze games-- horizontal lines are mirrored vertically
 		// and wins can exist anywhere within the line-- multiple payouts per line are possible
@@ -961,10 +1159,10 @@ func (engine EngineDef) DetermineWins(symbolGrid [][]int) ([]Prize, int) {
 			logger.Errorf("Requesting vertical and horizontal line win calculation on non-standard grid size")
 			return []Prize{}, 0
 		}
-		wins = DetermineLineWinsAnywhere(symbolGrid, engine.WinLines, engine.Payouts, engine.Wilds, compounding)
+		wins = DetermineLineWinsAnywhere(engine.rng, symbolGrid, engine.WinLines, engine.Payouts, engine.Wilds, compounding)
 		// transpose grid
 		sGTransposed := TransposeGrid(symbolGrid)
-		vWins := DetermineLineWinsAnywhere(sGTransposed, engine.WinLines, engine.Payouts, engine.Wilds, compounding)
+		vWins := DetermineLineWinsAnywhere(engine.rng, sGTransposed, engine.WinLines, engine.Payouts, engine.Wilds, compounding)
 		for w := 0; w < len(vWins); w++ {
 			// add prefix to index and adjust line number
 			// get base ref which is i reel first symbol
@@ -979,16 +1177,27 @@ func (engine EngineDef) DetermineWins(symbolGrid [][]int) ([]Prize, int) {
 		}
 		wins = append(wins, vWins...)
 	case "pAndF":
-		wins = determinePrimeAndFlopWins(symbolGrid, engine.Payouts, engine.Wilds)
+		wins = determinePrimeAndFlopWins(engine.rng, symbolGrid, engine.Payouts, engine.Wilds)
 	case "elysiumLines":
 		wins = DetermineElysiumLineWins(symbolGrid, engine.WinLines, engine.Payouts, engine.WinConfig)
 	case "cluster":
-		wins = DetermineClusterWins(symbolGrid, engine.Payouts, engine.Wilds)
+		wins = determineClusterWins(symbolGrid, engine.clusterConfig(), engine.Wilds)
+	case "scatterpays":
+		wins = DetermineScatterWins(symbolGrid, engine.clusterConfig(), engine.Wilds)
 	}
 	relativePayout := calculatePayoutWins(wins)
 	return wins, relativePayout
 }
 
+// clusterConfig returns the cluster configuration with the def payouts if it has no payouts of its own
+func (engine EngineDef) clusterConfig() ClusterConfiguration {
+	conf := engine.ClusterConfig
+	if len(conf.Payouts) == 0 {
+		conf.Payouts = engine.Payouts
+	}
+	return conf
+}
+
 func (engine EngineDef) addStickyWilds(previousGamestate Gamestate, symbolGrid [][]int) [][]int {
 	// this will fail if previous symbolGrid is of different dimensions than current
 	if len(symbolGrid) != len(previousGamestate.SymbolGrid) {
@@ -1042,7 +1251,7 @@ func (engine EngineDef) BaseRound(parameters GameParams) Gamestate {
 	// get Multiplier
 	multiplier := 1
 	if len(engine.Multiplier.Multipliers) > 0 {
-		multiplier = SelectFromWeightedOptions(engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
+		multiplier = SelectFromWeightedOptions(engine.rng, engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
 	}
 	// Build gamestate
 	gamestate := Gamestate{DefID: engine.Index, Prizes: wins, SymbolGrid: symbolGrid, RelativePayout: relativePayout, Multiplier: multiplier, StopList: stopList, NextActions: nextActions, SelectedWinLines: wl}
@@ -1069,7 +1278,7 @@ func (engine EngineDef) MultiplierXWilds(parameters GameParams) Gamestate {
 	gamestate.Multiplier = 1
 
 	for w := 0; w < ctWilds; w++ {
-		gamestate.Multiplier *= SelectFromWeightedOptions(engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
+		gamestate.Multiplier *= SelectFromWeightedOptions(engine.rng, engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
 	}
 
 	return gamestate
@@ -1088,64 +1297,38 @@ func (engine EngineDef) GuaranteedWin(parameters GameParams) Gamestate {
 	return gamestate
 }
 
-// Cascading round
+// Cascading round, the winning symbols of the previous cascade are removed and the view is refilled as configured
+// by CascadeConfig
 func (engine EngineDef) Cascade(parameters GameParams) Gamestate {
-	symbolGrid := make([][]int, len(engine.ViewSize))
-	stopList := make([]int, len(engine.ViewSize))
+	conf := engine.CascadeConfig
+	var symbolGrid [][]int
+	var stopList []int
 	var cascadePositions []int
+	cascadeIndex := 0
 	if parameters.Action == "cascade" {
 		previousGamestate := parameters.previousGamestate
+		cascadeIndex = previousGamestate.CascadeIndex + 1
 		// if previous gamestate contains a win, we need to cascade new tiles into the old space
-		previousGrid := previousGamestate.SymbolGrid
-		remainingGrid := [][]int{}
-		//determine map of symbols to disappear
-		for i := 0; i < len(previousGamestate.Prizes); i++ {
-			for j := 0; j < len(previousGamestate.Prizes[i].SymbolPositions); j++ {
-				// to avoid having to assume symbol grid is regular:
-				col := 0
-				row := 0
-
-				for ii := 0; ii < previousGamestate.Prizes[i].SymbolPositions[j]; ii++ {
-					row++
-					if row >= len(previousGamestate.SymbolGrid[col]) {
-						col++
-						row = 0
-					}
-				}
-				previousGrid[col][row] = -1
-			}
-		}
-
-		for i := 0; i < len(previousGrid); i++ {
-			remainingReel := []int{}
-			// remove winning symbols from the view
-			for j := 0; j < len(previousGrid[i]); j++ {
-				if previousGrid[i][j] >= 0 {
-					remainingReel = append(remainingReel, previousGrid[i][j])
-				}
-			}
-			remainingGrid = append(remainingGrid, remainingReel)
-		}
-
-		// adjust reels in case need to cascade symbols from the end of the strip
-		adjustedReels := make([][]int, len(engine.Reels))
-
-		for i := 0; i < len(engine.Reels); i++ {
-			adjustedReels[i] = append(engine.Reels[i], engine.Reels[i][:engine.ViewSize[i]]...)
-		}
+		remainingGrid := engine.cascadeRemaining(previousGamestate.SymbolGrid, previousGamestate.Prizes)
+		symbolGrid = make([][]int, len(engine.ViewSize))
+		stopList = make([]int, len(engine.ViewSize))
 		// return grid to full size by filling in empty spaces
 		for i := 0; i < len(engine.ViewSize); i++ {
-			numToAdd := engine.ViewSize[i] - len(remainingGrid[i])
+			// reels with variable heights keep the height they were spun with until the cascades end
+			height := engine.ViewSize[i]
+			if len(engine.ReelHeights) > 0 {
+				height = len(previousGamestate.SymbolGrid[i])
+			}
+			numToAdd := height - len(remainingGrid[i])
 			cascadePositions = append(cascadePositions, numToAdd)
-			stop := previousGamestate.StopList[i] - numToAdd
-			// get adjusted index if the previous win was at the top of the reel
-			if stop < 0 {
-				stop = len(engine.Reels[i]) + stop
+			var refill []int
+			refill, stopList[i] = engine.cascadeRefill(i, previousGamestate, height, numToAdd)
+			if conf.Direction == cascadedirection_up {
+				symbolGrid[i] = append(remainingGrid[i], refill...)
+			} else {
+				symbolGrid[i] = append(refill, remainingGrid[i]...)
 			}
-			symbolGrid[i] = append(adjustedReels[i][stop:stop+numToAdd], remainingGrid[i]...)
-			stopList[i] = stop
 		}
-
 	} else {
 		symbolGrid, stopList = engine.Spin()
 	}
@@ -1155,8 +1338,8 @@ func (engine EngineDef) Cascade(parameters GameParams) Gamestate {
 	// calculate specialWin
 	var nextActions []string
 	cascade := false
-	// if any win is present, next action should be cascade
-	if len(wins) > 0 {
+	// if any win is present, next action should be cascade. wins of sticky symbols only would repeat forever
+	if len(wins) > 0 && engine.cascadeRemoves(symbolGrid, wins) {
 		logger.Debugf("cascade is true")
 		cascade = true
 	} else {
@@ -1174,27 +1357,107 @@ func (engine EngineDef) Cascade(parameters GameParams) Gamestate {
 		nextActions = append([]string{"cascade"}, nextActions...)
 	}
 
-	// get first Multiplier
+	// the multiplier ladder climbs with each cascade, otherwise the first multiplier is used
 	multiplier := 1
-	if len(engine.Multiplier.Multipliers) > 0 {
-		//multiplier = SelectFromWeightedOptions(engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
+	if len(conf.Multipliers) > 0 {
+		multiplier = conf.Multipliers[minInt(cascadeIndex, len(conf.Multipliers)-1)]
+	} else if len(engine.Multiplier.Multipliers) > 0 {
+		//multiplier = SelectFromWeightedOptions(engine.rng, engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
 		multiplier = engine.Multiplier.Multipliers[0]
 	}
-	// for now, do a bit of a hack to get the cascade positions. as soon as we need to implement cascade with variable
-	// win lines, this will need to be adjusted to be added into a new field in the gamestate message
 
-	winlines := parameters.SelectedWinLines
-	if len(winlines) == 0 {
-		winlines = cascadePositions
-		logger.Debugf("NOT SELECTED WINLINES")
-	} else {
-		logger.Debugf("SELECTED WINLINES")
-	}
 	// Build gamestate
-	gamestate := Gamestate{DefID: engine.Index, Prizes: wins, SymbolGrid: symbolGrid, RelativePayout: relativePayout, Multiplier: multiplier, StopList: stopList, NextActions: nextActions, SelectedWinLines: winlines}
+	gamestate := Gamestate{DefID: engine.Index, Prizes: wins, SymbolGrid: symbolGrid, RelativePayout: relativePayout, Multiplier: multiplier, StopList: stopList, NextActions: nextActions, SelectedWinLines: parameters.SelectedWinLines, CascadePositions: cascadePositions, CascadeIndex: cascadeIndex}
 	return gamestate
 }
 
+// cascadeRemaining returns the symbols of each reel that stay in the view after the winning symbols are removed,
+// sticky symbols are never removed
+func (engine EngineDef) cascadeRemaining(symbolGrid [][]int, prizes []Prize) [][]int {
+	removed := make([][]bool, len(symbolGrid))
+	for i := range symbolGrid {
+		removed[i] = make([]bool, len(symbolGrid[i]))
+	}
+	// to avoid having to assume symbol grid is regular, positions are counted down the reels
+	offsets := gridPositions(symbolGrid)
+	for _, prize := range prizes {
+		for _, pos := range prize.SymbolPositions {
+			col := 0
+			for col < len(symbolGrid)-1 && pos >= offsets[col]+len(symbolGrid[col]) {
+				col++
+			}
+			row := pos - offsets[col]
+			if row < 0 || row >= len(symbolGrid[col]) {
+				continue
+			}
+			if getIndex(symbolGrid[col][row], engine.CascadeConfig.Sticky) < 0 {
+				removed[col][row] = true
+			}
+		}
+	}
+	remaining := make([][]int, len(symbolGrid))
+	for i := range symbolGrid {
+		remaining[i] = []int{}
+		for j, sym := range symbolGrid[i] {
+			if !removed[i][j] {
+				remaining[i] = append(remaining[i], sym)
+			}
+		}
+	}
+	return remaining
+}
+
+// cascadeRemoves reports whether the wins remove at least one symbol from the grid
+func (engine EngineDef) cascadeRemoves(symbolGrid [][]int, wins []Prize) bool {
+	for i, reel := range engine.cascadeRemaining(symbolGrid, wins) {
+		if len(reel) < len(symbolGrid[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// cascadeRefill returns the n symbols that refill reel i of a view with the given height and the stop after the
+// refill. For the reel and strip refills the stop is the position on the strip that the next refill continues from.
+func (engine EngineDef) cascadeRefill(i int, previousGamestate Gamestate, height int, n int) ([]int, int) {
+	conf := engine.CascadeConfig
+	stop := previousGamestate.StopList[i]
+	refill := make([]int, n)
+	var strip []int
+	switch conf.Refill {
+	case cascaderefill_random:
+		for j := range refill {
+			refill[j] = SelectFromWeightedOptions(engine.rng, conf.RefillSymbols, conf.RefillWeights)
+		}
+		return refill, stop
+	case cascaderefill_strip:
+		strip = conf.RefillStrips[i]
+		if previousGamestate.Action != "cascade" {
+			// the first cascade after the spin starts at a random position of the refill strip
+			stop = engine.rng.RandFromRange(len(strip))
+		}
+		// the refill strip is not in view, the next symbols follow the stop directly
+		height = 0
+	default:
+		strip = engine.Reels[i]
+	}
+	at := func(pos int) int {
+		return ((pos % len(strip)) + len(strip)) % len(strip)
+	}
+	if conf.Direction == cascadedirection_up {
+		// new symbols are taken from below the view, the view moves down the strip
+		for j := range refill {
+			refill[j] = strip[at(stop+height+j)]
+		}
+		return refill, at(stop + n)
+	}
+	// new symbols are taken from above the view, the view moves up the strip
+	for j := range refill {
+		refill[j] = strip[at(stop-n+j)]
+	}
+	return refill, at(stop - n)
+}
+
 func (engine EngineDef) CascadeMultiply(parameters GameParams) Gamestate {
 	// multiplier increments for each cascade, up to the highest multiplier in the engine
 	gamestate := engine.Cascade(parameters)
@@ -1253,7 +1516,7 @@ func (engine EngineDef) SelectPrize(parameters GameParams) Gamestate {
 	// get Multiplier
 	multiplier := 1
 	if len(engine.Multiplier.Multipliers) > 0 {
-		multiplier = SelectFromWeightedOptions(engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
+		multiplier = SelectFromWeightedOptions(engine.rng, engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
 	}
 	// Build gamestate
 
@@ -1273,7 +1536,7 @@ func (engine EngineDef) Respin(parameters GameParams) Gamestate {
 	respinIndex := parameters.RespinReel
 	previousGamestate := parameters.previousGamestate
 
-	newSymbols, newStopValue := EngineDef{Reels: [][]int{engine.Reels[respinIndex]}, ViewSize: []int{engine.ViewSize[respinIndex]}}.Spin()
+	newSymbols, newStopValue := EngineDef{Reels: [][]int{engine.Reels[respinIndex]}, ViewSize: []int{engine.ViewSize[respinIndex]}, rng: engine.rng}.Spin()
 	symbolGrid := previousGamestate.SymbolGrid
 	symbolGrid[respinIndex] = newSymbols[0]
 	stopList := previousGamestate.StopList
@@ -1332,7 +1595,7 @@ func (engine EngineDef) ShuffleBase(parameters GameParams, shuffleID string) Gam
 	logger.Debugf("previous reels : %v", symbolGrid)
 
 	for i := 0; i < len(shuffleReels); i++ {
-		newSymbols, newStopValue := EngineDef{Reels: [][]int{engine.Reels[shuffleReels[i]]}, ViewSize: []int{engine.ViewSize[shuffleReels[i]]}}.Spin()
+		newSymbols, newStopValue := EngineDef{Reels: [][]int{engine.Reels[shuffleReels[i]]}, ViewSize: []int{engine.ViewSize[shuffleReels[i]]}, rng: engine.rng}.Spin()
 		symbolGrid[shuffleReels[i]] = newSymbols[0]
 		stopList[shuffleReels[i]] = newStopValue[0]
 	}
@@ -1450,7 +1713,7 @@ func (engine EngineDef) DynamicWildWaysRound(parameters GameParams) Gamestate {
 
 	// build wild reels
 	// choose the number of wilds to appear
-	numWilds := GetWeightedIndex([]int{32, 48, 10, 10})
+	numWilds := GetWeightedIndex(engine.rng, []int{32, 48, 10, 10})
 	logger.Debugf("Number of Wilds selected: %v", numWilds)
 	// choose the locations of the wilds
 
@@ -1469,7 +1732,7 @@ func (engine EngineDef) DynamicWildWaysRound(parameters GameParams) Gamestate {
 		}, // for 3 wilds
 	}
 
-	wildLocations := potentialWildLocations[numWilds][rng.RandFromRange(len(potentialWildLocations[numWilds]))]
+	wildLocations := potentialWildLocations[numWilds][engine.rng.RandFromRange(len(potentialWildLocations[numWilds]))]
 
 	logger.Debugf("Wild locations: %v", wildLocations)
 
@@ -1484,11 +1747,11 @@ func (engine EngineDef) DynamicWildWaysRound(parameters GameParams) Gamestate {
 	//choose fs multiplier based on numWilds
 	// todo define in constants in config file
 	fsMultiplierP := [][]int{{7, 6, 2}, {11, 2, 2}, {12, 2, 1}, {13, 1, 1}}[numWilds] // each slice is weight of multiplier {1,2,3}
-	freespinMultiplier := SelectFromWeightedOptions([]int{1, 2, 3}, fsMultiplierP)
+	freespinMultiplier := SelectFromWeightedOptions(engine.rng, []int{1, 2, 3}, fsMultiplierP)
 	logger.Debugf("P: %v; Selected Multiplier: %v", fsMultiplierP, freespinMultiplier)
 
 	symbolGrid, stopList := engine.Spin()
-	wins := DetermineWaysWins(symbolGrid, engine.Payouts, engine.Wilds)
+	wins := DetermineWaysWins(engine.rng, symbolGrid, engine.Payouts, engine.Wilds)
 	logger.Debugf("symbolgrid: %v; wins: %v", symbolGrid, wins)
 	relativePayout := calculatePayoutWins(wins)
 	gamestate := Gamestate{DefID: engine.Index, Prizes: wins, SymbolGrid: symbolGrid, RelativePayout: relativePayout, Multiplier: freespinMultiplier, StopList: stopList, NextActions: []string{}}
@@ -1648,7 +1911,7 @@ func (engine EngineDef) PushReels(parameters GameParams) Gamestate {
 	// get Multiplier
 	multiplier := 1
 	if len(engine.Multiplier.Multipliers) > 0 {
-		multiplier = SelectFromWeightedOptions(engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
+		multiplier = SelectFromWeightedOptions(engine.rng, engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
 	}
 	// same hack as cascade, use selectedWinLines to mark changed symbols
 	wl := make([]int, len(engine.ViewSize))
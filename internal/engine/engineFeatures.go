@@ -10,7 +10,6 @@ import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
 	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
@@ -164,6 +163,7 @@ func triggerFeatures(engine EngineDef, fs *feature.FeatureState, parameters Game
 	fs.ReelsetId = engine.ReelsetId
 	fs.Reels = engine.Reels
 	fs.Action = parameters.Action
+	fs.Rng = engine.rng
 
 	featureparams := feature.FeatureParams{
 		"Engine": engine.ID,
@@ -324,7 +324,7 @@ func genForcedRound(gen GenerateRound, engine EngineDef, parameters GameParams)
 					stopList := make([]int, len(engine.Reels))
 					for i, s := range stopStrs {
 						rl := len(engine.Reels[i])
-						p := rng.RandFromRange(rl)
+						p := engine.rng.RandFromRange(rl)
 						if s != "" {
 							p64, err := strconv.ParseInt(s, 10, 64)
 							if err != nil {
@@ -411,7 +411,7 @@ func genFeatureRound(gen GenerateRound, engine EngineDef, parameters GameParams)
 	// get Multiplier
 	multiplier := 1
 	if len(engine.Multiplier.Multipliers) > 0 {
-		multiplier = SelectFromWeightedOptions(engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
+		multiplier = SelectFromWeightedOptions(engine.rng, engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
 	}
 	// if no features were generated then no need to store a featureview
 	if len(featurestate.Features) == 0 {
@@ -469,7 +469,7 @@ func genReplayRound(gen GenerateRound, engine EngineDef, parameters GameParams)
 	// get Multiplier
 	multiplier := 1
 	if len(engine.Multiplier.Multipliers) > 0 {
-		multiplier = SelectFromWeightedOptions(engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
+		multiplier = SelectFromWeightedOptions(engine.rng, engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
 	}
 	// if no features were generated then no need to store a featureview
 	if len(featurestate.Features) == 0 {
@@ -571,7 +571,7 @@ func genFeatureCascade(gen GenerateRound, engine EngineDef, parameters GameParam
 
 	// get first Multiplier
 	if multiplier == 0 && len(engine.Multiplier.Multipliers) > 0 {
-		//multiplier = SelectFromWeightedOptions(engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
+		//multiplier = SelectFromWeightedOptions(engine.rng, engine.Multiplier.Multipliers, engine.Multiplier.Probabilities)
 		multiplier = engine.Multiplier.Multipliers[0]
 		logger.Debugf("initiating multiplier: %d", multiplier)
 	}
@@ -625,9 +625,6 @@ func genFeatureCascade(gen GenerateRound, engine EngineDef, parameters GameParam
 	wins = append(wins, featureWins...)
 	nextActions = append(nextActions, featureNextActions...)
 
-	// for now, do a bit of a hack to get the cascade positions. as soon as we need to implement cascade with variable
-	// win lines, this will need to be adjusted to be added into a new field in the gamestate message
-
 	// Build gamestate
 	gamestate := Gamestate{
 		DefID:            engine.Index,
@@ -637,7 +634,7 @@ func genFeatureCascade(gen GenerateRound, engine EngineDef, parameters GameParam
 		Multiplier:       multiplier,
 		StopList:         stopList,
 		NextActions:      nextActions,
-		SelectedWinLines: cascadePositions, // resuse hack from regular cascade to encode positions in winlines
+		CascadePositions: cascadePositions,
 		Features:         featureState.Features,
 		FeatureView:      featureState.SymbolGrid,
 		ReelsetID:        featureState.ReelsetId,
@@ -717,7 +714,7 @@ func (engine EngineDef) ReplayRound(parameters GameParams) Gamestate {
 func (engine EngineDef) InitRound(parameters GameParams) (state Gamestate) {
 	stopList := make([]int, len(engine.ViewSize))
 	for i := range stopList {
-		stopList[i] = rng.RandFromRange(len(engine.Reels[i]))
+		stopList[i] = engine.rng.RandFromRange(len(engine.Reels[i]))
 	}
 	state.SymbolGrid = GetSymbolGridFromStopList(engine.Reels, engine.ViewSize, stopList)
 	engine.InitRoundFeatures(parameters, stopList, &state)
@@ -745,6 +742,7 @@ func (engine EngineDef) InitRoundFeatures(parameters GameParams, stopList []int,
 	fs.ReelsetId = engine.ReelsetId
 	fs.Reels = engine.Reels
 	fs.Action = parameters.Action
+	fs.Rng = engine.rng
 	feature.InitFeatures(featuredef, &fs)
 	state.Features = fs.Features
 	state.ReelsetID = fs.ReelsetId
@@ -3,6 +3,8 @@ package engine
 import (
 	"fmt"
 
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 
 	// "fmt"
@@ -75,6 +77,60 @@ func TestEngineDef_CascadeMultiply(t *testing.T) {
 	}
 }
 
+func TestEngineDef_CascadeConfig(t *testing.T) {
+	cascade := func(conf CascadeConfiguration, previous Gamestate) Gamestate {
+		engine := cascadeEngine
+		engine.CascadeConfig = conf
+		return engine.Cascade(GameParams{previousGamestate: previous, Action: "cascade"})
+	}
+	gs := cascade(CascadeConfiguration{}, cascadeGS)
+	if fmt.Sprint(gs.CascadePositions) != "[1 1 1]" || gs.SelectedWinLines != nil || gs.CascadeIndex != 1 {
+		t.Errorf("cascade positions %v, selected win lines %v, index %v", gs.CascadePositions, gs.SelectedWinLines, gs.CascadeIndex)
+	}
+
+	// symbols rise and new symbols enter from the reel strip below the view
+	gs = cascade(CascadeConfiguration{Direction: cascadedirection_up}, cascadeGS)
+	if fmt.Sprint(gs.SymbolGrid) != "[[0 0 0] [0 0 1] [0 2 3]]" || fmt.Sprint(gs.StopList) != "[3 1 5]" {
+		t.Errorf("up: grid %v stops %v", gs.SymbolGrid, gs.StopList)
+	}
+
+	gs = cascade(CascadeConfiguration{Refill: cascaderefill_random, RefillSymbols: []int{7}}, cascadeGS)
+	if fmt.Sprint(gs.SymbolGrid) != "[[7 0 0] [7 0 0] [7 0 2]]" {
+		t.Errorf("random: grid %v", gs.SymbolGrid)
+	}
+
+	// a cascade that follows a cascade continues on the refill strip from the previous stop
+	strips := CascadeConfiguration{Refill: cascaderefill_strip, RefillStrips: [][]int{{6, 7, 8}, {6, 7, 8}, {6, 7, 8}}}
+	previous := cascadeGS
+	previous.Action = "cascade"
+	gs = cascade(strips, previous)
+	if fmt.Sprint(gs.SymbolGrid) != "[[7 0 0] [8 0 0] [6 0 2]]" || fmt.Sprint(gs.StopList) != "[1 2 0]" {
+		t.Errorf("strip: grid %v stops %v", gs.SymbolGrid, gs.StopList)
+	}
+
+	// winning sticky symbols stay in the view, a win of sticky symbols only does not cascade
+	sticky := cascadeEngine
+	sticky.CascadeConfig = CascadeConfiguration{Sticky: []int{1}}
+	if sticky.cascadeRemoves(cascadeGS.SymbolGrid, cascadeGS.Prizes) || !cascadeEngine.cascadeRemoves(cascadeGS.SymbolGrid, cascadeGS.Prizes) {
+		t.Errorf("unexpected removal of sticky symbols")
+	}
+	gs = cascade(sticky.CascadeConfig, cascadeGS)
+	if fmt.Sprint(gs.SymbolGrid) != fmt.Sprint(cascadeGS.SymbolGrid) || fmt.Sprint(gs.CascadePositions) != "[0 0 0]" || len(gs.NextActions) > 0 && gs.NextActions[0] == "cascade" {
+		t.Errorf("sticky: grid %v positions %v next actions %v", gs.SymbolGrid, gs.CascadePositions, gs.NextActions)
+	}
+
+	// the multiplier ladder climbs with each cascade and holds at the last step
+	ladder := CascadeConfiguration{Multipliers: []int{1, 2, 3}}
+	if gs = cascade(ladder, cascadeGS); gs.Multiplier != 2 {
+		t.Errorf("first cascade multiplier %v", gs.Multiplier)
+	}
+	previous = cascadeGS
+	previous.CascadeIndex = 5
+	if gs = cascade(ladder, previous); gs.Multiplier != 3 || gs.CascadeIndex != 6 {
+		t.Errorf("cascade %v multiplier %v", gs.CascadeIndex, gs.Multiplier)
+	}
+}
+
 func TestSpinViewSize(t *testing.T) {
 
 	randomTestViewLength := rand.Intn(10)
@@ -134,6 +190,60 @@ func TestSpin(t *testing.T) {
 
 }
 
+var megawaysEngine = EngineDef{
+	Reels:    [][]int{{1, 2, 3, 4, 5, 6}, {1, 1, 2, 2, 3, 3}, {6, 5, 4, 3, 2, 1}},
+	ViewSize: []int{4, 4, 4},
+	ReelHeights: []reelHeight{
+		{Heights: []int{2, 3, 4}, Probabilities: []int{1, 1, 1}},
+		{Heights: []int{2, 4}, Probabilities: []int{1, 1}},
+		{Heights: []int{3}, Probabilities: []int{1}},
+	},
+	WinType: "ways",
+	Payouts: []Payout{{Symbol: 1, Count: 3, Multiplier: 10}},
+}
+
+func TestSpinReelHeights(t *testing.T) {
+	seen := map[int]bool{}
+	for i := 0; i < 200; i++ {
+		view, stops := megawaysEngine.Spin()
+		if len(view) != 3 || len(stops) != 3 {
+			t.Fatalf("unexpected view %v stops %v", view, stops)
+		}
+		for r, reel := range view {
+			h := megawaysEngine.ReelHeights[r]
+			if !slicesMatch(reel, GetSymbolGridFromStopList(megawaysEngine.Reels[r:r+1], []int{len(reel)}, stops[r:r+1])[0]) || getIndex(len(reel), h.Heights) < 0 {
+				t.Fatalf("reel %v: view %v with stop %v does not match heights %v", r, reel, stops[r], h.Heights)
+			}
+		}
+		seen[len(view[0])] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected every height of the first reel, got %v", seen)
+	}
+
+	config.GlobalConfig.DevMode = true
+	defer func() { config.GlobalConfig.DevMode = false }()
+	forced, _ := megawaysEngine.SetForce([]int{0, 0, 5})
+	forced, _ = forced.SetForceHeights([]int{2, 4, 3})
+	view, _ := forced.Spin()
+	if fmt.Sprint(view) != "[[1 2] [1 1 2 2] [1 6 5]]" {
+		t.Errorf("unexpected forced view %v", view)
+	}
+	// 1 way on the first reel, 2 on the second and 1 on the third
+	wins, payout := forced.DetermineWins(view)
+	if len(wins) != 2 || payout != 20 {
+		t.Errorf("unexpected ways wins %v payout %v", wins, payout)
+	}
+
+	// cascades refill the reels to the height they were spun with
+	gs := forced.Cascade(GameParams{Action: "cascade", previousGamestate: Gamestate{SymbolGrid: view, StopList: []int{0, 0, 5}, Prizes: wins}})
+	for r, reel := range gs.SymbolGrid {
+		if len(reel) != len(view[r]) {
+			t.Errorf("reel %v height %v after cascade, expected %v", r, len(reel), len(view[r]))
+		}
+	}
+}
+
 func compareNextActions(gs Gamestate, na []string) bool {
 	if len(gs.NextActions) != len(na) {
 		return false
@@ -269,7 +379,7 @@ func TestPrepareTransactions(t *testing.T) {
 func TestDetermineLineWins(t *testing.T) {
 	testGrid := [][]int{{1, 1, 1}, {1, 1, 1}, {1, 1, 1}, {1, 1, 1}, {1, 1, 1}}
 
-	wins := DetermineLineWins(testGrid, testWinLines, testPayouts, []wild{}, compounding_none, false)
+	wins := DetermineLineWins(nil, testGrid, testWinLines, testPayouts, []wild{}, compounding_none, false)
 	want := []Prize{{Payout: testPayouts[0], Index: "1:5", Multiplier: 1, SymbolPositions: []int{0, 3, 6, 9, 12}, Winline: 0}, {Payout: testPayouts[0], Index: "1:5", Multiplier: 1, SymbolPositions: []int{0, 4, 8, 10, 12}, Winline: 1}}
 	if wins[0].Winline != want[0].Winline || wins[1].Winline != want[1].Winline { // todo: add more criteria for pass
 		t.Errorf("first :\n %v \n %v \n second :\n %v \n %v", wins[0], want[0], wins[1], want[1])
@@ -277,21 +387,21 @@ func TestDetermineLineWins(t *testing.T) {
 
 	// test multiple wilds, highest multiplier only
 	testGrid = [][]int{{0, 1, 0}, {0, 7, 0}, {0, 8, 0}, {0, 1, 0}, {0, 1, 0}}
-	wins = DetermineLineWins(testGrid, [][]int{{1, 1, 1, 1, 1}}, testPayouts, testWilds, compounding_none, false)
+	wins = DetermineLineWins(nil, testGrid, [][]int{{1, 1, 1, 1, 1}}, testPayouts, testWilds, compounding_none, false)
 	if len(wins) != 1 || wins[0].Index != "1:5" || wins[0].Multiplier != 7 || wins[0].Winline != 0 {
 		t.Fail()
 	}
 
 	// test multiple wilds, highest multiplier only different order
 	testGrid = [][]int{{0, 1, 0}, {0, 8, 0}, {0, 7, 0}, {0, 1, 0}, {0, 1, 0}}
-	wins = DetermineLineWins(testGrid, [][]int{{1, 1, 1, 1, 1}}, testPayouts, testWilds, compounding_none, false)
+	wins = DetermineLineWins(nil, testGrid, [][]int{{1, 1, 1, 1, 1}}, testPayouts, testWilds, compounding_none, false)
 	if len(wins) != 1 || wins[0].Index != "1:5" || wins[0].Multiplier != 7 || wins[0].Winline != 0 {
 		t.Fail()
 	}
 
 	// test 5 wilds no prize set
 	testGrid = [][]int{{0, 7, 0}, {0, 7, 0}, {0, 7, 0}, {0, 7, 0}, {0, 7, 0}}
-	wins = DetermineLineWins(testGrid, [][]int{{1, 1, 1, 1, 1}}, testPayouts, testWilds, compounding_none, false)
+	wins = DetermineLineWins(nil, testGrid, [][]int{{1, 1, 1, 1, 1}}, testPayouts, testWilds, compounding_none, false)
 	if len(wins) != 0 {
 		// prize must be explicitly set
 		t.Fail()
@@ -299,7 +409,7 @@ func TestDetermineLineWins(t *testing.T) {
 
 	// test 5 wilds prize set
 	testGrid = [][]int{{0, 7, 0}, {0, 7, 0}, {0, 7, 0}, {0, 7, 0}, {0, 7, 0}}
-	wins = DetermineLineWins(testGrid, [][]int{{1, 1, 1, 1, 1}}, []Payout{{Symbol: 7, Count: 5, Multiplier: 10}}, testWilds, compounding_none, false)
+	wins = DetermineLineWins(nil, testGrid, [][]int{{1, 1, 1, 1, 1}}, []Payout{{Symbol: 7, Count: 5, Multiplier: 10}}, testWilds, compounding_none, false)
 	if len(wins) != 1 || wins[0].Index != "7:5" || wins[0].Multiplier != 1 {
 		// multiplier should not be counted
 		t.Fail()
@@ -307,7 +417,7 @@ func TestDetermineLineWins(t *testing.T) {
 
 	// test 4 wilds, only last symbol normal
 	testGrid = [][]int{{0, 7, 0}, {0, 7, 0}, {0, 7, 0}, {0, 7, 0}, {0, 1, 0}}
-	wins = DetermineLineWins(testGrid, [][]int{{1, 1, 1, 1, 1}}, testPayouts, testWilds, compounding_none, false)
+	wins = DetermineLineWins(nil, testGrid, [][]int{{1, 1, 1, 1, 1}}, testPayouts, testWilds, compounding_none, false)
 	if len(wins) != 1 || wins[0].Index != "1:5" || wins[0].Multiplier != 5 || wins[0].Winline != 0 {
 		t.Fail()
 	}
@@ -318,7 +428,7 @@ func TestDetermineLineWins(t *testing.T) {
 		Multiplier: weightedMultiplier{[]int{1, 2, 3, 4, 5, 6}, []int{1, 1, 1, 1, 1, 1}},
 	}}
 	testGrid = [][]int{{1, 0, 5}, {0, 1, 5}, {0, 1, 5}, {1, 0, 5}, {1, 1, 5}}
-	wins = DetermineLineWins(testGrid, [][]int{{0, 0, 0, 0, 0}, {1, 1, 1, 1, 1}}, testPayouts, wilds, compounding_none, false)
+	wins = DetermineLineWins(nil, testGrid, [][]int{{0, 0, 0, 0, 0}, {1, 1, 1, 1, 1}}, testPayouts, wilds, compounding_none, false)
 	if len(wins) != 2 || wins[0].Multiplier != wins[1].Multiplier {
 		t.Errorf("the wild multipliers were not properly stored between instances; %v, %v", wins[0], wins[1])
 		t.Fail()
@@ -328,7 +438,7 @@ func TestDetermineLineWins(t *testing.T) {
 func TestDeterminsWaysWinsNoWins(t *testing.T) {
 	//testGrid := [][]int{{1, 1, 1}, {1, 1, 1}, {3, 3, 3}, {1, 1, 1}, {1, 1, 1}}
 	testGrid := [][]int{{1, 1, 1}, {1, 1, 1}, {3, 3, 3}, {1, 1, 1}, {1, 1, 1}}
-	wins := DetermineWaysWins(testGrid, testWaysPayouts, []wild{})
+	wins := DetermineWaysWins(nil, testGrid, testWaysPayouts, []wild{})
 	// fmt.Println("wins = ", wins)
 	if len(wins) != 0 {
 		t.Errorf("wins = %v; want none", wins)
@@ -339,7 +449,7 @@ func TestDeterminsWaysWinsMultSameWins(t *testing.T) {
 	// testGrid := [][]int{{1, 1, 1}, {1, 3, 3}, {1, 3, 3}, {3, 3, 3}, {3, 3, 3}}
 	testGrid := [][]int{{1, 1, 1}, {1, 3, 3}, {1, 3, 3}, {3, 3, 3}, {3, 3, 3}}
 
-	wins := DetermineWaysWins(testGrid, testWaysPayouts, []wild{})
+	wins := DetermineWaysWins(nil, testGrid, testWaysPayouts, []wild{})
 	// fmt.Println("wins = ", wins)
 	want := Prize{Payout: testWaysPayouts[0], Index: "1:3", Multiplier: 1}
 	if len(wins) != 3 || wins[0].Index != want.Index || wins[1].Index != want.Index || wins[2].Index != want.Index {
@@ -351,7 +461,7 @@ func TestDeterminsWaysWinsThreeSymbolWins(t *testing.T) {
 	//testGrid := [][]int{{1, 3, 3}, {3, 3, 1}, {3, 1, 3}, {3, 3, 3}, {3, 3, 3}}
 	testGrid := [][]int{{1, 3, 3}, {3, 3, 1}, {3, 1, 3}, {3, 3, 3}, {3, 3, 3}}
 
-	wins := DetermineWaysWins(testGrid, testWaysPayouts, []wild{})
+	wins := DetermineWaysWins(nil, testGrid, testWaysPayouts, []wild{})
 	// fmt.Println("wins = ", wins)
 	want := Prize{Payout: testWaysPayouts[0], Index: "1:3", Multiplier: 1}
 	if len(wins) != 1 || wins[0].Index != want.Index {
@@ -363,7 +473,7 @@ func TestDeterminsWaysWinsDifferentSymbolWins(t *testing.T) {
 	// testGrid := [][]int{{1, 2, 3}, {2, 3, 1}, {2, 1, 3}, {2, 3, 3}, {3, 2, 3}}
 	testGrid := [][]int{{1, 2, 3}, {2, 3, 1}, {2, 1, 3}, {2, 3, 3}, {3, 2, 3}}
 
-	wins := DetermineWaysWins(testGrid, testWaysPayouts, []wild{})
+	wins := DetermineWaysWins(nil, testGrid, testWaysPayouts, []wild{})
 	// fmt.Println("wins = ", wins)
 	// wins should be ordered by symbol on first reel
 	want := []Prize{{Payout: testWaysPayouts[0], Index: "1:3", Multiplier: 1}, {Payout: testWaysPayouts[1], Index: "2:5", Multiplier: 1}}
@@ -372,6 +482,90 @@ func TestDeterminsWaysWinsDifferentSymbolWins(t *testing.T) {
 	}
 }
 
+var testClusterGrid = [][]int{{1, 1, 2}, {2, 1, 2}, {3, 8, 1}}
+var testClusterPayouts = []Payout{{Symbol: 1, Count: 3, Multiplier: 5}, {Symbol: 1, Count: 5, Multiplier: 20}, {Symbol: 2, Count: 3, Multiplier: 4}, {Symbol: 3, Count: 2, Multiplier: 1}}
+
+func winIndexes(wins []Prize) []string {
+	indexes := []string{}
+	for _, w := range wins {
+		indexes = append(indexes, w.Index)
+	}
+	return indexes
+}
+
+func TestDetermineClusterWins(t *testing.T) {
+	wilds := []wild{{Symbol: 8}}
+	tests := []struct {
+		conf ClusterConfiguration
+		want []string
+	}{
+		{ClusterConfiguration{}, []string{"1:5", "3:2"}},
+		{ClusterConfiguration{Wilds: clusterwilds_exclusive}, []string{"1:5"}},
+		{ClusterConfiguration{Wilds: clusterwilds_none}, []string{"1:3"}},
+		{ClusterConfiguration{Diagonal: true}, []string{"1:5", "2:3", "3:2"}},
+		{ClusterConfiguration{MinSize: 5}, []string{"1:5"}},
+	}
+	for _, test := range tests {
+		test.conf.Payouts = testClusterPayouts
+		wins := determineClusterWins(testClusterGrid, test.conf, wilds)
+		if fmt.Sprint(winIndexes(wins)) != fmt.Sprint(test.want) {
+			t.Errorf("%+v: wins %v, want %v", test.conf, winIndexes(wins), test.want)
+		}
+	}
+
+	wins := DetermineClusterWins(testClusterGrid, testClusterPayouts, wilds)
+	if len(wins) != 2 || fmt.Sprint(wins[0].SymbolPositions) != "[0 1 4 7 8]" || wins[0].Winline != -1 {
+		t.Errorf("unexpected cluster wins %v", wins)
+	}
+}
+
+func TestDetermineScatterWins(t *testing.T) {
+	wilds := []wild{{Symbol: 8}}
+	tests := []struct {
+		conf ClusterConfiguration
+		want []string
+	}{
+		{ClusterConfiguration{}, []string{"1:5", "2:3", "3:2"}},
+		{ClusterConfiguration{Wilds: clusterwilds_exclusive}, []string{"1:5", "2:3"}},
+		{ClusterConfiguration{Wilds: clusterwilds_none}, []string{"1:3", "2:3"}},
+		{ClusterConfiguration{MinSize: 4}, []string{"1:5", "2:3"}},
+	}
+	for _, test := range tests {
+		test.conf.Payouts = testClusterPayouts
+		wins := DetermineScatterWins(testClusterGrid, test.conf, wilds)
+		if fmt.Sprint(winIndexes(wins)) != fmt.Sprint(test.want) {
+			t.Errorf("%+v: wins %v, want %v", test.conf, winIndexes(wins), test.want)
+		}
+	}
+}
+
+func TestEngineDef_CascadeCluster(t *testing.T) {
+	engine := EngineDef{
+		Reels:         [][]int{{1, 2, 3, 4}, {4, 3, 2, 1}, {2, 2, 3, 3}},
+		ViewSize:      []int{3, 3, 3},
+		WinType:       "cluster",
+		Payouts:       []Payout{{Symbol: 9, Count: 3, Multiplier: 1}},
+		ClusterConfig: ClusterConfiguration{Payouts: testClusterPayouts},
+		Wilds:         []wild{{Symbol: 8}},
+	}
+	wins, payout := engine.DetermineWins(testClusterGrid)
+	if fmt.Sprint(winIndexes(wins)) != "[1:5 3:2]" || payout != 21 {
+		t.Fatalf("wins %v payout %v", winIndexes(wins), payout)
+	}
+	previous := Gamestate{
+		SymbolGrid:  [][]int{{1, 1, 2}, {2, 1, 2}, {3, 8, 1}},
+		Prizes:      wins,
+		StopList:    []int{1, 1, 1},
+		NextActions: []string{"cascade", "finish"},
+	}
+	gs := engine.Cascade(GameParams{previousGamestate: previous, Action: "cascade"})
+	// the winning positions are removed and the reels drop in new symbols from above
+	expected := [][]int{{4, 1, 2}, {4, 2, 2}, {3, 3, 2}}
+	if fmt.Sprint(gs.SymbolGrid) != fmt.Sprint(expected) {
+		t.Errorf("grid %v, expected %v", gs.SymbolGrid, expected)
+	}
+}
+
 // TEST DATATYPES
 
 func TestFixedToBytes(t *testing.T) {
@@ -681,7 +875,7 @@ func TestEngineConfig_DetectSpecialWins(t *testing.T) {
 }
 
 func TestDetermineBarLineWins(t *testing.T) {
-	//determineBarLineWins(symbolGrid [][]int, winLines [][]int, payouts []Payout, bars []bar, wilds []wild) []Prize {
+	//determineBarLineWins(nil, symbolGrid [][]int, winLines [][]int, payouts []Payout, bars []bar, wilds []wild) []Prize {
 	winLines := [][]int{{1, 1, 1}} // one win line
 	payouts := []Payout{
 		{1, 3, 10},
@@ -692,7 +886,7 @@ func TestDetermineBarLineWins(t *testing.T) {
 	symbolGrid := [][]int{{1, 1, 1}, {1, 1, 1}, {1, 1, 1}}
 
 	// test higher payout overrides lower
-	prizes := determineBarLineWins(symbolGrid, winLines, payouts, bars, []wild{}, false)
+	prizes := determineBarLineWins(nil, symbolGrid, winLines, payouts, bars, []wild{}, false)
 	if len(prizes) != 1 {
 		t.Errorf("Expected one win")
 	}
@@ -701,7 +895,7 @@ func TestDetermineBarLineWins(t *testing.T) {
 	}
 
 	bars = []bar{{3, []int{1, 4, 5}}} // less than line
-	prizes = determineBarLineWins(symbolGrid, winLines, payouts, bars, []wild{}, false)
+	prizes = determineBarLineWins(nil, symbolGrid, winLines, payouts, bars, []wild{}, false)
 	if len(prizes) != 1 {
 		t.Errorf("Expected one win")
 	}
@@ -711,7 +905,7 @@ func TestDetermineBarLineWins(t *testing.T) {
 
 	// test general symbol substitution
 	symbolGrid = [][]int{{1, 4, 1}, {1, 5, 0}, {1, 4, 0}}
-	prizes = determineBarLineWins(symbolGrid, winLines, payouts, bars, []wild{}, false)
+	prizes = determineBarLineWins(nil, symbolGrid, winLines, payouts, bars, []wild{}, false)
 	if len(prizes) != 1 {
 		t.Errorf("Expected one win")
 	}
@@ -721,7 +915,7 @@ func TestDetermineBarLineWins(t *testing.T) {
 
 	// test symbol substitution with normal payout symbol also present
 	symbolGrid = [][]int{{1, 1, 1}, {1, 5, 0}, {1, 4, 0}}
-	prizes = determineBarLineWins(symbolGrid, winLines, payouts, bars, []wild{}, false)
+	prizes = determineBarLineWins(nil, symbolGrid, winLines, payouts, bars, []wild{}, false)
 	if len(prizes) != 1 {
 		t.Errorf("Expected one win")
 	}
@@ -731,7 +925,7 @@ func TestDetermineBarLineWins(t *testing.T) {
 
 	// test symbol substitution with normal payout symbol also present
 	symbolGrid = [][]int{{1, 4, 1}, {1, 1, 0}, {1, 4, 0}}
-	prizes = determineBarLineWins(symbolGrid, winLines, payouts, bars, []wild{}, false)
+	prizes = determineBarLineWins(nil, symbolGrid, winLines, payouts, bars, []wild{}, false)
 	if len(prizes) != 1 {
 		t.Errorf("Expected one win")
 	}
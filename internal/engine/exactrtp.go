@@ -0,0 +1,217 @@
+package engine
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// ExactRTPResult is the outcome of enumerating every stop combination of the reels of an engine def
+type ExactRTPResult struct {
+	DefID        int                 `json:"defId"`
+	Function     string              `json:"function"`
+	WinType      string              `json:"winType"`
+	Combinations int64               `json:"combinations"`
+	RTP          float64             `json:"rtp"`               // return of one round relative to the stake, special payouts are included but not the features they trigger
+	HitFrequency float64             `json:"hitFrequency"`      // probability of a round with a payout
+	Multiplier   float64             `json:"multiplier"`        // expected round multiplier
+	Triggers     map[string]float64  `json:"triggers"`          // probability of each special payout by index
+	MaxWin       int                 `json:"maxWin,omitempty"`  // cap of the round win as a multiple of the stake, the rtp is of the capped payouts
+	CapRate      float64             `json:"capRate,omitempty"` // probability of a round whose payout reaches the cap
+	Distribution []PayoutProbability `json:"distribution"`
+}
+
+// PayoutProbability is one point of the payout distribution, payouts are in multiples of the stake before the round multiplier
+type PayoutProbability struct {
+	Payout      float64 `json:"payout"`
+	Count       int64   `json:"count"`
+	Probability float64 `json:"probability"`
+}
+
+type exactRTPCounts struct {
+	payouts  map[int]int64
+	triggers map[string]int64
+}
+
+func (c *exactRTPCounts) merge(o exactRTPCounts) {
+	for p, n := range o.payouts {
+		c.payouts[p] += n
+	}
+	for t, n := range o.triggers {
+		c.triggers[t] += n
+	}
+}
+
+// ExactRTP enumerates every stop combination of the reels and returns the exact return of the base round.
+// Only the win types with deterministic payouts are supported, wilds must have a single multiplier option.
+func (engine EngineDef) ExactRTP() (ExactRTPResult, rgse.RGSErr) {
+	return engine.ExactRTPWithMaxWin(0)
+}
+
+// ExactRTPWithMaxWin returns the exact return of the base round when the payout of a round is capped at
+// maxWinMultiplier times the stake, the round is not capped if it is zero. The cap of a round in a bet mode is a
+// multiple of its cost, see DefMaxWinMultiplier.
+func (engine EngineDef) ExactRTPWithMaxWin(maxWinMultiplier int) (ExactRTPResult, rgse.RGSErr) {
+	if len(engine.ScratchConfig.Prizes) > 0 {
+		// the tickets of a scratch card are counted from the odds of the prizes
+		result, err := engine.ScratchConfig.ExactRTP(maxWinMultiplier)
+		result.DefID = engine.Index
+		result.Function = engine.Function
+		return result, err
+	}
+	if engine.WinType != "lines" && engine.WinType != "ways" {
+		err := rgse.CreateWithoutException(rgse.GenericEngineError)
+		err.AppendErrorText(fmt.Sprintf("exact rtp is not supported for win type %v", engine.WinType))
+		return ExactRTPResult{}, err
+	}
+	if len(engine.Reels) == 0 || len(engine.Reels) != len(engine.ViewSize) {
+		err := rgse.CreateWithoutException(rgse.EngineConfigError)
+		err.AppendErrorText(fmt.Sprintf("engine def %v has %v reels and view size %v", engine.Index, len(engine.Reels), engine.ViewSize))
+		return ExactRTPResult{}, err
+	}
+	if len(engine.ReelHeights) > 0 {
+		err := rgse.CreateWithoutException(rgse.GenericEngineError)
+		err.AppendErrorText("exact rtp is not supported for variable reel heights")
+		return ExactRTPResult{}, err
+	}
+	for _, w := range engine.Wilds {
+		if len(w.Multiplier.Multipliers) > 1 {
+			err := rgse.CreateWithoutException(rgse.GenericEngineError)
+			err.AppendErrorText(fmt.Sprintf("wild %v has a random multiplier", w.Symbol))
+			return ExactRTPResult{}, err
+		}
+	}
+	_, engine = engine.ProcessWinLines(nil)
+	if engine.StakeDivisor == 0 {
+		err := rgse.CreateWithoutException(rgse.EngineConfigError)
+		err.AppendErrorText(fmt.Sprintf("engine def %v has no stake divisor", engine.Index))
+		return ExactRTPResult{}, err
+	}
+
+	// extend the reels with the symbols that wrap around so that every view is a plain slice of the reel
+	reels := make([][]int, len(engine.Reels))
+	combinations := int64(1)
+	for i, reel := range engine.Reels {
+		if len(reel) == 0 || len(reel) < engine.ViewSize[i] {
+			err := rgse.CreateWithoutException(rgse.EngineConfigError)
+			err.AppendErrorText(fmt.Sprintf("reel %v of engine def %v is shorter than the view", i, engine.Index))
+			return ExactRTPResult{}, err
+		}
+		reels[i] = append(append([]int{}, reel...), reel[:engine.ViewSize[i]]...)
+		combinations *= int64(len(reel))
+	}
+
+	// the stops of the first reel are shared out over the workers
+	workers := runtime.NumCPU()
+	results := make([]exactRTPCounts, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			results[w] = engine.enumerateStops(reels, w, workers)
+		}(w)
+	}
+	wg.Wait()
+	counts := exactRTPCounts{payouts: map[int]int64{}, triggers: map[string]int64{}}
+	for _, r := range results {
+		counts.merge(r)
+	}
+
+	multipliers, weights := []int{1}, []int{1}
+	if len(engine.Multiplier.Multipliers) > 0 {
+		multipliers, weights = engine.Multiplier.Multipliers, engine.Multiplier.Probabilities
+	}
+	var sum, totalWeight int
+	for i, m := range multipliers {
+		sum += m * weights[i]
+		totalWeight += weights[i]
+	}
+	multiplier := float64(sum) / float64(totalWeight)
+	// the cap applies to the payout after the round multiplier, in multiples of the bet per line
+	maxPayout := maxWinMultiplier * engine.StakeDivisor
+
+	result := ExactRTPResult{
+		DefID:        engine.Index,
+		Function:     engine.Function,
+		WinType:      engine.WinType,
+		Combinations: combinations,
+		Multiplier:   multiplier,
+		Triggers:     map[string]float64{},
+		MaxWin:       maxWinMultiplier,
+	}
+	var totalPayout, capped float64
+	var hits int64
+	for payout, n := range counts.payouts {
+		for i, m := range multipliers {
+			p := float64(n) * float64(weights[i]) / float64(totalWeight)
+			if maxPayout > 0 && payout > 0 && payout*m >= maxPayout {
+				totalPayout += float64(maxPayout) * p
+				capped += p
+			} else {
+				totalPayout += float64(payout*m) * p
+			}
+		}
+		if payout > 0 {
+			hits += n
+		}
+		result.Distribution = append(result.Distribution, PayoutProbability{
+			Payout:      float64(payout) / float64(engine.StakeDivisor),
+			Count:       n,
+			Probability: float64(n) / float64(combinations),
+		})
+	}
+	sort.Slice(result.Distribution, func(i, j int) bool { return result.Distribution[i].Payout < result.Distribution[j].Payout })
+	result.RTP = totalPayout / float64(combinations) / float64(engine.StakeDivisor)
+	result.CapRate = capped / float64(combinations)
+	result.HitFrequency = float64(hits) / float64(combinations)
+	for t, n := range counts.triggers {
+		result.Triggers[t] = float64(n) / float64(combinations)
+	}
+	logger.Infof("engine def %v: %v combinations, rtp %.6f, hit frequency %.6f", engine.Index, combinations, result.RTP, result.HitFrequency)
+	return result, nil
+}
+
+// enumerateStops counts the payouts of every stop combination whose first stop is worker modulo workers
+func (engine EngineDef) enumerateStops(reels [][]int, worker int, workers int) exactRTPCounts {
+	counts := exactRTPCounts{payouts: map[int]int64{}, triggers: map[string]int64{}}
+	if worker >= len(engine.Reels[0]) {
+		return counts
+	}
+	stopList := make([]int, len(reels))
+	stopList[0] = worker
+	symbolGrid := make([][]int, len(reels))
+	for {
+		for i := range reels {
+			symbolGrid[i] = reels[i][stopList[i] : stopList[i]+engine.ViewSize[i]]
+		}
+		_, relativePayout := engine.DetermineWins(symbolGrid)
+		specialWin := DetermineSpecialWins(symbolGrid, engine.SpecialPayouts)
+		if specialWin.Index != "" {
+			specialPayout, _ := engine.CalculatePayoutSpecialWin(&specialWin)
+			relativePayout += specialPayout
+			counts.triggers[specialWin.Index]++
+		}
+		counts.payouts[relativePayout]++
+
+		// advance the stops of the last reels like an odometer, the first reel moves by the number of workers
+		r := len(stopList) - 1
+		for ; r > 0; r-- {
+			stopList[r]++
+			if stopList[r] < len(engine.Reels[r]) {
+				break
+			}
+			stopList[r] = 0
+		}
+		if r == 0 {
+			stopList[0] += workers
+			if stopList[0] >= len(engine.Reels[0]) {
+				return counts
+			}
+		}
+	}
+}
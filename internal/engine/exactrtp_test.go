@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExactRTP(t *testing.T) {
+	ed := EngineDef{
+		Reels:          [][]int{{0, 1}, {0, 1}, {0, 1, 2}},
+		ViewSize:       []int{1, 1, 1},
+		WinLines:       [][]int{{0, 0, 0}},
+		Payouts:        []Payout{{Symbol: 0, Count: 3, Multiplier: 10}},
+		WinType:        "lines",
+		SpecialPayouts: []Prize{{Payout: Payout{Symbol: 1, Count: 3, Multiplier: 2}, Index: "freespin:5"}},
+		Multiplier:     weightedMultiplier{Multipliers: []int{1, 3}, Probabilities: []int{1, 1}},
+	}
+	result, err := ed.ExactRTP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Combinations != 12 {
+		t.Errorf("expected 12 combinations, got %v", result.Combinations)
+	}
+	// one combination pays 10, one pays the special payout of 2, each with expected multiplier 2
+	if math.Abs(result.RTP-(10.+2.)/12.*2.) > 1e-9 {
+		t.Errorf("unexpected rtp %v", result.RTP)
+	}
+	if math.Abs(result.HitFrequency-2./12.) > 1e-9 {
+		t.Errorf("unexpected hit frequency %v", result.HitFrequency)
+	}
+	if math.Abs(result.Triggers["freespin:5"]-1./12.) > 1e-9 {
+		t.Errorf("unexpected triggers %v", result.Triggers)
+	}
+	if len(result.Distribution) != 3 || result.Distribution[0].Count != 10 || result.Distribution[2].Payout != 10 {
+		t.Errorf("unexpected distribution %v", result.Distribution)
+	}
+
+	ed.WinType = "ways"
+	ed.WinLines = nil
+	if _, err := ed.ExactRTP(); err == nil {
+		t.Errorf("expected an error without a stake divisor")
+	}
+
+	ed.WinType = "cluster"
+	if _, err := ed.ExactRTP(); err == nil {
+		t.Errorf("expected an error for an unsupported win type")
+	}
+}
+
+func TestExactRTPMatchesBruteForce(t *testing.T) {
+	ed := EngineDef{
+		Reels:        testReels,
+		ViewSize:     testViewSize,
+		Payouts:      testWaysPayouts,
+		WinType:      "ways",
+		Wilds:        testWilds,
+		StakeDivisor: 50,
+	}
+	result, err := ed.ExactRTP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// brute force over the same stops through the regular grid construction
+	var total int
+	var combinations int
+	stops := make([]int, len(testReels))
+	var walk func(r int)
+	walk = func(r int) {
+		if r == len(testReels) {
+			_, payout := ed.DetermineWins(GetSymbolGridFromStopList(testReels, testViewSize, stops))
+			total += payout
+			combinations++
+			return
+		}
+		for s := range testReels[r] {
+			stops[r] = s
+			walk(r + 1)
+		}
+	}
+	walk(0)
+	expected := float64(total) / float64(combinations) / float64(ed.StakeDivisor)
+	if int64(combinations) != result.Combinations || math.Abs(result.RTP-expected) > 1e-9 {
+		t.Errorf("expected rtp %v over %v combinations, got %v over %v", expected, combinations, result.RTP, result.Combinations)
+	}
+}
@@ -0,0 +1,258 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// GambleAction is the action that gambles the win of the previous round, or of the previous gamble step, on the
+// gamble configured for the engine
+const GambleAction = "gamble"
+
+const (
+	gamblemode_colour = "colour" // guess the colour of a card, a win pays twice the stake
+	gamblemode_suit   = "suit"   // guess the suit of a card, a win pays four times the stake
+	gamblemode_ladder = "ladder" // climb a ladder, every step pays its own multiple of the stake
+)
+
+var gambleSuits = []string{"hearts", "diamonds", "clubs", "spades"}
+var gambleColours = map[string]string{"hearts": "red", "diamonds": "red", "clubs": "black", "spades": "black"}
+var gambleRanks = []string{"2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "Q", "K", "A"}
+
+// GambleConfiguration configures the gamble of round wins, it is set on the engine config under the key Gamble
+type GambleConfiguration struct {
+	Mode        string   `yaml:"mode"`        // colour, suit or ladder
+	MaxSteps    int      `yaml:"maxSteps"`    // number of times a win may be gambled in a row, unlimited if zero
+	MaxWin      int      `yaml:"maxWin"`      // a step is not offered if it could win more than this multiple of the round stake, unlimited if zero
+	RTP         float64  `yaml:"rtp"`         // return of each step, a fair gamble of 1.0 if not set
+	CollectHalf bool     `yaml:"collectHalf"` // the player may collect half the win and gamble the other half
+	Ladder      []int    `yaml:"ladder"`      // multiplier of each step in ladder mode
+	Actions     []string `yaml:"actions"`     // actions whose round wins may be gambled, any action if empty
+}
+
+// GambleState is the state of a gamble step
+type GambleState struct {
+	Step       int    `json:"step"`                // number of the step in the sequence of gambles of a win, the first step is 1
+	Stake      Fixed  `json:"stake"`               // amount wagered on the step
+	Collected  Fixed  `json:"collected,omitempty"` // part of the win collected before the step with the collect half option
+	Banked     Fixed  `json:"banked,omitempty"`    // amount collected on all steps of the sequence
+	Win        Fixed  `json:"win"`                 // amount won on the step, zero if the gamble was lost
+	RoundStake Fixed  `json:"roundStake"`          // total stake of the round whose win is gambled, the max win is relative to it
+	Choice     string `json:"choice,omitempty"`    // colour or suit chosen by the player
+	Card       string `json:"card,omitempty"`      // card drawn in colour and suit mode
+}
+
+// HasGamble reports whether round wins of the engine can be gambled
+func (config EngineConfig) HasGamble() bool {
+	return config.Gamble.Mode != ""
+}
+
+// Choices returns the choices the player can make on a gamble step, ladder steps have no choice
+func (gamble GambleConfiguration) Choices() []string {
+	switch gamble.Mode {
+	case gamblemode_colour:
+		return []string{"red", "black"}
+	case gamblemode_suit:
+		return gambleSuits
+	}
+	return []string{}
+}
+
+// Multiplier returns the multiple of the stake that a won step pays
+func (gamble GambleConfiguration) Multiplier(step int) int {
+	switch gamble.Mode {
+	case gamblemode_colour:
+		return 2
+	case gamblemode_suit:
+		return 4
+	case gamblemode_ladder:
+		if step < 1 || step > len(gamble.Ladder) {
+			return 0
+		}
+		return gamble.Ladder[step-1]
+	}
+	return 0
+}
+
+// WinProbability returns the probability of winning a step, the expected payout of the step is its rtp
+func (gamble GambleConfiguration) WinProbability(step int) float64 {
+	multiplier := gamble.Multiplier(step)
+	if multiplier <= 0 {
+		return 0
+	}
+	rtp := gamble.RTP
+	if rtp == 0 {
+		rtp = 1
+	}
+	return rtp / float64(multiplier)
+}
+
+func (gamble GambleConfiguration) maxSteps() int {
+	if gamble.Mode == gamblemode_ladder && (gamble.MaxSteps == 0 || gamble.MaxSteps > len(gamble.Ladder)) {
+		return len(gamble.Ladder)
+	}
+	return gamble.MaxSteps
+}
+
+// GambleOffer returns the gamble step that follows the gamestate, before the choice of the player and the draw.
+// An error is returned if the win of the gamestate can not be gambled.
+func (config EngineConfig) GambleOffer(previousGamestate Gamestate) (GambleState, rgse.RGSErr) {
+	offer := GambleState{Step: 1}
+	var reason string
+	switch {
+	case !config.HasGamble():
+		reason = "engine has no gamble"
+	case len(previousGamestate.NextActions) != 1 || previousGamestate.NextActions[0] != "finish" || previousGamestate.Closed:
+		reason = "the round is not finished or already closed"
+	case previousGamestate.Gamble != nil:
+		offer.Step = previousGamestate.Gamble.Step + 1
+		offer.Stake = previousGamestate.Gamble.Win
+		offer.Banked = previousGamestate.Gamble.Banked
+		offer.RoundStake = previousGamestate.Gamble.RoundStake
+	case len(config.Gamble.Actions) > 0 && !gambleActionAllowed(config.Gamble.Actions, previousGamestate.Action):
+		reason = fmt.Sprintf("wins of %v rounds can not be gambled", previousGamestate.Action)
+	default:
+		offer.Stake = previousGamestate.CumulativeWin
+		offer.RoundStake = previousGamestate.BetPerLine.Amount.Mul(NewFixedFromInt(config.EngineDefs[0].StakeDivisor))
+	}
+	if reason == "" {
+		maxSteps := config.Gamble.maxSteps()
+		switch {
+		case offer.Stake <= 0:
+			reason = "there is no win to gamble"
+		case maxSteps > 0 && offer.Step > maxSteps:
+			reason = fmt.Sprintf("the win was gambled %v times", maxSteps)
+		case config.Gamble.MaxWin > 0 && offer.Banked+offer.Stake.Mul(NewFixedFromInt(config.Gamble.Multiplier(offer.Step))) > offer.RoundStake.Mul(NewFixedFromInt(config.Gamble.MaxWin)):
+			reason = fmt.Sprintf("the gamble could win more than %v times the round stake", config.Gamble.MaxWin)
+		case previousGamestate.MaxWin > 0 && offer.Banked+offer.Stake.Mul(NewFixedFromInt(config.Gamble.Multiplier(offer.Step))) > previousGamestate.MaxWin:
+			reason = fmt.Sprintf("the gamble could win more than the max win %v of the round", previousGamestate.MaxWin)
+		}
+	}
+	if reason != "" {
+		err := rgse.Create(rgse.InvalidParamsError)
+		err.AppendErrorText(fmt.Sprintf("gamble not available: %v", reason))
+		return GambleState{}, err
+	}
+	return offer, nil
+}
+
+func gambleActionAllowed(actions []string, action string) bool {
+	for _, a := range actions {
+		if strings.HasPrefix(action, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// playGamble plays a step of the configured gamble, the wager of the step is the gambled amount
+func (config EngineConfig) playGamble(previousGamestate Gamestate, currency string, parameters GameParams) (Gamestate, EngineConfig, rgse.RGSErr) {
+	gamble, err := config.GambleOffer(previousGamestate)
+	if err != nil {
+		return Gamestate{}, EngineConfig{}, err
+	}
+	if parameters.GambleHalf {
+		if !config.Gamble.CollectHalf {
+			err = rgse.Create(rgse.InvalidParamsError)
+			err.AppendErrorText("gamble has no collect half option")
+			return Gamestate{}, EngineConfig{}, err
+		}
+		// the collected half is rounded up to the currency unit so that the stake stays payable
+		gamble.Collected = RoundUpToNearestCCYUnit(Money{gamble.Stake.Div(NewFixedFromInt(2)), currency}).Amount
+		if gamble.Collected >= gamble.Stake {
+			err = rgse.Create(rgse.InvalidParamsError)
+			err.AppendErrorText("win is too small to collect half")
+			return Gamestate{}, EngineConfig{}, err
+		}
+		gamble.Stake -= gamble.Collected
+		gamble.Banked += gamble.Collected
+	}
+
+	choices := config.Gamble.Choices()
+	valid := len(choices) == 0
+	for _, choice := range choices {
+		valid = valid || choice == parameters.Selection
+	}
+	if !valid {
+		err = rgse.Create(rgse.InvalidParamsError)
+		err.AppendErrorText(fmt.Sprintf("gamble choice %v is not one of %v", parameters.Selection, choices))
+		return Gamestate{}, EngineConfig{}, err
+	}
+	gamble.Choice = parameters.Selection
+
+	multiplier := config.Gamble.Multiplier(gamble.Step)
+	won := parameters.Rng.RandFromRange(int(fixedExp)) < int(config.Gamble.WinProbability(gamble.Step)*float64(fixedExp))
+	if len(choices) > 0 {
+		gamble.Card = config.Gamble.drawCard(parameters.Rng, gamble.Choice, won)
+	}
+	relativePayout := 0
+	if won {
+		relativePayout = multiplier
+		gamble.Win = gamble.Stake.Mul(NewFixedFromInt(multiplier))
+	}
+	logger.Debugf("gamble step %v staked %v on %v, drew %v and won %v", gamble.Step, gamble.Stake, gamble.Choice, gamble.Card, gamble.Win)
+
+	// the gamble is shown over the view of the round whose win is gambled
+	gamestate := Gamestate{
+		Action:         GambleAction,
+		DefID:          previousGamestate.DefID,
+		SymbolGrid:     previousGamestate.SymbolGrid,
+		StopList:       previousGamestate.StopList,
+		RelativePayout: relativePayout,
+		Multiplier:     1,
+		Gamble:         &gamble,
+	}
+	gamestate.PostProcess(previousGamestate, true, Money{gamble.Stake, currency}, config, gamble.Stake, []string{GambleAction, "finish"}, currency)
+	// the round win is what the player holds after the step, the collected part was paid with the previous win
+	gamestate.CumulativeWin = gamble.Banked + gamble.Win
+	return gamestate, config, nil
+}
+
+// drawCard draws a card that matches the choice if the step was won, and one that does not if it was lost
+func (gamble GambleConfiguration) drawCard(round *rng.Round, choice string, won bool) string {
+	suits := []string{}
+	for _, suit := range gambleSuits {
+		matches := suit == choice || gambleColours[suit] == choice
+		if matches == won {
+			suits = append(suits, suit)
+		}
+	}
+	suit := suits[round.RandFromRange(len(suits))]
+	return gambleRanks[round.RandFromRange(len(gambleRanks))] + strings.ToUpper(suit[:1])
+}
+
+func convertGambleFromPB(gamblePB *GamestatePB_Gamble) *GambleState {
+	if gamblePB == nil {
+		return nil
+	}
+	return &GambleState{
+		Step:       int(gamblePB.Step),
+		Stake:      Fixed(gamblePB.Stake),
+		Collected:  Fixed(gamblePB.Collected),
+		Banked:     Fixed(gamblePB.Banked),
+		Win:        Fixed(gamblePB.Win),
+		RoundStake: Fixed(gamblePB.RoundStake),
+		Choice:     gamblePB.Choice,
+		Card:       gamblePB.Card,
+	}
+}
+
+func convertGambleToPB(gamble *GambleState) *GamestatePB_Gamble {
+	if gamble == nil {
+		return nil
+	}
+	return &GamestatePB_Gamble{
+		Step:       int32(gamble.Step),
+		Stake:      gamble.Stake.ValueRaw(),
+		Collected:  gamble.Collected.ValueRaw(),
+		Banked:     gamble.Banked.ValueRaw(),
+		Win:        gamble.Win.ValueRaw(),
+		RoundStake: gamble.RoundStake.ValueRaw(),
+		Choice:     gamble.Choice,
+		Card:       gamble.Card,
+	}
+}
@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+)
+
+// playGambleWin plays base rounds of test-gamble until one wins
+func playGambleWin(t *testing.T) Gamestate {
+	previous := Gamestate{Game: "test-gamble", NextGamestate: "base", NextActions: []string{"finish"}}
+	for i := 0; i < 200; i++ {
+		gamestate, _, err := Play(previous, NewFixedFromInt(1), "USD", GameParams{Game: "test-gamble", Stake: NewFixedFromInt(1), Action: "base"})
+		if err != nil {
+			t.Fatalf("play: %v", err.Error())
+		}
+		if gamestate.CumulativeWin > 0 {
+			return gamestate
+		}
+		previous = gamestate
+	}
+	t.Fatalf("no winning base round")
+	return Gamestate{}
+}
+
+func TestGamble(t *testing.T) {
+	rng.Init()
+	EC := BuildEngineDefs("testGamble")
+	if !EC.HasGamble() || BuildEngineDefs("mvgEngineXVII").HasGamble() {
+		t.Fatalf("only testGamble has a configured gamble")
+	}
+
+	round := playGambleWin(t)
+	if round.CumulativeWin != NewFixedFromInt(38) {
+		t.Fatalf("round win %v, expected 38", round.CumulativeWin)
+	}
+	if _, _, err := Play(round, 0, "USD", GameParams{Game: "test-gamble", Action: GambleAction, Selection: "green"}); err == nil {
+		t.Errorf("expected an error for an invalid choice")
+	}
+
+	previous := round
+	for step := 1; step <= 2; step++ {
+		var gamestate Gamestate
+		// a lost step ends the gamble, play from the same state until the step is won
+		for i := 0; i < 100; i++ {
+			var err error
+			gamestate, _, err = Play(previous, 0, "USD", GameParams{Game: "test-gamble", Action: GambleAction, Selection: "red"})
+			if err != nil {
+				t.Fatalf("gamble: %v", err.Error())
+			}
+			gamble := gamestate.Gamble
+			if gamble == nil || gamble.Step != step || gamble.Stake != previous.CumulativeWin || gamble.RoundStake != NewFixedFromInt(5) {
+				t.Fatalf("unexpected gamble state %#v", gamble)
+			}
+			if len(gamestate.Transactions) != 2 || gamestate.Transactions[0].Type != "WAGER" || gamestate.Transactions[0].Amount.Amount != gamble.Stake ||
+				gamestate.Transactions[1].Type != "PAYOUT" || gamestate.Transactions[1].Amount.Amount != gamble.Win || gamestate.Transactions[0].Id == gamestate.Transactions[1].Id {
+				t.Fatalf("unexpected transactions %#v", gamestate.Transactions)
+			}
+			if gamestate.RoundID != round.RoundID || gamestate.CumulativeWin != gamble.Win || len(gamestate.NextActions) != 1 {
+				t.Errorf("gamble step in round %v with win %v and next actions %v", gamestate.RoundID, gamestate.CumulativeWin, gamestate.NextActions)
+			}
+			red := gamble.Card[1] == 'H' || gamble.Card[1] == 'D'
+			if red != (gamble.Win > 0) {
+				t.Errorf("card %v drawn for a win of %v on red", gamble.Card, gamble.Win)
+			}
+			if gamble.Win > 0 {
+				break
+			}
+			if _, err := EC.GambleOffer(gamestate); err == nil {
+				t.Errorf("gamble offered after a lost step")
+			}
+		}
+		if gamestate.Gamble.Win != previous.CumulativeWin.Mul(NewFixedFromInt(2)) {
+			t.Fatalf("step %v not won", step)
+		}
+		if decoded := gamestate.Convert().Convert(); decoded.Gamble == nil || *decoded.Gamble != *gamestate.Gamble || decoded.Action != GambleAction {
+			t.Errorf("gamble state lost in serialization: %#v", decoded.Gamble)
+		}
+		if _, diff, err := ReplayRound(previous, gamestate); err != nil || len(diff) != 0 {
+			t.Errorf("replayed gamble differs: %v %v", err, diff)
+		}
+		previous = gamestate
+	}
+
+	// a third step could win 304 line bets, more than 50 total stakes of 5 line bets
+	if _, err := EC.GambleOffer(previous); err == nil {
+		t.Errorf("gamble offered above the max win")
+	}
+
+	// collecting half banks 19 and gambles 19
+	gamestate, _, err := Play(round, 0, "USD", GameParams{Game: "test-gamble", Action: GambleAction, Selection: "black", GambleHalf: true})
+	if err != nil {
+		t.Fatalf("gamble: %v", err.Error())
+	}
+	if gamestate.Gamble.Collected != NewFixedFromInt(19) || gamestate.Gamble.Stake != NewFixedFromInt(19) || gamestate.CumulativeWin != gamestate.Gamble.Banked+gamestate.Gamble.Win {
+		t.Errorf("unexpected collect half state %#v with round win %v", gamestate.Gamble, gamestate.CumulativeWin)
+	}
+}
+
+func TestGambleWinProbability(t *testing.T) {
+	for _, gamble := range []GambleConfiguration{
+		{Mode: gamblemode_colour},
+		{Mode: gamblemode_suit, RTP: .96},
+		{Mode: gamblemode_ladder, Ladder: []int{2, 3, 5}, RTP: .9},
+	} {
+		rtp := gamble.RTP
+		if rtp == 0 {
+			rtp = 1
+		}
+		for step := 1; step <= 3; step++ {
+			if r := gamble.WinProbability(step) * float64(gamble.Multiplier(step)); math.Abs(r-rtp) > 1e-9 {
+				t.Errorf("%v step %v returns %v, expected %v", gamble.Mode, step, r, rtp)
+			}
+		}
+	}
+	ladder := GambleConfiguration{Mode: gamblemode_ladder, Ladder: []int{2, 3, 5}, MaxSteps: 10}
+	if ladder.maxSteps() != 3 || ladder.Multiplier(4) != 0 {
+		t.Errorf("ladder of 3 steps allows %v steps", ladder.maxSteps())
+	}
+}
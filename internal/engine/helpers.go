@@ -24,7 +24,7 @@ import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
-func SelectFromWeightedOptions(options []int, weights []int) int {
+func SelectFromWeightedOptions(round *rng.Round, options []int, weights []int) int {
 	// This method is used for multipliers, which are always integers
 	// Select from a list (options) with weights (weights)
 	if len(options) == 0 {
@@ -33,16 +33,16 @@ func SelectFromWeightedOptions(options []int, weights []int) int {
 		return options[0]
 	}
 
-	return options[GetWeightedIndex(weights)]
+	return options[GetWeightedIndex(round, weights)]
 }
 
-func GetWeightedIndex(weights []int) int {
-	// randomly selects an index given a list of weights
+func GetWeightedIndex(round *rng.Round, weights []int) int {
+	// randomly selects an index given a list of weights, drawn from the rng of the round
 	var weightsSum int
 	for _, weight := range weights {
 		weightsSum += weight
 	}
-	random := rng.RandFromRange(weightsSum) + 1 // number is in range [1,weightsSum], inclusive
+	random := round.RandFromRange(weightsSum) + 1 // number is in range [1,weightsSum], inclusive
 	var optionIndex int
 	for p := weights[0]; p < random; p += weights[optionIndex] {
 		optionIndex++
@@ -226,16 +226,24 @@ type GameParams struct {
 	Selection        string `json:"selectedFeature"`
 	RespinReel       int    `json:"respinReel"`
 	Action           string `json:"action"`
+	BetMode          string `json:"betMode"`    // bet mode of a new base round, the stake excludes the cost of the mode
+	GambleHalf       bool   `json:"gambleHalf"` // collect half the win and gamble the other half
 	Game             string `json:"game"`
 	Wallet           string `json:"wallet"`
 	PreviousID       string `json:"previousID"`
 	Force            string `json:"force"`
 	AutoClose        bool   `json:"autoClose"`
+	Autoplay         bool   `json:"autoplay"` // the round is started by the autoplay of the client
+	Turbo            bool   `json:"turbo"`    // the round is played in the turbo mode of the client
 	//	Replay            bool      `json:"replay"`
 	Replay            []Gamestate
 	ReplayTries       int
 	ReplayParams      feature.FeatureParams
-	previousGamestate Gamestate // this cannot be passed in
+	Rng               *rng.Round `json:"-"` // the rng of the round, a new round is seeded by Play when it is nil
+	MaxWinMultiplier  int        `json:"-"` // cap of the round win set by the operator or jurisdiction, it can only lower the cap of the engine
+	previousGamestate Gamestate  // this cannot be passed in
+	engineHash        string     // forces the engine config snapshot, used to replay rounds
+	maxWin            Fixed      // forces the max win of the round, used to replay rounds
 	//stopPostitions    []int     // this can also not be passed in from outside the package (only for testing)
 }
 
@@ -258,7 +266,7 @@ func (gp *GameParams) Decode(request *http.Request) (rgserr rgse.RGSErr) {
 }
 
 // if this is the action, a wager must be charged
-var paidActions = []string{"base", "maxBase", "respin", "gamble"}
+var paidActions = []string{"base", "maxBase", "respin", "gamble", BuyFeatureAction}
 
 func (p GameParams) Validate() (err rgse.RGSErr) {
 	if p.Game == "" || p.Action == "" {
@@ -358,7 +366,7 @@ func GetHashMd5(file io.Reader) (string, error) {
 }
 
 func randomRangeInt32(min, max int) int32 {
-	// cast to int32
+	// cast to int32, the gamification counters are not part of the outcome of the round and are drawn from the pool
 	return int32(rng.RandFromRange(max-min+1) + min)
 }
 
@@ -498,7 +506,7 @@ func GetDefaultView(gameName string) (symbolGrid [][]int, features []feature.Fea
 	if err != nil {
 		return
 	}
-	method, id, err := e.getEngineAndMethodInternal("init", false)
+	method, id, err := e.getEngineAndMethodInternal("init", false, nil)
 	if err == nil {
 		symbolGrid, features = GetDefaultViewFromFunction(method)
 		defId, reelsetId = id, e.EngineDefs[id].ReelsetId
@@ -576,13 +584,11 @@ func (gamestate Gamestate) GetChoices() (choices []string) {
 func (gamestate Gamestate) GetTtl() int64 {
 	// returns number of seconds a completed round should stay open depending on features
 	for _, action := range gamestate.NextActions {
-		if action == "base" || action == "finish" {
+		// any pending feature keeps the round open, the action needs no entry in the Action enum
+		if action == "" || action == "base" || action == "finish" {
 			continue
 		}
-		_, ok := GamestatePB_Action_value[action]
-		if ok {
-			return 3600 * 24 * 2
-		}
+		return 3600 * 24 * 2
 	}
 	return 3600
 }
@@ -0,0 +1,646 @@
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintDiagnostic describes one problem found in an engine config, Def is -1 for problems that concern the whole file
+type LintDiagnostic struct {
+	Engine   string `json:"engine"`
+	Def      int    `json:"def"`
+	Field    string `json:"field,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (d LintDiagnostic) String() string {
+	where := d.Engine
+	if d.Def >= 0 {
+		where = fmt.Sprintf("%v def %v", where, d.Def)
+	}
+	if d.Field != "" {
+		where = fmt.Sprintf("%v %v", where, d.Field)
+	}
+	return fmt.Sprintf("%v: %v: %v", d.Severity, where, d.Message)
+}
+
+// CountLintErrors returns the number of diagnostics with error severity
+func CountLintErrors(diagnostics []LintDiagnostic) int {
+	n := 0
+	for _, d := range diagnostics {
+		if d.Severity == LintError {
+			n++
+		}
+	}
+	return n
+}
+
+// win types handled by DetermineWins
+var lintWinTypes = []string{"", "none", "ways", "lines", "barLines", "blazeLines", "pAndF", "elysiumLines", "cluster", "scatterpays"}
+
+// win types that evaluate the WinLines
+var lintLineWinTypes = []string{"lines", "barLines", "blazeLines", "elysiumLines"}
+
+// round functions that build their own reels instead of spinning the configured ones
+var lintCustomReelFunctions = []string{"DynamicWildWaysRound"}
+
+func lintContainsInt(list []int, n int) bool {
+	for _, l := range list {
+		if l == n {
+			return true
+		}
+	}
+	return false
+}
+
+func lintContains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+type engineLinter struct {
+	engineID    string
+	category    string // engines with a category are played by their own game type rather than by the round functions
+	diagnostics []LintDiagnostic
+}
+
+// add records a diagnostic, problems that a def inherits are only reported for the first def that has them
+func (l *engineLinter) add(def int, field string, severity string, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	for _, d := range l.diagnostics {
+		if d.Def >= 0 && d.Field == field && d.Message == message {
+			return
+		}
+	}
+	l.diagnostics = append(l.diagnostics, LintDiagnostic{
+		Engine:   l.engineID,
+		Def:      def,
+		Field:    field,
+		Severity: severity,
+		Message:  message,
+	})
+}
+
+// LintEngineConfigs checks every file in internal/engine/engineConfigs and every engine referenced by the game config
+func LintEngineConfigs() []LintDiagnostic {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return []LintDiagnostic{{Def: -1, Severity: LintError, Message: fmt.Sprintf("failed opening current directory: %v", err)}}
+	}
+	files, err := ioutil.ReadDir(filepath.Join(currentDir, "internal/engine/engineConfigs"))
+	if err != nil {
+		return []LintDiagnostic{{Def: -1, Severity: LintError, Message: fmt.Sprintf("failed reading engine configs: %v", err)}}
+	}
+	engineIDs := map[string]bool{}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".yml" {
+			continue
+		}
+		engineIDs[strings.TrimSuffix(f.Name(), ".yml")] = true
+	}
+	for _, g := range config.GlobalGameConfig {
+		engineIDs[g.EngineID] = true
+	}
+	ids := make([]string, 0, len(engineIDs))
+	for id := range engineIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	diagnostics := []LintDiagnostic{}
+	for _, id := range ids {
+		diagnostics = append(diagnostics, LintEngineConfig(id)...)
+	}
+	return diagnostics
+}
+
+// LintEngineConfig checks the config file of one engine
+func LintEngineConfig(engineID string) []LintDiagnostic {
+	l := newEngineLinter(engineID)
+	currentDir, err := os.Getwd()
+	if err != nil {
+		l.add(-1, "", LintError, "failed opening current directory: %v", err)
+		return l.diagnostics
+	}
+	yamlFile, err := ioutil.ReadFile(engineConfigPath(currentDir, engineID))
+	if err != nil {
+		l.add(-1, "", LintError, "no config found: %v", err)
+		return l.diagnostics
+	}
+	l.lint(yamlFile)
+	return l.diagnostics
+}
+
+func newEngineLinter(engineID string) *engineLinter {
+	l := engineLinter{engineID: engineID, diagnostics: []LintDiagnostic{}}
+	for _, g := range config.GlobalGameConfig {
+		if g.EngineID == engineID {
+			l.category = g.Category
+		}
+	}
+	return &l
+}
+
+func (l *engineLinter) lint(yamlFile []byte) {
+	c := EngineConfig{}
+	err := yaml.Unmarshal(yamlFile, &c)
+	if err != nil {
+		l.add(-1, "", LintError, "%v", err)
+		return
+	}
+	// decode a second time to find keys that do not match any field
+	strict := yaml.NewDecoder(bytes.NewReader(yamlFile))
+	strict.KnownFields(true)
+	if err := strict.Decode(&EngineConfig{}); err != nil {
+		if typeErr, ok := err.(*yaml.TypeError); ok {
+			for _, e := range typeErr.Errors {
+				l.add(-1, "", LintWarning, "%v", e)
+			}
+		} else {
+			l.add(-1, "", LintWarning, "%v", err)
+		}
+	}
+
+	if len(c.EngineDefs) == 0 {
+		l.add(-1, "EngineDefs", LintError, "no engine defs")
+		return
+	}
+	inheritanceOk := true
+	for i, def := range c.EngineDefs {
+		switch def.Inheritance {
+		case "", inheritance_none, inheritance_first, inheritance_prev:
+		default:
+			l.add(i, "inheritance", LintError, "unrecognized inheritance mode %v", def.Inheritance)
+			inheritanceOk = false
+		}
+	}
+	if !inheritanceOk {
+		return
+	}
+	c = fillEngineDefs(c)
+	for i, def := range c.EngineDefs {
+		l.lintDef(i, def)
+	}
+	if defID := c.DefIdByName(BuyFeatureAction); defID >= 0 {
+		if _, err := c.BuyFeaturePrice(); err != nil {
+			l.add(defID, "BuyFeature", LintError, "%v", err.Error())
+		}
+	}
+	l.lintBetModes(c)
+	l.lintGamble(c)
+	l.lintMaxWin(c)
+}
+
+func (l *engineLinter) lintBetModes(c EngineConfig) {
+	names := map[string]bool{}
+	for _, mode := range c.BetModes {
+		if mode.Name == "" {
+			l.add(-1, "BetModes", LintError, "bet mode without a name")
+		} else if names[mode.Name] {
+			l.add(-1, "BetModes", LintError, "duplicate bet mode %v", mode.Name)
+		}
+		names[mode.Name] = true
+		if mode.Cost <= 0 {
+			l.add(-1, "BetModes", LintError, "bet mode %v has cost %v, it must be positive", mode.Name, mode.Cost)
+		}
+		if c.DefIdByName(mode.Def) < 0 {
+			l.add(-1, "BetModes", LintError, "bet mode %v plays on def %v which does not exist", mode.Name, mode.Def)
+		}
+	}
+}
+
+func (l *engineLinter) lintGamble(c EngineConfig) {
+	gamble := c.Gamble
+	switch gamble.Mode {
+	case "":
+		if gamble.MaxSteps != 0 || gamble.MaxWin != 0 || gamble.RTP != 0 || gamble.CollectHalf || len(gamble.Ladder) > 0 || len(gamble.Actions) > 0 {
+			l.add(-1, "Gamble", LintWarning, "gamble is configured without a mode and is not offered")
+		}
+		return
+	case gamblemode_colour, gamblemode_suit:
+		if len(gamble.Ladder) > 0 {
+			l.add(-1, "Gamble", LintWarning, "ladder is ignored in %v mode", gamble.Mode)
+		}
+	case gamblemode_ladder:
+		if len(gamble.Ladder) == 0 {
+			l.add(-1, "Gamble", LintError, "ladder mode without a ladder")
+		}
+		for step, multiplier := range gamble.Ladder {
+			if multiplier < 2 {
+				l.add(-1, "Gamble", LintError, "ladder step %v pays %v, a step must at least double the stake", step+1, multiplier)
+			}
+		}
+	default:
+		l.add(-1, "Gamble", LintError, "unrecognized gamble mode %v", gamble.Mode)
+	}
+	if gamble.MaxSteps < 0 || gamble.MaxWin < 0 {
+		l.add(-1, "Gamble", LintError, "maxSteps and maxWin must not be negative")
+	}
+	if gamble.RTP < 0 || gamble.RTP > 1 {
+		l.add(-1, "Gamble", LintError, "gamble rtp %v must lie between 0 and 1", gamble.RTP)
+	}
+	for _, action := range gamble.Actions {
+		if c.DefIdByName(action) < 0 {
+			l.add(-1, "Gamble", LintWarning, "gamble is offered after %v rounds but the engine has no def %v", action, action)
+		}
+	}
+	if c.DefIdByName(GambleAction) >= 0 || c.DefIdByName(GambleAction+"0") >= 0 {
+		l.add(-1, "Gamble", LintWarning, "the gamble config replaces the gamble defs of the engine")
+	}
+}
+
+func (l *engineLinter) lintMaxWin(c EngineConfig) {
+	if c.MaxWinMultiplier < 0 {
+		l.add(-1, "MaxWinMultiplier", LintError, "maxWinMultiplier must not be negative")
+	}
+	if c.MaxWinMultiplier > 0 && c.HasGamble() && c.Gamble.MaxWin > c.MaxWinMultiplier {
+		l.add(-1, "Gamble", LintWarning, "gamble maxWin %v is above the max win %v of the round and is never reached", c.Gamble.MaxWin, c.MaxWinMultiplier)
+	}
+}
+
+func (l *engineLinter) lintDef(i int, def EngineDef) {
+	if l.category == "" {
+		l.lintFunction(i, def)
+	}
+
+	if !lintContains(lintWinTypes, def.WinType) {
+		l.add(i, "WinType", LintError, "unknown win type %v", def.WinType)
+	}
+
+	if l.category == "" && !lintContains(lintCustomReelFunctions, def.Function) && len(def.Reels) != len(def.ViewSize) {
+		l.add(i, "Reels", LintError, "%v reels but view size has %v entries", len(def.Reels), len(def.ViewSize))
+	}
+	l.lintReelHeights(i, def)
+	symbols := map[int]bool{}
+	for r, reel := range def.Reels {
+		if r < len(def.ViewSize) && len(reel) < def.ViewSize[r] {
+			l.add(i, "Reels", LintError, "reel %v has %v symbols, fewer than the view size %v", r, len(reel), def.ViewSize[r])
+		}
+		for _, s := range reel {
+			symbols[s] = true
+		}
+	}
+
+	if !reflect.DeepEqual(def.CascadeConfig, CascadeConfiguration{}) {
+		l.lintCascadeConfig(i, def)
+		for _, strip := range def.CascadeConfig.RefillStrips {
+			for _, s := range strip {
+				symbols[s] = true
+			}
+		}
+		for _, s := range def.CascadeConfig.RefillSymbols {
+			symbols[s] = true
+		}
+	}
+
+	if def.WinType == "cluster" || def.WinType == "scatterpays" {
+		l.lintClusterConfig(i, def)
+	}
+
+	if !reflect.DeepEqual(def.RouletteConfig, RouletteConfiguration{}) {
+		l.lintRouletteConfig(i, def)
+	}
+	if def.BlackjackConfig != (BlackjackConfiguration{}) {
+		l.lintBlackjackConfig(i, def)
+	}
+	if !reflect.DeepEqual(def.ScratchConfig, ScratchConfiguration{}) {
+		l.lintScratchConfig(i, def)
+	}
+
+	lines := def.WinLines
+	if !lintContains(lintLineWinTypes, def.WinType) {
+		lines = nil
+	}
+	for w, line := range lines {
+		if len(line) != len(def.ViewSize) {
+			l.add(i, "WinLines", LintError, "win line %v has %v positions for %v reels", w, len(line), len(def.ViewSize))
+		}
+		for r, pos := range line {
+			if r < len(def.ViewSize) && (pos < 0 || pos >= def.ViewSize[r]) {
+				l.add(i, "WinLines", LintError, "win line %v position %v on reel %v is outside the view", w, pos, r)
+			}
+		}
+	}
+
+	// symbols may be placed on the grid by wilds and features, so payouts for symbols missing from the reels are only suspicious
+	if len(def.Reels) > 0 {
+		for _, w := range def.Wilds {
+			symbols[w.Symbol] = true
+		}
+		missing := map[int]bool{}
+		for _, p := range def.Payouts {
+			missing[p.Symbol] = missing[p.Symbol] || !symbols[p.Symbol]
+		}
+		for _, p := range def.SpecialPayouts {
+			missing[p.Payout.Symbol] = missing[p.Payout.Symbol] || !symbols[p.Payout.Symbol]
+		}
+		missingSymbols := []int{}
+		for s, m := range missing {
+			if m {
+				missingSymbols = append(missingSymbols, s)
+			}
+		}
+		sort.Ints(missingSymbols)
+		for _, s := range missingSymbols {
+			l.add(i, "Payouts", LintWarning, "payout for symbol %v which is not on any reel", s)
+		}
+	}
+
+	for _, w := range def.Wilds {
+		l.lintMultiplier(i, fmt.Sprintf("wilds[%v].multiplier", w.Symbol), w.Multiplier)
+	}
+	l.lintMultiplier(i, "multiplier", def.Multiplier)
+
+	l.lintFeatures(i, def.Features)
+}
+
+// lintFunction checks that the function is a round function, it is looked up by name when the def is played
+func (l *engineLinter) lintFunction(i int, def EngineDef) {
+	if def.Function == "" {
+		l.add(i, "function", LintError, "no function")
+		return
+	}
+	method, ok := reflect.TypeOf(def).MethodByName(def.Function)
+	if !ok {
+		l.add(i, "function", LintError, "unknown function %v", def.Function)
+		return
+	}
+	if method.Type.NumIn() != 2 || method.Type.In(1) != reflect.TypeOf(GameParams{}) ||
+		method.Type.NumOut() != 1 || method.Type.Out(0) != reflect.TypeOf(Gamestate{}) {
+		l.add(i, "function", LintError, "function %v is not a round function", def.Function)
+	}
+}
+
+func (l *engineLinter) lintReelHeights(i int, def EngineDef) {
+	if len(def.ReelHeights) == 0 {
+		return
+	}
+	if len(def.ReelHeights) != len(def.ViewSize) {
+		l.add(i, "ReelHeights", LintError, "%v reel heights but view size has %v entries", len(def.ReelHeights), len(def.ViewSize))
+	}
+	if lintContains(lintLineWinTypes, def.WinType) {
+		l.add(i, "ReelHeights", LintError, "variable reel heights are not supported by win type %v", def.WinType)
+	}
+	for r, h := range def.ReelHeights {
+		field := fmt.Sprintf("ReelHeights[%v]", r)
+		l.lintMultiplier(i, field, weightedMultiplier{Multipliers: h.Heights, Probabilities: h.Probabilities})
+		for _, height := range h.Heights {
+			if height < 1 || (r < len(def.ViewSize) && height > def.ViewSize[r]) {
+				l.add(i, field, LintError, "height %v is outside 1 to the view size", height)
+			}
+		}
+	}
+}
+
+func (l *engineLinter) lintClusterConfig(i int, def EngineDef) {
+	switch def.ClusterConfig.Wilds {
+	case "", clusterwilds_shared, clusterwilds_exclusive, clusterwilds_none:
+	default:
+		l.add(i, "ClusterConfig.Wilds", LintError, "unknown wild participation %v", def.ClusterConfig.Wilds)
+	}
+	if def.ClusterConfig.Diagonal && def.WinType == "scatterpays" {
+		l.add(i, "ClusterConfig.Diagonal", LintWarning, "adjacency has no effect on scatter pays")
+	}
+	payouts := def.clusterConfig().Payouts
+	if len(payouts) == 0 {
+		l.add(i, "Payouts", LintError, "no payouts for win type %v", def.WinType)
+	}
+	for _, p := range payouts {
+		if p.Count < def.ClusterConfig.MinSize {
+			l.add(i, "ClusterConfig.MinSize", LintWarning, "payout %v:%v is below the minimum size %v", p.Symbol, p.Count, def.ClusterConfig.MinSize)
+		}
+	}
+}
+
+func (l *engineLinter) lintBlackjackConfig(i int, def EngineDef) {
+	conf := def.BlackjackConfig
+	if conf.Decks < 0 || conf.Decks > blackjackMaxDecks {
+		l.add(i, "BlackjackConfig.Decks", LintError, "%v decks, a shoe holds 1 to %v", conf.Decks, blackjackMaxDecks)
+	}
+	if conf.MaxHands < 0 {
+		l.add(i, "BlackjackConfig.MaxHands", LintError, "max hands must not be negative")
+	}
+	if _, _, err := conf.BlackjackOdds(); err != nil {
+		l.add(i, "BlackjackConfig.BlackjackPays", LintError, "%v", err.Error())
+	}
+}
+
+func (l *engineLinter) lintScratchConfig(i int, def EngineDef) {
+	conf := def.ScratchConfig
+	switch conf.Mode {
+	case "", ScratchModeTable, ScratchModePool:
+	default:
+		l.add(i, "ScratchConfig.Mode", LintError, "unknown mode %v", conf.Mode)
+	}
+	switch conf.Exhaustion {
+	case "", ScratchPoolRefill, ScratchPoolClose:
+		if conf.Exhaustion != "" && !conf.PoolMode() {
+			l.add(i, "ScratchConfig.Exhaustion", LintWarning, "exhaustion has no effect on a prize table")
+		}
+	default:
+		l.add(i, "ScratchConfig.Exhaustion", LintError, "unknown exhaustion rule %v", conf.Exhaustion)
+	}
+
+	layout := conf.Layout
+	cells := layout.Rows * layout.Columns
+	match := conf.MatchCount()
+	if layout.Rows < 1 || layout.Columns < 1 {
+		l.add(i, "ScratchConfig.Layout", LintError, "layout of %v rows and %v columns has no cells", layout.Rows, layout.Columns)
+	} else if match < 2 || match > cells {
+		l.add(i, "ScratchConfig.Layout.Match", LintError, "a match of %v symbols does not fit %v cells", match, cells)
+	}
+
+	total := 0
+	losing, winning := false, false
+	symbols := map[int]int{}
+	for p, prize := range conf.Prizes {
+		if prize.Multiplier < 0 {
+			l.add(i, "ScratchConfig.Prizes", LintError, "prize %v has negative multiplier %v", p, prize.Multiplier)
+		}
+		if prize.Weight < 0 || prize.Count < 0 {
+			l.add(i, "ScratchConfig.Prizes", LintError, "prize %v has negative odds", p)
+			continue
+		}
+		odds := conf.Odds()[p]
+		total += odds
+		if prize.Multiplier <= 0 {
+			losing = losing || odds > 0
+			continue
+		}
+		winning = winning || odds > 0
+		if !lintContainsInt(layout.Symbols, prize.Symbol) {
+			l.add(i, "ScratchConfig.Prizes", LintError, "prize %v reveals symbol %v which is not in the layout", p, prize.Symbol)
+		}
+		if m, ok := symbols[prize.Symbol]; ok && m != prize.Multiplier {
+			l.add(i, "ScratchConfig.Prizes", LintError, "symbol %v reveals multipliers %v and %v", prize.Symbol, m, prize.Multiplier)
+		}
+		symbols[prize.Symbol] = prize.Multiplier
+	}
+	if total == 0 {
+		l.add(i, "ScratchConfig.Prizes", LintError, "the prizes of the %v have no odds", conf.modeName())
+	}
+
+	// the cells around a match show every other symbol fewer than match times
+	if cells > 0 && match >= 2 {
+		if losing && len(layout.Symbols)*(match-1) < cells {
+			l.add(i, "ScratchConfig.Layout.Symbols", LintError, "%v symbols cannot fill a losing ticket of %v cells", len(layout.Symbols), cells)
+		}
+		if winning && match+(len(layout.Symbols)-1)*(match-1) < cells {
+			l.add(i, "ScratchConfig.Layout.Symbols", LintError, "%v symbols cannot fill a winning ticket of %v cells", len(layout.Symbols), cells)
+		}
+	}
+}
+
+func (l *engineLinter) lintRouletteConfig(i int, def EngineDef) {
+	conf := def.RouletteConfig
+	wheel, ok := RouletteWheel(conf.WheelName())
+	if !ok {
+		l.add(i, "RouletteConfig.Wheel", LintError, "unknown wheel %v", conf.Wheel)
+		return
+	}
+	for r, reel := range def.Reels {
+		for _, s := range reel {
+			if !containsRouletteSymbol(wheel, s) {
+				l.add(i, "Reels", LintError, "reel %v has symbol %v which is not on the %v wheel", r, s, conf.WheelName())
+			}
+		}
+	}
+	switch conf.EvenMoney {
+	case "", RouletteLaPartage, RouletteEnPrison:
+	default:
+		l.add(i, "RouletteConfig.EvenMoney", LintError, "unknown even money rule %v", conf.EvenMoney)
+	}
+	if conf.Neighbours < 0 || 2*conf.NeighbourCount()+1 > len(wheel) {
+		l.add(i, "RouletteConfig.Neighbours", LintError, "%v neighbours do not fit on the wheel", conf.Neighbours)
+	}
+	for _, name := range conf.CallBets {
+		if name == RouletteNeighbours {
+			continue
+		}
+		chips, ok := RouletteCallBetChips(name, 0, wheel, conf.NeighbourCount())
+		if !ok {
+			l.add(i, "RouletteConfig.CallBets", LintError, "unknown call bet %v", name)
+			continue
+		}
+		if conf.WheelName() == RouletteWheelAmerican {
+			l.add(i, "RouletteConfig.CallBets", LintError, "call bet %v is only played on single zero wheels", name)
+			continue
+		}
+		for index := range chips {
+			if _, ok := def.RoulettePayouts[index]; !ok {
+				l.add(i, "RouletteConfig.CallBets", LintError, "call bet %v places bet %v which has no payout", name, index)
+			}
+		}
+	}
+	for betType, limit := range conf.Limits {
+		if !lintContains(RouletteBetTypes, betType) {
+			l.add(i, "RouletteConfig.Limits", LintError, "unknown bet type %v", betType)
+		}
+		if limit.Min < 0 || limit.Max < 0 {
+			l.add(i, "RouletteConfig.Limits", LintError, "limits of %v must not be negative", betType)
+		} else if limit.Max > 0 && limit.Min > limit.Max {
+			l.add(i, "RouletteConfig.Limits", LintError, "min %v of %v is above the max %v", limit.Min, betType, limit.Max)
+		}
+	}
+	if conf.History < 0 {
+		l.add(i, "RouletteConfig.History", LintError, "history must not be negative")
+	}
+}
+
+func (l *engineLinter) lintCascadeConfig(i int, def EngineDef) {
+	conf := def.CascadeConfig
+	if l.category == "" && def.Function != "Cascade" && def.Function != "CascadeMultiply" {
+		l.add(i, "CascadeConfig", LintWarning, "cascade config has no effect on function %v", def.Function)
+	}
+	switch conf.Refill {
+	case "", cascaderefill_reel:
+	case cascaderefill_strip:
+		if len(conf.RefillStrips) != len(def.ViewSize) {
+			l.add(i, "CascadeConfig.RefillStrips", LintError, "%v refill strips but view size has %v entries", len(conf.RefillStrips), len(def.ViewSize))
+		}
+		for r, strip := range conf.RefillStrips {
+			if len(strip) == 0 {
+				l.add(i, "CascadeConfig.RefillStrips", LintError, "refill strip %v is empty", r)
+			}
+		}
+	case cascaderefill_random:
+		if len(conf.RefillSymbols) == 0 {
+			l.add(i, "CascadeConfig.RefillSymbols", LintError, "no symbols for the random refill")
+		} else if len(conf.RefillSymbols) > 1 {
+			if len(conf.RefillWeights) != len(conf.RefillSymbols) {
+				l.add(i, "CascadeConfig.RefillWeights", LintError, "%v refill symbols but %v weights", len(conf.RefillSymbols), len(conf.RefillWeights))
+			}
+			sum := 0
+			for _, w := range conf.RefillWeights {
+				if w < 0 {
+					l.add(i, "CascadeConfig.RefillWeights", LintError, "negative weight %v", w)
+				}
+				sum += w
+			}
+			if sum <= 0 {
+				l.add(i, "CascadeConfig.RefillWeights", LintError, "weights sum to %v", sum)
+			}
+		}
+	default:
+		l.add(i, "CascadeConfig.Refill", LintError, "unknown refill %v", conf.Refill)
+	}
+	switch conf.Direction {
+	case "", cascadedirection_down, cascadedirection_up:
+	default:
+		l.add(i, "CascadeConfig.Direction", LintError, "unknown direction %v", conf.Direction)
+	}
+	for _, m := range conf.Multipliers {
+		if m < 1 {
+			l.add(i, "CascadeConfig.Multipliers", LintError, "multiplier %v is below 1", m)
+		}
+	}
+}
+
+func (l *engineLinter) lintMultiplier(i int, field string, m weightedMultiplier) {
+	// a single multiplier is selected without looking at the probabilities
+	if len(m.Multipliers) > 1 && len(m.Multipliers) != len(m.Probabilities) {
+		l.add(i, field, LintError, "%v multipliers but %v probabilities", len(m.Multipliers), len(m.Probabilities))
+		return
+	}
+	if len(m.Multipliers) > 1 {
+		sum := 0
+		for _, p := range m.Probabilities {
+			if p < 0 {
+				l.add(i, field, LintError, "negative probability %v", p)
+			}
+			sum += p
+		}
+		if sum <= 0 {
+			l.add(i, field, LintError, "probabilities sum to %v", sum)
+		}
+	}
+}
+
+func (l *engineLinter) lintFeatures(i int, defs []feature.FeatureDef) {
+	for _, f := range defs {
+		if feature.MakeFeature(f.Type) == nil {
+			l.add(i, "Features", LintError, "feature %v (id %v) is not registered", f.Type, f.Id)
+		}
+		l.lintFeatures(i, f.Features)
+	}
+}
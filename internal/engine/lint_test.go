@@ -0,0 +1,372 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+const lintTestConfig = `
+rtp: 0.95
+EngineDefs:
+  - name: base
+    function: BaseRound
+    WinType: lines
+    StakeDivisor: 2
+    Reels: [[0, 1, 2], [0, 1, 2], [0, 1, 2]]
+    ViewSize: [1, 1, 1]
+    WinLines: [[0, 0, 0], [0, 1, 0]]
+    Payouts:
+      - {Symbol: 0, Count: 3, Multiplier: 10}
+      - {Symbol: 9, Count: 3, Multiplier: 10}
+    wilds:
+      - symbol: 2
+        multiplier: {multipliers: [1, 2], probabilities: [1]}
+    Unknown: 1
+  - name: freespin
+    function: NoSuchRound
+    Reels: [[0, 1, 2], [0, 1, 2]]
+    Features:
+      - Type: NoSuchFeature
+        Id: 1
+  - name: other
+    inheritance: sideways
+`
+
+func lintMessages(diagnostics []LintDiagnostic, severity string) string {
+	messages := []string{}
+	for _, d := range diagnostics {
+		if d.Severity == severity {
+			messages = append(messages, d.String())
+		}
+	}
+	return strings.Join(messages, "\n")
+}
+
+func TestLintEngineConfig(t *testing.T) {
+	l := engineLinter{engineID: "test"}
+	l.lint([]byte(lintTestConfig))
+	errors := lintMessages(l.diagnostics, LintError)
+	if !strings.Contains(errors, "unrecognized inheritance mode sideways") {
+		t.Fatalf("expected an inheritance error, got:\n%v", errors)
+	}
+
+	// without the bad inheritance the defs are resolved and checked
+	l = engineLinter{engineID: "test"}
+	l.lint([]byte(strings.Replace(lintTestConfig, "sideways", "first", 1)))
+	errors = lintMessages(l.diagnostics, LintError)
+	warnings := lintMessages(l.diagnostics, LintWarning)
+	for _, expected := range []string{
+		"test def 0 WinLines: win line 1 position 1 on reel 1 is outside the view",
+		"test def 0 wilds[2].multiplier: 2 multipliers but 1 probabilities",
+		"test def 1 function: unknown function NoSuchRound",
+		"test def 1 Reels: 2 reels but view size has 3 entries",
+		"test def 1 Features: feature NoSuchFeature (id 1) is not registered",
+	} {
+		if !strings.Contains(errors, expected) {
+			t.Errorf("missing error %q in:\n%v", expected, errors)
+		}
+	}
+	if strings.Contains(errors, "test def 2") {
+		t.Errorf("unexpected errors for def 2:\n%v", errors)
+	}
+	for _, expected := range []string{
+		"field Unknown not found",
+		"test def 0 Payouts: payout for symbol 9 which is not on any reel",
+	} {
+		if !strings.Contains(warnings, expected) {
+			t.Errorf("missing warning %q in:\n%v", expected, warnings)
+		}
+	}
+	if CountLintErrors(l.diagnostics) != 5 {
+		t.Errorf("expected 5 errors, got:\n%v", errors)
+	}
+}
+
+func TestLintEngineConfigMissing(t *testing.T) {
+	diagnostics := LintEngineConfig("noSuchEngine")
+	if CountLintErrors(diagnostics) != 1 || !strings.Contains(diagnostics[0].Message, "no config found") {
+		t.Errorf("unexpected diagnostics %v", diagnostics)
+	}
+}
+
+func TestLintClusterConfig(t *testing.T) {
+	l := engineLinter{engineID: "test"}
+	l.lint([]byte(`
+EngineDefs:
+  - name: base
+    function: Cascade
+    WinType: cluster
+    Reels: [[0, 1], [0, 1]]
+    ViewSize: [2, 2]
+    ClusterConfig:
+      MinSize: 4
+      Wilds: sometimes
+      Payouts:
+        - {Symbol: 0, Count: 3, Multiplier: 10}
+`))
+	errors := lintMessages(l.diagnostics, LintError)
+	warnings := lintMessages(l.diagnostics, LintWarning)
+	if CountLintErrors(l.diagnostics) != 1 || !strings.Contains(errors, "unknown wild participation sometimes") {
+		t.Errorf("unexpected errors:\n%v", errors)
+	}
+	if !strings.Contains(warnings, "payout 0:3 is below the minimum size 4") {
+		t.Errorf("missing minimum size warning in:\n%v", warnings)
+	}
+}
+
+func TestLintBetModes(t *testing.T) {
+	l := engineLinter{engineID: "test"}
+	l.lint([]byte(`
+EngineDefs:
+  - name: base
+    function: BaseRound
+    WinType: ways
+    StakeDivisor: 5
+    Reels: [[0, 1], [0, 1]]
+    ViewSize: [1, 1]
+    Payouts:
+      - {Symbol: 0, Count: 2, Multiplier: 10}
+  - name: ante
+BetModes:
+  - {name: ante, cost: 1.25, def: ante}
+  - {name: ante, cost: 0, def: base}
+  - {name: super, cost: 2, def: superBase}
+`))
+	errors := lintMessages(l.diagnostics, LintError)
+	for _, expected := range []string{
+		"duplicate bet mode ante",
+		"bet mode ante has cost 0, it must be positive",
+		"bet mode super plays on def superBase which does not exist",
+	} {
+		if !strings.Contains(errors, expected) {
+			t.Errorf("missing error %q in:\n%v", expected, errors)
+		}
+	}
+	if CountLintErrors(l.diagnostics) != 3 {
+		t.Errorf("expected 3 errors, got:\n%v", errors)
+	}
+}
+
+func TestLintCascadeConfig(t *testing.T) {
+	l := engineLinter{engineID: "test"}
+	l.lint([]byte(`
+EngineDefs:
+  - name: base
+    function: Cascade
+    WinType: ways
+    StakeDivisor: 5
+    Reels: [[0, 1], [0, 1]]
+    ViewSize: [1, 1]
+    Payouts:
+      - {Symbol: 0, Count: 2, Multiplier: 10}
+      - {Symbol: 5, Count: 2, Multiplier: 10}
+    CascadeConfig:
+      Refill: strip
+      RefillStrips: [[5, 1]]
+      Direction: sideways
+      Multipliers: [1, 0]
+  - name: freespin
+    function: BaseRound
+`))
+	errors := lintMessages(l.diagnostics, LintError)
+	warnings := lintMessages(l.diagnostics, LintWarning)
+	for _, expected := range []string{
+		"test def 0 CascadeConfig.RefillStrips: 1 refill strips but view size has 2 entries",
+		"test def 0 CascadeConfig.Direction: unknown direction sideways",
+		"test def 0 CascadeConfig.Multipliers: multiplier 0 is below 1",
+	} {
+		if !strings.Contains(errors, expected) {
+			t.Errorf("missing error %q in:\n%v", expected, errors)
+		}
+	}
+	if CountLintErrors(l.diagnostics) != 3 {
+		t.Errorf("expected 3 errors, got:\n%v", errors)
+	}
+	// symbols of the refill strips are on the reels, the inherited config does not apply to the freespin function
+	if strings.Contains(warnings, "symbol 5") || !strings.Contains(warnings, "test def 1 CascadeConfig: cascade config has no effect on function BaseRound") {
+		t.Errorf("unexpected warnings:\n%v", warnings)
+	}
+}
+
+func TestLintGamble(t *testing.T) {
+	l := engineLinter{engineID: "test"}
+	l.lint([]byte(`
+EngineDefs:
+  - name: base
+    function: BaseRound
+    WinType: ways
+    StakeDivisor: 5
+    Reels: [[0, 1], [0, 1]]
+    ViewSize: [1, 1]
+    Payouts:
+      - {Symbol: 0, Count: 2, Multiplier: 10}
+Gamble:
+  mode: ladder
+  ladder: [2, 1, 3]
+  rtp: 1.2
+  actions: [freespin]
+`))
+	errors := lintMessages(l.diagnostics, LintError)
+	for _, expected := range []string{
+		"ladder step 2 pays 1, a step must at least double the stake",
+		"gamble rtp 1.2 must lie between 0 and 1",
+	} {
+		if !strings.Contains(errors, expected) {
+			t.Errorf("missing error %q in:\n%v", expected, errors)
+		}
+	}
+	if CountLintErrors(l.diagnostics) != 2 {
+		t.Errorf("expected 2 errors, got:\n%v", errors)
+	}
+	if warnings := lintMessages(l.diagnostics, LintWarning); !strings.Contains(warnings, "gamble is offered after freespin rounds but the engine has no def freespin") {
+		t.Errorf("missing warning for the freespin action in:\n%v", warnings)
+	}
+}
+
+func TestLintMaxWin(t *testing.T) {
+	l := engineLinter{engineID: "test"}
+	l.lint([]byte(`
+maxWinMultiplier: -5
+EngineDefs:
+  - name: base
+    function: BaseRound
+    WinType: ways
+    StakeDivisor: 5
+    Reels: [[0, 1], [0, 1]]
+    ViewSize: [1, 1]
+    Payouts:
+      - {Symbol: 0, Count: 2, Multiplier: 10}
+`))
+	if errors := lintMessages(l.diagnostics, LintError); CountLintErrors(l.diagnostics) != 1 || !strings.Contains(errors, "maxWinMultiplier must not be negative") {
+		t.Errorf("expected the negative max win error, got:\n%v", errors)
+	}
+
+	l = engineLinter{engineID: "test"}
+	l.lint([]byte(`
+maxWinMultiplier: 20
+EngineDefs:
+  - name: base
+    function: BaseRound
+    WinType: ways
+    StakeDivisor: 5
+    Reels: [[0, 1], [0, 1]]
+    ViewSize: [1, 1]
+    Payouts:
+      - {Symbol: 0, Count: 2, Multiplier: 10}
+Gamble:
+  mode: colour
+  maxWin: 50
+`))
+	if warnings := lintMessages(l.diagnostics, LintWarning); !strings.Contains(warnings, "gamble maxWin 50 is above the max win 20 of the round") {
+		t.Errorf("missing warning for the gamble max win in:\n%v", warnings)
+	}
+}
+
+func TestLintRouletteConfig(t *testing.T) {
+	l := engineLinter{engineID: "test", category: "roulette"}
+	l.lint([]byte(`
+EngineDefs:
+  - name: base
+    function: RouletteRound
+    RouletteConfig:
+      Wheel: american
+      EvenMoney: surrender
+      CallBets: [voisins, neighbours, snake]
+      Neighbours: 20
+      Limits:
+        straight: {Min: 10, Max: 5}
+        sixline: {Max: 5}
+  - name: european
+    Reels: [[0, 1, 37]]
+    RoulettePayouts:
+      red: {Multiplier: 2, Symbols: [1]}
+    RouletteConfig:
+      Wheel: european
+      CallBets: [tiers]
+`))
+	errors := lintMessages(l.diagnostics, LintError)
+	for _, expected := range []string{
+		"test def 0 RouletteConfig.EvenMoney: unknown even money rule surrender",
+		"test def 0 RouletteConfig.CallBets: call bet voisins is only played on single zero wheels",
+		"test def 0 RouletteConfig.CallBets: unknown call bet snake",
+		"test def 0 RouletteConfig.Neighbours: 20 neighbours do not fit on the wheel",
+		"test def 0 RouletteConfig.Limits: min 10 of straight is above the max 5",
+		"test def 0 RouletteConfig.Limits: unknown bet type sixline",
+		"test def 1 Reels: reel 0 has symbol 37 which is not on the european wheel",
+		"test def 1 RouletteConfig.CallBets: call bet tiers places bet split5-8 which has no payout",
+	} {
+		if !strings.Contains(errors, expected) {
+			t.Errorf("missing error %q in:\n%v", expected, errors)
+		}
+	}
+
+	// the roulette engine of the games lints clean
+	if diagnostics := LintEngineConfig("mvgEngineRoulette1"); CountLintErrors(diagnostics) != 0 {
+		t.Errorf("unexpected errors:\n%v", lintMessages(diagnostics, LintError))
+	}
+}
+
+func TestLintBlackjackConfig(t *testing.T) {
+	l := engineLinter{engineID: "test", category: "blackjack"}
+	l.lint([]byte(`
+EngineDefs:
+  - name: base
+    BlackjackConfig:
+      Decks: 12
+      BlackjackPays: "3 to 2"
+      MaxHands: -1
+`))
+	errors := lintMessages(l.diagnostics, LintError)
+	for _, expected := range []string{
+		"test def 0 BlackjackConfig.Decks: 12 decks, a shoe holds 1 to 8",
+		"test def 0 BlackjackConfig.BlackjackPays: blackjack pays 3 to 2 is not of the form 3:2",
+		"test def 0 BlackjackConfig.MaxHands: max hands must not be negative",
+	} {
+		if !strings.Contains(errors, expected) {
+			t.Errorf("missing error %q in:\n%v", expected, errors)
+		}
+	}
+
+	if diagnostics := LintEngineConfig("mvgEngineBlackjack1"); CountLintErrors(diagnostics) != 0 {
+		t.Errorf("unexpected errors:\n%v", lintMessages(diagnostics, LintError))
+	}
+}
+
+func TestLintScratchConfig(t *testing.T) {
+	l := engineLinter{engineID: "test", category: "scratch"}
+	l.lint([]byte(`
+EngineDefs:
+  - name: base
+    ScratchConfig:
+      Mode: draw
+      Exhaustion: stop
+      Layout:
+        Rows: 3
+        Columns: 3
+        Symbols: [1, 2, 3]
+      Prizes:
+        - {Multiplier: 10, Symbol: 1, Weight: 10}
+        - {Multiplier: 5, Symbol: 1, Weight: 20}
+        - {Multiplier: 2, Symbol: 7, Weight: 30}
+        - {Multiplier: -1, Weight: 40}
+`))
+	errors := lintMessages(l.diagnostics, LintError)
+	for _, expected := range []string{
+		"test def 0 ScratchConfig.Mode: unknown mode draw",
+		"test def 0 ScratchConfig.Exhaustion: unknown exhaustion rule stop",
+		"test def 0 ScratchConfig.Prizes: symbol 1 reveals multipliers 10 and 5",
+		"test def 0 ScratchConfig.Prizes: prize 2 reveals symbol 7 which is not in the layout",
+		"test def 0 ScratchConfig.Prizes: prize 3 has negative multiplier -1",
+		"test def 0 ScratchConfig.Layout.Symbols: 3 symbols cannot fill a losing ticket of 9 cells",
+	} {
+		if !strings.Contains(errors, expected) {
+			t.Errorf("missing error %q in:\n%v", expected, errors)
+		}
+	}
+
+	for _, engineID := range []string{"mvgEngineScratch1", "mvgEngineScratch2"} {
+		if diagnostics := LintEngineConfig(engineID); CountLintErrors(diagnostics) != 0 {
+			t.Errorf("unexpected errors in %v:\n%v", engineID, lintMessages(diagnostics, LintError))
+		}
+	}
+}
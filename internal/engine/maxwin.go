@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"strings"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// WithMaxWinMultiplier returns the config with the cap of the round win lowered to the cap of the operator or
+// jurisdiction. The cap of the engine is part of its certified rtp, so an override can not raise it.
+func (config EngineConfig) WithMaxWinMultiplier(multiplier int) EngineConfig {
+	if multiplier > 0 && (config.MaxWinMultiplier == 0 || multiplier < config.MaxWinMultiplier) {
+		config.MaxWinMultiplier = multiplier
+	}
+	return config
+}
+
+// MaxWin returns the cap of the win of a round that wagered the amount, zero if the round win is not capped. The cap is
+// a multiple of the wager of the round, so a round in a bet mode or a bought feature is capped relative to its cost.
+func (config EngineConfig) MaxWin(wager Fixed) Fixed {
+	if config.MaxWinMultiplier <= 0 {
+		return 0
+	}
+	return wager.Mul(NewFixedFromInt(config.MaxWinMultiplier))
+}
+
+// DefMaxWinMultiplier returns the cap of the rounds played on the def as a multiple of the total stake, the rounds of
+// a bet mode are capped relative to its cost
+func (config EngineConfig) DefMaxWinMultiplier(defID int) int {
+	for _, mode := range config.BetModes {
+		// the first def is played by the rounds without a bet mode
+		if defID > 0 && config.DefIdByName(mode.Def) == defID {
+			return int(float64(config.MaxWinMultiplier) * mode.Cost)
+		}
+	}
+	return config.MaxWinMultiplier
+}
+
+// setMaxWin fixes the max win of a gamestate that starts a round, the gamestates that continue the round keep it
+func (gamestate *Gamestate) setMaxWin(previousGamestate Gamestate, chargeWager bool, engineConf EngineConfig) {
+	if !chargeWager || gamestate.Action == "respin" || strings.Contains(gamestate.Action, "gamble") {
+		gamestate.MaxWin = previousGamestate.MaxWin
+		return
+	}
+	if gamestate.MaxWin == 0 && len(gamestate.Transactions) > 0 && gamestate.Transactions[0].Type == "WAGER" {
+		gamestate.MaxWin = engineConf.MaxWin(gamestate.Transactions[0].Amount.Amount)
+	}
+}
+
+// capWin truncates the win of the gamestate so that the round win does not exceed the max win of the round. The
+// round is finished once the cap is reached, the actions that are still queued are dropped.
+func (gamestate *Gamestate) capWin(roundWin Fixed, win Fixed) Fixed {
+	if gamestate.MaxWin <= 0 || roundWin+win < gamestate.MaxWin || strings.Contains(gamestate.Action, "gamble") {
+		// gamble steps are not offered if they could exceed the max win
+		return win
+	}
+	logger.Debugf("round win %v capped at %v, dropping actions %v", roundWin+win, gamestate.MaxWin, gamestate.NextActions)
+	gamestate.Capped = true
+	gamestate.NextActions = []string{"finish"}
+	if roundWin >= gamestate.MaxWin {
+		return 0
+	}
+	return gamestate.MaxWin - roundWin
+}
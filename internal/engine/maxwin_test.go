@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+)
+
+// playMaxWinRound plays rounds of test-max-win until the predicate holds for a base round, the previous gamestate is
+// returned with it
+func playMaxWinRound(t *testing.T, params GameParams, found func(Gamestate) bool) (Gamestate, Gamestate) {
+	previous := Gamestate{Game: "test-max-win", NextGamestate: "base", NextActions: []string{"finish"}}
+	params.Game = "test-max-win"
+	params.Action = "base"
+	for i := 0; i < 200; i++ {
+		gamestate, _, err := Play(previous, NewFixedFromInt(1), "USD", params)
+		if err != nil {
+			t.Fatalf("play: %v", err.Error())
+		}
+		if gamestate.Action == "base" && found(gamestate) {
+			return previous, gamestate
+		}
+		previous = gamestate
+	}
+	t.Fatalf("no matching base round")
+	return Gamestate{}, Gamestate{}
+}
+
+func TestMaxWin(t *testing.T) {
+	rng.Init()
+	_, gamestate := playMaxWinRound(t, GameParams{}, func(gs Gamestate) bool { return len(gs.NextActions) > 1 })
+	if gamestate.MaxWin != NewFixedFromInt(100) || gamestate.Capped {
+		t.Fatalf("round started with max win %v and capped %v", gamestate.MaxWin, gamestate.Capped)
+	}
+
+	// the freespins pay 40 each, the third reaches the cap of 100 and ends the round
+	for i, expected := range []int{40, 40, 20} {
+		previous := gamestate
+		var err error
+		gamestate, _, err = Play(previous, 0, "USD", GameParams{Game: "test-max-win", Action: previous.NextActions[0]})
+		if err != nil {
+			t.Fatalf("play: %v", err.Error())
+		}
+		if win, _ := GetCurrentWinAndStake(gamestate); win != NewFixedFromInt(expected) {
+			t.Errorf("freespin %v paid %v, expected %v", i+1, win, expected)
+		}
+		if gamestate.MaxWin != previous.MaxWin || gamestate.RoundID != previous.RoundID {
+			t.Errorf("freespin %v of round %v has max win %v", i+1, gamestate.RoundID, gamestate.MaxWin)
+		}
+		if _, diff, err := ReplayRound(previous, gamestate); err != nil || len(diff) != 0 {
+			t.Errorf("replayed freespin differs: %v %v", err, diff)
+		}
+	}
+	if !gamestate.Capped || gamestate.CumulativeWin != gamestate.MaxWin || len(gamestate.NextActions) != 1 || gamestate.NextActions[0] != "finish" {
+		t.Errorf("round win %v capped %v with next actions %v", gamestate.CumulativeWin, gamestate.Capped, gamestate.NextActions)
+	}
+	if decoded := gamestate.Convert().Convert(); !decoded.Capped || decoded.MaxWin != gamestate.MaxWin {
+		t.Errorf("max win lost in serialization: %v %v", decoded.Capped, decoded.MaxWin)
+	}
+}
+
+func TestMaxWinOverride(t *testing.T) {
+	rng.Init()
+	// the operator cap of 5 total stakes truncates the base win of 40 line bets to 25
+	previous, gamestate := playMaxWinRound(t, GameParams{MaxWinMultiplier: 5}, func(gs Gamestate) bool { return gs.RelativePayout > 0 })
+	if !gamestate.Capped || gamestate.MaxWin != NewFixedFromInt(25) || gamestate.CumulativeWin != NewFixedFromInt(25) {
+		t.Errorf("base round with max win %v capped %v won %v", gamestate.MaxWin, gamestate.Capped, gamestate.CumulativeWin)
+	}
+	if len(gamestate.Transactions) != 2 || gamestate.Transactions[1].Amount.Amount != NewFixedFromInt(25) {
+		t.Errorf("unexpected transactions %#v", gamestate.Transactions)
+	}
+	if _, diff, err := ReplayRound(previous, gamestate); err != nil || len(diff) != 0 {
+		t.Errorf("replayed capped round differs: %v %v", err, diff)
+	}
+
+	// an override can not raise the cap of the engine
+	_, gamestate = playMaxWinRound(t, GameParams{MaxWinMultiplier: 40}, func(gs Gamestate) bool { return true })
+	if gamestate.MaxWin != NewFixedFromInt(100) {
+		t.Errorf("max win %v raised above the engine cap", gamestate.MaxWin)
+	}
+	EC := BuildEngineDefs("testMaxWin")
+	if EC.WithMaxWinMultiplier(0).MaxWinMultiplier != 20 || EC.WithMaxWinMultiplier(10).MaxWinMultiplier != 10 || BuildEngineDefs("testGamble").WithMaxWinMultiplier(30).MaxWinMultiplier != 30 {
+		t.Errorf("unexpected max win multipliers")
+	}
+}
+
+func TestMaxWinBetMode(t *testing.T) {
+	rng.Init()
+	// the cap of 10 wagers of an ante round is 10 times its cost of 1.25 total stakes
+	previous := Gamestate{Game: "test-ante-bet", NextGamestate: "ante", NextActions: []string{"finish"}}
+	params := GameParams{Game: "test-ante-bet", Stake: NewFixedFromInt(1), Action: "base", BetMode: "ante", MaxWinMultiplier: 10}
+	gamestate, _, err := Play(previous, NewFixedFromInt(1), "USD", params)
+	if err != nil {
+		t.Fatalf("play: %v", err.Error())
+	}
+	if gamestate.MaxWin != NewFixedFromFloat64(62.5) {
+		t.Errorf("ante round with a wager of %v has max win %v", gamestate.Transactions[0].Amount.Amount, gamestate.MaxWin)
+	}
+	EC := BuildEngineDefs("testAnteBet").WithMaxWinMultiplier(10)
+	if EC.DefMaxWinMultiplier(EC.DefIdByName("base")) != 10 || EC.DefMaxWinMultiplier(EC.DefIdByName("ante")) != 12 {
+		t.Errorf("unexpected max win multipliers of the defs")
+	}
+}
+
+func TestMaxWinGamble(t *testing.T) {
+	rng.Init()
+	// a cap of 10 total stakes is 50 line bets, the win of 38 can not be doubled
+	previous := Gamestate{Game: "test-gamble", NextGamestate: "base", NextActions: []string{"finish"}}
+	for i := 0; i < 200; i++ {
+		gamestate, EC, err := Play(previous, NewFixedFromInt(1), "USD", GameParams{Game: "test-gamble", Stake: NewFixedFromInt(1), Action: "base", MaxWinMultiplier: 10})
+		if err != nil {
+			t.Fatalf("play: %v", err.Error())
+		}
+		if gamestate.CumulativeWin > 0 {
+			if gamestate.Capped || gamestate.MaxWin != NewFixedFromInt(50) {
+				t.Errorf("round win %v capped %v at %v", gamestate.CumulativeWin, gamestate.Capped, gamestate.MaxWin)
+			}
+			if _, err := EC.GambleOffer(gamestate); err == nil {
+				t.Errorf("gamble offered above the max win of the round")
+			}
+			return
+		}
+		previous = gamestate
+	}
+	t.Errorf("no winning base round")
+}
+
+func TestExactRTPWithMaxWin(t *testing.T) {
+	ed := BuildEngineDefs("testMaxWin").EngineDefs[0]
+	for _, c := range []struct {
+		maxWin  int
+		rtp     float64
+		capRate float64
+	}{
+		{0, 1, 0},
+		{20, 1, 0},
+		{8, 1, 0.125}, // a win of exactly the cap is capped
+		{5, 0.625, 0.125},
+	} {
+		result, err := ed.ExactRTPWithMaxWin(c.maxWin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(result.RTP-c.rtp) > 1e-9 || math.Abs(result.CapRate-c.capRate) > 1e-9 || result.MaxWin != c.maxWin {
+			t.Errorf("max win %v: rtp %v cap rate %v, expected %v and %v", c.maxWin, result.RTP, result.CapRate, c.rtp, c.capRate)
+		}
+	}
+}
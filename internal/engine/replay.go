@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	rgserror "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// ReplayRound re-executes the play that produced the stored gamestate using the rng seed recorded in it.
+// If the previous gamestate is unknown, pass an empty Gamestate and the play is replayed as a new round.
+// Returns the regenerated gamestate and a description of every outcome field that differs from the stored one.
+func ReplayRound(previousGamestate Gamestate, storedGamestate Gamestate) (Gamestate, []string, rgserror.RGSErr) {
+	if len(storedGamestate.RngSeed) == 0 {
+		err := rgserror.Create(rgserror.InvalidParamsError)
+		err.AppendErrorText(fmt.Sprintf(": gamestate %s has no rng seed", storedGamestate.Id))
+		return Gamestate{}, nil, err
+	}
+	if previousGamestate.Id == "" && previousGamestate.Game == "" {
+		previousGamestate = Gamestate{
+			Id:            storedGamestate.PreviousGamestate,
+			Game:          storedGamestate.Game,
+			BetPerLine:    storedGamestate.BetPerLine,
+			NextGamestate: storedGamestate.Id,
+			NextActions:   []string{"finish"},
+			RoundID:       storedGamestate.PreviousGamestate,
+		}
+	}
+	parameters := replayParameters(previousGamestate, storedGamestate)
+	logger.Debugf("replaying gamestate %s with parameters %#v", storedGamestate.Id, parameters)
+
+	replayed, _, err := PlayWithSeed(previousGamestate, parameters.Stake, storedGamestate.BetPerLine.Currency, parameters, storedGamestate.RngSeed)
+	if err != nil {
+		return Gamestate{}, nil, err
+	}
+	return replayed, DiffGamestates(storedGamestate, replayed), nil
+}
+
+func replayParameters(previousGamestate Gamestate, storedGamestate Gamestate) GameParams {
+	parameters := GameParams{
+		Game:             storedGamestate.Game,
+		Stake:            storedGamestate.BetPerLine.Amount,
+		Action:           storedGamestate.Action,
+		BetMode:          storedGamestate.BetMode, // a round that continues keeps the bet mode of its previous gamestate
+		SelectedWinLines: storedGamestate.SelectedWinLines,
+		PreviousID:       previousGamestate.Id,
+		RespinReel:       -1,
+		engineHash:       storedGamestate.EngineHash,
+		maxWin:           storedGamestate.MaxWin,
+	}
+	if storedGamestate.Gamble != nil {
+		// a step of the configured gamble, the choice of the player is kept in its state
+		parameters.Selection = storedGamestate.Gamble.Choice
+		parameters.GambleHalf = storedGamestate.Gamble.Collected > 0
+	} else if strings.HasPrefix(storedGamestate.Action, "gamble") {
+		// the gamble index is appended to the action during play
+		parameters.Action = "gamble"
+		parameters.RespinReel, _ = strconv.Atoi(strings.TrimPrefix(storedGamestate.Action, "gamble"))
+	}
+	return parameters
+}
+
+// DiffGamestates lists the fields of actual that differ from expected. Identifiers that are generated for
+// every play and the fields that are set by the api after the engine has played are not compared.
+func DiffGamestates(expected Gamestate, actual Gamestate) []string {
+	normalize := func(gamestate Gamestate) Gamestate {
+		gamestate.NextGamestate = ""
+		gamestate.Closed = false
+		gamestate.CampaignWin = 0
+		gamestate.CampaignRef = ""
+		gamestate.Replay = false
+		gamestate.ReplayParams = nil
+		transactions := make([]WalletTransaction, len(gamestate.Transactions))
+		for i, tx := range gamestate.Transactions {
+			tx.Id = ""
+			transactions[i] = tx
+		}
+		gamestate.Transactions = transactions
+		return gamestate
+	}
+	e, a := reflect.ValueOf(normalize(expected)), reflect.ValueOf(normalize(actual))
+
+	diff := []string{}
+	for i := 0; i < e.NumField(); i++ {
+		ejson, _ := json.Marshal(e.Field(i).Interface())
+		ajson, _ := json.Marshal(a.Field(i).Interface())
+		if emptyJson(ejson) && emptyJson(ajson) {
+			continue
+		}
+		if string(ejson) != string(ajson) {
+			diff = append(diff, fmt.Sprintf("%s: expected %s got %s", e.Type().Field(i).Name, ejson, ajson))
+		}
+	}
+	return diff
+}
+
+func emptyJson(b []byte) bool {
+	switch string(b) {
+	case "null", "[]", "{}", "\"\"", "0", "false":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+func TestReplayRound(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	wd, _ := os.Getwd()
+	// engine and game configs are read relative to the repository root
+	for dir := wd; dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "config", "gameConfig.yml")); err == nil {
+			os.Chdir(dir)
+			break
+		}
+	}
+	defer os.Chdir(wd)
+	if err := config.InitGameConfig(); err != nil {
+		t.Fatalf("game config: %v", err)
+	}
+	rng.Init()
+
+	previous := Gamestate{
+		Id:            "prev",
+		Game:          "the-year-of-zhu",
+		NextGamestate: "played",
+		NextActions:   []string{"finish"},
+		BetPerLine:    Money{NewFixedFromInt(1), "USD"},
+	}
+	params := GameParams{Game: "the-year-of-zhu", Stake: NewFixedFromInt(1), Action: "base"}
+
+	for i := 0; i < 20; i++ {
+		played, _, err := Play(previous, params.Stake, "USD", params)
+		if err != nil {
+			t.Fatalf("play: %v", err.Error())
+		}
+		if len(played.RngSeed) == 0 {
+			t.Fatalf("no rng seed recorded")
+		}
+		replayed, diff, err := ReplayRound(previous, played)
+		if err != nil {
+			t.Fatalf("replay: %v", err.Error())
+		}
+		if len(diff) != 0 {
+			t.Errorf("replay differs from played gamestate: %v", diff)
+		}
+		if replayed.Id != played.Id {
+			t.Errorf("replayed id %s does not match %s", replayed.Id, played.Id)
+		}
+	}
+}
+
+func TestReplayBetMode(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	rng.Init()
+	previous := Gamestate{Id: "prev", Game: "test-ante-bet", NextGamestate: "ante", NextActions: []string{"finish"}}
+	params := GameParams{Game: "test-ante-bet", Stake: NewFixedFromInt(1), Action: "base", BetMode: "ante"}
+	played, _, err := PlayWithSeed(previous, params.Stake, "USD", params, betModeFreespinSeed)
+	if err != nil {
+		t.Fatalf("play: %v", err.Error())
+	}
+	freespin, _, err := Play(played, params.Stake, "USD", GameParams{Game: "test-ante-bet", Action: "freespin"})
+	if err != nil {
+		t.Fatalf("play: %v", err.Error())
+	}
+
+	// the round is replayed in its bet mode, with the previous gamestate and as a new round
+	for _, prev := range []Gamestate{previous, {}} {
+		replayed, diff, err := ReplayRound(prev, played)
+		if err != nil {
+			t.Fatalf("replay: %v", err.Error())
+		}
+		if len(diff) != 0 || replayed.BetMode != "ante" {
+			t.Errorf("replay in mode %v differs from played gamestate: %v", replayed.BetMode, diff)
+		}
+	}
+	if _, diff, err := ReplayRound(played, freespin); err != nil || len(diff) != 0 {
+		t.Errorf("freespin replay differs from played gamestate: %v %v", diff, err)
+	}
+}
+
+func TestDiffGamestates(t *testing.T) {
+	a := Gamestate{
+		Id:            "a",
+		NextGamestate: "next-a",
+		StopList:      []int{1, 2, 3},
+		Transactions:  []WalletTransaction{{Id: "tx-a", Amount: Money{NewFixedFromInt(1), "USD"}, Type: "WAGER"}},
+	}
+	b := a
+	b.NextGamestate = "next-b"
+	b.Transactions = []WalletTransaction{{Id: "tx-b", Amount: Money{NewFixedFromInt(1), "USD"}, Type: "WAGER"}}
+	b.Prizes = []Prize{}
+	if diff := DiffGamestates(a, b); len(diff) != 0 {
+		t.Errorf("expected no differences, got %v", diff)
+	}
+	b.StopList = []int{1, 2, 4}
+	if diff := DiffGamestates(a, b); len(diff) != 1 {
+		t.Errorf("expected a stop list difference, got %v", diff)
+	}
+}
@@ -56,13 +56,14 @@ func (gamestate Gamestate) ExpectedReelValue(reelIndex int) Fixed {
 
 		view[reelIndex] = reel[i : i+viewSize]
 		// calculate win
+		// the price is not part of the outcome of a round, wild multipliers are drawn from the pool
 		var wins []Prize
 
 		switch def.WinType {
 		case "ways":
-			wins = DetermineWaysWins(view, def.Payouts, def.Wilds)
+			wins = DetermineWaysWins(nil, view, def.Payouts, def.Wilds)
 		case "lines":
-			wins = DetermineLineWins(view, def.WinLines, def.Payouts, def.Wilds, compounding, false)
+			wins = DetermineLineWins(nil, view, def.WinLines, def.Payouts, def.Wilds, compounding, false)
 		}
 		for _, win := range wins {
 			// add win amount (multipliers are relative to betPerLine)
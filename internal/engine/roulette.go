@@ -2,6 +2,9 @@ package engine
 
 import (
 	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
 
 	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
@@ -16,6 +19,9 @@ type GameStateRoulette struct {
 	Bets     map[string]BetRoulette `json:"bets"`
 	Bet      Fixed                  `json:"bet"`
 	Win      Fixed                  `json:"win"`
+	CallBets []RouletteCallBet      `json:"callBets,omitempty"` // the call bets as they were placed, Bets holds the bets they were placed on
+	Prison   map[string]BetRoulette `json:"prison,omitempty"`   // the even money bets held en prison for the next spin
+	History  []int                  `json:"history,omitempty"`  // the results of the last rounds, the latest last
 }
 
 func (g *GameStateRoulette) Base() *GameStateV3 {
@@ -49,4 +55,354 @@ type BetRoulette struct {
 type PrizeRoulette struct {
 	Index  string `json:"index"`
 	Amount Fixed  `json:"amount"`
+	Rule   string `json:"rule,omitempty"` // laPartage or enPrison if the bet was settled by the rule of the even money bets
+}
+
+const (
+	RouletteWheelEuropean = "european"
+	RouletteWheelFrench   = "french"
+	RouletteWheelAmerican = "american"
+
+	// rules for the even money bets when a zero wins
+	RouletteLaPartage = "laPartage" // half of the bet is returned
+	RouletteEnPrison  = "enPrison"  // the bet is held for the next spin and returned if it wins then
+
+	RouletteVoisins    = "voisins"
+	RouletteTiers      = "tiers"
+	RouletteOrphelins  = "orphelins"
+	RouletteJeuZero    = "jeuzero"
+	RouletteNeighbours = "neighbours"
+
+	// the symbol of the double zero of the american wheel
+	RouletteDoubleZero = 37
+
+	// the neighbours on each side of the number of a neighbours bet if the engine does not set them
+	rouletteDefaultNeighbours = 2
+	// the number of hot and of cold numbers of the statistics
+	rouletteHotColdNumbers = 5
+)
+
+// the numbers of the wheels in their order on the wheel, the french wheel is the european one
+var rouletteWheels = map[string][]int{
+	RouletteWheelEuropean: {0, 32, 15, 19, 4, 21, 2, 25, 17, 34, 6, 27, 13, 36, 11, 30, 8, 23, 10, 5, 24, 16, 33, 1, 20, 14, 31, 9, 22, 18, 29, 7, 28, 12, 35, 3, 26},
+	RouletteWheelAmerican: {0, 28, 9, 26, 30, 11, 7, 20, 32, 17, 5, 22, 34, 15, 3, 24, 36, 13, 1, 37, 27, 10, 25, 29, 12, 8, 19, 31, 18, 6, 21, 33, 16, 4, 23, 35, 14, 2},
+}
+
+// the bets of the call bets on the single zero wheels and the chips that each bet takes
+var rouletteCallBets = map[string]map[string]int{
+	RouletteVoisins: {
+		"trio0-2-3": 2, "split4-7": 1, "split12-15": 1, "split18-21": 1, "split19-22": 1, "split32-35": 1, "corner25-26-28-29": 2,
+	},
+	RouletteTiers: {
+		"split5-8": 1, "split10-11": 1, "split13-16": 1, "split23-24": 1, "split27-30": 1, "split33-36": 1,
+	},
+	RouletteOrphelins: {
+		"1": 1, "split6-9": 1, "split14-17": 1, "split17-20": 1, "split31-34": 1,
+	},
+	RouletteJeuZero: {
+		"split0-3": 1, "split12-15": 1, "split32-35": 1, "26": 1,
+	},
+}
+
+var rouletteRed = []int{1, 3, 5, 7, 9, 12, 14, 16, 18, 19, 21, 23, 25, 27, 30, 32, 34, 36}
+
+// RouletteConfiguration holds the table rules of a roulette engine def, a def without them plays its reel and
+// payouts as they are configured
+type RouletteConfiguration struct {
+	Wheel      string                   `yaml:"Wheel"`      // european, french or american, a def without reels or payouts gets those of the wheel
+	EvenMoney  string                   `yaml:"EvenMoney"`  // laPartage or enPrison, the french wheel plays la partage if not set
+	CallBets   []string                 `yaml:"CallBets"`   // the call bets offered, voisins, tiers, orphelins, jeuzero and neighbours
+	Neighbours int                      `yaml:"Neighbours"` // the neighbours on each side of the number of a neighbours bet, 2 if not set
+	Limits     map[string]RouletteLimit `yaml:"Limits"`     // the limits of one bet by bet type, see RouletteBetType
+	History    int                      `yaml:"History"`    // the number of results kept for the hot and cold numbers, none if zero
+}
+
+// RouletteLimit limits the stake of one bet in multiples of the smallest stake value of the currency
+type RouletteLimit struct {
+	Min int `yaml:"Min"`
+	Max int `yaml:"Max"`
+}
+
+// RouletteCallBet is a call bet as it is placed, the amount is the stake of one chip
+type RouletteCallBet struct {
+	Name   string `json:"name"`
+	Number int    `json:"number,omitempty"` // the number of a neighbours bet
+	Amount Fixed  `json:"amount"`
+}
+
+// RouletteStats are the hot and cold numbers of the results kept in the gamestate
+type RouletteStats struct {
+	Hot    []int `json:"hot"`
+	Cold   []int `json:"cold"`
+	Rounds int   `json:"rounds"`
+}
+
+func (c RouletteConfiguration) WheelName() string {
+	if c.Wheel == "" {
+		return RouletteWheelEuropean
+	}
+	return c.Wheel
+}
+
+// EvenMoneyRule returns the rule of the even money bets when a zero wins, an empty rule loses the bets
+func (c RouletteConfiguration) EvenMoneyRule() string {
+	if c.EvenMoney == "" && c.Wheel == RouletteWheelFrench {
+		return RouletteLaPartage
+	}
+	return c.EvenMoney
+}
+
+func (c RouletteConfiguration) Zeros() []int {
+	if c.Wheel == RouletteWheelAmerican {
+		return []int{0, RouletteDoubleZero}
+	}
+	return []int{0}
+}
+
+func (c RouletteConfiguration) IsZero(symbol int) bool {
+	return containsRouletteSymbol(c.Zeros(), symbol)
+}
+
+func (c RouletteConfiguration) NeighbourCount() int {
+	if c.Neighbours == 0 {
+		return rouletteDefaultNeighbours
+	}
+	return c.Neighbours
+}
+
+func (c RouletteConfiguration) OffersCallBet(name string) bool {
+	for _, b := range c.CallBets {
+		if b == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEvenMoney reports whether the payout is an even money bet, red, black, even, odd, low or high
+func (c RouletteConfiguration) IsEvenMoney(payout RoulettePayout) bool {
+	if payout.Multiplier != 2 {
+		return false
+	}
+	for _, s := range payout.Symbols {
+		if c.IsZero(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// RouletteWheel returns the numbers of the wheel in their order on the wheel
+func RouletteWheel(wheel string) ([]int, bool) {
+	if wheel == RouletteWheelFrench {
+		wheel = RouletteWheelEuropean
+	}
+	numbers, ok := rouletteWheels[wheel]
+	if !ok {
+		return nil, false
+	}
+	return append([]int{}, numbers...), true
+}
+
+// RouletteSymbolName returns the name of the number on the table, the double zero is 00
+func RouletteSymbolName(symbol int) string {
+	if symbol == RouletteDoubleZero {
+		return "00"
+	}
+	return strconv.Itoa(symbol)
+}
+
+func rouletteBetName(prefix string, symbols ...int) string {
+	names := make([]string, len(symbols))
+	for i, s := range symbols {
+		names[i] = RouletteSymbolName(s)
+	}
+	return prefix + strings.Join(names, "-")
+}
+
+// RouletteBetType returns the type of the bet that the table limits are set for
+func RouletteBetType(payout RoulettePayout) string {
+	switch len(payout.Symbols) {
+	case 1:
+		return "straight"
+	case 2:
+		return "split"
+	case 3:
+		return "street"
+	case 4:
+		return "corner"
+	case 5:
+		return "basket"
+	case 6:
+		return "line"
+	case 12:
+		return "dozen"
+	case 18:
+		return "evenMoney"
+	}
+	return "other"
+}
+
+// RouletteBetTypes are the bet types that limits can be set for
+var RouletteBetTypes = []string{"straight", "split", "street", "corner", "basket", "line", "dozen", "evenMoney", "other"}
+
+// StandardRoulettePayouts returns the inside and outside bets of the table of the wheel, the multipliers include
+// the stake
+func StandardRoulettePayouts(wheel string) map[string]RoulettePayout {
+	payouts := map[string]RoulettePayout{}
+	add := func(prefix string, multiplier int, symbols ...int) {
+		payouts[rouletteBetName(prefix, symbols...)] = RoulettePayout{Multiplier: multiplier, Symbols: symbols}
+	}
+	american := wheel == RouletteWheelAmerican
+
+	add("", 36, 0)
+	if american {
+		add("", 36, RouletteDoubleZero)
+		add("split", 18, 0, RouletteDoubleZero)
+		add("split", 18, 0, 1)
+		add("split", 18, 0, 2)
+		add("split", 18, 2, RouletteDoubleZero)
+		add("split", 18, 3, RouletteDoubleZero)
+		add("trio", 12, 0, 1, 2)
+		add("trio", 12, 0, 2, RouletteDoubleZero)
+		add("trio", 12, 2, 3, RouletteDoubleZero)
+		add("basket", 7, 0, RouletteDoubleZero, 1, 2, 3)
+	} else {
+		add("split", 18, 0, 1)
+		add("split", 18, 0, 2)
+		add("split", 18, 0, 3)
+		add("trio", 12, 0, 1, 2)
+		add("trio", 12, 0, 2, 3)
+		add("corner", 9, 0, 1, 2, 3)
+	}
+	for n := 1; n <= 36; n++ {
+		add("", 36, n)
+		if n%3 != 0 {
+			add("split", 18, n, n+1)
+		}
+		if n <= 33 {
+			add("split", 18, n, n+3)
+		}
+		if n%3 == 1 {
+			add("street", 12, n, n+1, n+2)
+		}
+		if n%3 != 0 && n <= 32 {
+			add("corner", 9, n, n+1, n+3, n+4)
+		}
+		if n%3 == 1 && n <= 31 {
+			add("line", 6, n, n+1, n+2, n+3, n+4, n+5)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		dozen, column := []int{}, []int{}
+		for j := 0; j < 12; j++ {
+			dozen = append(dozen, i*12+j+1)
+			column = append(column, j*3+i+1)
+		}
+		payouts["dozen"+strconv.Itoa(i+1)] = RoulettePayout{Multiplier: 3, Symbols: dozen}
+		payouts["column"+strconv.Itoa(i+1)] = RoulettePayout{Multiplier: 3, Symbols: column}
+	}
+	outside := map[string][]int{}
+	for n := 1; n <= 36; n++ {
+		if containsRouletteSymbol(rouletteRed, n) {
+			outside["red"] = append(outside["red"], n)
+		} else {
+			outside["black"] = append(outside["black"], n)
+		}
+		if n%2 == 0 {
+			outside["even"] = append(outside["even"], n)
+		} else {
+			outside["odd"] = append(outside["odd"], n)
+		}
+		if n <= 18 {
+			outside["low"] = append(outside["low"], n)
+		} else {
+			outside["high"] = append(outside["high"], n)
+		}
+	}
+	for name, symbols := range outside {
+		payouts[name] = RoulettePayout{Multiplier: 2, Symbols: symbols}
+	}
+	return payouts
+}
+
+// fillRouletteDef gives a roulette def that names a wheel the reel and the payouts of the wheel if it has none
+func fillRouletteDef(def EngineDef) EngineDef {
+	if def.RouletteConfig.Wheel == "" {
+		return def
+	}
+	if len(def.Reels) == 0 {
+		if wheel, ok := RouletteWheel(def.RouletteConfig.Wheel); ok {
+			def.Reels = [][]int{wheel}
+		}
+	}
+	if len(def.RoulettePayouts) == 0 {
+		def.RoulettePayouts = StandardRoulettePayouts(def.RouletteConfig.Wheel)
+	}
+	return def
+}
+
+// RouletteCallBetChips returns the bets of a call bet and the chips of each bet, the neighbours bet takes the number
+// and its neighbours on the wheel
+func RouletteCallBetChips(name string, number int, wheel []int, neighbours int) (map[string]int, bool) {
+	if name != RouletteNeighbours {
+		chips, ok := rouletteCallBets[name]
+		if !ok {
+			return nil, false
+		}
+		copied := make(map[string]int, len(chips))
+		for k, v := range chips {
+			copied[k] = v
+		}
+		return copied, true
+	}
+	position := -1
+	for i, n := range wheel {
+		if n == number {
+			position = i
+		}
+	}
+	if position < 0 || 2*neighbours+1 > len(wheel) {
+		return nil, false
+	}
+	chips := map[string]int{}
+	for offset := -neighbours; offset <= neighbours; offset++ {
+		chips[RouletteSymbolName(wheel[(position+offset+len(wheel))%len(wheel)])] = 1
+	}
+	return chips, true
+}
+
+// RouletteHotCold returns the most and the least frequent numbers of the wheel in the history, ties go to the
+// lower number
+func RouletteHotCold(history []int, wheel []int) RouletteStats {
+	counts := map[int]int{}
+	for _, s := range history {
+		counts[s]++
+	}
+	numbers := append([]int{}, wheel...)
+	sort.Slice(numbers, func(i, j int) bool {
+		if counts[numbers[i]] != counts[numbers[j]] {
+			return counts[numbers[i]] > counts[numbers[j]]
+		}
+		return numbers[i] < numbers[j]
+	})
+	n := rouletteHotColdNumbers
+	if n > len(numbers) {
+		n = len(numbers)
+	}
+	stats := RouletteStats{Hot: append([]int{}, numbers[:n]...), Cold: make([]int, 0, n), Rounds: len(history)}
+	for i := len(numbers) - 1; i >= len(numbers)-n; i-- {
+		stats.Cold = append(stats.Cold, numbers[i])
+	}
+	sort.Ints(stats.Cold)
+	return stats
+}
+
+func containsRouletteSymbol(symbols []int, symbol int) bool {
+	for _, s := range symbols {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
 }
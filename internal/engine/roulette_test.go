@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestStandardRoulettePayouts(t *testing.T) {
+	// the trios are streets that take a zero
+	for wheel, counts := range map[string][2]int{RouletteWheelEuropean: {37, 14}, RouletteWheelFrench: {37, 14}, RouletteWheelAmerican: {38, 15}} {
+		payouts := StandardRoulettePayouts(wheel)
+		numbers, _ := RouletteWheel(wheel)
+		types := map[string]int{}
+		for index, p := range payouts {
+			types[RouletteBetType(p)]++
+			if RouletteBetType(p) == "basket" {
+				if p.Multiplier != 7 {
+					t.Errorf("%v: basket %v pays %v", wheel, index, p.Multiplier)
+				}
+			} else if p.Multiplier*len(p.Symbols) != 36 {
+				t.Errorf("%v: bet %v pays %v on %v symbols", wheel, index, p.Multiplier, len(p.Symbols))
+			}
+			for _, s := range p.Symbols {
+				if !containsRouletteSymbol(numbers, s) {
+					t.Errorf("%v: bet %v has symbol %v which is not on the wheel", wheel, index, s)
+				}
+			}
+		}
+		if types["straight"] != counts[0] || types["street"] != counts[1] || types["line"] != 11 || types["dozen"] != 6 || types["evenMoney"] != 6 {
+			t.Errorf("%v: unexpected bets %v", wheel, types)
+		}
+	}
+	european := StandardRoulettePayouts(RouletteWheelEuropean)
+	if p := european["split17-20"]; !reflect.DeepEqual(p.Symbols, []int{17, 20}) || p.Multiplier != 18 {
+		t.Errorf("unexpected split %v", p)
+	}
+	if _, ok := european["corner0-1-2-3"]; !ok {
+		t.Errorf("european table has no first four")
+	}
+	if p := StandardRoulettePayouts(RouletteWheelAmerican)["00"]; !reflect.DeepEqual(p.Symbols, []int{RouletteDoubleZero}) {
+		t.Errorf("american table has no double zero: %v", p)
+	}
+}
+
+func TestRouletteCallBetChips(t *testing.T) {
+	wheel, _ := RouletteWheel(RouletteWheelFrench)
+	payouts := StandardRoulettePayouts(RouletteWheelFrench)
+	covered := map[int]string{}
+	for name, count := range map[string]int{RouletteVoisins: 9, RouletteTiers: 6, RouletteOrphelins: 5, RouletteJeuZero: 4} {
+		chips, ok := RouletteCallBetChips(name, 0, wheel, 2)
+		if !ok {
+			t.Fatalf("no call bet %v", name)
+		}
+		sum := 0
+		for index, n := range chips {
+			sum += n
+			p, ok := payouts[index]
+			if !ok {
+				t.Errorf("%v places unknown bet %v", name, index)
+			}
+			if name == RouletteJeuZero {
+				continue
+			}
+			for _, s := range p.Symbols {
+				if other, ok := covered[s]; ok && other != name {
+					t.Errorf("number %v is covered by %v and %v", s, other, name)
+				}
+				covered[s] = name
+			}
+		}
+		if sum != count {
+			t.Errorf("%v takes %v chips, expected %v", name, sum, count)
+		}
+	}
+	// voisins, tiers and orphelins cover the wheel
+	if len(covered) != 37 {
+		t.Errorf("call bets cover %v numbers", len(covered))
+	}
+
+	chips, ok := RouletteCallBetChips(RouletteNeighbours, 0, wheel, 2)
+	numbers := []string{}
+	for index := range chips {
+		numbers = append(numbers, index)
+	}
+	sort.Strings(numbers)
+	if !ok || !reflect.DeepEqual(numbers, []string{"0", "15", "26", "3", "32"}) {
+		t.Errorf("unexpected neighbours of 0: %v", numbers)
+	}
+	if _, ok := RouletteCallBetChips(RouletteNeighbours, 40, wheel, 2); ok {
+		t.Errorf("neighbours of a number that is not on the wheel")
+	}
+	if _, ok := RouletteCallBetChips("snake", 0, wheel, 2); ok {
+		t.Errorf("unknown call bet")
+	}
+}
+
+func TestRouletteHotCold(t *testing.T) {
+	wheel, _ := RouletteWheel(RouletteWheelEuropean)
+	stats := RouletteHotCold([]int{7, 7, 7, 12, 12, 3, 30, 30, 30, 30, 1}, wheel)
+	if !reflect.DeepEqual(stats.Hot, []int{30, 7, 12, 1, 3}) || stats.Rounds != 11 {
+		t.Errorf("unexpected hot numbers %v", stats)
+	}
+	if !reflect.DeepEqual(stats.Cold, []int{32, 33, 34, 35, 36}) {
+		t.Errorf("unexpected cold numbers %v", stats.Cold)
+	}
+}
+
+func TestRouletteConfiguration(t *testing.T) {
+	c := EngineConfig{}
+	if err := yaml.Unmarshal([]byte(`
+EngineDefs:
+  - name: base
+    function: RouletteRound
+    RouletteConfig:
+      Wheel: french
+      CallBets: [voisins, neighbours]
+      Limits:
+        straight: {Min: 1, Max: 50}
+  - name: american
+    RouletteConfig:
+      Wheel: american
+`), &c); err != nil {
+		t.Fatalf("%v", err)
+	}
+	c = fillEngineDefs(c)
+	french := c.EngineDefs[0]
+	if len(french.Reels) != 1 || len(french.Reels[0]) != 37 || len(french.RoulettePayouts) == 0 {
+		t.Errorf("french def has no wheel")
+	}
+	if french.RouletteConfig.EvenMoneyRule() != RouletteLaPartage || french.RouletteConfig.NeighbourCount() != 2 {
+		t.Errorf("unexpected french rules %#v", french.RouletteConfig)
+	}
+	// the american def replaces the inherited config and gets the reel of its own wheel
+	american := c.EngineDefs[1]
+	if len(american.Reels[0]) != 38 || american.RouletteConfig.EvenMoneyRule() != "" || !american.RouletteConfig.IsZero(RouletteDoubleZero) {
+		t.Errorf("unexpected american def %#v", american.RouletteConfig)
+	}
+	if american.Function != "RouletteRound" {
+		t.Errorf("american def did not inherit the function")
+	}
+	if american.RouletteConfig.IsEvenMoney(american.RoulettePayouts["split0-00"]) || !american.RouletteConfig.IsEvenMoney(american.RoulettePayouts["red"]) {
+		t.Errorf("unexpected even money bets")
+	}
+}
@@ -0,0 +1,367 @@
+package engine
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+const (
+	ScratchModeTable = "table" // every ticket is drawn from the weights of the prizes
+	ScratchModePool  = "pool"  // the tickets are sold from a finite pool that holds the counts of the prizes
+
+	ScratchPoolRefill = "refill" // a sold out pool is followed by a new pool
+	ScratchPoolClose  = "close"  // the sales stop when the pool is sold out
+
+	scratchDefaultMatch = 3
+
+	// the largest range of a draw that shuffles several positions at once
+	scratchDrawRange = int(^uint(0) >> 2)
+)
+
+// ScratchConfiguration holds the prizes and the reveal layout of a scratch card engine
+type ScratchConfiguration struct {
+	Mode       string         `yaml:"Mode"`       // table or pool, table if not set
+	Prizes     []ScratchPrize `yaml:"Prizes"`     // the losing tickets are a prize with multiplier 0
+	Exhaustion string         `yaml:"Exhaustion"` // refill or close when a pool is sold out, refill if not set
+	Layout     ScratchLayout  `yaml:"Layout"`
+}
+
+type ScratchPrize struct {
+	Multiplier int `yaml:"Multiplier"` // the win as a multiple of the price of the ticket
+	Symbol     int `yaml:"Symbol"`     // the symbol that a winning ticket reveals Match times
+	Weight     int `yaml:"Weight"`     // the weight of the prize in a table
+	Count      int `yaml:"Count"`      // the tickets of the prize in a pool
+}
+
+// ScratchLayout is the grid of cells that the player scratches, a ticket wins if Match cells show the same symbol
+type ScratchLayout struct {
+	Rows    int   `yaml:"Rows"`
+	Columns int   `yaml:"Columns"`
+	Match   int   `yaml:"Match"`   // 3 if not set
+	Symbols []int `yaml:"Symbols"` // the symbols that the cells are filled with
+}
+
+func (c ScratchConfiguration) PoolMode() bool {
+	return c.Mode == ScratchModePool
+}
+
+func (c ScratchConfiguration) MatchCount() int {
+	if c.Layout.Match == 0 {
+		return scratchDefaultMatch
+	}
+	return c.Layout.Match
+}
+
+func (c ScratchConfiguration) CloseWhenSoldOut() bool {
+	return c.Exhaustion == ScratchPoolClose
+}
+
+// Odds returns the weights of the prizes of a table or the tickets of the prizes of a pool
+func (c ScratchConfiguration) Odds() []int {
+	odds := make([]int, len(c.Prizes))
+	for i, p := range c.Prizes {
+		if c.PoolMode() {
+			odds[i] = p.Count
+		} else {
+			odds[i] = p.Weight
+		}
+	}
+	return odds
+}
+
+// PoolSize returns the tickets of a pool
+func (c ScratchConfiguration) PoolSize() int {
+	size := 0
+	for _, p := range c.Prizes {
+		size += p.Count
+	}
+	return size
+}
+
+// DrawPrize draws the prize of a ticket from the weights of the table with the rng of the round
+func (c ScratchConfiguration) DrawPrize(round *rng.Round) int {
+	return GetWeightedIndex(round, c.Odds())
+}
+
+// GameStateScratch is a ticket, the round is played in one step
+type GameStateScratch struct {
+	GameStateV3
+
+	Bet        Fixed  `json:"bet"`
+	Win        Fixed  `json:"win"`
+	Prize      int    `json:"prize"` // the index of the prize in the prize table
+	Multiplier int    `json:"multiplier"`
+	Symbol     int    `json:"symbol"`              // the matched symbol of a winning ticket
+	Columns    int    `json:"columns"`             // the cells of a row of the layout
+	Layout     []int  `json:"layout"`              // the symbols of the cells row by row
+	Positions  []int  `json:"positions,omitempty"` // the cells that show the matched symbol
+	Pool       string `json:"pool,omitempty"`      // the pool the ticket was sold from
+	Ticket     int    `json:"ticket,omitempty"`    // the number of the ticket in its pool, the first is 1
+}
+
+func (g *GameStateScratch) Base() *GameStateV3 {
+	return &g.GameStateV3
+}
+
+func (s GameStateScratch) Serialize() []byte {
+	b, _ := json.Marshal(s)
+	logger.Debugf("GameStateScratch.Serialize %s", string(b))
+	return b
+}
+
+func (s *GameStateScratch) Deserialize(serialized []byte) rgse.RGSErr {
+	err := json.Unmarshal(serialized, s)
+	if err != nil {
+		logger.Debugf("unmarshal json failed with error %s", err.Error())
+		return rgse.Create(rgse.GamestateByteDeserializerError)
+	}
+	return nil
+}
+
+func (s GameStateScratch) GetTtl() int64 {
+	return 3600
+}
+
+// PlayScratch reveals the ticket of the prize with the rng of the round
+func PlayScratch(round *rng.Round, conf ScratchConfiguration, prize int, bet Fixed) GameStateScratch {
+	p := conf.Prizes[prize]
+	state := GameStateScratch{
+		Bet:        bet,
+		Win:        bet.MulInt(int64(p.Multiplier)),
+		Prize:      prize,
+		Multiplier: p.Multiplier,
+		Columns:    conf.Layout.Columns,
+	}
+	if p.Multiplier > 0 {
+		state.Symbol = p.Symbol
+	}
+	state.Layout, state.Positions = ScratchReveal(round, conf, prize)
+	return state
+}
+
+// ScratchReveal fills the cells of the layout for the prize. A winning ticket shows its symbol in Match cells, every
+// other symbol is shown in fewer cells so that no other prize can be read from the ticket.
+func ScratchReveal(round *rng.Round, conf ScratchConfiguration, prize int) (layout []int, positions []int) {
+	p := conf.Prizes[prize]
+	match := conf.MatchCount()
+	cells := make([]int, conf.Layout.Rows*conf.Layout.Columns)
+	for i := range cells {
+		cells[i] = i
+	}
+	shuffleScratch(round, cells, "scratchLayout")
+	layout = make([]int, len(cells))
+	if p.Multiplier > 0 {
+		positions = append([]int{}, cells[:match]...)
+		sort.Ints(positions)
+		for _, c := range positions {
+			layout[c] = p.Symbol
+		}
+		cells = cells[match:]
+	}
+	fillers := []int{}
+	for _, s := range conf.Layout.Symbols {
+		if p.Multiplier > 0 && s == p.Symbol {
+			continue
+		}
+		for n := 0; n < match-1; n++ {
+			fillers = append(fillers, s)
+		}
+	}
+	shuffleScratch(round, fillers, "scratchLayout")
+	for i, c := range cells {
+		layout[c] = fillers[i]
+	}
+	return
+}
+
+// shuffleScratch shuffles the values with Fisher-Yates. The swaps of consecutive positions are drawn together as the
+// digits of one draw whose range is the product of their ranges, so that a ticket is revealed in a few draws.
+func shuffleScratch(round *rng.Round, values []int, tag string) {
+	i := len(values) - 1
+	for i > 0 {
+		radix, j := 1, i
+		for j > 0 && radix <= scratchDrawRange/(j+1) {
+			radix *= j + 1
+			j--
+		}
+		r := round.RandFromRangeTagged(radix, tag)
+		for ; i > j; i-- {
+			k := r % (i + 1)
+			r /= i + 1
+			values[i], values[k] = values[k], values[i]
+		}
+	}
+}
+
+// ScratchPool is a finite run of tickets whose prizes are shuffled before the first ticket is sold, the tickets are
+// sold in order. The hash of the tickets can be published before the sale to commit to the order of the prizes.
+// A ticket is reserved until the wager of its round is settled, a ticket whose wager failed is returned and sold again
+// before the next ones.
+type ScratchPool struct {
+	Id       string         `json:"id"`
+	Serial   int            `json:"serial"` // the number of the pool in the run of pools of a game, the first is 1
+	Seed     []uint64       `json:"seed"`   // the seed the tickets were shuffled with
+	Hash     string         `json:"hash"`   // sha1 of the prizes of the tickets in order of sale
+	Tickets  []int          `json:"-"`      // the prize of every ticket in order of sale
+	Sold     int            `json:"sold"`   // the tickets taken from the pool in order, reserved and returned included
+	Reserved []int          `json:"reserved,omitempty"`
+	Returned []int          `json:"returned,omitempty"`
+	Prizes   []ScratchPrize `json:"prizes"` // the prizes the pool was shuffled from
+}
+
+// NewScratchPool shuffles the tickets of the prizes of the pool with the seed, a new seed is read if it is empty.
+// The pool is shuffled on its own rng round.
+func NewScratchPool(conf ScratchConfiguration, serial int, seed []uint64) *ScratchPool {
+	round := rng.NewRound(seed)
+	tickets := make([]int, 0, conf.PoolSize())
+	for i, p := range conf.Prizes {
+		for n := 0; n < p.Count; n++ {
+			tickets = append(tickets, i)
+		}
+	}
+	shuffleScratch(round, tickets, "scratchPool")
+	h := sha1.New()
+	b := make([]byte, 4)
+	for _, t := range tickets {
+		binary.BigEndian.PutUint32(b, uint32(t))
+		h.Write(b)
+	}
+	return &ScratchPool{
+		Id:      rng.Uuid(),
+		Serial:  serial,
+		Seed:    round.Seed,
+		Hash:    hex.EncodeToString(h.Sum(nil)),
+		Tickets: tickets,
+		Prizes:  append([]ScratchPrize{}, conf.Prizes...),
+	}
+}
+
+// Restore shuffles the tickets of a pool that was stored without them from its seed and its prizes, it fails if they
+// do not match the hash of the pool.
+func (p *ScratchPool) Restore() bool {
+	shuffled := NewScratchPool(ScratchConfiguration{Prizes: p.Prizes}, p.Serial, p.Seed)
+	if shuffled.Hash != p.Hash {
+		return false
+	}
+	p.Tickets = shuffled.Tickets
+	return true
+}
+
+// Next reserves the next ticket, a returned ticket first, and returns its prize and number, ok is false when the
+// pool is sold out
+func (p *ScratchPool) Next() (prize int, ticket int, ok bool) {
+	switch {
+	case len(p.Returned) > 0:
+		ticket = p.Returned[0]
+		p.Returned = p.Returned[1:]
+	case p.Sold < len(p.Tickets):
+		p.Sold++
+		ticket = p.Sold
+	default:
+		return 0, 0, false
+	}
+	p.Reserved = append(p.Reserved, ticket)
+	return p.Tickets[ticket-1], ticket, true
+}
+
+// Sell completes the sale of a reserved ticket, it returns false if the ticket is not reserved
+func (p *ScratchPool) Sell(ticket int) bool {
+	return p.release(ticket)
+}
+
+// Return puts a reserved ticket back in the pool, it returns false if the ticket is not reserved
+func (p *ScratchPool) Return(ticket int) bool {
+	if !p.release(ticket) {
+		return false
+	}
+	p.Returned = append(p.Returned, ticket)
+	return true
+}
+
+func (p *ScratchPool) release(ticket int) bool {
+	for i, t := range p.Reserved {
+		if t == ticket {
+			p.Reserved = append(p.Reserved[:i], p.Reserved[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (p ScratchPool) Size() int {
+	return len(p.Tickets)
+}
+
+// Remaining returns the tickets that can still be sold, the reserved tickets are not counted
+func (p ScratchPool) Remaining() int {
+	return len(p.Tickets) - p.Sold + len(p.Returned)
+}
+
+// Holds reports whether the pool was generated from the prizes of the configuration
+func (p ScratchPool) Holds(conf ScratchConfiguration) bool {
+	return reflect.DeepEqual(p.Prizes, conf.Prizes)
+}
+
+// ExactRTP returns the return of a ticket from the odds of the prizes, the return of a pool is exact once every ticket
+// of the pool is sold. The multipliers are capped at maxWinMultiplier unless it is zero.
+func (c ScratchConfiguration) ExactRTP(maxWinMultiplier int) (ExactRTPResult, rgse.RGSErr) {
+	odds := c.Odds()
+	var total int64
+	for _, o := range odds {
+		total += int64(o)
+	}
+	if total == 0 {
+		err := rgse.CreateWithoutException(rgse.EngineConfigError)
+		err.AppendErrorText(fmt.Sprintf("the prizes of the %v have no odds", c.modeName()))
+		return ExactRTPResult{}, err
+	}
+	result := ExactRTPResult{
+		WinType:      "scratch",
+		Combinations: total,
+		Triggers:     map[string]float64{},
+		MaxWin:       maxWinMultiplier,
+	}
+	counts := map[int]int64{}
+	var payout, hits, capped int64
+	for i, p := range c.Prizes {
+		multiplier := p.Multiplier
+		if maxWinMultiplier > 0 && multiplier >= maxWinMultiplier {
+			multiplier = maxWinMultiplier
+			capped += int64(odds[i])
+		}
+		counts[multiplier] += int64(odds[i])
+		payout += int64(multiplier) * int64(odds[i])
+		if multiplier > 0 {
+			hits += int64(odds[i])
+		}
+	}
+	result.RTP = float64(payout) / float64(total)
+	result.Multiplier = result.RTP
+	result.HitFrequency = float64(hits) / float64(total)
+	result.CapRate = float64(capped) / float64(total)
+	for m, n := range counts {
+		result.Distribution = append(result.Distribution, PayoutProbability{
+			Payout:      float64(m),
+			Count:       n,
+			Probability: float64(n) / float64(total),
+		})
+	}
+	sort.Slice(result.Distribution, func(i, j int) bool { return result.Distribution[i].Payout < result.Distribution[j].Payout })
+	return result, nil
+}
+
+func (c ScratchConfiguration) modeName() string {
+	if c.PoolMode() {
+		return ScratchModePool
+	}
+	return ScratchModeTable
+}
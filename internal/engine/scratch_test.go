@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+)
+
+func testScratchConfig(mode string) ScratchConfiguration {
+	return ScratchConfiguration{
+		Mode:   mode,
+		Layout: ScratchLayout{Rows: 3, Columns: 3, Symbols: []int{1, 2, 3, 4, 5}},
+		Prizes: []ScratchPrize{
+			{Multiplier: 10, Symbol: 1, Weight: 1, Count: 1},
+			{Multiplier: 2, Symbol: 2, Weight: 3, Count: 3},
+			{Multiplier: 0, Weight: 6, Count: 6},
+		},
+	}
+}
+
+func TestScratchReveal(t *testing.T) {
+	rng.Init()
+	conf := testScratchConfig(ScratchModeTable)
+	for i := 0; i < 100; i++ {
+		prize := i % len(conf.Prizes)
+		state := PlayScratch(nil, conf, prize, NewFixedFromInt(2))
+		if len(state.Layout) != 9 || state.Columns != 3 {
+			t.Fatalf("unexpected layout %v", state.Layout)
+		}
+		counts := map[int]int{}
+		for _, s := range state.Layout {
+			counts[s]++
+		}
+		p := conf.Prizes[prize]
+		if state.Win != NewFixedFromInt(2).MulInt(int64(p.Multiplier)) || state.Multiplier != p.Multiplier {
+			t.Errorf("prize %v won %v", prize, state.Win)
+		}
+		for s, n := range counts {
+			if s == 0 || n > 3 || (n == 3 && (p.Multiplier == 0 || s != p.Symbol)) {
+				t.Errorf("prize %v shows symbol %v %v times: %v", prize, s, n, state.Layout)
+			}
+		}
+		if p.Multiplier > 0 {
+			if counts[p.Symbol] != 3 || len(state.Positions) != 3 {
+				t.Errorf("prize %v does not show its symbol: %v %v", prize, state.Layout, state.Positions)
+			}
+			for _, c := range state.Positions {
+				if state.Layout[c] != p.Symbol {
+					t.Errorf("position %v does not show symbol %v: %v", c, p.Symbol, state.Layout)
+				}
+			}
+		} else if len(state.Positions) != 0 || state.Symbol != 0 {
+			t.Errorf("losing ticket has positions %v", state.Positions)
+		}
+	}
+
+	// the cells of a ticket are revealed with the seed of its round
+	round := rng.NewRound(nil)
+	revealed := PlayScratch(round, conf, 0, NewFixedFromInt(1))
+	replayed := PlayScratch(rng.NewRound(round.Seed), conf, 0, NewFixedFromInt(1))
+	if !reflect.DeepEqual(revealed, replayed) {
+		t.Errorf("reveal of the seed differs")
+	}
+}
+
+func TestScratchPool(t *testing.T) {
+	rng.Init()
+	conf := testScratchConfig(ScratchModePool)
+	pool := NewScratchPool(conf, 1, nil)
+	if pool.Size() != 10 || pool.Remaining() != 10 || !pool.Holds(conf) {
+		t.Fatalf("unexpected pool %#v", pool)
+	}
+	sold := make([]int, len(conf.Prizes))
+	for n := 1; n <= 10; n++ {
+		prize, ticket, ok := pool.Next()
+		if !ok || ticket != n {
+			t.Fatalf("ticket %v sold as %v %v", n, ticket, ok)
+		}
+		if !pool.Sell(ticket) {
+			t.Fatalf("ticket %v was not reserved", ticket)
+		}
+		sold[prize]++
+	}
+	if !reflect.DeepEqual(sold, []int{1, 3, 6}) {
+		t.Errorf("pool sold %v", sold)
+	}
+	if _, _, ok := pool.Next(); ok || pool.Remaining() != 0 {
+		t.Errorf("sold out pool sold a ticket")
+	}
+
+	// the tickets and the hash of a pool follow from its seed
+	replayed := NewScratchPool(conf, 1, pool.Seed)
+	if !reflect.DeepEqual(replayed.Tickets, pool.Tickets) || replayed.Hash != pool.Hash || replayed.Id == pool.Id {
+		t.Errorf("pool of the seed differs")
+	}
+	stored := ScratchPool{Id: pool.Id, Serial: pool.Serial, Seed: pool.Seed, Hash: pool.Hash, Prizes: pool.Prizes}
+	if !stored.Restore() || !reflect.DeepEqual(stored.Tickets, pool.Tickets) {
+		t.Errorf("stored pool was not restored")
+	}
+	stored.Hash = "0"
+	if stored.Restore() {
+		t.Errorf("pool restored with another hash")
+	}
+	conf.Prizes[2].Count = 7
+	if pool.Holds(conf) {
+		t.Errorf("pool holds changed prizes")
+	}
+}
+
+func TestScratchPoolReturn(t *testing.T) {
+	rng.Init()
+	pool := NewScratchPool(testScratchConfig(ScratchModePool), 1, nil)
+	first, ticket, _ := pool.Next()
+	if pool.Remaining() != 9 || !reflect.DeepEqual(pool.Reserved, []int{1}) {
+		t.Fatalf("unexpected pool after a reservation %#v", pool)
+	}
+	// a returned ticket is sold again before the next ones
+	if !pool.Return(ticket) || pool.Return(ticket) || pool.Remaining() != 10 {
+		t.Fatalf("ticket was not returned once %#v", pool)
+	}
+	prize, again, _ := pool.Next()
+	if again != ticket || prize != first {
+		t.Errorf("returned ticket %v sold as %v", ticket, again)
+	}
+	if _, next, _ := pool.Next(); next != 2 {
+		t.Errorf("ticket %v sold after the returned ticket, expected 2", next)
+	}
+	if !pool.Sell(again) || pool.Sell(again) || len(pool.Reserved) != 1 {
+		t.Errorf("ticket was not sold once %#v", pool)
+	}
+}
+
+func TestScratchExactRTP(t *testing.T) {
+	result, err := testScratchConfig(ScratchModeTable).ExactRTP(0)
+	if err != nil {
+		t.Fatalf("exact rtp: %v", err.Error())
+	}
+	if math.Abs(result.RTP-1.6) > 1e-9 || math.Abs(result.HitFrequency-0.4) > 1e-9 || result.Combinations != 10 || len(result.Distribution) != 3 {
+		t.Errorf("unexpected result %#v", result)
+	}
+	capped, _ := testScratchConfig(ScratchModePool).ExactRTP(5)
+	if math.Abs(capped.RTP-1.1) > 1e-9 || math.Abs(capped.CapRate-0.1) > 1e-9 {
+		t.Errorf("unexpected capped result %#v", capped)
+	}
+	if _, err := (ScratchConfiguration{Prizes: []ScratchPrize{{Multiplier: 1}}}).ExactRTP(0); err == nil {
+		t.Errorf("prizes without odds have an rtp")
+	}
+
+	for _, engineID := range []string{"mvgEngineScratch1", "mvgEngineScratch2"} {
+		conf := BuildEngineDefs(engineID)
+		result, err := conf.EngineDefs[0].ExactRTPWithMaxWin(conf.MaxWinMultiplier)
+		if err != nil || math.Abs(result.RTP-float64(conf.RTP)) > 1e-6 {
+			t.Errorf("%v has rtp %v, configured %v: %v", engineID, result.RTP, conf.RTP, err)
+		}
+	}
+}
+
+func TestShuffleScratch(t *testing.T) {
+	rng.Init()
+	// every order of the values is drawn about as often
+	orders := map[[4]int]int{}
+	trials := 24000
+	for i := 0; i < trials; i++ {
+		values := []int{0, 1, 2, 3}
+		shuffleScratch(nil, values, "test")
+		orders[[4]int{values[0], values[1], values[2], values[3]}]++
+	}
+	if len(orders) != 24 {
+		t.Fatalf("%v of 24 orders drawn", len(orders))
+	}
+	for order, n := range orders {
+		if n < 800 || n > 1200 {
+			t.Errorf("order %v drawn %v times of %v", order, n, trials/24)
+		}
+	}
+
+	// a long run of values is shuffled in draws of several positions
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	shuffleScratch(nil, values, "test")
+	seen := make([]bool, len(values))
+	for _, v := range values {
+		seen[v] = true
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("value %v lost in the shuffle", v)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
@@ -34,6 +35,7 @@ type FeatureState struct {
 	Replay           bool
 	ReplayTries      int
 	ReplayParams     FeatureParams
+	Rng              *rng.Round // the rng of the round the features are triggered in
 }
 
 func (fs *FeatureState) SetGrid(symbolgrid [][]int) {
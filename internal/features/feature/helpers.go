@@ -2,12 +2,13 @@ package feature
 
 import "gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 
-func WeightedRandomIndex(weights []int) int {
+// WeightedRandomIndex draws an index of weights with the probability of its weight from the rng of the round
+func WeightedRandomIndex(round *rng.Round, weights []int) int {
 	var sum, i, w int
 	for _, w = range weights {
 		sum += w
 	}
-	r := rng.RandFromRange(sum)
+	r := round.RandFromRange(sum)
 	sum = 0
 	for i, w = range weights {
 		sum += w
@@ -18,14 +19,15 @@ func WeightedRandomIndex(weights []int) int {
 	return i
 }
 
-func RandomPermutation(arr []int) []int {
+// RandomPermutation shuffles a copy of arr with the rng of the round
+func RandomPermutation(round *rng.Round, arr []int) []int {
 	a := make([]int, len(arr))
 	for i, v := range arr {
 		a[i] = v
 	}
 	ret := []int{}
 	for len(a) > 0 {
-		i := rng.RandFromRange(len(a))
+		i := round.RandFromRange(len(a))
 		ret = append(ret, a[i])
 		a0 := a[:i]
 		a1 := a[i+1:]
@@ -3,7 +3,6 @@ package featureTriggers
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -40,9 +39,9 @@ func (f TriggerBattleOfMyths) Trigger(state *feature.FeatureState, params featur
 	params[PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_RUN_PRINCESS] = runPrincess
 	if !runPrincess {
 		featureProb := params.GetInt(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_FEATURE_PROBABILITY)
-		random := rng.RandFromRange(10000)
+		random := state.Rng.RandFromRange(10000)
 		if random < featureProb {
-			random = rng.RandFromRange(2)
+			random = state.Rng.RandFromRange(2)
 			if random == 0 {
 				params[PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_RUN_TIGER] = true
 			} else {
@@ -2,7 +2,6 @@ package featureTriggers
 
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -33,16 +32,16 @@ func (f TriggerBattleOfMythsDragon) Trigger(state *feature.FeatureState, params
 
 	number := params.GetIntSlice(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_DRAGON_NUMBER)
 	numberProbs := params.GetIntSlice(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_DRAGON_NUMBER_PROBABILITIES)
-	numIdx := feature.WeightedRandomIndex(numberProbs)
+	numIdx := feature.WeightedRandomIndex(state.Rng, numberProbs)
 	num := number[numIdx]
 
 	reelProbs := feature.ConvertIntSlice(params.GetSlice(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_DRAGON_REEL_PROBABILITIES)[numIdx])
-	reelsIdx := feature.WeightedRandomIndex(reelProbs)
+	reelsIdx := feature.WeightedRandomIndex(state.Rng, reelProbs)
 
 	reels := feature.ConvertIntSlice(params.GetSlice(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_DRAGON_REEL_POSITIONS)[numIdx].([]interface{})[reelsIdx])
 
 	patterns := params.GetSlice(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_DRAGON_PATTERNS)
-	patternIdx := rng.RandFromRange(len(patterns))
+	patternIdx := state.Rng.RandFromRange(len(patterns))
 	pattern := feature.ConvertIntSlice(patterns[patternIdx])
 
 	gridh := len(state.SymbolGrid[0])
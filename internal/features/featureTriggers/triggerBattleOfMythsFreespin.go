@@ -5,7 +5,6 @@ import (
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
@@ -78,7 +77,7 @@ func (f TriggerBattleOfMythsFreespin) Trigger(state *feature.FeatureState, param
 
 		if params.HasKey(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_FREESPIN_NUM_SCATTERS) {
 			numScatters := params.GetIntSlice(
-				PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_FREESPIN_NUM_SCATTERS)[feature.WeightedRandomIndex(
+				PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_FREESPIN_NUM_SCATTERS)[feature.WeightedRandomIndex(state.Rng,
 				params.GetIntSlice(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_FREESPIN_NUM_PROBABILITIES))]
 
 			if (params.HasKey(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_RUN_TIGER) && params.GetBool(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_RUN_TIGER)) ||
@@ -86,7 +85,7 @@ func (f TriggerBattleOfMythsFreespin) Trigger(state *feature.FeatureState, param
 				(params.HasKey(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_RUN_PRINCESS) && params.GetBool(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_RUN_PRINCESS)) {
 				// logger.Debugf("skipping placing scatters due to conflicting features")
 			} else {
-				if rng.RandFromRange(10000) > params.GetInt("ScatterProbability") {
+				if state.Rng.RandFromRange(10000) > params.GetInt("ScatterProbability") {
 					// logger.Debugf("skipping placing scatters dues to activation probability")
 				} else {
 					Abs := func(x int) int {
@@ -106,7 +105,7 @@ func (f TriggerBattleOfMythsFreespin) Trigger(state *feature.FeatureState, param
 					absCounter := Abs(counter)
 					if absCounter < len(sameTypeProbs) {
 						sameProb := sameTypeProbs[absCounter]
-						if rng.RandFromRange(10000) > sameProb {
+						if state.Rng.RandFromRange(10000) > sameProb {
 							scatterType = scatterType ^ 1
 						}
 					}
@@ -129,8 +128,8 @@ func (f TriggerBattleOfMythsFreespin) Trigger(state *feature.FeatureState, param
 						var reel, symb, pos int
 						cont := true
 						for cont {
-							reel = rng.RandFromRange(gridw)
-							symb = rng.RandFromRange(gridh)
+							reel = state.Rng.RandFromRange(gridw)
+							symb = state.Rng.RandFromRange(gridh)
 							pos = reel*gridh + symb
 							tile := state.SymbolGrid[reel][symb]
 							cont = func() bool {
@@ -3,7 +3,6 @@ package featureTriggers
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -29,11 +28,11 @@ func (f TriggerBattleOfMythsTiger) Trigger(state *feature.FeatureState, params f
 	sizeProbs := params.GetIntSlice(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_TIGER_SIZE_PROBABILITIES)
 	reelProbs := params.GetSlice(PARAM_ID_TRIGGER_BATTLE_OF_MYTHS_TIGER_REEL_PROBABILITIES)
 
-	sizeidx := feature.WeightedRandomIndex(sizeProbs)
-	reelidx := feature.WeightedRandomIndex(feature.ConvertIntSlice(reelProbs[sizeidx]))
+	sizeidx := feature.WeightedRandomIndex(state.Rng, sizeProbs)
+	reelidx := feature.WeightedRandomIndex(state.Rng, feature.ConvertIntSlice(reelProbs[sizeidx]))
 	rowidx := 0
 	if sizes[sizeidx] < len(state.SymbolGrid[0]) {
-		rowidx = rng.RandFromRange(len(state.SymbolGrid[0]) - sizes[sizeidx])
+		rowidx = state.Rng.RandFromRange(len(state.SymbolGrid[0]) - sizes[sizeidx])
 	}
 
 	params[featureProducts.PARAM_ID_FAT_TILE_W] = sizes[sizeidx]
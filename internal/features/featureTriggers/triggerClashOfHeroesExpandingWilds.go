@@ -37,7 +37,7 @@ func (f TriggerClashOfHeroesExpandingWilds) Trigger(state *feature.FeatureState,
 			return true
 		}()
 		if match {
-			pos := patternpositions[feature.WeightedRandomIndex(feature.ConvertIntSlice(probabilities[idx]))]
+			pos := patternpositions[feature.WeightedRandomIndex(state.Rng, feature.ConvertIntSlice(probabilities[idx]))]
 			positions := make([]int, 9)
 			pidx := 0
 			for x := -1; x <= 1; x++ {
@@ -3,7 +3,6 @@ package featureTriggers
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -25,14 +24,14 @@ type TriggerClashOfHeroesRandomWilds struct {
 func (f TriggerClashOfHeroesRandomWilds) Trigger(state *feature.FeatureState, params feature.FeatureParams) {
 	gridh := len(state.SymbolGrid[0])
 	wildId := params.GetInt(PARAM_ID_TRIGGER_CLASH_OF_HEROES_RANDOM_WILDS_TILE_ID)
-	numWilds := params.GetIntSlice(PARAM_ID_TRIGGER_CLASH_OF_HEROES_RANDOM_WILDS_NUM_WILDS)[feature.WeightedRandomIndex(
+	numWilds := params.GetIntSlice(PARAM_ID_TRIGGER_CLASH_OF_HEROES_RANDOM_WILDS_NUM_WILDS)[feature.WeightedRandomIndex(state.Rng,
 		params.GetIntSlice(PARAM_ID_TRIGGER_CLASH_OF_HEROES_RANDOM_WILDS_NUM_PROBABILITIES))]
 	numTries := params.GetInt(PARAM_ID_TRIGGER_CLASH_OF_HEROES_RANDOM_WILDS_RETRY_FACTOR) * numWilds
 	positions := []int{}
 	for try := 0; len(positions) < numWilds && try < numTries+1; try++ {
-		reelidx := feature.WeightedRandomIndex(
+		reelidx := feature.WeightedRandomIndex(state.Rng,
 			params.GetIntSlice(PARAM_ID_TRIGGER_CLASH_OF_HEROES_RANDOM_WILDS_REEL_PROBABILITIES))
-		rowidx := rng.RandFromRange(3)
+		rowidx := state.Rng.RandFromRange(3)
 		pos := reelidx*gridh + rowidx
 		if func() bool {
 			if state.SymbolGrid[reelidx][rowidx] == wildId {
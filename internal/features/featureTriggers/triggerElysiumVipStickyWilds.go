@@ -5,7 +5,6 @@ import (
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
@@ -53,7 +52,7 @@ func (f TriggerElysiumVipStickyWilds) Trigger(state *feature.FeatureState, param
 	}
 	probabilityLevels := params.GetIntSlice(PARAM_ID_TRIGGER_ELYSIUM_VIP_STICKY_WILDS_PROBABILITY_LEVELS)
 
-	if rng.RandFromRange(10000) < probabilityLevels[level] {
+	if state.Rng.RandFromRange(10000) < probabilityLevels[level] {
 
 		gridw := len(state.SymbolGrid)
 		gridh := len(state.SymbolGrid[0])
@@ -86,7 +85,7 @@ func (f TriggerElysiumVipStickyWilds) Trigger(state *feature.FeatureState, param
 			panic("no reel probabilites for this view size")
 		}
 
-		numWilds := numWildsLevel[feature.WeightedRandomIndex(numProbabilitiesLevel)]
+		numWilds := numWildsLevel[feature.WeightedRandomIndex(state.Rng, numProbabilitiesLevel)]
 		numTries := params.GetInt(PARAM_ID_TRIGGER_ELYSIUM_VIP_STICKY_WILDS_RETRY_FACTOR) * numWilds
 		positions := []int{}
 
@@ -133,8 +132,8 @@ func (f TriggerElysiumVipStickyWilds) Trigger(state *feature.FeatureState, param
 		}
 
 		for try := 0; len(positions) < numWilds && try < numTries; try++ {
-			reelidx := feature.WeightedRandomIndex(reelProbabilities)
-			rowidx := rng.RandFromRange(3)
+			reelidx := feature.WeightedRandomIndex(state.Rng, reelProbabilities)
+			rowidx := state.Rng.RandFromRange(3)
 			pos := reelidx*gridh + rowidx
 			if !isWild(reelidx, rowidx) {
 				positions = append(positions, pos)
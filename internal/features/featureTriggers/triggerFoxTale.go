@@ -2,7 +2,6 @@ package featureTriggers
 
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -19,7 +18,7 @@ type TriggerFoxTale struct {
 }
 
 func (f TriggerFoxTale) Trigger(state *feature.FeatureState, params feature.FeatureParams) {
-	params[PARAM_ID_TRIGGER_FOX_TALE_RANDOM] = rng.RandFromRange(f.FeatureDef.Params[PARAM_ID_TRIGGER_FOX_TALE_RANDOM_RANGE].(int))
+	params[PARAM_ID_TRIGGER_FOX_TALE_RANDOM] = state.Rng.RandFromRange(f.FeatureDef.Params[PARAM_ID_TRIGGER_FOX_TALE_RANDOM_RANGE].(int))
 	feature.ActivateFeatures(f.FeatureDef, state, params)
 	return
 }
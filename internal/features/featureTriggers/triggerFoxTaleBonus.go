@@ -3,7 +3,6 @@ package featureTriggers
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -34,7 +33,7 @@ func (f TriggerFoxTaleBonus) Trigger(state *feature.FeatureState, params feature
 		index += gridh
 	}
 	if len(positions) >= 3 {
-		ran8 := rng.RandFromRange(8)
+		ran8 := state.Rng.RandFromRange(8)
 		params[featureProducts.PARAM_ID_INSTA_WIN_TYPE] = featureProducts.PARAM_VALUE_INSTA_WIN_BONUS
 		params[featureProducts.PARAM_ID_INSTA_WIN_SOURCE_ID] = f.FeatureDef.Id
 		params[featureProducts.PARAM_ID_INSTA_WIN_AMOUNT] = []int{
@@ -5,7 +5,6 @@ import (
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
@@ -58,7 +57,7 @@ func (f TriggerLawOfGilgameshFreespinScatter) Trigger(state *feature.FeatureStat
 	newPositions := []int{}
 	numScatters := params.GetIntSlice(PARAM_ID_TRIGGER_LAW_OF_GILGAMESH_FREESPIN_SCATTER_NUM_SCATTERS)
 	numProbs := params.GetIntSlice(PARAM_ID_TRIGGER_LAW_OF_GILGAMESH_FREESPIN_SCATTER_NUM_PROBABILITIES)
-	numPicks := numScatters[feature.WeightedRandomIndex(numProbs)]
+	numPicks := numScatters[feature.WeightedRandomIndex(state.Rng, numProbs)]
 	logger.Debugf("placing %d freespin scatters", numPicks)
 
 	if isRespin {
@@ -66,7 +65,7 @@ func (f TriggerLawOfGilgameshFreespinScatter) Trigger(state *feature.FeatureStat
 		candidates := state.GetCandidatePositions()
 
 		for i := 0; i < numPicks && len(candidates) > 0; i++ {
-			ic := rng.RandFromRange(len(candidates))
+			ic := state.Rng.RandFromRange(len(candidates))
 			p := candidates[ic]
 			candidates = append(candidates[:ic], candidates[ic+1:]...)
 			// state.SymbolGrid[p / gridh][p % gridh] = tileId
@@ -83,8 +82,8 @@ func (f TriggerLawOfGilgameshFreespinScatter) Trigger(state *feature.FeatureStat
 
 		tries := numPicks * retryFactor
 		for i := 0; i < tries && len(newPositions) < numPicks; i++ {
-			reel := feature.WeightedRandomIndex(reelProbs)
-			row := feature.WeightedRandomIndex(rowProbs)
+			reel := feature.WeightedRandomIndex(state.Rng, reelProbs)
+			row := feature.WeightedRandomIndex(state.Rng, rowProbs)
 			if func(sym int) bool {
 				for _, s := range keepIds {
 					if s == sym {
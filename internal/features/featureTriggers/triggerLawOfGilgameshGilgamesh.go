@@ -40,11 +40,11 @@ func (f TriggerLawOfGilgameshGilgamesh) Trigger(state *feature.FeatureState, par
 
 	positions := []int{}
 	gridh := len(state.SymbolGrid[0])
-	nw := numWilds[feature.WeightedRandomIndex(numProbs)]
+	nw := numWilds[feature.WeightedRandomIndex(state.Rng, numProbs)]
 	tries := nw * retryFactor
 	for i := 0; i < tries && len(positions) < nw; i++ {
-		reel := feature.WeightedRandomIndex(reelProbs)
-		row := feature.WeightedRandomIndex(rowProbs)
+		reel := feature.WeightedRandomIndex(state.Rng, reelProbs)
+		row := feature.WeightedRandomIndex(state.Rng, rowProbs)
 		pos := reel*gridh + row
 		if func(sym int) bool {
 			for _, s := range keepIds {
@@ -5,6 +5,7 @@ import (
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
@@ -44,7 +45,7 @@ func (f TriggerLawOfGilgameshTowerBonus) Trigger(state *feature.FeatureState, pa
 		winsLevels := params.GetSlice(PARAM_ID_TRIGGER_LAW_OF_GILGAMESH_TOWER_BONUS_WINS_LEVELS)
 		probLevels := params.GetSlice(PARAM_ID_TRIGGER_LAW_OF_GILGAMESH_TOWER_BONUS_PROB_LEVELS)
 
-		amount, payouts := f.towerBonus(winsLevels, probLevels)
+		amount, payouts := f.towerBonus(state.Rng, winsLevels, probLevels)
 
 		if amount > 0 {
 
@@ -73,12 +74,12 @@ func (f TriggerLawOfGilgameshTowerBonus) Trigger(state *feature.FeatureState, pa
 	return
 }
 
-func (f TriggerLawOfGilgameshTowerBonus) towerBonus(winsLevels []interface{}, probLevels []interface{}) (int, []int) {
+func (f TriggerLawOfGilgameshTowerBonus) towerBonus(round *rng.Round, winsLevels []interface{}, probLevels []interface{}) (int, []int) {
 	level := 0
 	amount := 0
 	payouts := []int{}
 	for level < len(winsLevels) {
-		win := feature.WeightedRandomIndex(feature.ConvertIntSlice(probLevels[level]))
+		win := feature.WeightedRandomIndex(round, feature.ConvertIntSlice(probLevels[level]))
 		amount = feature.ConvertIntSlice(winsLevels[level])[win]
 		if amount < 0 {
 			payouts = append(payouts, 0)
@@ -96,7 +97,7 @@ func (f TriggerLawOfGilgameshTowerBonus) testBonusProbabilites(winsLevels []inte
 	num := 100000
 	tot := 0
 	for i := 0; i < num; i++ {
-		a, _ := f.towerBonus(winsLevels, probLevels)
+		a, _ := f.towerBonus(nil, winsLevels, probLevels)
 		tot += a
 		n, ok := stats[a]
 		if !ok {
@@ -5,7 +5,6 @@ import (
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
@@ -64,14 +63,14 @@ func (f TriggerLawOfGilgameshTowerScatter) Trigger(state *feature.FeatureState,
 	newPositions := []int{}
 	numScatters := params.GetIntSlice(PARAM_ID_TRIGGER_LAW_OF_GILGAMESH_TOWER_SCATTER_NUM_SCATTERS)
 	numProbs := params.GetIntSlice(PARAM_ID_TRIGGER_LAW_OF_GILGAMESH_TOWER_SCATTER_NUM_PROBABILITIES)
-	ns := numScatters[feature.WeightedRandomIndex(numProbs)]
+	ns := numScatters[feature.WeightedRandomIndex(state.Rng, numProbs)]
 
 	if isRespin {
 
 		candidates := state.GetCandidatePositions()
 
 		for i := 0; i < ns && len(candidates) > 0; i++ {
-			ic := rng.RandFromRange(len(candidates))
+			ic := state.Rng.RandFromRange(len(candidates))
 			p := candidates[ic]
 			candidates = append(candidates[:ic], candidates[ic+1:]...)
 			// state.SymbolGrid[p / gridh][p % gridh] = tileId
@@ -88,8 +87,8 @@ func (f TriggerLawOfGilgameshTowerScatter) Trigger(state *feature.FeatureState,
 
 		tries := ns * retryFactor
 		for i := 0; i < tries && ns > 0; i++ {
-			reel := feature.WeightedRandomIndex(reelProbs)
-			row := feature.WeightedRandomIndex(rowProbs)
+			reel := feature.WeightedRandomIndex(state.Rng, reelProbs)
+			row := feature.WeightedRandomIndex(state.Rng, rowProbs)
 			if func(sym int) bool {
 				for _, s := range keepIds {
 					if s == sym {
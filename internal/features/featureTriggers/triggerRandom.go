@@ -2,7 +2,6 @@ package featureTriggers
 
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -19,7 +18,7 @@ type TriggerRandom struct {
 
 func (f TriggerRandom) Trigger(state *feature.FeatureState, params feature.FeatureParams) {
 	probability := params.GetInt(PARAM_ID_TRIGGER_RANDOM_PROBABILITY)
-	rand := rng.RandFromRange(10000)
+	rand := state.Rng.RandFromRange(10000)
 	if rand < probability {
 		feature.ActivateFeatures(f.FeatureDef, state, params)
 	}
@@ -3,7 +3,6 @@ package featureTriggers
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -35,7 +34,7 @@ func (f TriggerSpiritHuntersBonus) Trigger(state *feature.FeatureState, params f
 	}
 	if len(positions) >= 3 {
 		prizes := params.GetIntSlice(PARAM_ID_TRIGGER_SPIRIT_HUNTERS_BONUS_PRIZES)
-		ran := rng.RandFromRange(len(prizes))
+		ran := state.Rng.RandFromRange(len(prizes))
 		params[featureProducts.PARAM_ID_INSTA_WIN_TYPE] = featureProducts.PARAM_VALUE_INSTA_WIN_BONUS
 		params[featureProducts.PARAM_ID_INSTA_WIN_SOURCE_ID] = f.FeatureDef.Id
 		params[featureProducts.PARAM_ID_INSTA_WIN_AMOUNT] = prizes[ran]
@@ -2,7 +2,6 @@ package featureTriggers
 
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -22,7 +21,7 @@ func (f TriggerSupaCrew) Trigger(state *feature.FeatureState, params feature.Fea
 	if f.ForceTrigger(state, params) {
 		return
 	}
-	params[PARAM_ID_TRIGGER_SUPA_CREW_RANDOM] = rng.RandFromRange(
+	params[PARAM_ID_TRIGGER_SUPA_CREW_RANDOM] = state.Rng.RandFromRange(
 		f.FeatureDef.Params[PARAM_ID_TRIGGER_SUPA_CREW_RANDOM_RANGE].(int))
 	feature.ActivateFeatures(f.FeatureDef, state, params)
 	return
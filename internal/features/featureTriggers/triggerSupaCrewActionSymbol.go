@@ -6,7 +6,6 @@ import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -62,11 +61,11 @@ func (f TriggerSupaCrewActionSymbol) ForceTrigger(state *feature.FeatureState, p
 		}
 	}
 	state.Features = []feature.Feature{}
-	num := rng.RandFromRange(15) + 1
+	num := state.Rng.RandFromRange(15) + 1
 	tileid := params.GetInt(PARAM_ID_TRIGGER_SUPA_CREW_ACTION_SYMBOL_TILE_ID)
 	for i := 0; i < num; i++ {
-		x := rng.RandFromRange(5)
-		y := rng.RandFromRange(3)
+		x := state.Rng.RandFromRange(5)
+		y := state.Rng.RandFromRange(3)
 		state.SourceGrid[x][y] = tileid
 		state.SymbolGrid[x][y] = tileid
 	}
@@ -5,7 +5,6 @@ import (
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -32,7 +31,7 @@ func (f TriggerSupaCrewSuperSymbol) Trigger(state *feature.FeatureState, params
 	}
 
 	random := params.GetInt(PARAM_ID_TRIGGER_SUPA_CREW_SUPER_SYMBOL_RANDOM)
-	ran15 := rng.RandFromRange(15)
+	ran15 := state.Rng.RandFromRange(15)
 	ran9 := random / 9
 	if ran9 >= 30 && ran9 <= 39 {
 		x := ran15 / 5
@@ -49,11 +48,11 @@ func (f TriggerSupaCrewSuperSymbol) Trigger(state *feature.FeatureState, params
 }
 
 func (f TriggerSupaCrewSuperSymbol) ForceTrigger(state *feature.FeatureState, params feature.FeatureParams) {
-	params[featureProducts.PARAM_ID_FAT_TILE_X] = rng.RandFromRange(3)
-	params[featureProducts.PARAM_ID_FAT_TILE_Y] = rng.RandFromRange(5) - 2
+	params[featureProducts.PARAM_ID_FAT_TILE_X] = state.Rng.RandFromRange(3)
+	params[featureProducts.PARAM_ID_FAT_TILE_Y] = state.Rng.RandFromRange(5) - 2
 	params[featureProducts.PARAM_ID_FAT_TILE_W] = 3
 	params[featureProducts.PARAM_ID_FAT_TILE_H] = 3
-	params[featureProducts.PARAM_ID_FAT_TILE_TILE_ID] = rng.RandFromRange(9)
+	params[featureProducts.PARAM_ID_FAT_TILE_TILE_ID] = state.Rng.RandFromRange(9)
 
 	feature.ActivateFeatures(f.FeatureDef, state, params)
 }
@@ -28,11 +28,11 @@ func (f *TriggerSwordKingBonus) DataPtr() interface{} {
 func (f TriggerSwordKingBonus) Trigger(state *feature.FeatureState, params feature.FeatureParams) {
 	number := params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_BONUS_NUM_REELS)
 	numberProbs := params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_BONUS_NUM_PROBABILITIES)
-	numIdx := feature.WeightedRandomIndex(numberProbs)
+	numIdx := feature.WeightedRandomIndex(state.Rng, numberProbs)
 	num := number[numIdx]
 
 	reelProbs := feature.ConvertIntSlice(params.GetSlice(PARAM_ID_TRIGGER_SWORD_KING_BONUS_REEL_PROBABILITIES)[numIdx])
-	reelsIdx := feature.WeightedRandomIndex(reelProbs)
+	reelsIdx := feature.WeightedRandomIndex(state.Rng, reelProbs)
 
 	reels := feature.ConvertIntSlice(params.GetSlice(PARAM_ID_TRIGGER_SWORD_KING_BONUS_REEL_POSITIONS)[numIdx].([]interface{})[reelsIdx])
 
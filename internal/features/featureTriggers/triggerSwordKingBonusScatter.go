@@ -5,7 +5,6 @@ import (
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -33,22 +32,22 @@ func (f TriggerSwordKingBonusScatter) Trigger(state *feature.FeatureState, param
 	}
 
 	Probability := params.GetInt(PARAM_ID_TRIGGER_SWORD_KING_BONUS_SCATTER_PROBABILITY)
-	if rng.RandFromRange(10000) < Probability {
+	if state.Rng.RandFromRange(10000) < Probability {
 
 		activate := false
 		positions := []int{}
 		if !params.HasKey(PARAM_ID_TRIGGER_SWORD_KING_BONUS_SCATTER_NUM_SCATTERS) {
 			activate = true
 		} else {
-			numScatters := params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_BONUS_SCATTER_NUM_SCATTERS)[feature.WeightedRandomIndex(
+			numScatters := params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_BONUS_SCATTER_NUM_SCATTERS)[feature.WeightedRandomIndex(state.Rng,
 				params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_BONUS_SCATTER_NUM_PROBABILITIES))]
 			gridh := len(state.SymbolGrid[0])
 
-			reels := feature.RandomPermutation([]int{0, 1, 2, 3, 4})
+			reels := feature.RandomPermutation(state.Rng, []int{0, 1, 2, 3, 4})
 
 			for s := 0; s < numScatters; s++ {
 				reel := reels[s]
-				row := rng.RandFromRange(4)
+				row := state.Rng.RandFromRange(4)
 				pos := reel*gridh + row
 				positions = append(positions, pos)
 			}
@@ -5,7 +5,6 @@ import (
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -38,17 +37,17 @@ func (f TriggerSwordKingFreespin) Trigger(state *feature.FeatureState, params fe
 	}
 
 	Probability := params.GetInt(PARAM_ID_TRIGGER_SWORD_KING_FREESPIN_PROBABILITY)
-	if rng.RandFromRange(10000) < Probability {
-		numIdx := feature.WeightedRandomIndex(params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_FREESPIN_NUM_PROBABILITIES))
+	if state.Rng.RandFromRange(10000) < Probability {
+		numIdx := feature.WeightedRandomIndex(state.Rng, params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_FREESPIN_NUM_PROBABILITIES))
 		numScatters := params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_FREESPIN_NUM_SCATTERS)[numIdx]
 		positions := []int{}
 		gridh := len(state.SymbolGrid[0])
 
-		reels := feature.RandomPermutation([]int{0, 1, 2, 3, 4})
+		reels := feature.RandomPermutation(state.Rng, []int{0, 1, 2, 3, 4})
 
 		for s := 0; s < numScatters; s++ {
 			reel := reels[s]
-			row := rng.RandFromRange(4)
+			row := state.Rng.RandFromRange(4)
 			pos := reel*gridh + row
 			positions = append(positions, pos)
 		}
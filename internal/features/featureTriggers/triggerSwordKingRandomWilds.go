@@ -3,7 +3,6 @@ package featureTriggers
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -29,16 +28,16 @@ func (f *TriggerSwordKingRandomWilds) DataPtr() interface{} {
 
 func (f TriggerSwordKingRandomWilds) Trigger(state *feature.FeatureState, params feature.FeatureParams) {
 	Probability := params.GetInt(PARAM_ID_TRIGGER_SWORD_KING_RANDOM_WILDS_PROBABILITY)
-	if rng.RandFromRange(10000) < Probability {
+	if state.Rng.RandFromRange(10000) < Probability {
 
-		numWilds := params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_RANDOM_WILDS_NUM_WILDS)[feature.WeightedRandomIndex(
+		numWilds := params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_RANDOM_WILDS_NUM_WILDS)[feature.WeightedRandomIndex(state.Rng,
 			params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_RANDOM_WILDS_NUM_PROBABILITIES))]
 		positions := []int{}
 		gridh := len(state.SymbolGrid[0])
 
 		for tries := numWilds * params.GetInt(PARAM_ID_TRIGGER_SWORD_KING_RANDOM_WILDS_RETRY_FACTOR); numWilds > 0 && tries > 0; tries-- {
-			reel := feature.WeightedRandomIndex(params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_RANDOM_WILDS_REEL_PROBABILITIES))
-			row := feature.WeightedRandomIndex(params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_RANDOM_WILDS_ROW_PROBABILITIES))
+			reel := feature.WeightedRandomIndex(state.Rng, params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_RANDOM_WILDS_REEL_PROBABILITIES))
+			row := feature.WeightedRandomIndex(state.Rng, params.GetIntSlice(PARAM_ID_TRIGGER_SWORD_KING_RANDOM_WILDS_ROW_PROBABILITIES))
 			pos := reel*gridh + row
 			if func() bool {
 				for _, p := range positions {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -31,7 +30,7 @@ func (f TriggerSwordKingRespin) Trigger(state *feature.FeatureState, params feat
 	}
 
 	Probability := params.GetInt(PARAM_ID_TRIGGER_SWORD_KING_RESPIN_PROBABILITY)
-	if rng.RandFromRange(10000) < Probability {
+	if state.Rng.RandFromRange(10000) < Probability {
 
 		fstype := params.GetString(PARAM_ID_TRIGGER_SWORD_KING_RESPIN_FSTYPE)
 		numFreespins := params.GetInt(PARAM_ID_TRIGGER_SWORD_KING_RESPIN_NUM_FREESPINS)
@@ -3,7 +3,6 @@ package featureTriggers
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
@@ -34,7 +33,7 @@ func (f TriggerTipsyCharmsFreespin) Trigger(state *feature.FeatureState, params
 		params.GetSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_FREESPIN_NUM_SCATTERS_LEVELS)[level])
 	numScattersProbabilitiesLevels := feature.ConvertIntSlice(
 		params.GetSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_FREESPIN_NUM_SCATTERS_PROBABILITIES_LEVELS)[level])
-	numScatters := numScattersLevels[feature.WeightedRandomIndex(numScattersProbabilitiesLevels)]
+	numScatters := numScattersLevels[feature.WeightedRandomIndex(state.Rng, numScattersProbabilitiesLevels)]
 
 	scatterId := params.GetInt(PARAM_ID_TRIGGER_TIPSY_CHARMS_FREESPIN_SCATTER_ID)
 	gridw, gridh := len(state.SymbolGrid), len(state.SymbolGrid[0])
@@ -44,8 +43,8 @@ func (f TriggerTipsyCharmsFreespin) Trigger(state *feature.FeatureState, params
 	ns := countSymbols(scatterId, state.SymbolGrid)
 
 	for i := 0; i < tries && len(positions) < numScatters; i++ {
-		reel := rng.RandFromRange(gridw)
-		row := rng.RandFromRange(gridh)
+		reel := state.Rng.RandFromRange(gridw)
+		row := state.Rng.RandFromRange(gridh)
 		pos := reel*gridh + row
 		if func() bool {
 			if state.SymbolGrid[reel][row] == scatterId {
@@ -65,7 +64,7 @@ func (f TriggerTipsyCharmsFreespin) Trigger(state *feature.FeatureState, params
 	if len(positions) > 0 {
 		if len(positions)+ns >= 3 {
 			// activate freespins
-			numFreespins := params.GetIntSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_FREESPIN_AMOUNTS)[feature.WeightedRandomIndex(
+			numFreespins := params.GetIntSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_FREESPIN_AMOUNTS)[feature.WeightedRandomIndex(state.Rng,
 				params.GetIntSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_FREESPIN_AMOUNTS_PROBABILITIES))]
 
 			logger.Debugf("activate %d freespins", numFreespins)
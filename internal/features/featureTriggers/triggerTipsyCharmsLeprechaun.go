@@ -50,7 +50,7 @@ func (f TriggerTipsyCharmsLeprechaun) Trigger(state *feature.FeatureState, param
 			for isymbol, s := range r {
 				for iscatter, scatter := range scatterIds {
 					if s == scatter {
-						increment := increments[feature.WeightedRandomIndex(incrementsProbs)]
+						increment := increments[feature.WeightedRandomIndex(state.Rng, incrementsProbs)]
 						iscatter += increment
 						if iscatter >= len(scatterIds) {
 							iscatter = len(scatterIds) - 1
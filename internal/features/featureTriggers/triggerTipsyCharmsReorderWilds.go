@@ -3,7 +3,6 @@ package featureTriggers
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -51,7 +50,7 @@ func (f TriggerTipsyCharmsReorderWilds) Trigger(state *feature.FeatureState, par
 		if nc == 0 {
 			panic("no candidate destination")
 		}
-		ic := rng.RandFromRange(nc)
+		ic := state.Rng.RandFromRange(nc)
 		destinations[i] = candidates[ic]
 		candidates = append(candidates[:ic], candidates[ic+1:]...)
 	}
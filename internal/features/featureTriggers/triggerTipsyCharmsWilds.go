@@ -3,7 +3,6 @@ package featureTriggers
 import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/feature"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
-	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
 )
 
 const (
@@ -30,7 +29,7 @@ func (f TriggerTipsyCharmsWilds) Trigger(state *feature.FeatureState, params fea
 	probabilityLevels := params.GetIntSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_WILDS_PROBABILITY_LEVELS)
 	level := 0
 
-	if probabilityLevels[0] < rng.RandFromRange(10000) {
+	if probabilityLevels[0] < state.Rng.RandFromRange(10000) {
 
 		numWildsLevels := feature.ConvertIntSlice(params.GetSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_WILDS_NUM_WILDS_LEVELS)[level])
 		numProbabilitiesLevels := feature.ConvertIntSlice(params.GetSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_WILDS_NUM_PROBABILITIES_LEVELS)[level])
@@ -38,15 +37,15 @@ func (f TriggerTipsyCharmsWilds) Trigger(state *feature.FeatureState, params fea
 		wildProbabilities := feature.ConvertIntSlice(params.GetSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_WILDS_WILD_PROBABILITIES_LEVELS)[level])
 		reelProbabilities := feature.ConvertIntSlice(params.GetSlice(PARAM_ID_TRIGGER_TIPSY_CHARMS_WILDS_REEL_PROBABILITIES_LEVELS)[level])
 
-		numWilds := numWildsLevels[feature.WeightedRandomIndex(numProbabilitiesLevels)]
+		numWilds := numWildsLevels[feature.WeightedRandomIndex(state.Rng, numProbabilitiesLevels)]
 		tries := numWilds * params.GetInt(PARAM_ID_TRIGGER_TIPSY_CHARMS_WILDS_RETRY_FACTOR)
 		gridh := len(state.SymbolGrid[0])
 		positions := []int{}
 		replaceids := []int{}
 		for i := 0; i < tries && len(positions) < numWilds; i++ {
-			wild := wilds[feature.WeightedRandomIndex(wildProbabilities)]
-			reel := feature.WeightedRandomIndex(reelProbabilities)
-			row := rng.RandFromRange(gridh)
+			wild := wilds[feature.WeightedRandomIndex(state.Rng, wildProbabilities)]
+			reel := feature.WeightedRandomIndex(state.Rng, reelProbabilities)
+			row := state.Rng.RandFromRange(gridh)
 			positions = append(positions, reel*gridh+row)
 			replaceids = append(replaceids, wild)
 		}
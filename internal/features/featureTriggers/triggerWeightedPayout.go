@@ -29,7 +29,7 @@ func (f TriggerWeightedPayout) Trigger(state *feature.FeatureState, params featu
 			weights[i] = 1
 		}
 	}
-	idx := feature.WeightedRandomIndex(weights)
+	idx := feature.WeightedRandomIndex(state.Rng, weights)
 	params[featureProducts.PARAM_ID_INSTA_WIN_TYPE] = featureProducts.PARAM_VALUE_INSTA_WIN_BONUS
 	params[featureProducts.PARAM_ID_INSTA_WIN_SOURCE_ID] = f.FeatureDef.Id
 	params[featureProducts.PARAM_ID_INSTA_WIN_AMOUNT] = payouts[idx]
@@ -25,7 +25,7 @@ func (f TriggerWeightedRandom) Trigger(state *feature.FeatureState, params featu
 			weights[i] = 1
 		}
 	}
-	idx := feature.WeightedRandomIndex(weights)
+	idx := feature.WeightedRandomIndex(state.Rng, weights)
 	matchidx := func(i int, d feature.FeatureDef, s *feature.FeatureState, p feature.FeatureParams) bool {
 		return i == idx
 	}
@@ -60,6 +60,7 @@ type ForceGameplay struct {
 	Action    string `yaml:"action"`
 	ReelsetId int    `yaml:"reelsetId"`
 	StopList  []int  `yaml:"stopList"`
+	Heights   []int  `yaml:"reelHeights"` // forces the reel heights of defs with variable reel heights
 }
 
 func BuildForce(engineID string) []ForceGameplay {
@@ -103,7 +104,10 @@ func smartForceFromID(params engine.GameParams, previousGamestate engine.Gamesta
 	if err != nil {
 		return engine.Gamestate{}, err
 	}
-	engineConf := engine.BuildEngineDefs(engineID)
+	engineConf, err := engine.LoadEngineDefs(engineID)
+	if err != nil {
+		return engine.Gamestate{}, err
+	}
 	forces := BuildForce(engineID)
 	actions := previousGamestate.NextActions
 	if len(actions) == 1 && actions[0] == "finish" {
@@ -147,6 +151,12 @@ func smartForceFromID(params engine.GameParams, previousGamestate engine.Gamesta
 			if err != nil {
 				return gamestate, err
 			}
+			if len(force.Heights) > 0 {
+				engineDef, err = engineDef.SetForceHeights(force.Heights)
+				if err != nil {
+					return gamestate, err
+				}
+			}
 			// get engine and action
 			method := reflect.ValueOf(engineDef).MethodByName(engineDef.Function)
 			gamestateAndNextActions := method.Call([]reflect.Value{reflect.ValueOf(params)})
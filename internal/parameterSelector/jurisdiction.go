@@ -0,0 +1,97 @@
+package parameterSelector
+
+import (
+	"fmt"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// Jurisdiction is the profile of the rules of a regulated market. The profile of a company is resolved when a game is
+// initialised, the rules are enforced on every play and passed on to the client.
+type Jurisdiction struct {
+	Code             string             `yaml:"-" json:"code"`
+	MinSpinDuration  int                `yaml:"minSpinDuration" json:"minSpinDuration,omitempty"`   // milliseconds that must pass before the next round is started
+	NoAutoplay       bool               `yaml:"noAutoplay" json:"noAutoplay,omitempty"`             // rounds may not be started by the autoplay of the client
+	NoTurbo          bool               `yaml:"noTurbo" json:"noTurbo,omitempty"`                   // rounds may not be played in the turbo mode of the client
+	NoGamble         bool               `yaml:"noGamble" json:"noGamble,omitempty"`                 // round wins may not be gambled
+	MaxStake         map[string]float32 `yaml:"maxStake" json:"-"`                                  // max amount wagered on a round by currency
+	MaxWinMultiplier int                `yaml:"maxWinMultiplier" json:"maxWinMultiplier,omitempty"` // cap of the round win as a multiple of the total stake
+	NetPosition      bool               `yaml:"netPosition" json:"netPosition,omitempty"`           // the client must display the net position of the session
+	RealityCheck     int                `yaml:"realityCheck" json:"realityCheck,omitempty"`         // minutes between the reality checks of the client
+}
+
+// GetJurisdiction returns the jurisdiction profile of the company, or else the one of its operator in the operator
+// registry, a company without a jurisdiction has no rules
+func GetJurisdiction(companyId string) (Jurisdiction, rgse.RGSErr) {
+	betConf, err := parseBetConfig()
+	if err != nil {
+		return Jurisdiction{}, err
+	}
+	return getJurisdiction(companyId, betConf)
+}
+
+func getJurisdiction(companyId string, betConf betConfig) (Jurisdiction, rgse.RGSErr) {
+	code, ok := betConf.CompanyJurisdictions[companyId]
+	if !ok {
+		// companies without a jurisdiction of their own have the one of their operator
+		if operator, found := config.GetOperatorByCompany(companyId); found && operator.Jurisdiction != "" {
+			code, ok = operator.Jurisdiction, true
+		}
+	}
+	if !ok {
+		code = betConf.CompanyJurisdictions["default"]
+	}
+	if code == "" {
+		return Jurisdiction{}, nil
+	}
+	jurisdiction, ok := betConf.Jurisdictions[code]
+	if !ok {
+		logger.Errorf("company %v has undefined jurisdiction %v", companyId, code)
+		rgserr := rgse.Create(rgse.BetConfigError)
+		rgserr.AppendErrorText(fmt.Sprintf("undefined jurisdiction %v", code))
+		return Jurisdiction{}, rgserr
+	}
+	jurisdiction.Code = code
+	return jurisdiction, nil
+}
+
+// MaxStakeFor returns the max amount that may be wagered on a round in the currency, zero if it is not limited
+func (jurisdiction Jurisdiction) MaxStakeFor(currency string) engine.Fixed {
+	return engine.NewFixedFromFloat(jurisdiction.MaxStake[currency])
+}
+
+// LimitStakeValues drops the stake values whose total stake is above the max stake of the jurisdiction. A default bet
+// that was dropped is lowered to the highest stake value left.
+func (jurisdiction Jurisdiction) LimitStakeValues(stakeValues []engine.Fixed, defaultBet engine.Fixed, stakeDivisor int, currency string) ([]engine.Fixed, engine.Fixed, rgse.RGSErr) {
+	maxStake := jurisdiction.MaxStakeFor(currency)
+	if maxStake <= 0 {
+		return stakeValues, defaultBet, nil
+	}
+	limited := []engine.Fixed{}
+	for _, s := range stakeValues {
+		if s.Mul(engine.NewFixedFromInt(stakeDivisor)) <= maxStake {
+			limited = append(limited, s)
+		}
+	}
+	if len(limited) == 0 {
+		logger.Errorf("jurisdiction %v max stake %v disallowed all stakes in %v", jurisdiction.Code, maxStake, currency)
+		return nil, 0, rgse.Create(rgse.BetConfigError)
+	}
+	if defaultBet > limited[len(limited)-1] {
+		defaultBet = limited[len(limited)-1]
+	}
+	return limited, defaultBet, nil
+}
+
+// validateJurisdictions checks that the jurisdictions selected for companies are defined
+func validateJurisdictions(betConf betConfig) rgse.RGSErr {
+	for companyId := range betConf.CompanyJurisdictions {
+		if _, err := getJurisdiction(companyId, betConf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -23,11 +23,14 @@ type betConfig struct {
 	StakeValues []int `yaml:"stakeValues"`
 	DefaultBet  int   `yaml:"defaultBet"`
 	//	CcyMultipliers map[string]float32        `yaml:"ccyMultipliers"`
-	CcyMultipliers map[string]map[string]float32      `yaml:"ccyMultipliers"`
-	CcyMinorUnits  map[string]int                     `yaml:"ccyMinorUnits"`
-	Profiles       map[string]map[string]int          `yaml:"profiles"`
-	HostProfiles   map[string]string                  `yaml:"hostProfiles"`
-	Override       map[string]map[string]stakeConfigs `yaml:"override`
+	CcyMultipliers       map[string]map[string]float32      `yaml:"ccyMultipliers"`
+	CcyMinorUnits        map[string]int                     `yaml:"ccyMinorUnits"`
+	Profiles             map[string]map[string]int          `yaml:"profiles"`
+	HostProfiles         map[string]string                  `yaml:"hostProfiles"`
+	Override             map[string]map[string]stakeConfigs `yaml:"override`
+	MaxWinMultipliers    map[string]int                     `yaml:"maxWinMultipliers"`    // cap of the round win by company id
+	Jurisdictions        map[string]Jurisdiction            `yaml:"jurisdictions"`        // rules of regulated markets by code
+	CompanyJurisdictions map[string]string                  `yaml:"companyJurisdictions"` // code of the jurisdiction by company id
 }
 
 type stakeConfigs map[string]stakeConfig
@@ -109,6 +112,9 @@ func readBetConfig() (betConfig, rgse.RGSErr) {
 }
 
 func validateBetConfig(betConf betConfig) rgse.RGSErr {
+	if err := validateJurisdictions(betConf); err != nil {
+		return err
+	}
 	paramService := createLocalParameterService(betConf)
 	valid := true
 	for _, gc := range config.GlobalGameConfig {
@@ -176,6 +182,31 @@ func GetDemoWalletDefaults(currency string, gameID string, betSettingsCode strin
 	return
 }
 
+// GetMaxWinMultiplier returns the cap of the round win, as a multiple of the total stake, that the operator or the
+// jurisdiction of the company requires, the lower one if both do. Zero is returned if round wins are not capped.
+func GetMaxWinMultiplier(companyId string) (int, rgse.RGSErr) {
+	betConf, err := parseBetConfig()
+	if err != nil {
+		return 0, err
+	}
+	return getMaxWinMultiplier(companyId, betConf)
+}
+
+func getMaxWinMultiplier(companyId string, betConf betConfig) (int, rgse.RGSErr) {
+	multiplier, ok := betConf.MaxWinMultipliers[companyId]
+	if !ok {
+		multiplier = betConf.MaxWinMultipliers["default"]
+	}
+	jurisdiction, err := getJurisdiction(companyId, betConf)
+	if err != nil {
+		return 0, err
+	}
+	if jurisdiction.MaxWinMultiplier > 0 && (multiplier == 0 || jurisdiction.MaxWinMultiplier < multiplier) {
+		multiplier = jurisdiction.MaxWinMultiplier
+	}
+	return multiplier, nil
+}
+
 func GetGameplayParameters(lastBet engine.Money, betSettingsCode string, gameID string, betSettingId string) (
 	stakeValues []engine.Fixed, defaultBet engine.Fixed, minBet engine.Fixed, maxBet engine.Fixed, rgserr rgse.RGSErr) {
 	betConf, err := parseBetConfig()
@@ -92,3 +92,55 @@ func testBetLimitNumStakes(game string, company string, numStakes int, t *testin
 		t.Errorf("Bet limit for company %s did not allow the correct number of stakes %d", company, numStakes)
 	}
 }
+
+func TestMaxWinMultiplier(t *testing.T) {
+	multiplier, err := GetMaxWinMultiplier(testCompany)
+	if err != nil || multiplier != 0 {
+		t.Errorf("expected no max win for company %v, got %v %v", testCompany, multiplier, err)
+	}
+}
+
+func TestJurisdiction(t *testing.T) {
+	jurisdiction, err := GetJurisdiction(testCompany)
+	if err != nil || jurisdiction.Code != "" {
+		t.Errorf("expected no jurisdiction for company %v, got %v %v", testCompany, jurisdiction.Code, err)
+	}
+
+	betConf := betConfig{
+		MaxWinMultipliers: map[string]int{"default": 0, "uk-capped": 5000},
+		Jurisdictions: map[string]Jurisdiction{
+			"UK": {MinSpinDuration: 2500, NoAutoplay: true, MaxWinMultiplier: 10000, MaxStake: map[string]float32{"GBP": 5}},
+		},
+		CompanyJurisdictions: map[string]string{"default": "", "uk": "UK", "uk-capped": "UK", "undefined": "XX"},
+	}
+	jurisdiction, err = getJurisdiction("uk", betConf)
+	if err != nil || jurisdiction.Code != "UK" || !jurisdiction.NoAutoplay || jurisdiction.MinSpinDuration != 2500 {
+		t.Errorf("unexpected jurisdiction %#v %v", jurisdiction, err)
+	}
+	if _, err = getJurisdiction("undefined", betConf); err == nil {
+		t.Errorf("expected an error for an undefined jurisdiction")
+	}
+	if validateJurisdictions(betConf) == nil {
+		t.Errorf("expected the undefined jurisdiction to fail validation")
+	}
+
+	// the lower of the caps of the operator and the jurisdiction applies
+	for company, expected := range map[string]int{"other": 0, "uk": 10000, "uk-capped": 5000} {
+		if multiplier, err := getMaxWinMultiplier(company, betConf); err != nil || multiplier != expected {
+			t.Errorf("company %v has max win multiplier %v, expected %v", company, multiplier, expected)
+		}
+	}
+
+	// stakes whose total is above 5 GBP are dropped, a dropped default falls back to the highest stake left
+	stakes := []engine.Fixed{engine.NewFixedFromFloat(0.1), engine.NewFixedFromFloat(0.25), engine.NewFixedFromFloat(0.5)}
+	limited, defaultBet, err := jurisdiction.LimitStakeValues(stakes, stakes[2], 20, "GBP")
+	if err != nil || len(limited) != 2 || defaultBet != stakes[1] {
+		t.Errorf("limited stakes %v with default %v: %v", limited, defaultBet, err)
+	}
+	if limited, _, _ = jurisdiction.LimitStakeValues(stakes, stakes[0], 20, "EUR"); len(limited) != 3 {
+		t.Errorf("stakes limited in a currency without a max stake: %v", limited)
+	}
+	if _, _, err = jurisdiction.LimitStakeValues(stakes, stakes[0], 100, "GBP"); err == nil {
+		t.Errorf("expected an error when no stake is allowed")
+	}
+}
@@ -0,0 +1,129 @@
+package rng
+
+import (
+	"encoding/json"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// Draw is a single random number drawn while auditing a round
+type Draw struct {
+	Range int    `json:"range"`
+	Value int    `json:"value"`
+	Tag   string `json:"tag"`
+}
+
+// AuditRecord holds all draws that were used to derive the outcome of a round
+type AuditRecord struct {
+	RoundId string `json:"roundId"`
+	Draws   []Draw `json:"draws"`
+}
+
+// AuditSink receives the audit record of every finished round, by default the record is logged
+type AuditSink func(record AuditRecord)
+
+// Audit collects the draws of the round that started it
+type Audit struct {
+	round *Round
+	draws []Draw
+}
+
+var auditEnabled int32
+var auditLock sync.Mutex
+var auditSink AuditSink = logAuditRecord
+
+// EnableAudit switches recording of draws on or off
+func EnableAudit(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&auditEnabled, v)
+}
+
+func AuditEnabled() bool {
+	return atomic.LoadInt32(&auditEnabled) == 1
+}
+
+// SetAuditSink replaces the destination of finished audit records
+func SetAuditSink(sink AuditSink) {
+	auditLock.Lock()
+	defer auditLock.Unlock()
+	if sink == nil {
+		sink = logAuditRecord
+	}
+	auditSink = sink
+}
+
+// StartAudit begins recording the draws of the round. Returns nil when auditing is disabled or the round draws from
+// the shared pool.
+func (round *Round) StartAudit() *Audit {
+	if round == nil || !AuditEnabled() {
+		return nil
+	}
+	round.audit = &Audit{round: round}
+	return round.audit
+}
+
+// Stop ends the recording and returns the draws
+func (audit *Audit) Stop() []Draw {
+	if audit == nil {
+		return nil
+	}
+	if audit.round.audit == audit {
+		audit.round.audit = nil
+	}
+	return audit.draws
+}
+
+// Finish ends the recording and passes the draws of the round to the audit sink
+func (audit *Audit) Finish(roundId string) {
+	if audit == nil {
+		return
+	}
+	record := AuditRecord{RoundId: roundId, Draws: audit.Stop()}
+	auditLock.Lock()
+	sink := auditSink
+	auditLock.Unlock()
+	sink(record)
+}
+
+func (audit *Audit) record(n int, value int, tag string) {
+	if tag == "" {
+		tag = callerTag()
+	}
+	audit.draws = append(audit.draws, Draw{Range: n, Value: value, Tag: tag})
+}
+
+func logAuditRecord(record AuditRecord) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		logger.Errorf("could not serialize rng audit record for round %s: %s", record.RoundId, err.Error())
+		return
+	}
+	logger.Infof("rng audit: %s", string(b))
+}
+
+// callerTag returns the name of the function that asked for the draw
+func callerTag() string {
+	pc := make([]uintptr, 8)
+	n := runtime.Callers(3, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		name := frame.Function
+		if idx := strings.LastIndex(name, "/"); idx >= 0 {
+			name = name[idx+1:]
+		}
+		if !strings.HasPrefix(name, "rng.(*Round).RandFromRange") {
+			return name
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -23,7 +23,13 @@ func (pool *Pool) insert(rng *rand.Rand) {
 }
 
 func (pool *Pool) New() *rand.Rand {
-	mt := NewRNG()
+	rng := pool.Seeded(pool.NewSeed())
+	pool.insert(rng)
+	return rng
+}
+
+// NewSeed reads a seed for a mt19937 stream from crypto entropy
+func (pool *Pool) NewSeed() []uint64 {
 	b := make([]byte, 32)
 	_, err := cryptorand.Read(b)
 	if err != nil {
@@ -34,10 +40,14 @@ func (pool *Pool) New() *rand.Rand {
 		seed[i] = binary.LittleEndian.Uint64(b[:8])
 		b = b[8:]
 	}
+	return seed
+}
+
+// Seeded returns a mt19937 stream for the seed, the stream is not added to the pool
+func (pool *Pool) Seeded(seed []uint64) *rand.Rand {
+	mt := NewRNG()
 	mt.SeedFromSlice(seed)
-	rng := rand.New(mt)
-	pool.insert(rng)
-	return rng
+	return rand.New(mt)
 }
 
 func (pool *Pool) Get() *rand.Rand {
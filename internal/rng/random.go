@@ -24,13 +24,33 @@ func RandStringRunes(n int) string {
 	return randStringRunes(rng, n)
 }
 
-// RandFromRange
+// RandFromRange returns a uniformly distributed integer in [0, n) drawn from the pool. The draws of a game round are
+// taken from its Round, the draws of the pool are not part of a round and are not audited.
 func RandFromRange(n int) int {
 	rng := rngPool.Get()
 	defer rngPool.Put(rng)
 	return randFromRange(rng, n)
 }
 
+// NewSeed reads a seed for a mt19937 stream from crypto entropy
+func NewSeed() []uint64 {
+	return rngPool.NewSeed()
+}
+
+// Seeded returns a mt19937 stream that is owned by the caller
+func Seeded(seed []uint64) *rand.Rand {
+	return rngPool.Seeded(seed)
+}
+
+// SeedFrom draws a seed for a round stream from another stream
+func SeedFrom(r *rand.Rand) []uint64 {
+	seed := make([]uint64, 4)
+	for i := range seed {
+		seed[i] = r.Uint64()
+	}
+	return seed
+}
+
 func Uuid() string {
 	return strings.ReplaceAll(base64.StdEncoding.EncodeToString(uuid.NewV4().Bytes()), "/", "_")
 }
@@ -58,5 +78,16 @@ func (rng *MT19937) randStringRunes(n int) string {
 
 func randFromRange(rng *rand.Rand, n int) int {
 	// returns a random integer from 0 to n-1
-	return int(rng.Uint64() % uint64(n))
+	// values from the incomplete last block of size n are rejected to avoid modulo bias
+	if n <= 0 {
+		panic("randFromRange with a non-positive range")
+	}
+	un := uint64(n)
+	limit := -un % un // 2^64 mod n
+	for {
+		v := rng.Uint64()
+		if v >= limit {
+			return int(v % un)
+		}
+	}
 }
@@ -0,0 +1,113 @@
+package rng
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// chi-square critical value for the given degrees of freedom at p = 0.001 (Wilson-Hilferty approximation)
+func chiSquareCritical(df int) float64 {
+	const z = 3.090
+	k := float64(df)
+	h := 2.0 / (9.0 * k)
+	return k * math.Pow(1.0-h+z*math.Sqrt(h), 3)
+}
+
+func TestRandFromRangeUniformity(t *testing.T) {
+	mt := NewRNG()
+	mt.SeedFromSlice([]uint64{0x12345, 0x23456, 0x34567, 0x45678})
+	r := rand.New(mt)
+
+	for _, n := range []int{2, 3, 7, 37, 100, 10000, 4536} {
+		samples := n * 200
+		counts := make([]int, n)
+		for i := 0; i < samples; i++ {
+			v := randFromRange(r, n)
+			if v < 0 || v >= n {
+				t.Fatalf("value %d out of range [0,%d)", v, n)
+			}
+			counts[v]++
+		}
+		expected := float64(samples) / float64(n)
+		chi := 0.0
+		for _, c := range counts {
+			d := float64(c) - expected
+			chi += d * d / expected
+		}
+		if crit := chiSquareCritical(n - 1); chi > crit {
+			t.Errorf("range %d not uniform: chi-square %.2f exceeds %.2f", n, chi, crit)
+		}
+	}
+}
+
+func TestRandFromRangeAudit(t *testing.T) {
+	var records []AuditRecord
+	SetAuditSink(func(record AuditRecord) { records = append(records, record) })
+	EnableAudit(true)
+	defer func() {
+		EnableAudit(false)
+		SetAuditSink(nil)
+	}()
+
+	round := NewRound(nil)
+	audit := round.StartAudit()
+	a := round.RandFromRange(4536)
+	// the draws of the pool and of other rounds are not part of the audited round
+	RandFromRange(10)
+	NewRound(nil).RandFromRange(10)
+	b := round.RandFromRangeTagged(37, "roulette")
+	audit.Finish("round-1")
+	round.RandFromRange(10)
+
+	if len(records) != 1 || records[0].RoundId != "round-1" {
+		t.Fatalf("expected a single audit record for round-1, got %#v", records)
+	}
+	draws := records[0].Draws
+	if len(draws) != 2 {
+		t.Fatalf("expected 2 draws, got %#v", draws)
+	}
+	if draws[0].Range != 4536 || draws[0].Value != a || !strings.Contains(draws[0].Tag, "TestRandFromRangeAudit") {
+		t.Errorf("unexpected first draw %#v", draws[0])
+	}
+	if draws[1].Range != 37 || draws[1].Value != b || draws[1].Tag != "roulette" {
+		t.Errorf("unexpected second draw %#v", draws[1])
+	}
+}
+
+func TestRound(t *testing.T) {
+	Init()
+	round := NewRound(nil)
+	if len(round.Seed) == 0 {
+		t.Fatalf("round has no seed")
+	}
+	drawn := make([]int, 20)
+	for i := range drawn {
+		drawn[i] = round.RandFromRange(4536)
+	}
+
+	// the draws of the round follow from its seed, the goroutine that draws them does not matter
+	replayed := NewRound(round.Seed)
+	done := make(chan []int)
+	go func() {
+		draws := make([]int, len(drawn))
+		for i := range draws {
+			draws[i] = replayed.RandFromRange(4536)
+		}
+		done <- draws
+	}()
+	if draws := <-done; !reflect.DeepEqual(draws, drawn) {
+		t.Errorf("round of the seed drew %v, expected %v", draws, drawn)
+	}
+
+	// a stream round draws from the stream of the caller
+	stream := Seeded(round.Seed)
+	streamed := StreamRound(stream)
+	for i, d := range drawn {
+		if v := streamed.RandFromRange(4536); v != d {
+			t.Fatalf("draw %v of the stream is %v, expected %v", i, v, d)
+		}
+	}
+}
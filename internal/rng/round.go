@@ -0,0 +1,46 @@
+package rng
+
+import (
+	"math/rand"
+)
+
+// Round is the rng of a single game round. Every draw of the round is taken from it, the round is passed to the code
+// that draws so that the round can be reproduced from its seed. A nil round draws from the shared pool, for the draws
+// that are not part of a round.
+type Round struct {
+	Seed  []uint64 // the seed of the stream, empty if the round draws from a stream of the caller
+	rng   *rand.Rand
+	audit *Audit // records the draws of the round while it is audited
+}
+
+// NewRound seeds a stream for a round. A new seed is read from crypto entropy when seed is empty.
+func NewRound(seed []uint64) *Round {
+	if len(seed) == 0 {
+		seed = rngPool.NewSeed()
+	}
+	return &Round{Seed: seed, rng: rngPool.Seeded(seed)}
+}
+
+// StreamRound returns a round that draws from a stream owned by the caller, e.g. the stream of a volume test worker.
+// The round has no seed of its own, its draws follow from the seed of the stream and the draws made on it before.
+func StreamRound(stream *rand.Rand) *Round {
+	return &Round{rng: stream}
+}
+
+// RandFromRange returns a uniformly distributed integer in [0, n) drawn for the round
+func (round *Round) RandFromRange(n int) int {
+	return round.RandFromRangeTagged(n, "")
+}
+
+// RandFromRangeTagged is RandFromRange with an explicit tag for the audit record.
+// The calling function is used as tag when it is empty.
+func (round *Round) RandFromRangeTagged(n int, tag string) int {
+	if round == nil {
+		return RandFromRange(n)
+	}
+	value := randFromRange(round.rng, n)
+	if round.audit != nil {
+		round.audit.record(n, value, tag)
+	}
+	return value
+}
@@ -0,0 +1,148 @@
+// Package seamless is the generic seamless wallet protocol. The rgs posts json requests to the endpoints of the wallet
+// of an operator and the wallet answers with json responses, both are signed with an hmac-sha256 of a shared secret.
+//
+// A wallet must answer a transaction that it already processed, i.e. one with a known transaction id, with the current
+// balance without processing it again, as the rgs resends a transaction that was not answered. A rollback cancels a
+// debit or a credit, a rollback of a transaction that the wallet does not know must be accepted and the transaction
+// refused if it arrives later.
+package seamless
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+)
+
+const (
+	EndpointAuthenticate    = "authenticate"    // AuthenticateRequest, answered with the player and the last transaction of the game
+	EndpointBalance         = "balance"         // BalanceRequest
+	EndpointTransaction     = "transaction"     // Transaction
+	EndpointRollback        = "rollback"        // RollbackRequest
+	EndpointLastTransaction = "lasttransaction" // LastTransactionRequest, answered with the last transaction of the game
+
+	HeaderSignature = "X-Signature" // hex encoded hmac-sha256 of the body
+	HeaderRequestId = "X-Request-Id"
+
+	TypeDebit    = "DEBIT"
+	TypeCredit   = "CREDIT"
+	TypeEndRound = "ENDROUND"
+
+	ErrorInsufficientFunds = "INSUFFICIENT_FUNDS"
+	ErrorTokenExpired      = "TOKEN_EXPIRED"
+	ErrorPlayerNotFound    = "PLAYER_NOT_FOUND"
+	ErrorNotFound          = "NOT_FOUND" // the player has no transaction in the game
+	ErrorLimitExceeded     = "LIMIT_EXCEEDED"
+	ErrorBlocked           = "BLOCKED"
+	ErrorRolledBack        = "ROLLED_BACK" // the transaction was rolled back before it arrived
+	ErrorBadRequest        = "BAD_REQUEST"
+	ErrorInvalidSignature  = "INVALID_SIGNATURE"
+	ErrorInternal          = "INTERNAL_ERROR"
+)
+
+type AuthenticateRequest struct {
+	Token    string `json:"token"`
+	Game     string `json:"game"`
+	Mode     string `json:"mode"`
+	Platform string `json:"platform"`
+	Language string `json:"language"`
+}
+
+type BalanceRequest struct {
+	Token string `json:"token"`
+	Mode  string `json:"mode"`
+}
+
+type LastTransactionRequest struct {
+	Token string `json:"token"`
+	Game  string `json:"game"`
+	Mode  string `json:"mode"`
+}
+
+// Transaction moves an amount of the balance of a player, the wallet keeps the gamestate of the last transaction of
+// the player in a game for the rgs
+type Transaction struct {
+	Token         string `json:"token"`
+	Game          string `json:"game"`
+	Mode          string `json:"mode"`
+	Round         string `json:"round"`
+	TransactionId string `json:"transactionId"`
+	Type          string `json:"type"`
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+	CampaignRef   string `json:"campaignRef,omitempty"`
+	CloseRound    bool   `json:"closeRound"`
+	GameState     string `json:"gameState,omitempty"` // base64 encoded
+	Ttl           int64  `json:"ttl"`                 // seconds that the gamestate must be kept
+	Time          int64  `json:"time,omitempty"`      // unix time that the wallet processed the transaction at
+}
+
+type RollbackRequest struct {
+	Token                 string `json:"token"`
+	Game                  string `json:"game"`
+	Mode                  string `json:"mode"`
+	Round                 string `json:"round"`
+	TransactionId         string `json:"transactionId"`
+	OriginalTransactionId string `json:"originalTransactionId"`
+}
+
+type FreeGames struct {
+	CampaignRef string `json:"campaignRef"`
+	Count       int    `json:"count"`
+	WagerAmount string `json:"wagerAmount"`
+}
+
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// Response is the answer to every request, Error is set if the request was refused
+type Response struct {
+	PlayerId        string       `json:"playerId,omitempty"`
+	Token           string       `json:"token,omitempty"`
+	Currency        string       `json:"currency,omitempty"`
+	Balance         string       `json:"balance,omitempty"`
+	CompanyId       string       `json:"companyId,omitempty"`
+	BetProfile      string       `json:"betProfile,omitempty"`
+	FreeGames       *FreeGames   `json:"freeGames,omitempty"`
+	LastTransaction *Transaction `json:"lastTransaction,omitempty"`
+	Error           *Error       `json:"error,omitempty"`
+}
+
+// Sign returns the signature of the body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of the body
+func Verify(secret string, body []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// FormatAmount returns the decimal amount of the protocol
+func FormatAmount(amount engine.Fixed) string {
+	return amount.ValueAsString()
+}
+
+// ParseAmount reads a decimal amount of the protocol, an empty amount is zero
+func ParseAmount(amount string) (engine.Fixed, error) {
+	if amount == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0, err
+	}
+	return engine.NewFixedFromFloat64(f), nil
+}
@@ -0,0 +1,29 @@
+package seamless
+
+import (
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+)
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"token":"t1"}`)
+	signature := Sign("secret", body)
+	if !Verify("secret", body, signature) {
+		t.Errorf("signature does not verify")
+	}
+	if Verify("other", body, signature) || Verify("secret", []byte(`{"token":"t2"}`), signature) || Verify("secret", body, "zz") {
+		t.Errorf("bad signature verifies")
+	}
+}
+
+func TestAmount(t *testing.T) {
+	amount := engine.NewFixedFromFloat64(12.34)
+	parsed, err := ParseAmount(FormatAmount(amount))
+	if err != nil || parsed != amount {
+		t.Errorf("amount %v read back as %v: %v", amount, parsed, err)
+	}
+	if parsed, err := ParseAmount(""); err != nil || parsed != 0 {
+		t.Errorf("empty amount read as %v: %v", parsed, err)
+	}
+}
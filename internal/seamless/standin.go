@@ -0,0 +1,298 @@
+package seamless
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// StandIn is an in memory seamless wallet that follows the protocol, for integration tests and local development. Its
+// faults make it fail calls so that the retries and rollbacks of the rgs can be tested.
+type StandIn struct {
+	lock         sync.Mutex
+	secret       string
+	players      map[string]*standInPlayer
+	tokens       map[string]string         // player id by token
+	transactions map[string]Transaction    // processed transactions by id
+	freeDebits   map[string]bool           // ids of the debits that were paid with a free game
+	rollbacks    map[string]bool           // ids of the rolled back transactions
+	last         map[string]Transaction    // last transaction by player and game
+	faults       map[string][]StandInFault // faults of the next calls by endpoint
+	calls        map[string]int            // calls by endpoint
+}
+
+type standInPlayer struct {
+	id        string
+	currency  string
+	balance   engine.Fixed
+	companyId string
+	freeGames *FreeGames
+}
+
+// StandInFault is how the stand-in fails a call, a zero fault lets the call through
+type StandInFault struct {
+	Status  int  // http status of the answer
+	Process bool // the call is processed before it fails, as if the answer was lost
+}
+
+// NewStandIn returns a stand-in wallet that signs with the secret
+func NewStandIn(secret string) *StandIn {
+	return &StandIn{
+		secret:       secret,
+		players:      make(map[string]*standInPlayer),
+		tokens:       make(map[string]string),
+		transactions: make(map[string]Transaction),
+		freeDebits:   make(map[string]bool),
+		rollbacks:    make(map[string]bool),
+		last:         make(map[string]Transaction),
+		faults:       make(map[string][]StandInFault),
+		calls:        make(map[string]int),
+	}
+}
+
+// AddPlayer adds a player that authenticates with the token
+func (s *StandIn) AddPlayer(token string, playerId string, currency string, balance engine.Fixed, companyId string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.players[playerId] = &standInPlayer{id: playerId, currency: currency, balance: balance, companyId: companyId}
+	s.tokens[token] = playerId
+}
+
+// SetFreeGames awards free games to the player
+func (s *StandIn) SetFreeGames(playerId string, campaignRef string, count int, wagerAmount engine.Fixed) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if player, ok := s.players[playerId]; ok {
+		player.freeGames = &FreeGames{CampaignRef: campaignRef, Count: count, WagerAmount: FormatAmount(wagerAmount)}
+	}
+}
+
+// Balance returns the balance of the player
+func (s *StandIn) Balance(playerId string) engine.Fixed {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if player, ok := s.players[playerId]; ok {
+		return player.balance
+	}
+	return 0
+}
+
+// Fail makes the next calls of the endpoint fail, one fault per call
+func (s *StandIn) Fail(endpoint string, faults ...StandInFault) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.faults[endpoint] = append(s.faults[endpoint], faults...)
+}
+
+// Calls returns the number of calls of the endpoint, retries included
+func (s *StandIn) Calls(endpoint string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.calls[endpoint]
+}
+
+// Processed tells whether the transaction was processed and not rolled back
+func (s *StandIn) Processed(transactionId string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, ok := s.transactions[transactionId]
+	return ok && !s.rollbacks[transactionId]
+}
+
+func (s *StandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	endpoint := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.answer(w, http.StatusBadRequest, Response{Error: &Error{Code: ErrorBadRequest}})
+		return
+	}
+	if !Verify(s.secret, body, r.Header.Get(HeaderSignature)) {
+		s.answer(w, http.StatusUnauthorized, Response{Error: &Error{Code: ErrorInvalidSignature}})
+		return
+	}
+
+	s.lock.Lock()
+	s.calls[endpoint]++
+	var fault *StandInFault
+	if faults := s.faults[endpoint]; len(faults) > 0 {
+		if faults[0].Status != 0 {
+			fault = &faults[0]
+		}
+		s.faults[endpoint] = faults[1:]
+	}
+	if fault != nil && !fault.Process {
+		s.lock.Unlock()
+		w.WriteHeader(fault.Status)
+		return
+	}
+	status, response := s.process(endpoint, body)
+	s.lock.Unlock()
+
+	if fault != nil {
+		w.WriteHeader(fault.Status)
+		return
+	}
+	s.answer(w, status, response)
+}
+
+func (s *StandIn) answer(w http.ResponseWriter, status int, response Response) {
+	body, _ := json.Marshal(response)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(HeaderSignature, Sign(s.secret, body))
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func refused(status int, code string, message string) (int, Response) {
+	return status, Response{Error: &Error{Code: code, Message: message}}
+}
+
+// process handles a call, the lock must be held
+func (s *StandIn) process(endpoint string, body []byte) (int, Response) {
+	switch endpoint {
+	case EndpointAuthenticate:
+		var rq AuthenticateRequest
+		if err := json.Unmarshal(body, &rq); err != nil {
+			return refused(http.StatusBadRequest, ErrorBadRequest, err.Error())
+		}
+		player, ok := s.player(rq.Token)
+		if !ok {
+			return refused(http.StatusNotFound, ErrorPlayerNotFound, "")
+		}
+		response := s.response(rq.Token, player)
+		if tx, ok := s.last[player.id+"::"+rq.Game]; ok {
+			response.LastTransaction = &tx
+		}
+		return http.StatusOK, response
+	case EndpointBalance:
+		var rq BalanceRequest
+		if err := json.Unmarshal(body, &rq); err != nil {
+			return refused(http.StatusBadRequest, ErrorBadRequest, err.Error())
+		}
+		player, ok := s.player(rq.Token)
+		if !ok {
+			return refused(http.StatusForbidden, ErrorTokenExpired, "")
+		}
+		return http.StatusOK, s.response(rq.Token, player)
+	case EndpointLastTransaction:
+		var rq LastTransactionRequest
+		if err := json.Unmarshal(body, &rq); err != nil {
+			return refused(http.StatusBadRequest, ErrorBadRequest, err.Error())
+		}
+		player, ok := s.player(rq.Token)
+		if !ok {
+			return refused(http.StatusForbidden, ErrorTokenExpired, "")
+		}
+		tx, ok := s.last[player.id+"::"+rq.Game]
+		if !ok {
+			return refused(http.StatusNotFound, ErrorNotFound, "")
+		}
+		response := s.response(rq.Token, player)
+		response.LastTransaction = &tx
+		return http.StatusOK, response
+	case EndpointTransaction:
+		var tx Transaction
+		if err := json.Unmarshal(body, &tx); err != nil {
+			return refused(http.StatusBadRequest, ErrorBadRequest, err.Error())
+		}
+		return s.transaction(tx)
+	case EndpointRollback:
+		var rq RollbackRequest
+		if err := json.Unmarshal(body, &rq); err != nil {
+			return refused(http.StatusBadRequest, ErrorBadRequest, err.Error())
+		}
+		return s.rollback(rq)
+	}
+	return refused(http.StatusNotFound, ErrorBadRequest, "unknown endpoint "+endpoint)
+}
+
+func (s *StandIn) player(token string) (*standInPlayer, bool) {
+	player, ok := s.players[s.tokens[token]]
+	return player, ok
+}
+
+func (s *StandIn) response(token string, player *standInPlayer) Response {
+	return Response{
+		PlayerId:  player.id,
+		Token:     token,
+		Currency:  player.currency,
+		Balance:   FormatAmount(player.balance),
+		CompanyId: player.companyId,
+		FreeGames: player.freeGames,
+	}
+}
+
+func (s *StandIn) transaction(tx Transaction) (int, Response) {
+	player, ok := s.player(tx.Token)
+	if !ok {
+		return refused(http.StatusForbidden, ErrorTokenExpired, "")
+	}
+	if s.rollbacks[tx.TransactionId] {
+		return refused(http.StatusConflict, ErrorRolledBack, "")
+	}
+	if _, ok := s.transactions[tx.TransactionId]; ok {
+		// a retry of a processed transaction
+		logger.Debugf("stand-in wallet repeated transaction %v", tx.TransactionId)
+		return http.StatusOK, s.response(tx.Token, player)
+	}
+	if tx.Currency != "" && tx.Currency != player.currency {
+		return refused(http.StatusBadRequest, ErrorBadRequest, "currency "+tx.Currency)
+	}
+	amount, err := ParseAmount(tx.Amount)
+	if err != nil || amount < 0 {
+		return refused(http.StatusBadRequest, ErrorBadRequest, "amount "+tx.Amount)
+	}
+	switch tx.Type {
+	case TypeDebit:
+		if tx.CampaignRef != "" && player.freeGames != nil && player.freeGames.CampaignRef == tx.CampaignRef && player.freeGames.Count > 0 {
+			player.freeGames.Count--
+			s.freeDebits[tx.TransactionId] = true
+		} else {
+			if player.balance < amount {
+				return refused(http.StatusPaymentRequired, ErrorInsufficientFunds, "")
+			}
+			player.balance -= amount
+		}
+	case TypeCredit:
+		player.balance += amount
+	case TypeEndRound:
+	default:
+		return refused(http.StatusBadRequest, ErrorBadRequest, "type "+tx.Type)
+	}
+	tx.Time = time.Now().Unix()
+	s.transactions[tx.TransactionId] = tx
+	s.last[player.id+"::"+tx.Game] = tx
+	return http.StatusOK, s.response(tx.Token, player)
+}
+
+func (s *StandIn) rollback(rq RollbackRequest) (int, Response) {
+	player, ok := s.player(rq.Token)
+	if !ok {
+		return refused(http.StatusForbidden, ErrorTokenExpired, "")
+	}
+	if s.rollbacks[rq.OriginalTransactionId] {
+		return http.StatusOK, s.response(rq.Token, player)
+	}
+	s.rollbacks[rq.OriginalTransactionId] = true
+	tx, ok := s.transactions[rq.OriginalTransactionId]
+	if !ok {
+		// the transaction is refused if it arrives later
+		return http.StatusOK, s.response(rq.Token, player)
+	}
+	amount, _ := ParseAmount(tx.Amount)
+	switch {
+	case tx.Type == TypeDebit && s.freeDebits[tx.TransactionId]:
+		player.freeGames.Count++
+	case tx.Type == TypeDebit:
+		player.balance += amount
+	case tx.Type == TypeCredit:
+		player.balance -= amount
+	}
+	return http.StatusOK, s.response(rq.Token, player)
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+)
+
+// the services of the seamless wallets by wallet name, a service is rebuilt when its wallet changes in the registry
+var seamlessServices struct {
+	sync.Mutex
+	wallets  map[string]config.Wallet
+	services map[string]Service
+}
+
+// adapterService returns the service that the sessions and transactions of the wallet go through, the transactions
+// of the remote wallets that fail without an answer are queued for reconciliation
+func adapterService(wallet config.Wallet) Service {
+	service := walletAdapter(wallet)
+	if wallet.Adapter == config.WalletAdapterDemo || reconcile == nil {
+		return service
+	}
+	return &reconcileService{service, wallet.Name, reconcile}
+}
+
+func walletAdapter(wallet config.Wallet) Service {
+	switch wallet.Adapter {
+	case config.WalletAdapterDemo:
+		return ServLocal
+	case config.WalletAdapterDashur:
+		return Serv
+	case config.WalletAdapterSeamless:
+		return seamlessService(wallet)
+	}
+	panic(fmt.Sprintf("unknown wallet adapter [%s]", wallet.Adapter))
+}
+
+func seamlessService(wallet config.Wallet) Service {
+	seamlessServices.Lock()
+	defer seamlessServices.Unlock()
+	if seamlessServices.services == nil {
+		seamlessServices.wallets = make(map[string]config.Wallet)
+		seamlessServices.services = make(map[string]Service)
+	}
+	if service, ok := seamlessServices.services[wallet.Name]; ok && seamlessServices.wallets[wallet.Name] == wallet {
+		return service
+	}
+	// the responsible gaming limits are enforced the same as on the other wallets
+	service := &rgService{NewSeamless(wallet, &config.GlobalConfig), rgSessions}
+	seamlessServices.wallets[wallet.Name] = wallet
+	seamlessServices.services[wallet.Name] = service
+	return service
+}
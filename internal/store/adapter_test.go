@@ -0,0 +1,53 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+func TestWalletRegistry(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	wd, _ := os.Getwd()
+	// the operator registry is read relative to the repository root
+	for dir := wd; dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "config", "operatorConfig.yml")); err == nil {
+			os.Chdir(dir)
+			break
+		}
+	}
+	defer os.Chdir(wd)
+
+	if mode := WalletMode("demo"); mode != ModeDemo {
+		t.Errorf("demo wallet has mode %v", mode)
+	}
+	if err := ValidateWallet("unknown"); err == nil {
+		t.Errorf("unknown wallet validated")
+	}
+	if service := GetService(ModeDemo); service != ServLocal {
+		t.Errorf("demo mode is not served by the demo wallet")
+	}
+
+	tests := []struct {
+		name string
+		call func()
+	}{
+		{"unknown wallet mode", func() { WalletMode("unknown") }},
+		{"unknown wallet adapter", func() { WalletAdapter("unknown") }},
+		{"unknown service mode", func() { GetService(Mode("TEST")) }},
+		{"unknown adapter", func() { walletAdapter(config.Wallet{Name: "paypal", Mode: "REAL", Adapter: "paypal"}) }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic")
+				}
+			}()
+			test.call()
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// GameCategoryBlackjack is the game category of the blackjack engines
+const GameCategoryBlackjack = "blackjack"
+
+var _ GameV3Factory = RegisterGameV3(GameCategoryBlackjack, func() IGameV3 { return new(GameBlackjackV3) })
+
+type GameBlackjackV3 struct {
+	GameV3
+}
+
+func (g *GameBlackjackV3) Base() *GameV3 {
+	return &g.GameV3
+}
+
+func (g GameBlackjackV3) InitState() engine.IGameStateV3 {
+	blackjackState := InitStateBlackjack(g.GameV3.Game, g.GameV3.Currency)
+	return &blackjackState
+}
+
+func (g GameBlackjackV3) SerializeState(state engine.IGameStateV3) []byte {
+	return CompressState(state.Serialize(), COMPRESSION_LZW)
+}
+
+func (g GameBlackjackV3) DeserializeState(serialized []byte) (engine.IGameStateV3, rgse.RGSErr) {
+	state, err := g.DeserializeStateBlackjack(serialized)
+	return &state, err
+}
+
+func (g GameBlackjackV3) DeserializeStateBlackjack(serialized []byte) (state engine.GameStateBlackjack, rgserr rgse.RGSErr) {
+	var uncompressed []byte
+	uncompressed, rgserr = DecompressState(serialized)
+	if rgserr != nil {
+		return
+	}
+	rgserr = state.Deserialize(uncompressed)
+	return
+}
+
+func InitStateBlackjack(game string, currency string) engine.GameStateBlackjack {
+	gameState := engine.GameStateBlackjack{
+		GameStateV3: engine.GameStateV3{
+			Id:            rng.Uuid(),
+			NextGamestate: rng.Uuid(),
+			Game:          game,
+			Version:       "3",
+			Currency:      currency,
+		},
+	}
+	logger.Debugf("init state blackjack %#v", gameState)
+	return gameState
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+const (
+	fileLockWait  = 2 * time.Second
+	fileLockStale = 10 * time.Second // a lock left by an instance that stopped while it held it
+)
+
+// lockFile takes the lock of a file that the instances share on a volume, the lock is a file next to it that only one
+// instance can create. The lock of an instance that stopped while it held it is taken over once it is stale.
+func lockFile(path string) (unlock func(), err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	lock := path + ".lock"
+	deadline := time.Now().Add(fileLockWait)
+	for {
+		file, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(lock) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, statErr := os.Stat(lock); statErr == nil && time.Since(info.ModTime()) > fileLockStale {
+			logger.Warnf("removing stale lock %v", lock)
+			os.Remove(lock)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("lock %v is held", lock)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,157 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureProducts"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/features/featureTriggers"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	_ "gitlab.maverick-ops.com/maverick/rgs-core-v2/testing"
+)
+
+// serializeGamestateV1 writes the gamestate the way it was written before the string fields, the bytes that are
+// already stored in the wallets
+func serializeGamestateV1(gamestate engine.Gamestate) []byte {
+	gamestatePB := gamestate.Convert()
+	gamestatePB.Version = 0
+	gamestatePB.Game = ""
+	gamestatePB.CurrencyCode = ""
+	gamestatePB.ActionName = ""
+	gamestatePB.NextActionNames = nil
+	for _, tx := range gamestatePB.Transactions {
+		tx.CurrencyCode = ""
+		tx.TypeName = ""
+	}
+	data, _ := proto.Marshal(&gamestatePB)
+	return data
+}
+
+// enumEncodable reports whether the version 1 format can hold the gamestate
+func enumEncodable(gamestate engine.Gamestate) bool {
+	if _, ok := engine.GamestatePB_GameID_value[engine.GetPBFromGameID(gamestate.Game)]; !ok {
+		return false
+	}
+	if _, ok := engine.Ccy_value[gamestate.BetPerLine.Currency]; !ok {
+		return false
+	}
+	for _, action := range append([]string{gamestate.Action}, gamestate.NextActions...) {
+		if _, ok := engine.GamestatePB_Action_value[action]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// playGamestates plays base rounds of the game, the rounds that trigger a feature carry its actions
+func playGamestates(t *testing.T, game string, rounds int) []engine.Gamestate {
+	previous := CreateInitGS(PlayerStore{PlayerId: "test", Balance: engine.Money{Amount: 0, Currency: "USD"}}, game)
+	gamestates := []engine.Gamestate{}
+	for i := 0; i < rounds; i++ {
+		params := engine.GameParams{Game: game, Stake: engine.NewFixedFromInt(1), Action: "base"}
+		gamestate, _, err := engine.Play(previous, params.Stake, "USD", params)
+		if err != nil {
+			t.Errorf("%v: play: %v", game, err.Error())
+			break
+		}
+		gamestates = append(gamestates, gamestate)
+	}
+	return gamestates
+}
+
+func assertGamestateMigrated(t *testing.T, gamestate engine.Gamestate) {
+	v2 := DeserializeGamestateFromBytes(SerializeGamestateToBytes(gamestate))
+	if v2.Id != gamestate.Id || v2.Game != gamestate.Game || v2.Action != gamestate.Action ||
+		v2.BetPerLine != gamestate.BetPerLine || !reflect.DeepEqual(v2.NextActions, gamestate.NextActions) ||
+		!reflect.DeepEqual(v2.Transactions, gamestate.Transactions) {
+		t.Errorf("%v: version 2 gamestate did not round trip: %#v", gamestate.Game, v2)
+		return
+	}
+	if !enumEncodable(gamestate) {
+		return
+	}
+	v1 := DeserializeGamestateFromBytes(serializeGamestateV1(gamestate))
+	if !reflect.DeepEqual(v1, v2) {
+		t.Errorf("%v: version 1 gamestate reads %#v, version 2 reads %#v", gamestate.Game, v1, v2)
+	}
+}
+
+func TestGamestateMigration_Engines(t *testing.T) {
+	rng.Init()
+	featureProducts.Register()
+	featureTriggers.Register()
+	for _, engineConf := range config.GlobalGameConfig {
+		if len(engineConf.Games) == 0 {
+			continue
+		}
+		if _, err := CreateGameV3(engineConf.Games[0].Name); err == nil {
+			// the gamestates of the V3 games are not protobuf
+			continue
+		}
+		gamestates := playGamestates(t, engineConf.Games[0].Name, 20)
+		if len(gamestates) == 0 {
+			continue
+		}
+		for _, gamestate := range gamestates {
+			assertGamestateMigrated(t, gamestate)
+		}
+		// every game of the engine and every action of its engine defs
+		for _, game := range engineConf.Games {
+			gamestate := gamestates[0]
+			gamestate.Game = game.Name
+			assertGamestateMigrated(t, gamestate)
+		}
+		for _, def := range engine.BuildEngineDefs(engineConf.EngineID).EngineDefs {
+			gamestate := gamestates[0]
+			gamestate.Action = def.ID
+			gamestate.NextActions = []string{def.ID, "finish"}
+			assertGamestateMigrated(t, gamestate)
+		}
+	}
+}
+
+func TestGamestateMigration_Unregistered(t *testing.T) {
+	// a game, currency and actions that the enums do not have survive the version 2 format
+	gamestate := engine.Gamestate{
+		Id:           "gs-1",
+		Game:         "a-game-without-an-enum",
+		BetPerLine:   engine.Money{Amount: engine.NewFixedFromInt(1), Currency: "XBT"},
+		Action:       "superSpin",
+		NextActions:  []string{"superSpin", "finish"},
+		Transactions: []engine.WalletTransaction{{Id: "gs-1", Amount: engine.Money{Amount: engine.NewFixedFromInt(20), Currency: "XBT"}, Type: "WAGER"}},
+		RoundID:      "gs-1",
+	}
+	if enumEncodable(gamestate) {
+		t.Fatalf("gamestate can be written in version 1")
+	}
+	assertGamestateMigrated(t, gamestate)
+
+	if gamestate.GetTtl() != 3600*24*2 {
+		t.Errorf("round with a pending feature has ttl %v", gamestate.GetTtl())
+	}
+}
+
+func TestGamestateMigration_Version(t *testing.T) {
+	gamestate := engine.Gamestate{
+		Id:           "gs-1",
+		Game:         "the-year-of-zhu",
+		BetPerLine:   engine.Money{Amount: engine.NewFixedFromInt(1), Currency: "USD"},
+		Action:       "base",
+		NextActions:  []string{"finish"},
+		Transactions: []engine.WalletTransaction{{Id: "gs-1", Amount: engine.Money{Amount: engine.NewFixedFromInt(20), Currency: "USD"}, Type: "WAGER"}},
+	}
+	var v1, v2 engine.GamestatePB
+	if err := proto.Unmarshal(serializeGamestateV1(gamestate), &v1); err != nil || v1.WireVersion() != engine.GamestateVersionEnum {
+		t.Errorf("stored gamestate is not version 1: %v", err)
+	}
+	if err := proto.Unmarshal(SerializeGamestateToBytes(gamestate), &v2); err != nil || v2.WireVersion() != engine.GamestateVersionString {
+		t.Errorf("written gamestate is not version 2: %v", err)
+	}
+	// the enums are still written for the readers of version 1
+	if v2.GameId != engine.GamestatePB_THE_YEAR_OF_ZHU || v2.Currency != engine.Ccy_USD || len(v2.NextActions) != 1 || v2.NextActions[0] != engine.GamestatePB_finish {
+		t.Errorf("enums were not written: %v %v %v", v2.GameId, v2.Currency, v2.NextActions)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/lzw"
 	"compress/zlib"
+	"fmt"
 	"io"
 	"time"
 
@@ -159,23 +160,38 @@ func DecompressStateLzw(compressed []byte) (serialized []byte, rgserr rgse.RGSEr
 	return
 }
 
-func (g *GameV3) Init(token Token, wallet string, currency string) {
+func (g *GameV3) Init(token Token, wallet string, currency string) (rgserr rgse.RGSErr) {
 	g.Token = token
 	g.Wallet = wallet
 	g.Currency = currency
-	g.EngineConf = engine.BuildEngineDefs(g.EngineId)
+	g.EngineConf, rgserr = engine.LoadEngineDefs(g.EngineId)
+	return
+}
+
+// GameV3Factory creates the game of a V3 game type
+type GameV3Factory func() IGameV3
+
+// the V3 game types by the category of their engines in the game config
+var gameV3Types = map[string]GameV3Factory{}
+
+// RegisterGameV3 registers the game of a V3 game type, the engines of the category are played by the game and their
+// gamestates are its state type
+func RegisterGameV3(category string, factory GameV3Factory) GameV3Factory {
+	if _, exists := gameV3Types[category]; exists {
+		panic(fmt.Sprintf("V3 game type %s already registered", category))
+	}
+	gameV3Types[category] = factory
+	return factory
 }
 
 func CreateGameV3FromEngine(engineId string) (IGameV3, rgse.RGSErr) {
-	switch engineId {
-	case "mvgEngineRoulette1":
-		return &GameRouletteV3{
-			GameV3: GameV3{
-				EngineId: engineId,
-			},
-		}, nil
+	factory, ok := gameV3Types[config.GetCategoryFromEngine(engineId)]
+	if !ok {
+		return nil, rgse.Create(rgse.EngineNotFoundError)
 	}
-	return nil, rgse.Create(rgse.EngineNotFoundError)
+	gameV3 := factory()
+	gameV3.Base().EngineId = engineId
+	return gameV3, nil
 }
 
 func CreateGameV3(game string) (IGameV3, rgse.RGSErr) {
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"testing"
+
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	_ "gitlab.maverick-ops.com/maverick/rgs-core-v2/testing"
+)
+
+func TestCreateGameV3(t *testing.T) {
+	gameV3, err := CreateGameV3("dragon-roulette")
+	if err != nil {
+		t.Fatalf("create roulette: %v", err.Error())
+	}
+	if _, ok := gameV3.(*GameRouletteV3); !ok {
+		t.Fatalf("dragon-roulette is played by %T", gameV3)
+	}
+	if gameV3.Base().Game != "dragon-roulette" || gameV3.Base().EngineId != "mvgEngineRoulette1" {
+		t.Errorf("unexpected game %#v", gameV3.Base())
+	}
+	gameV3.Base().Currency = "USD"
+	if state := gameV3.InitState(); state == nil || state.Base().Game != "dragon-roulette" {
+		t.Errorf("unexpected init state %#v", state)
+	}
+
+	// the slot games have no game category and are not V3 games
+	if _, err := CreateGameV3("the-year-of-zhu"); err == nil || err.(*rgse.RGSError).ErrCode != rgse.EngineNotFoundError {
+		t.Errorf("slot game was created as a V3 game: %v", err)
+	}
+}
+
+func TestRegisterGameV3(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("game type was registered twice")
+		}
+	}()
+	RegisterGameV3(GameCategoryRoulette, func() IGameV3 { return new(GameRouletteV3) })
+}
@@ -27,8 +27,22 @@ var Serv Service
 func Init(getHashes bool) rgse.RGSErr {
 
 	//ServLocal = New(&config.GlobalConfig)
-	ServLocal = NewLocal()
-	Serv = New(&config.GlobalConfig)
+	// the responsible gaming limits of player sessions are enforced on both wallets
+	rgSessions = newRGSessionTracker(config.GlobalConfig.RGSession)
+	ServLocal = &rgLocalService{NewLocal(), rgSessions}
+	Serv = &rgService{New(&config.GlobalConfig), rgSessions}
+	// transactions that fail without an answer are retried or refunded in the background
+	var err rgse.RGSErr
+	if config.GlobalConfig.Reconcile.Path == "" && !config.GlobalConfig.DevMode {
+		// a queue in memory is lost with the instance and is not seen by the other instances
+		err = rgse.Create(rgse.StoreInitError)
+		err.AppendErrorText("reconcile.path is not set, the reconciliation queue must be kept on a shared volume")
+		return err
+	}
+	if reconcile, err = newReconciler(config.GlobalConfig.Reconcile); err != nil {
+		return err
+	}
+	go reconcile.run()
 	if config.GlobalConfig.DevMode {
 		MC = memcache.New(config.GlobalConfig.MCRouter)
 	}
@@ -39,6 +53,22 @@ func Init(getHashes bool) rgse.RGSErr {
 		}
 	}
 
+	// fail on a broken engine config here rather than in the middle of a round
+	diagnostics := engine.LintEngineConfigs()
+	for _, d := range diagnostics {
+		if d.Severity == engine.LintError {
+			logger.Errorf("%v", d.String())
+		} else {
+			logger.Debugf("%v", d.String())
+		}
+	}
+	if n := engine.CountLintErrors(diagnostics); n > 0 {
+		err := rgse.Create(rgse.BadConfigError)
+		err.AppendErrorText(fmt.Sprintf("%v errors in engine configs", n))
+		return err
+	}
+	logger.Infof("engine configs checked, %v warnings", len(diagnostics))
+
 	return nil
 }
 
@@ -70,6 +100,11 @@ func DeserializeGamestateFromBytes(serialized []byte) engine.Gamestate {
 		logger.Warnf("Attempting old format deserialization")
 		return DeserializeGamestateFromBytesLegacy(serialized)
 	}
+	// Convert reads the enums of version 1 and the strings of version 2
+	if version := deserializedGS.WireVersion(); version > engine.GamestateVersion {
+		// a newer writer is being rolled out, the fields this reader knows are still read
+		logger.Warnf("Gamestate wire format version %v is newer than %v", version, engine.GamestateVersion)
+	}
 	gs := deserializedGS.Convert()
 
 	if gs.Id == "" {
@@ -198,32 +233,45 @@ func (r restTransactionRequest) String() string {
 		r.CampaignRef, r.CloseRound, r.Round, r.TxRef, r.Description, r.InternalStatus, r.Ttl, r.TtlStamp)
 }
 
+// GetService returns the service of the default wallet of the mode in the operator registry
 func GetService(mode Mode) Service {
-	switch mode {
-	case ModeDemo:
-		return ServLocal
-	case ModeReal:
-		return Serv
+	wallet, err := config.GetModeWallet(string(mode))
+	if err != nil {
+		panic(fmt.Sprintf("unknown store service mode [%s]", mode))
 	}
-	panic("unknown store service mode")
+	return adapterService(wallet)
 }
 
+// WalletMode returns the mode of the wallet in the operator registry
 func WalletMode(wallet string) Mode {
-	switch wallet {
-	case "demo":
-		return ModeDemo
-	case "dashur":
-		return ModeReal
+	w, err := config.GetWallet(wallet)
+	if err != nil {
+		panic(fmt.Sprintf("unknown wallet mode [%s]", wallet))
 	}
-	panic(fmt.Sprintf("unknown wallet mode [%s]", wallet))
+	return Mode(w.Mode)
 }
 
+// ValidateWallet checks that the wallet is in the operator registry
 func ValidateWallet(wallet string) rgse.RGSErr {
-	switch wallet {
-	case "demo":
-		return nil
-	case "dashur":
-		return nil
+	_, err := config.GetWallet(wallet)
+	return err
+}
+
+// WalletAdapter returns the adapter of the wallet in the operator registry
+func WalletAdapter(wallet string) string {
+	w, err := config.GetWallet(wallet)
+	if err != nil {
+		panic(fmt.Sprintf("unknown wallet adapter [%s]", wallet))
+	}
+	return w.Adapter
+}
+
+// WalletService returns the service of the adapter and the mode of a wallet of the operator registry
+func WalletService(wallet string) (Service, Mode, rgse.RGSErr) {
+	w, err := config.GetWallet(wallet)
+	if err != nil {
+		logger.Debugf("No such wallet '%v'", wallet)
+		return nil, "", err
 	}
-	return rgse.Create(rgse.InvalidWallet)
+	return adapterService(w), Mode(w.Mode), nil
 }
@@ -0,0 +1,499 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"io"
+	"os"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+const (
+	LocalStoreMemory string = "memory"
+	LocalStoreFile   string = "file"
+)
+
+// LocalStore is the storage backend behind LocalServiceImpl. Every value carries a ttl which is
+// applied with gcdata.stamp, expired values are removed by the garbage collector.
+type LocalStore interface {
+	SetToken(token Token, playerId string, ttl int64)
+	GetToken(token Token) (string, bool)
+	DeleteToken(token Token)
+	SetPlayer(playerId string, player PlayerStore, ttl int64)
+	GetPlayer(playerId string) (PlayerStore, bool)
+	SetMessage(playerId string, message string, ttl int64)
+	GetMessage(playerId string) (string, bool)
+	SetTransaction(transactionId string, transaction TransactionStore, ttl int64)
+	GetTransaction(transactionId string) (TransactionStore, bool)
+	SetTransactionByPlayerGame(key string, transaction TransactionStore, ttl int64)
+	GetTransactionByPlayerGame(key string) (TransactionStore, bool)
+	Close() error
+}
+
+var lds LocalStore
+
+func newLocalData() *LocalData {
+	data := new(LocalData)
+	data.Token = make(map[Token]gcstring)
+	data.Player = make(map[string]gcPlayerStore)
+	data.Message = make(map[string]gcstring)
+	data.Transaction = make(map[string]gcTransactionStore)
+	data.TransactionByPlayerGame = make(map[string]gcTransactionStore)
+	return data
+}
+
+// NewLocalStore creates the local store backend selected in the config. The in-memory LocalData is
+// used by the garbage collector in both cases, the file store keeps it in sync with a log on disk.
+func NewLocalStore(c *config.Config, data *LocalData) (LocalStore, rgse.RGSErr) {
+	switch c.LocalStore {
+	case "", LocalStoreMemory:
+		return data, nil
+	case LocalStoreFile:
+		return NewFileLocalStore(c.LocalStorePath, data)
+	}
+	logger.Errorf("unknown local store type %s", c.LocalStore)
+	return nil, rgse.Create(rgse.StoreInitError)
+}
+
+func (ld *LocalData) SetToken(token Token, playerId string, ttl int64) {
+	ld.Lock.Lock()
+	defer ld.Lock.Unlock()
+	ld.Token[token] = NewGcString(playerId, ttl)
+}
+
+func (ld *LocalData) GetToken(token Token) (string, bool) {
+	ld.Lock.RLock()
+	defer ld.Lock.RUnlock()
+	playerId, ok := ld.Token[token]
+	return playerId.str, ok
+}
+
+func (ld *LocalData) DeleteToken(token Token) {
+	ld.Lock.Lock()
+	defer ld.Lock.Unlock()
+	ld.deleteToken(token)
+}
+
+func (ld *LocalData) deleteToken(token Token) bool {
+	playerId, ok := ld.Token[token]
+	// don't delete the token if it matches the player id
+	if ok && string(token) != playerId.str {
+		delete(ld.Token, token)
+		return true
+	}
+	return false
+}
+
+func (ld *LocalData) SetPlayer(playerId string, player PlayerStore, ttl int64) {
+	ld.Lock.Lock()
+	defer ld.Lock.Unlock()
+	ld.Player[playerId] = NewGcPlayerStore(player, ttl)
+}
+
+func (ld *LocalData) GetPlayer(playerId string) (PlayerStore, bool) {
+	ld.Lock.RLock()
+	defer ld.Lock.RUnlock()
+	player, ok := ld.Player[playerId]
+	return player.ps, ok
+}
+
+func (ld *LocalData) SetMessage(playerId string, message string, ttl int64) {
+	ld.Lock.Lock()
+	defer ld.Lock.Unlock()
+	ld.Message[playerId] = NewGcString(message, ttl)
+}
+
+func (ld *LocalData) GetMessage(playerId string) (string, bool) {
+	ld.Lock.RLock()
+	defer ld.Lock.RUnlock()
+	message, ok := ld.Message[playerId]
+	return message.str, ok
+}
+
+func (ld *LocalData) SetTransaction(transactionId string, transaction TransactionStore, ttl int64) {
+	ld.Lock.Lock()
+	defer ld.Lock.Unlock()
+	ld.Transaction[transactionId] = NewGcTransactionStore(transaction, ttl)
+}
+
+func (ld *LocalData) GetTransaction(transactionId string) (TransactionStore, bool) {
+	ld.Lock.RLock()
+	defer ld.Lock.RUnlock()
+	transaction, ok := ld.Transaction[transactionId]
+	return transaction.ts, ok
+}
+
+func (ld *LocalData) SetTransactionByPlayerGame(key string, transaction TransactionStore, ttl int64) {
+	ld.Lock.Lock()
+	defer ld.Lock.Unlock()
+	ld.TransactionByPlayerGame[key] = NewGcTransactionStore(transaction, ttl)
+}
+
+func (ld *LocalData) GetTransactionByPlayerGame(key string) (TransactionStore, bool) {
+	ld.Lock.RLock()
+	defer ld.Lock.RUnlock()
+	transaction, ok := ld.TransactionByPlayerGame[key]
+	return transaction.ts, ok
+}
+
+func (ld *LocalData) Close() error {
+	return nil
+}
+
+type localRecordKind int
+
+const (
+	localRecordToken localRecordKind = iota
+	localRecordPlayer
+	localRecordMessage
+	localRecordTransaction
+	localRecordTransactionByPlayerGame
+	localRecordDeleteToken
+)
+
+type localRecord struct {
+	Kind        localRecordKind
+	Key         string
+	ExpireTs    int64
+	Str         string
+	Player      PlayerStore
+	Transaction TransactionStore
+}
+
+// the log is rewritten when it holds this many more records than there are live values
+const fileStoreCompactSlack int = 50000
+
+// FileLocalStore keeps the local data in memory and in a log file that the instances share on a volume. Every change
+// is appended to the log under the lock of the file and every call first applies the records that the other instances
+// appended. The log is replayed when the store is opened, skipping values that expired while the server was down, and
+// compacted so that it only holds live values. A record is a gob of its own with its length in front of it so that the
+// instances can append to the same log.
+type FileLocalStore struct {
+	*LocalData
+	path    string
+	log     os.FileInfo // the log that was read, a compaction replaces it
+	offset  int64       // the length of the log that was read
+	records int
+}
+
+func NewFileLocalStore(path string, data *LocalData) (*FileLocalStore, rgse.RGSErr) {
+	if path == "" {
+		logger.Errorf("file local store requires a path")
+		return nil, rgse.Create(rgse.StoreInitError)
+	}
+	fs := &FileLocalStore{LocalData: data, path: path}
+	fs.Lock.Lock()
+	defer fs.Lock.Unlock()
+	unlock, err := lockFile(path)
+	if err == nil {
+		defer unlock()
+		if err = fs.read(); err == nil {
+			err = fs.compact()
+		}
+	}
+	if err != nil {
+		logger.Errorf("could not open local store %s: %s", path, err.Error())
+		return nil, rgse.Create(rgse.StoreInitError)
+	}
+	logger.Infof("opened local store %s with %d tokens, %d players, %d tx", path,
+		len(fs.Token), len(fs.Player), len(fs.Transaction))
+	return fs, nil
+}
+
+// read applies the records that were appended to the log since it was last read, the log is read from the start if
+// it was compacted. A record that is still being written is read the next time. Requires the write lock.
+func (fs *FileLocalStore) read() error {
+	file, err := os.Open(fs.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if fs.log == nil || !os.SameFile(fs.log, info) || info.Size() < fs.offset {
+		if fs.log != nil {
+			fs.clear()
+		}
+		fs.log, fs.offset, fs.records = info, 0, 0
+	}
+	if _, err := file.Seek(fs.offset, io.SeekStart); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(file)
+	now := time.Now().Unix()
+	for {
+		body, n, err := readLocalRecord(reader)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fs.offset += n
+		fs.records++
+		var rec localRecord
+		if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&rec); err != nil {
+			logger.Warnf("local store %s skipped a record: %s", fs.path, err.Error())
+			continue
+		}
+		if rec.Kind != localRecordDeleteToken && rec.ExpireTs <= now {
+			continue
+		}
+		fs.apply(rec)
+	}
+}
+
+// clear drops the local data before the log is read from the start. Requires the write lock.
+func (fs *FileLocalStore) clear() {
+	fs.Token = make(map[Token]gcstring)
+	fs.Player = make(map[string]gcPlayerStore)
+	fs.Message = make(map[string]gcstring)
+	fs.Transaction = make(map[string]gcTransactionStore)
+	fs.TransactionByPlayerGame = make(map[string]gcTransactionStore)
+}
+
+// apply sets the value of a record in the local data. Requires the write lock.
+func (fs *FileLocalStore) apply(rec localRecord) {
+	gc := gcdata{expireTs: rec.ExpireTs}
+	switch rec.Kind {
+	case localRecordToken:
+		fs.Token[Token(rec.Key)] = gcstring{gc, rec.Str}
+	case localRecordPlayer:
+		fs.Player[rec.Key] = gcPlayerStore{gc, rec.Player}
+	case localRecordMessage:
+		fs.Message[rec.Key] = gcstring{gc, rec.Str}
+	case localRecordTransaction:
+		fs.Transaction[rec.Key] = gcTransactionStore{gc, rec.Transaction}
+	case localRecordTransactionByPlayerGame:
+		fs.TransactionByPlayerGame[rec.Key] = gcTransactionStore{gc, rec.Transaction}
+	case localRecordDeleteToken:
+		fs.deleteToken(Token(rec.Key))
+	}
+}
+
+func encodeLocalRecord(rec localRecord) ([]byte, error) {
+	var body bytes.Buffer
+	if err := gob.NewEncoder(&body).Encode(rec); err != nil {
+		return nil, err
+	}
+	b := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+body.Len())
+	return append(b[:binary.PutUvarint(b, uint64(body.Len()))], body.Bytes()...), nil
+}
+
+// readLocalRecord reads the next record of the log, n is its length in the log
+func readLocalRecord(reader *bufio.Reader) (body []byte, n int64, err error) {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	body = make([]byte, size)
+	if _, err = io.ReadFull(reader, body); err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	prefix := make([]byte, binary.MaxVarintLen64)
+	return body, int64(binary.PutUvarint(prefix, size)) + int64(size), err
+}
+
+// compact writes all unexpired values to a new log and replaces the current one. Requires the write lock and the
+// lock of the file.
+func (fs *FileLocalStore) compact() error {
+	tmpPath := fs.path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	records := 0
+	now := time.Now().Unix()
+	write := func(rec localRecord) {
+		if err != nil || rec.ExpireTs <= now {
+			return
+		}
+		var b []byte
+		if b, err = encodeLocalRecord(rec); err == nil {
+			_, err = writer.Write(b)
+			records++
+		}
+	}
+	for k, v := range fs.Token {
+		write(localRecord{Kind: localRecordToken, Key: string(k), ExpireTs: v.expireTs, Str: v.str})
+	}
+	for k, v := range fs.Player {
+		write(localRecord{Kind: localRecordPlayer, Key: k, ExpireTs: v.expireTs, Player: v.ps})
+	}
+	for k, v := range fs.Message {
+		write(localRecord{Kind: localRecordMessage, Key: k, ExpireTs: v.expireTs, Str: v.str})
+	}
+	for k, v := range fs.Transaction {
+		write(localRecord{Kind: localRecordTransaction, Key: k, ExpireTs: v.expireTs, Transaction: v.ts})
+	}
+	for k, v := range fs.TransactionByPlayerGame {
+		write(localRecord{Kind: localRecordTransactionByPlayerGame, Key: k, ExpireTs: v.expireTs, Transaction: v.ts})
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	var info os.FileInfo
+	if err == nil {
+		info, err = file.Stat()
+	}
+	file.Close()
+	if err == nil {
+		err = os.Rename(tmpPath, fs.path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	fs.log, fs.offset, fs.records = info, info.Size(), records
+	return nil
+}
+
+// append writes a record to the log after the records of the other instances and applies it. Requires the write lock.
+func (fs *FileLocalStore) append(rec localRecord) {
+	if err := fs.write(rec); err != nil {
+		logger.Errorf("local store %s write failed: %s", fs.path, err.Error())
+	}
+	fs.apply(rec)
+}
+
+func (fs *FileLocalStore) write(rec localRecord) error {
+	b, err := encodeLocalRecord(rec)
+	if err != nil {
+		return err
+	}
+	unlock, err := lockFile(fs.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := fs.read(); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(fs.path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if fs.log == nil {
+		fs.log = info
+	}
+	if info.Size() > fs.offset {
+		// a record left unfinished by an instance that stopped while it wrote it
+		if err := file.Truncate(fs.offset); err != nil {
+			return err
+		}
+	}
+	if _, err := file.WriteAt(b, fs.offset); err != nil {
+		return err
+	}
+	fs.offset += int64(len(b))
+	fs.records++
+	live := len(fs.Token) + len(fs.Player) + len(fs.Message) + len(fs.Transaction) + len(fs.TransactionByPlayerGame)
+	if fs.records > live+fileStoreCompactSlack {
+		// the compacted log holds the record
+		fs.apply(rec)
+		if err := fs.compact(); err != nil {
+			logger.Errorf("local store %s compaction failed: %s", fs.path, err.Error())
+		}
+	}
+	return nil
+}
+
+// refresh applies the records that the other instances appended to the log
+func (fs *FileLocalStore) refresh() {
+	fs.Lock.Lock()
+	defer fs.Lock.Unlock()
+	if err := fs.read(); err != nil {
+		logger.Errorf("local store %s read failed: %s", fs.path, err.Error())
+	}
+}
+
+func (fs *FileLocalStore) SetToken(token Token, playerId string, ttl int64) {
+	fs.Lock.Lock()
+	defer fs.Lock.Unlock()
+	gc := NewGcString(playerId, ttl)
+	fs.append(localRecord{Kind: localRecordToken, Key: string(token), ExpireTs: gc.expireTs, Str: playerId})
+}
+
+func (fs *FileLocalStore) GetToken(token Token) (string, bool) {
+	fs.refresh()
+	return fs.LocalData.GetToken(token)
+}
+
+func (fs *FileLocalStore) DeleteToken(token Token) {
+	fs.Lock.Lock()
+	defer fs.Lock.Unlock()
+	fs.append(localRecord{Kind: localRecordDeleteToken, Key: string(token)})
+}
+
+func (fs *FileLocalStore) SetPlayer(playerId string, player PlayerStore, ttl int64) {
+	fs.Lock.Lock()
+	defer fs.Lock.Unlock()
+	gc := NewGcPlayerStore(player, ttl)
+	fs.append(localRecord{Kind: localRecordPlayer, Key: playerId, ExpireTs: gc.expireTs, Player: player})
+}
+
+func (fs *FileLocalStore) GetPlayer(playerId string) (PlayerStore, bool) {
+	fs.refresh()
+	return fs.LocalData.GetPlayer(playerId)
+}
+
+func (fs *FileLocalStore) SetMessage(playerId string, message string, ttl int64) {
+	fs.Lock.Lock()
+	defer fs.Lock.Unlock()
+	gc := NewGcString(message, ttl)
+	fs.append(localRecord{Kind: localRecordMessage, Key: playerId, ExpireTs: gc.expireTs, Str: message})
+}
+
+func (fs *FileLocalStore) GetMessage(playerId string) (string, bool) {
+	fs.refresh()
+	return fs.LocalData.GetMessage(playerId)
+}
+
+func (fs *FileLocalStore) SetTransaction(transactionId string, transaction TransactionStore, ttl int64) {
+	fs.Lock.Lock()
+	defer fs.Lock.Unlock()
+	gc := NewGcTransactionStore(transaction, ttl)
+	fs.append(localRecord{Kind: localRecordTransaction, Key: transactionId, ExpireTs: gc.expireTs, Transaction: transaction})
+}
+
+func (fs *FileLocalStore) GetTransaction(transactionId string) (TransactionStore, bool) {
+	fs.refresh()
+	return fs.LocalData.GetTransaction(transactionId)
+}
+
+func (fs *FileLocalStore) SetTransactionByPlayerGame(key string, transaction TransactionStore, ttl int64) {
+	fs.Lock.Lock()
+	defer fs.Lock.Unlock()
+	gc := NewGcTransactionStore(transaction, ttl)
+	fs.append(localRecord{Kind: localRecordTransactionByPlayerGame, Key: key, ExpireTs: gc.expireTs, Transaction: transaction})
+}
+
+func (fs *FileLocalStore) GetTransactionByPlayerGame(key string) (TransactionStore, bool) {
+	fs.refresh()
+	return fs.LocalData.GetTransactionByPlayerGame(key)
+}
+
+// Close is a no-op, the log is only open while it is read or written
+func (fs *FileLocalStore) Close() error {
+	return nil
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+func TestFileLocalStore_Reopen(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	dir, _ := ioutil.TempDir("", "localstore")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "local", "store.gob")
+	c := config.Config{LocalStore: LocalStoreFile, LocalStorePath: path}
+
+	store, err := NewLocalStore(&c, newLocalData())
+	if err != nil {
+		t.Fatalf("open local store: %v", err.Error())
+	}
+	player := PlayerStore{
+		PlayerId: "id-1",
+		Token:    "token-1",
+		Balance:  engine.Money{Amount: engine.NewFixedFromInt(100), Currency: "USD"},
+	}
+	tx := TransactionStore{
+		TransactionId: "tx-1",
+		PlayerId:      "id-1",
+		GameState:     []byte{1, 2, 3},
+		Ttl:           3600,
+	}
+	store.SetToken("token-1", "id-1", 3600)
+	store.SetToken("token-2", "id-1", 3600)
+	store.DeleteToken("token-2")
+	store.SetPlayer("id-1", player, 3600)
+	store.SetMessage("id-1", "hello", 3600)
+	store.SetTransaction(tx.TransactionId, tx, tx.Ttl)
+	store.SetTransactionByPlayerGame("id-1::game", tx, tx.Ttl)
+	if e := store.Close(); e != nil {
+		t.Fatalf("close local store: %v", e)
+	}
+
+	reopened, err := NewLocalStore(&c, newLocalData())
+	if err != nil {
+		t.Fatalf("reopen local store: %v", err.Error())
+	}
+	defer reopened.Close()
+
+	if playerId, ok := reopened.GetToken("token-1"); !ok || playerId != "id-1" {
+		t.Errorf("token not restored [%v] [%v]", playerId, ok)
+	}
+	if _, ok := reopened.GetToken("token-2"); ok {
+		t.Errorf("deleted token was restored")
+	}
+	if p, ok := reopened.GetPlayer("id-1"); !ok || p.Balance != player.Balance {
+		t.Errorf("player not restored [%#v]", p)
+	}
+	if m, ok := reopened.GetMessage("id-1"); !ok || m != "hello" {
+		t.Errorf("message not restored [%v]", m)
+	}
+	if rtx, ok := reopened.GetTransaction("tx-1"); !ok || len(rtx.GameState) != 3 {
+		t.Errorf("transaction not restored [%#v]", rtx)
+	}
+	if _, ok := reopened.GetTransactionByPlayerGame("id-1::game"); !ok {
+		t.Errorf("transaction by player game not restored")
+	}
+}
+
+func TestFileLocalStore_Expired(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	dir, _ := ioutil.TempDir("", "localstore")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "store.gob")
+	c := config.Config{LocalStore: LocalStoreFile, LocalStorePath: path}
+
+	store, err := NewLocalStore(&c, newLocalData())
+	if err != nil {
+		t.Fatalf("open local store: %v", err.Error())
+	}
+	// stamp relative to a timestamp far in the past so that the value is already expired on replay
+	ts := gcTs
+	gcTs = ts - 7200
+	store.SetToken("token-old", "id-1", 3600)
+	gcTs = ts
+	store.SetToken("token-new", "id-1", 3600)
+	store.Close()
+
+	reopened, err := NewLocalStore(&c, newLocalData())
+	if err != nil {
+		t.Fatalf("reopen local store: %v", err.Error())
+	}
+	defer reopened.Close()
+	if _, ok := reopened.GetToken("token-old"); ok {
+		t.Errorf("expired token was restored")
+	}
+	if _, ok := reopened.GetToken("token-new"); !ok {
+		t.Errorf("token not restored")
+	}
+}
+
+func TestFileLocalStore_Shared(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	dir, _ := ioutil.TempDir("", "localstore")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "store.gob")
+	c := config.Config{LocalStore: LocalStoreFile, LocalStorePath: path}
+
+	// two instances on the same log
+	first, err := NewLocalStore(&c, newLocalData())
+	if err != nil {
+		t.Fatalf("open local store: %v", err.Error())
+	}
+	second, err := NewLocalStore(&c, newLocalData())
+	if err != nil {
+		t.Fatalf("open local store: %v", err.Error())
+	}
+	first.SetToken("token-1", "id-1", 3600)
+	if playerId, ok := second.GetToken("token-1"); !ok || playerId != "id-1" {
+		t.Errorf("token of the other instance not read [%v] [%v]", playerId, ok)
+	}
+	second.DeleteToken("token-1")
+	if _, ok := first.GetToken("token-1"); ok {
+		t.Errorf("token deleted by the other instance was read")
+	}
+
+	// an instance stopped while it wrote a record
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	file.Write([]byte{100, 1, 2})
+	file.Close()
+	if _, ok := first.GetToken("token-1"); ok {
+		t.Errorf("unfinished record was read")
+	}
+	second.SetMessage("id-1", "hello", 3600)
+	if m, ok := first.GetMessage("id-1"); !ok || m != "hello" {
+		t.Errorf("record after an unfinished record not read [%v]", m)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock of the log was not released")
+	}
+}
@@ -0,0 +1,596 @@
+package store
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// ReconcileAction is what the reconciliation worker sends to settle a transaction that failed without an answer
+type ReconcileAction string
+
+// ReconcileStatus is the state of a pending transaction
+type ReconcileStatus string
+
+const (
+	ReconcileRetry  ReconcileAction = "RETRY"  // resend the transaction with the same id, the wallet does not apply it twice
+	ReconcileRefund ReconcileAction = "REFUND" // refund a transaction of a round that the wallet rolled back or refused
+	ReconcileClose  ReconcileAction = "CLOSE"  // resend the close of a round
+
+	ReconcilePending  ReconcileStatus = "PENDING"
+	ReconcileResolved ReconcileStatus = "RESOLVED"
+	ReconcileManual   ReconcileStatus = "MANUAL" // the attempts ran out, the transaction is resolved on the admin endpoint
+)
+
+// PendingTransaction is a transaction of the reconciliation queue
+type PendingTransaction struct {
+	Id          string
+	Wallet      string
+	Action      ReconcileAction
+	Status      ReconcileStatus
+	Transaction TransactionStore
+	Attempts    int
+	LastError   string
+	Note        string
+	Created     time.Time
+	Updated     time.Time
+	NextAttempt time.Time
+}
+
+// reconciler holds the queue of the transactions to settle. With a queue file the instances share the queue in the
+// file, it is read and written under the lock of the file so that any instance settles the transactions that another
+// one queued, also once that one stopped.
+type reconciler struct {
+	lock    sync.Mutex
+	conf    config.ReconcileConfig
+	pending map[string]*PendingTransaction
+	// unsaved are the transactions that were queued while the file could not be locked, they are added to the queue
+	// the next time it is read
+	unsaved    map[string]*PendingTransaction
+	unlockFile func()
+	// service returns the service of a wallet that the transactions are resent through
+	service func(wallet string) (Service, rgse.RGSErr)
+}
+
+var reconcile *reconciler
+
+func newReconciler(conf config.ReconcileConfig) (*reconciler, rgse.RGSErr) {
+	r := &reconciler{
+		conf:    conf,
+		pending: make(map[string]*PendingTransaction),
+		unsaved: make(map[string]*PendingTransaction),
+		service: reconcileWalletService,
+	}
+	if err := r.acquire(); err != nil {
+		logger.Errorf("could not load reconciliation queue %s: %s", conf.Path, err.Error())
+		return nil, rgse.Create(rgse.StoreInitError)
+	}
+	logger.Infof("loaded %v transactions of the reconciliation queue", len(r.pending))
+	r.release(false)
+	return r, nil
+}
+
+// acquire takes the lock of the queue, with a queue file it locks the file and reads the queue from it
+func (r *reconciler) acquire() error {
+	r.lock.Lock()
+	if r.conf.Path == "" {
+		return nil
+	}
+	unlock, err := lockFile(r.conf.Path)
+	if err == nil {
+		if err = r.load(); err != nil {
+			unlock()
+		}
+	}
+	if err != nil {
+		r.lock.Unlock()
+		return err
+	}
+	r.unlockFile = unlock
+	for id, p := range r.unsaved {
+		if _, ok := r.pending[id]; !ok {
+			r.pending[id] = p
+		}
+	}
+	return nil
+}
+
+// release writes the queue to the file if it changed and releases the lock of the queue
+func (r *reconciler) release(changed bool) {
+	if changed || len(r.unsaved) > 0 {
+		r.save()
+	}
+	if r.unlockFile != nil {
+		r.unlockFile()
+		r.unlockFile = nil
+	}
+	r.lock.Unlock()
+}
+
+// reconcileWalletService returns the adapter of the wallet without the decorators, a resent transaction is neither
+// queued again nor counted against the limits of the player session a second time
+func reconcileWalletService(wallet string) (Service, rgse.RGSErr) {
+	w, err := config.GetWallet(wallet)
+	if err != nil {
+		return nil, err
+	}
+	service := walletAdapter(w)
+	if rg, ok := service.(*rgService); ok {
+		service = rg.Service
+	}
+	return service, nil
+}
+
+func (r *reconciler) interval() time.Duration {
+	if r.conf.Interval > 0 {
+		return time.Duration(r.conf.Interval) * time.Second
+	}
+	return 10 * time.Second
+}
+
+func (r *reconciler) backoff(attempts int) time.Duration {
+	backoff, max := time.Duration(r.conf.Backoff)*time.Second, time.Duration(r.conf.MaxBackoff)*time.Second
+	if backoff <= 0 {
+		backoff = 10 * time.Second
+	}
+	if max <= 0 {
+		max = 10 * time.Minute
+	}
+	for i := 1; i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	return backoff
+}
+
+func (r *reconciler) maxAttempts() int {
+	if r.conf.MaxAttempts > 0 {
+		return r.conf.MaxAttempts
+	}
+	return 10
+}
+
+func (r *reconciler) retention() time.Duration {
+	if r.conf.Retention > 0 {
+		return time.Duration(r.conf.Retention) * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// unanswered tells whether the wallet may or may not have applied a transaction that failed with the error
+func unanswered(err rgse.RGSErr) bool {
+	rgserr, ok := err.(*rgse.RGSError)
+	if !ok {
+		return false
+	}
+	switch rgserr.ErrCode {
+	case rgse.RestError, rgse.RequestTimeout, rgse.GenericWalletError:
+		return true
+	}
+	return false
+}
+
+// Unanswered tells whether the wallet may or may not have applied a transaction that failed with the error, such a
+// transaction is settled by the reconciliation worker
+func Unanswered(err rgse.RGSErr) bool {
+	return unanswered(err)
+}
+
+// enqueue queues the transactions of a round that failed without an answer. They are resent with the same ids, a
+// round that the wallet applied stands and one that it rolled back is refused, nothing is refunded until then.
+func (r *reconciler) enqueue(wallet string, action ReconcileAction, transactions []TransactionStore) {
+	now := time.Now()
+	var queue map[string]*PendingTransaction
+	if err := r.acquire(); err != nil {
+		// the transactions are kept until the queue can be read again
+		logger.Errorf("could not lock reconciliation queue %s: %s", r.conf.Path, err.Error())
+		r.lock.Lock()
+		queue = r.unsaved
+		defer r.lock.Unlock()
+	} else {
+		queue = r.pending
+		defer r.release(true)
+	}
+	for _, tx := range transactions {
+		if _, ok := queue[tx.TransactionId]; ok {
+			continue
+		}
+		logger.Warnf("queued %v of transaction %v of round %v on wallet %v", action, tx.TransactionId, tx.RoundId, wallet)
+		queue[tx.TransactionId] = &PendingTransaction{
+			Id:          tx.TransactionId,
+			Wallet:      wallet,
+			Action:      action,
+			Status:      ReconcilePending,
+			Transaction: tx,
+			Created:     now,
+			Updated:     now,
+			NextAttempt: now.Add(r.backoff(1)),
+		}
+	}
+}
+
+// refundRound refunds the other transactions of the round of a transaction that the wallet rolled back or refused,
+// they may have been applied on their own. The lock must be held.
+func (r *reconciler) refundRound(refused *PendingTransaction, now time.Time) {
+	for _, p := range r.pending {
+		tx := p.Transaction
+		if p == refused || p.Action != ReconcileRetry || p.Wallet != refused.Wallet ||
+			tx.GameId != refused.Transaction.GameId || tx.RoundId != refused.Transaction.RoundId ||
+			(tx.Category != CategoryWager && tx.Category != CategoryPayout) {
+			continue
+		}
+		r.refund(p, now)
+	}
+}
+
+// refund replaces the retry of a transaction with its refund. The refund has the id of the rollback of the seamless
+// adapter so that a rollback that already reached the wallet is not applied twice, the refund of a transaction that
+// the wallet never received is void. The lock must be held.
+func (r *reconciler) refund(p *PendingTransaction, now time.Time) {
+	tx := p.Transaction
+	logger.Warnf("queued %v of transaction %v of round %v on wallet %v", ReconcileRefund, p.Id, tx.RoundId, p.Wallet)
+	settleReconciledScratchTicket(tx, false)
+	p.Action = ReconcileRefund
+	p.Status = ReconcilePending
+	p.Attempts = 0
+	p.NextAttempt = now
+	p.Updated = now
+	p.Transaction = TransactionStore{
+		TransactionId:       "rollback-" + tx.TransactionId,
+		Token:               tx.Token,
+		Mode:                tx.Mode,
+		Category:            CategoryRefund,
+		RoundStatus:         RoundStatusClose,
+		PlayerId:            tx.PlayerId,
+		GameId:              tx.GameId,
+		RoundId:             tx.RoundId,
+		Amount:              tx.Amount,
+		ParentTransactionId: tx.TransactionId,
+		TxTime:              now,
+		FreeGames:           tx.FreeGames,
+		Ttl:                 tx.Ttl,
+	}
+}
+
+// refreshToken sets the token of the queued transactions of the player to the token that the wallet issued last. A
+// wallet that issues a new token on every call refuses a transaction that is resent with the token of its first send.
+func (r *reconciler) refreshToken(wallet string, playerId string, token Token) {
+	if playerId == "" || token == "" {
+		return
+	}
+	stale := func(p *PendingTransaction) bool {
+		return p.Wallet == wallet && p.Transaction.PlayerId == playerId && p.Status != ReconcileResolved && p.Transaction.Token != token
+	}
+	// the queue as it was last read tells whether the player has queued transactions at all
+	r.lock.Lock()
+	refresh := false
+	for _, p := range r.pending {
+		refresh = refresh || stale(p)
+	}
+	r.lock.Unlock()
+	if !refresh {
+		return
+	}
+	if err := r.acquire(); err != nil {
+		logger.Errorf("could not lock reconciliation queue %s: %s", r.conf.Path, err.Error())
+		return
+	}
+	defer r.release(true)
+	for _, p := range r.pending {
+		if stale(p) {
+			p.Transaction.Token = token
+		}
+	}
+}
+
+// send makes one attempt to settle the transaction, the lock must not be held
+func (r *reconciler) send(p PendingTransaction) rgse.RGSErr {
+	service, err := r.service(p.Wallet)
+	if err != nil {
+		return err
+	}
+	tx := p.Transaction
+	switch p.Action {
+	case ReconcileClose:
+		_, err = service.CloseRound(tx.Token, tx.Mode, tx.GameId, tx.RoundId, tx.FreeGames.CampaignRef, tx.GameState, tx.Ttl, nil)
+	default:
+		_, err = service.Transaction(tx.Token, tx.Mode, tx)
+		if rgserr, ok := err.(*rgse.RGSError); ok && p.Action == ReconcileRefund && rgserr.ErrCode == rgse.EntityNotFound {
+			// the wallet never received the transaction, there is nothing to refund
+			logger.Infof("transaction %v of refund %v is unknown to the wallet", tx.ParentTransactionId, p.Id)
+			return nil
+		}
+	}
+	return err
+}
+
+// attempt settles the transaction and records the outcome
+func (r *reconciler) attempt(id string, now time.Time) (PendingTransaction, rgse.RGSErr) {
+	if err := r.acquire(); err != nil {
+		return PendingTransaction{}, reconcileQueueError(err)
+	}
+	p, ok := r.pending[id]
+	if !ok {
+		r.release(false)
+		return PendingTransaction{}, rgse.Create(rgse.EntityNotFound)
+	}
+	pending := *p
+	r.release(false)
+
+	err := r.send(pending)
+	rgserr, ok := err.(*rgse.RGSError)
+	rolledBack := ok && pending.Action == ReconcileRetry && rgserr.ErrCode == rgse.TransactionRolledBack
+	wager := pending.Action == ReconcileRetry && pending.Transaction.Category == CategoryWager
+
+	if lockErr := r.acquire(); lockErr != nil {
+		// the transaction is settled again on a later attempt, the wallet does not apply it twice
+		logger.Errorf("outcome of %v of transaction %v was not recorded", pending.Action, pending.Id)
+		return pending, reconcileQueueError(lockErr)
+	}
+	defer r.release(true)
+	if p, ok = r.pending[id]; !ok {
+		return pending, err
+	}
+	p.Attempts++
+	p.Updated = now
+	switch {
+	case rolledBack:
+		// the wallet did not apply the round, the rest of it is refunded
+		logger.Infof("transaction %v was rolled back by the wallet", p.Id)
+		p.Status = ReconcileResolved
+		p.LastError = ""
+		p.Note = "rolled back by the wallet"
+		settleReconciledScratchTicket(p.Transaction, false)
+		r.refundRound(p, now)
+		err = nil
+	case err == nil:
+		logger.Infof("reconciled %v of transaction %v after %v attempts", p.Action, p.Id, p.Attempts)
+		p.Status = ReconcileResolved
+		p.LastError = ""
+		if p.Action == ReconcileRetry {
+			settleReconciledScratchTicket(p.Transaction, true)
+		}
+	case wager && (!unanswered(err) || p.Attempts >= r.maxAttempts()):
+		// a wallet that cannot report a rollback refuses the resent wager or stops answering, it may still have
+		// applied the wager when it was first sent. The wager and the rest of the round are refunded.
+		logger.Warnf("resent wager %v was not taken by the wallet after %v attempts: %v", p.Id, p.Attempts, err.Error())
+		r.refundRound(p, now)
+		r.refund(p, now)
+		p.LastError = err.Error()
+		p.Note = "refunded, the resent wager was not taken"
+		err = nil
+	default:
+		p.LastError = err.Error()
+		if p.Attempts >= r.maxAttempts() {
+			logger.Errorf("%v of transaction %v failed %v times, it should be resolved by hand: %v", p.Action, p.Id, p.Attempts, p.LastError)
+			p.Status = ReconcileManual
+		} else {
+			p.NextAttempt = now.Add(r.backoff(p.Attempts + 1))
+		}
+	}
+	return *p, err
+}
+
+// process settles the transactions that are due and forgets the ones that were resolved a while ago
+func (r *reconciler) process(now time.Time) {
+	if err := r.acquire(); err != nil {
+		logger.Errorf("could not lock reconciliation queue %s: %s", r.conf.Path, err.Error())
+		return
+	}
+	var due []string
+	for id, p := range r.pending {
+		switch {
+		case p.Status == ReconcilePending && !p.NextAttempt.After(now):
+			// the other instances leave the transaction to this one until its next attempt
+			due = append(due, id)
+			p.NextAttempt = now.Add(r.backoff(p.Attempts + 1))
+		case p.Status == ReconcileResolved && now.Sub(p.Updated) > r.retention():
+			delete(r.pending, id)
+		}
+	}
+	r.release(true)
+	sort.Strings(due)
+	for _, id := range due {
+		r.attempt(id, now)
+	}
+}
+
+func (r *reconciler) run() {
+	for {
+		time.Sleep(r.interval())
+		r.process(time.Now())
+	}
+}
+
+// save writes the queue to the file, the lock must be held
+func (r *reconciler) save() {
+	if r.conf.Path == "" {
+		return
+	}
+	tmp := r.conf.Path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		logger.Errorf("could not write reconciliation queue %s: %s", tmp, err.Error())
+		return
+	}
+	err = gob.NewEncoder(file).Encode(r.pending)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, r.conf.Path)
+	}
+	if err != nil {
+		logger.Errorf("could not write reconciliation queue %s: %s", r.conf.Path, err.Error())
+		return
+	}
+	r.unsaved = make(map[string]*PendingTransaction)
+}
+
+// load reads the queue from the file, the lock must be held
+func (r *reconciler) load() error {
+	file, err := os.Open(r.conf.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	pending := make(map[string]*PendingTransaction)
+	if err := gob.NewDecoder(file).Decode(&pending); err != nil {
+		return err
+	}
+	r.pending = pending
+	return nil
+}
+
+func reconcileQueueError(err error) rgse.RGSErr {
+	rgserr := rgse.Create(rgse.InternalServerError)
+	rgserr.AppendErrorText(fmt.Sprintf("reconciliation queue is not available: %v", err.Error()))
+	return rgserr
+}
+
+// reconcileService queues the transactions of a wallet that fail without an answer
+type reconcileService struct {
+	Service
+	wallet     string
+	reconciler *reconciler
+}
+
+func (s *reconcileService) PlayerByToken(token Token, mode Mode, gameId string) (PlayerStore, GameStateStore, rgse.RGSErr) {
+	player, gameState, err := s.Service.PlayerByToken(token, mode, gameId)
+	if err == nil {
+		s.reconciler.refreshToken(s.wallet, player.PlayerId, player.Token)
+	}
+	return player, gameState, err
+}
+
+func (s *reconcileService) BalanceByToken(token Token, mode Mode) (BalanceStore, rgse.RGSErr) {
+	balance, err := s.Service.BalanceByToken(token, mode)
+	if err == nil {
+		s.reconciler.refreshToken(s.wallet, balance.PlayerId, balance.Token)
+	}
+	return balance, err
+}
+
+func (s *reconcileService) Transaction(token Token, mode Mode, transaction TransactionStore) (BalanceStore, rgse.RGSErr) {
+	balance, err := s.Service.Transaction(token, mode, transaction)
+	if err != nil && unanswered(err) {
+		transaction.Token, transaction.Mode = token, mode
+		s.reconciler.enqueue(s.wallet, ReconcileRetry, []TransactionStore{transaction})
+	}
+	if err == nil {
+		s.reconciler.refreshToken(s.wallet, balance.PlayerId, balance.Token)
+	}
+	return balance, err
+}
+
+func (s *reconcileService) MultiTransaction(token Token, mode Mode, transactions []TransactionStore) (BalanceStore, rgse.RGSErr) {
+	balance, err := s.Service.MultiTransaction(token, mode, transactions)
+	if err != nil && unanswered(err) {
+		queued := make([]TransactionStore, len(transactions))
+		for i, tx := range transactions {
+			tx.Token, tx.Mode = token, mode
+			queued[i] = tx
+		}
+		s.reconciler.enqueue(s.wallet, ReconcileRetry, queued)
+	}
+	if err == nil {
+		s.reconciler.refreshToken(s.wallet, balance.PlayerId, balance.Token)
+	}
+	return balance, err
+}
+
+func (s *reconcileService) CloseRound(token Token, mode Mode, gameId string, roundId string, campaignRef string, gamestate []byte, ttl int64, history *TransactionHistory) (BalanceStore, rgse.RGSErr) {
+	balance, err := s.Service.CloseRound(token, mode, gameId, roundId, campaignRef, gamestate, ttl, history)
+	if err != nil && unanswered(err) {
+		s.reconciler.enqueue(s.wallet, ReconcileClose, []TransactionStore{{
+			TransactionId: "close-" + roundId,
+			Token:         token,
+			Mode:          mode,
+			Category:      CategoryClose,
+			RoundStatus:   RoundStatusClose,
+			GameId:        gameId,
+			RoundId:       roundId,
+			TxTime:        time.Now(),
+			GameState:     gamestate,
+			FreeGames:     FreeGamesStore{CampaignRef: campaignRef},
+			Ttl:           ttl,
+		}})
+	}
+	return balance, err
+}
+
+// PendingTransactions returns the transactions of the reconciliation queue with the status, all of them if the
+// status is empty, oldest first
+func PendingTransactions(status ReconcileStatus) []PendingTransaction {
+	if reconcile == nil {
+		return []PendingTransaction{}
+	}
+	if err := reconcile.acquire(); err != nil {
+		logger.Errorf("could not lock reconciliation queue %s: %s", reconcile.conf.Path, err.Error())
+		return []PendingTransaction{}
+	}
+	defer reconcile.release(false)
+	list := make([]PendingTransaction, 0, len(reconcile.pending))
+	for _, p := range reconcile.pending {
+		if status == "" || p.Status == status {
+			list = append(list, *p)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Created.Before(list[j].Created) })
+	return list
+}
+
+// RetryPendingTransaction makes an attempt to settle a transaction of the queue now, the attempts of a transaction
+// that was left to be resolved by hand start over
+func RetryPendingTransaction(id string) (PendingTransaction, rgse.RGSErr) {
+	if reconcile == nil {
+		return PendingTransaction{}, rgse.Create(rgse.EntityNotFound)
+	}
+	if err := reconcile.acquire(); err != nil {
+		return PendingTransaction{}, reconcileQueueError(err)
+	}
+	p, ok := reconcile.pending[id]
+	if ok && p.Status == ReconcileManual {
+		p.Status = ReconcilePending
+		p.Attempts = 0
+	}
+	pending := ok && p.Status == ReconcilePending
+	reconcile.release(ok)
+	if !pending {
+		return PendingTransaction{}, rgse.Create(rgse.EntityNotFound)
+	}
+	return reconcile.attempt(id, time.Now())
+}
+
+// ResolvePendingTransaction marks a transaction of the queue as settled by hand
+func ResolvePendingTransaction(id string, note string) (PendingTransaction, rgse.RGSErr) {
+	if reconcile == nil {
+		return PendingTransaction{}, rgse.Create(rgse.EntityNotFound)
+	}
+	if err := reconcile.acquire(); err != nil {
+		return PendingTransaction{}, reconcileQueueError(err)
+	}
+	p, ok := reconcile.pending[id]
+	if !ok {
+		reconcile.release(false)
+		return PendingTransaction{}, rgse.Create(rgse.EntityNotFound)
+	}
+	defer reconcile.release(true)
+	logger.Infof("%v of transaction %v resolved by hand: %v", p.Action, p.Id, note)
+	p.Status = ReconcileResolved
+	p.Note = note
+	p.Updated = time.Now()
+	return *p, nil
+}
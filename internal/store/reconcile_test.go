@@ -0,0 +1,314 @@
+package store
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/seamless"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+func testReconciler(t *testing.T, service Service) (*reconciler, string) {
+	dir, err := ioutil.TempDir("", "reconcile")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	r, rgserr := newReconciler(config.ReconcileConfig{Path: filepath.Join(dir, "reconcile.gob"), MaxAttempts: 2})
+	if rgserr != nil {
+		t.Fatalf("new reconciler: %v", rgserr.Error())
+	}
+	r.service = func(string) (Service, rgse.RGSErr) { return service, nil }
+	return r, dir
+}
+
+func TestReconciler_RetryRound(t *testing.T) {
+	service, standIn, server := testSeamlessService("secret")
+	defer server.Close()
+	r, dir := testReconciler(t, service)
+	defer os.RemoveAll(dir)
+	wallet := &reconcileService{service, "seamless-test", r}
+
+	// the wager is taken but neither it nor the rollback of the adapter is answered
+	lost := seamless.StandInFault{Status: http.StatusBadGateway, Process: true}
+	down := seamless.StandInFault{Status: http.StatusBadGateway}
+	standIn.Fail(seamless.EndpointTransaction, lost, down, down)
+	standIn.Fail(seamless.EndpointRollback, down, down, down)
+	_, err := wallet.MultiTransaction("token-1", ModeReal, []TransactionStore{
+		testSeamlessTransaction("tx-1", CategoryWager, 10),
+		testSeamlessTransaction("tx-2", CategoryPayout, 4),
+	})
+	if err == nil {
+		t.Fatalf("unanswered round succeeded")
+	}
+	if standIn.Balance("player-1") != engine.NewFixedFromInt(90) {
+		t.Fatalf("wallet balance %v, expected the wager to be taken", standIn.Balance("player-1"))
+	}
+	pending := pendingTransactionsOf(r, "")
+	if len(pending) != 2 || pending[0].Action != ReconcileRetry || pending[1].Action != ReconcileRetry {
+		t.Fatalf("unexpected queue %#v", pending)
+	}
+
+	// the queue survives a restart
+	restarted, rgserr := newReconciler(r.conf)
+	if rgserr != nil || len(restarted.pending) != 2 {
+		t.Fatalf("queue was not reloaded: %v", rgserr)
+	}
+
+	// the wallet applied the wager, the round stands and is paid
+	r.process(time.Now().Add(time.Hour))
+	if !standIn.Processed("tx-1") || !standIn.Processed("tx-2") || standIn.Balance("player-1") != engine.NewFixedFromInt(94) {
+		t.Errorf("round was not settled, wallet balance %v", standIn.Balance("player-1"))
+	}
+	if pending := pendingTransactionsOf(r, ReconcileResolved); len(pending) != 2 {
+		t.Errorf("round was not resolved: %#v", pendingTransactionsOf(r, ""))
+	}
+}
+
+func TestReconciler_RolledBackRound(t *testing.T) {
+	service, standIn, server := testSeamlessService("secret")
+	defer server.Close()
+	r, dir := testReconciler(t, service)
+	defer os.RemoveAll(dir)
+	wallet := &reconcileService{service, "seamless-test", r}
+
+	// the wager is not answered and the adapter rolls it back
+	lost := seamless.StandInFault{Status: http.StatusBadGateway, Process: true}
+	down := seamless.StandInFault{Status: http.StatusBadGateway}
+	standIn.Fail(seamless.EndpointTransaction, lost, down, down)
+	_, err := wallet.MultiTransaction("token-1", ModeReal, []TransactionStore{
+		testSeamlessTransaction("tx-1", CategoryWager, 10),
+		testSeamlessTransaction("tx-2", CategoryPayout, 4),
+	})
+	if err == nil {
+		t.Fatalf("unanswered round succeeded")
+	}
+	rollbacks := standIn.Calls(seamless.EndpointRollback)
+	if rollbacks != 1 || standIn.Balance("player-1") != engine.NewFixedFromInt(100) {
+		t.Fatalf("wager was not rolled back, wallet balance %v", standIn.Balance("player-1"))
+	}
+
+	// the resent wager is refused, the payout is refunded instead of paid and the wager is not refunded twice
+	r.process(time.Now().Add(time.Hour))
+	if standIn.Processed("tx-1") || standIn.Processed("tx-2") || standIn.Balance("player-1") != engine.NewFixedFromInt(100) {
+		t.Errorf("rolled back round was applied, wallet balance %v", standIn.Balance("player-1"))
+	}
+	if standIn.Calls(seamless.EndpointRollback) != rollbacks+1 {
+		t.Errorf("%v rollbacks, expected only the refund of the payout", standIn.Calls(seamless.EndpointRollback)-rollbacks)
+	}
+	pending := pendingTransactionsOf(r, ReconcileResolved)
+	if len(pending) != 2 {
+		t.Fatalf("round was not resolved: %#v", pendingTransactionsOf(r, ""))
+	}
+	for _, p := range pending {
+		if p.Id == "tx-2" && (p.Action != ReconcileRefund || p.Transaction.TransactionId != "rollback-tx-2") {
+			t.Errorf("payout was not refunded: %#v", p)
+		}
+	}
+}
+
+func TestReconciler_RetryPayout(t *testing.T) {
+	service, standIn, server := testSeamlessService("secret")
+	defer server.Close()
+	r, dir := testReconciler(t, service)
+	defer os.RemoveAll(dir)
+	wallet := &reconcileService{service, "seamless-test", r}
+
+	down := seamless.StandInFault{Status: http.StatusServiceUnavailable}
+	standIn.Fail(seamless.EndpointTransaction, down, down, down)
+	if _, err := wallet.Transaction("token-1", ModeReal, testSeamlessTransaction("tx-2", CategoryPayout, 4)); err == nil {
+		t.Fatalf("unanswered payout succeeded")
+	}
+	pending := pendingTransactionsOf(r, ReconcilePending)
+	if len(pending) != 1 || pending[0].Action != ReconcileRetry {
+		t.Fatalf("unexpected queue %#v", pending)
+	}
+	// the payout is not due before its backoff
+	r.process(time.Now())
+	if standIn.Processed("tx-2") {
+		t.Fatalf("payout was resent before its backoff")
+	}
+
+	// the wallet is still down on the first retry, the attempts then run out
+	standIn.Fail(seamless.EndpointTransaction, down, down, down, down, down, down)
+	r.process(time.Now().Add(time.Hour))
+	r.process(time.Now().Add(2 * time.Hour))
+	if pending := pendingTransactionsOf(r, ReconcileManual); len(pending) != 1 || pending[0].Attempts != 2 {
+		t.Fatalf("payout was not left to be resolved by hand: %#v", pendingTransactionsOf(r, ""))
+	}
+
+	saved := reconcile
+	defer func() { reconcile = saved }()
+	reconcile = r
+	p, err := RetryPendingTransaction("tx-2")
+	if err != nil || p.Status != ReconcileResolved {
+		t.Fatalf("retry by hand failed: %v %#v", err, p)
+	}
+	if !standIn.Processed("tx-2") || standIn.Balance("player-1") != engine.NewFixedFromInt(104) {
+		t.Errorf("payout was not paid, wallet balance %v", standIn.Balance("player-1"))
+	}
+}
+
+func TestReconciler_Definite(t *testing.T) {
+	service, _, server := testSeamlessService("secret")
+	defer server.Close()
+	r, dir := testReconciler(t, service)
+	defer os.RemoveAll(dir)
+	wallet := &reconcileService{service, "seamless-test", r}
+
+	// a wager that the wallet refused is not queued
+	if _, err := wallet.Transaction("token-1", ModeReal, testSeamlessTransaction("tx-1", CategoryWager, 1000)); err == nil {
+		t.Fatalf("wager above the balance succeeded")
+	}
+	if pending := pendingTransactionsOf(r, ""); len(pending) != 0 {
+		t.Errorf("refused wager was queued: %#v", pending)
+	}
+}
+
+func TestReconciler_RefundWithoutRollback(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	// a dashur wallet that issues a new token on every call and cannot report a rollback
+	var lock sync.Mutex
+	token, balance, down, refuse := "token-1", int64(10000), true, false
+	applied := map[string]bool{}
+	var sent []restTransactionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		var rq restTransactionRequest
+		json.NewDecoder(req.Body).Decode(&rq)
+		transaction := strings.HasSuffix(req.URL.Path, "/transaction")
+		if transaction {
+			sent = append(sent, rq)
+		}
+		if transaction && down {
+			// the wager is taken but not answered
+			if !applied[rq.TxRef] {
+				applied[rq.TxRef] = true
+				balance -= rq.Amount
+			}
+			rw.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if rq.Token != token {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if transaction && refuse && rq.Category == string(CategoryWager) {
+			json.NewEncoder(rw).Encode(restTransactionResponse{restErrorResponse: restErrorResponse{ResponseCode: string(ResponseCodeDataError)}})
+			return
+		}
+		if transaction && rq.Category == string(CategoryRefund) {
+			balance += rq.Amount
+		}
+		token = rng.Uuid()
+		json.NewEncoder(rw).Encode(restTransactionResponse{
+			restErrorResponse: restErrorResponse{ResponseCode: string(ResponseCodeOk)},
+			Token:             token,
+			PlayerId:          "player-1",
+			Balance:           balance,
+			Currency:          "USD",
+		})
+	}))
+	defer server.Close()
+	service := testRemoteServiceForTransaction(server.URL)
+	r, dir := testReconciler(t, service)
+	defer os.RemoveAll(dir)
+	wallet := &reconcileService{service, "dashur-test", r}
+
+	wager := testSeamlessTransaction("tx-1", CategoryWager, 10)
+	wager.PlayerId = "player-1"
+	if _, err := wallet.Transaction("token-1", ModeReal, wager); err == nil {
+		t.Fatalf("unanswered wager succeeded")
+	}
+	if balance != 9000 {
+		t.Fatalf("wallet balance %v, expected the wager to be taken", balance)
+	}
+
+	// the player goes on with a new token, the queued wager is resent with it
+	lock.Lock()
+	down = false
+	lock.Unlock()
+	if _, err := wallet.BalanceByToken("token-1", ModeReal); err != nil {
+		t.Fatalf("balance: %v", err.Error())
+	}
+	if pending := pendingTransactionsOf(r, ReconcilePending); len(pending) != 1 || pending[0].Transaction.Token != Token(token) {
+		t.Fatalf("token of the queued wager was not refreshed: %#v", pending)
+	}
+
+	// the wallet refuses the resent wager, the wager is refunded instead of left to be resolved by hand
+	lock.Lock()
+	refuse = true
+	issued := token
+	lock.Unlock()
+	r.process(time.Now().Add(time.Hour))
+	if last := sent[len(sent)-1]; last.TxRef != "tx-1" || last.Token != issued {
+		t.Fatalf("wager was resent as %#v", last)
+	}
+	pending := pendingTransactionsOf(r, ReconcilePending)
+	if len(pending) != 1 || pending[0].Action != ReconcileRefund || pending[0].Transaction.TransactionId != "rollback-tx-1" {
+		t.Fatalf("refund of the wager was not queued: %#v", pendingTransactionsOf(r, ""))
+	}
+	r.process(time.Now().Add(2 * time.Hour))
+	if balance != 10000 {
+		t.Errorf("wager was not refunded, wallet balance %v", balance)
+	}
+	if pending := pendingTransactionsOf(r, ReconcileResolved); len(pending) != 1 || pending[0].Action != ReconcileRefund {
+		t.Errorf("refund was not resolved: %#v", pendingTransactionsOf(r, ""))
+	}
+}
+
+// pendingTransactionsOf returns the queue of a reconciler that is not the one of the worker
+func pendingTransactionsOf(r *reconciler, status ReconcileStatus) []PendingTransaction {
+	saved := reconcile
+	defer func() { reconcile = saved }()
+	reconcile = r
+	return PendingTransactions(status)
+}
+
+func TestReconciler_Shared(t *testing.T) {
+	service, standIn, server := testSeamlessService("secret")
+	defer server.Close()
+	r, dir := testReconciler(t, service)
+	defer os.RemoveAll(dir)
+	other, rgserr := newReconciler(r.conf)
+	if rgserr != nil {
+		t.Fatalf("new reconciler: %v", rgserr.Error())
+	}
+	other.service = r.service
+	wallet := &reconcileService{service, "seamless-test", r}
+
+	down := seamless.StandInFault{Status: http.StatusServiceUnavailable}
+	standIn.Fail(seamless.EndpointTransaction, down, down, down)
+	if _, err := wallet.Transaction("token-1", ModeReal, testSeamlessTransaction("tx-2", CategoryPayout, 4)); err == nil {
+		t.Fatalf("unanswered payout succeeded")
+	}
+
+	// another instance settles the payout that this one queued, this one does not send it again
+	other.process(time.Now().Add(time.Hour))
+	if !standIn.Processed("tx-2") {
+		t.Fatalf("payout was not settled by the other instance")
+	}
+	calls := standIn.Calls(seamless.EndpointTransaction)
+	r.process(time.Now().Add(time.Hour))
+	if standIn.Calls(seamless.EndpointTransaction) != calls {
+		t.Errorf("resolved payout was sent again")
+	}
+	if pending := pendingTransactionsOf(r, ReconcileResolved); len(pending) != 1 {
+		t.Errorf("payout is not resolved in the shared queue: %#v", pendingTransactionsOf(r, ""))
+	}
+	if _, err := os.Stat(r.conf.Path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock of the queue was not released")
+	}
+}
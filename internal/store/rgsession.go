@@ -0,0 +1,393 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// RGLimits are the responsible gaming limits of a player session, a zero limit is not enforced
+type RGLimits struct {
+	LossLimit    engine.Fixed  // max net loss of the session
+	WagerLimit   engine.Fixed  // max amount wagered in the session
+	TimeLimit    time.Duration // wagers are refused once the session is older
+	RealityCheck time.Duration // interval of the reality checks
+}
+
+// RGSession accumulates the play of a player from the first transaction until the player is idle
+type RGSession struct {
+	PlayerId     string
+	Currency     string
+	Start        time.Time
+	LastActivity time.Time
+	LastCheck    time.Time // time of the last reality check, the start of the session before the first one
+	Wagered      engine.Fixed
+	Won          engine.Fixed
+	Limits       RGLimits
+}
+
+// RealityCheck reminds the player of the time spent and the amounts played in the session
+type RealityCheck struct {
+	Elapsed     int64        `json:"elapsed"` // seconds since the start of the session
+	Wagered     engine.Fixed `json:"wagered"`
+	Won         engine.Fixed `json:"won"`
+	NetPosition engine.Fixed `json:"netPosition"`
+	Currency    string       `json:"currency"`
+}
+
+// NetPosition returns the amount won less the amount wagered in the session
+func (session RGSession) NetPosition() engine.Fixed {
+	return session.Won - session.Wagered
+}
+
+// rgSessionTracker keeps the sessions by token and by player id and currency. Tokens are renewed on most wallet calls,
+// the session follows the renewed token. The sessions are held in memory only: they start over when the instance
+// restarts and each instance tracks the players that play on it, so the limits hold across a session only when the
+// player is routed to the same instance. The limits are advisory, see the README.
+type rgSessionTracker struct {
+	lock     sync.Mutex
+	conf     config.RGSessionConfig
+	sessions map[string]*RGSession
+}
+
+var rgSessions *rgSessionTracker
+
+func newRGSessionTracker(conf config.RGSessionConfig) *rgSessionTracker {
+	return &rgSessionTracker{conf: conf, sessions: make(map[string]*RGSession)}
+}
+
+// defaultLimits returns the configured limits of a session in the currency, the amounts of a currency without a
+// configured limit are not limited
+func (t *rgSessionTracker) defaultLimits(currency string) RGLimits {
+	return RGLimits{
+		LossLimit:    engine.NewFixedFromFloat64(t.conf.LossLimit[currency]),
+		WagerLimit:   engine.NewFixedFromFloat64(t.conf.WagerLimit[currency]),
+		TimeLimit:    time.Duration(t.conf.TimeLimit) * time.Minute,
+		RealityCheck: time.Duration(t.conf.RealityCheck) * time.Minute,
+	}
+}
+
+func (t *rgSessionTracker) idle() time.Duration {
+	if t.conf.Idle <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(t.conf.Idle) * time.Minute
+}
+
+func tokenKey(token Token) string {
+	return "token:" + string(token)
+}
+
+func playerKey(playerId string, currency string) string {
+	return "player:" + playerId + ":" + currency
+}
+
+// find returns the active session of the token or of the player in the currency, the lock must be held
+func (t *rgSessionTracker) find(token Token, playerId string, currency string, now time.Time) *RGSession {
+	session, ok := t.sessions[tokenKey(token)]
+	if !ok && playerId != "" && currency != "" {
+		session, ok = t.sessions[playerKey(playerId, currency)]
+	}
+	if !ok || now.Sub(session.LastActivity) > t.idle() {
+		return nil
+	}
+	return session
+}
+
+// session returns the active session of the token or player, a new session is started if there is none. The lock must
+// be held.
+func (t *rgSessionTracker) session(token Token, playerId string, currency string, now time.Time) *RGSession {
+	session := t.find(token, playerId, currency, now)
+	if session == nil {
+		t.prune(now)
+		session = &RGSession{PlayerId: playerId, Currency: currency, Start: now, LastActivity: now, LastCheck: now, Limits: t.defaultLimits(currency)}
+		logger.Debugf("starting responsible gaming session of player %v", playerId)
+	}
+	if session.PlayerId == "" {
+		session.PlayerId = playerId
+	}
+	if session.Currency == "" && currency != "" {
+		// the amounts of a session that was started without a currency are limited once its currency is known
+		session.Currency = currency
+		defaults := t.defaultLimits(currency)
+		if session.Limits.LossLimit == 0 && session.Limits.WagerLimit == 0 {
+			session.Limits.LossLimit, session.Limits.WagerLimit = defaults.LossLimit, defaults.WagerLimit
+		}
+	}
+	t.sessions[tokenKey(token)] = session
+	if session.PlayerId != "" && session.Currency != "" {
+		t.sessions[playerKey(session.PlayerId, session.Currency)] = session
+	}
+	return session
+}
+
+// follow moves the session of the token to the renewed token
+func (t *rgSessionTracker) follow(token Token, newToken Token, playerId string) {
+	if newToken == "" || newToken == token {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if session := t.find(token, playerId, "", time.Now()); session != nil {
+		delete(t.sessions, tokenKey(token))
+		t.sessions[tokenKey(newToken)] = session
+	}
+}
+
+// prune drops the sessions of idle players, the lock must be held
+func (t *rgSessionTracker) prune(now time.Time) {
+	for key, session := range t.sessions {
+		if now.Sub(session.LastActivity) > t.idle() {
+			delete(t.sessions, key)
+		}
+	}
+}
+
+// wagered returns the amount wagered by the player in the transactions, free game wagers are not paid by the player
+func wagered(transactions []TransactionStore) (wager engine.Fixed) {
+	for _, tx := range transactions {
+		if tx.Category == CategoryWager && tx.FreeGames.CampaignRef == "" {
+			wager += tx.Amount.Amount
+		}
+	}
+	return
+}
+
+// reserve refuses transactions that wager more than the limits of the session allow and adds the wager of the others to
+// the session before they are sent, so that rounds of the player that are sent at the same time are held to the limits
+// together. The session is nil if the transactions wager nothing.
+func (t *rgSessionTracker) reserve(token Token, transactions []TransactionStore) (*RGSession, engine.Fixed, rgse.RGSErr) {
+	wager := wagered(transactions)
+	if wager == 0 {
+		// payouts of rounds that were already paid for are never refused
+		return nil, 0, nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := time.Now()
+	session := t.session(token, transactions[0].PlayerId, transactions[0].Amount.Currency, now)
+	limits := session.Limits
+	switch {
+	case limits.TimeLimit > 0 && now.Sub(session.Start) > limits.TimeLimit:
+		logger.Debugf("player %v session started %v ago, the limit is %v", session.PlayerId, now.Sub(session.Start), limits.TimeLimit)
+		return nil, 0, rgse.Create(rgse.SessionTimeLimit)
+	case limits.WagerLimit > 0 && session.Wagered+wager > limits.WagerLimit:
+		logger.Debugf("player %v wager %v exceeds the session wager limit %v", session.PlayerId, session.Wagered+wager, limits.WagerLimit)
+		return nil, 0, rgse.Create(rgse.SpendingBudgetExceeded)
+	case limits.LossLimit > 0 && session.Wagered+wager-session.Won > limits.LossLimit:
+		logger.Debugf("player %v loss %v exceeds the session loss limit %v", session.PlayerId, session.Wagered+wager-session.Won, limits.LossLimit)
+		return nil, 0, rgse.Create(rgse.SpendingBudgetExceeded)
+	}
+	session.Wagered += wager
+	return session, wager, nil
+}
+
+// release takes the reserved wager of transactions that the wallet refused back from the session
+func (t *rgSessionTracker) release(session *RGSession, wager engine.Fixed) {
+	if session == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	session.Wagered -= wager
+}
+
+// record adds the payouts of the transactions that the wallet accepted to the session, their wager was added when it
+// was reserved. Refunds are not recorded, the wallet does not tell whether a wager or a payout was refunded.
+func (t *rgSessionTracker) record(token Token, transactions []TransactionStore, balance BalanceStore) {
+	if len(transactions) == 0 {
+		return
+	}
+	t.lock.Lock()
+	now := time.Now()
+	session := t.session(token, transactions[0].PlayerId, transactions[0].Amount.Currency, now)
+	for _, tx := range transactions {
+		if tx.Category == CategoryPayout {
+			session.Won += tx.Amount.Amount
+		}
+	}
+	session.LastActivity = now
+	t.lock.Unlock()
+	t.follow(token, balance.Token, balance.PlayerId)
+}
+
+func (t *rgSessionTracker) transact(token Token, transactions []TransactionStore, send func() (BalanceStore, rgse.RGSErr)) (BalanceStore, rgse.RGSErr) {
+	session, wager, err := t.reserve(token, transactions)
+	if err != nil {
+		return BalanceStore{}, err
+	}
+	balance, err := send()
+	if err != nil {
+		if !unanswered(err) {
+			t.release(session, wager)
+		}
+		// the wager of a transaction that was not answered stays reserved, the wallet may have taken it
+		return balance, err
+	}
+	t.record(token, transactions, balance)
+	return balance, nil
+}
+
+// rgService enforces the responsible gaming limits of the player session on the transactions of a wallet service
+type rgService struct {
+	Service
+	sessions *rgSessionTracker
+}
+
+func (s *rgService) PlayerByToken(token Token, mode Mode, gameId string) (PlayerStore, GameStateStore, rgse.RGSErr) {
+	player, gs, err := s.Service.PlayerByToken(token, mode, gameId)
+	if err == nil {
+		s.sessions.follow(token, player.Token, player.PlayerId)
+	}
+	return player, gs, err
+}
+
+func (s *rgService) BalanceByToken(token Token, mode Mode) (BalanceStore, rgse.RGSErr) {
+	balance, err := s.Service.BalanceByToken(token, mode)
+	if err == nil {
+		s.sessions.follow(token, balance.Token, balance.PlayerId)
+	}
+	return balance, err
+}
+
+func (s *rgService) Transaction(token Token, mode Mode, transaction TransactionStore) (BalanceStore, rgse.RGSErr) {
+	return s.sessions.transact(token, []TransactionStore{transaction}, func() (BalanceStore, rgse.RGSErr) {
+		return s.Service.Transaction(token, mode, transaction)
+	})
+}
+
+func (s *rgService) MultiTransaction(token Token, mode Mode, transactions []TransactionStore) (BalanceStore, rgse.RGSErr) {
+	return s.sessions.transact(token, transactions, func() (BalanceStore, rgse.RGSErr) {
+		return s.Service.MultiTransaction(token, mode, transactions)
+	})
+}
+
+func (s *rgService) CloseRound(token Token, mode Mode, gameId string, roundId string, campaignRef string, gamestate []byte, ttl int64, history *TransactionHistory) (BalanceStore, rgse.RGSErr) {
+	balance, err := s.Service.CloseRound(token, mode, gameId, roundId, campaignRef, gamestate, ttl, history)
+	if err == nil {
+		s.sessions.follow(token, balance.Token, balance.PlayerId)
+	}
+	return balance, err
+}
+
+// rgLocalService enforces the same limits on the demo wallet so that they can be tested without an operator
+type rgLocalService struct {
+	LocalService
+	sessions *rgSessionTracker
+}
+
+func (s *rgLocalService) PlayerByToken(token Token, mode Mode, gameId string) (PlayerStore, GameStateStore, rgse.RGSErr) {
+	player, gs, err := s.LocalService.PlayerByToken(token, mode, gameId)
+	if err == nil {
+		s.sessions.follow(token, player.Token, player.PlayerId)
+	}
+	return player, gs, err
+}
+
+func (s *rgLocalService) BalanceByToken(token Token, mode Mode) (BalanceStore, rgse.RGSErr) {
+	balance, err := s.LocalService.BalanceByToken(token, mode)
+	if err == nil {
+		s.sessions.follow(token, balance.Token, balance.PlayerId)
+	}
+	return balance, err
+}
+
+func (s *rgLocalService) Transaction(token Token, mode Mode, transaction TransactionStore) (BalanceStore, rgse.RGSErr) {
+	return s.sessions.transact(token, []TransactionStore{transaction}, func() (BalanceStore, rgse.RGSErr) {
+		return s.LocalService.Transaction(token, mode, transaction)
+	})
+}
+
+func (s *rgLocalService) MultiTransaction(token Token, mode Mode, transactions []TransactionStore) (BalanceStore, rgse.RGSErr) {
+	return s.sessions.transact(token, transactions, func() (BalanceStore, rgse.RGSErr) {
+		return s.LocalService.MultiTransaction(token, mode, transactions)
+	})
+}
+
+func (s *rgLocalService) CloseRound(token Token, mode Mode, gameId string, roundId string, campaignRef string, gamestate []byte, ttl int64, history *TransactionHistory) (BalanceStore, rgse.RGSErr) {
+	balance, err := s.LocalService.CloseRound(token, mode, gameId, roundId, campaignRef, gamestate, ttl, history)
+	if err == nil {
+		s.sessions.follow(token, balance.Token, balance.PlayerId)
+	}
+	return balance, err
+}
+
+// DefaultRGLimits returns the configured limits of new sessions in the currency
+func DefaultRGLimits(currency string) RGLimits {
+	if rgSessions == nil {
+		return RGLimits{}
+	}
+	return rgSessions.defaultLimits(currency)
+}
+
+// StartRGSession starts the session of the player if there is none. A reality check interval, e.g. the one of the
+// jurisdiction of the player, replaces a longer interval of the session.
+func StartRGSession(token Token, playerId string, currency string, realityCheck time.Duration) rgse.RGSErr {
+	if rgSessions == nil {
+		return rgse.Create(rgse.StoreInitError)
+	}
+	rgSessions.lock.Lock()
+	defer rgSessions.lock.Unlock()
+	now := time.Now()
+	session := rgSessions.session(token, playerId, currency, now)
+	if realityCheck > 0 && (session.Limits.RealityCheck == 0 || realityCheck < session.Limits.RealityCheck) {
+		session.Limits.RealityCheck = realityCheck
+	}
+	session.LastActivity = now
+	return nil
+}
+
+// SetRGLimits sets the limits of the session of the player, a session is started if the player has none
+func SetRGLimits(token Token, playerId string, currency string, limits RGLimits) rgse.RGSErr {
+	if rgSessions == nil {
+		return rgse.Create(rgse.StoreInitError)
+	}
+	rgSessions.lock.Lock()
+	defer rgSessions.lock.Unlock()
+	now := time.Now()
+	session := rgSessions.session(token, playerId, currency, now)
+	session.Limits = limits
+	session.LastActivity = now
+	return nil
+}
+
+// RGSessionByToken returns a copy of the active session of the token
+func RGSessionByToken(token Token) (RGSession, bool) {
+	if rgSessions == nil {
+		return RGSession{}, false
+	}
+	rgSessions.lock.Lock()
+	defer rgSessions.lock.Unlock()
+	session := rgSessions.find(token, "", "", time.Now())
+	if session == nil {
+		return RGSession{}, false
+	}
+	return *session, true
+}
+
+// DueRealityCheck returns the reality check of the session of the token if its interval has passed since the last one,
+// nil otherwise. The check is returned once per interval.
+func DueRealityCheck(token Token) *RealityCheck {
+	if rgSessions == nil {
+		return nil
+	}
+	rgSessions.lock.Lock()
+	defer rgSessions.lock.Unlock()
+	now := time.Now()
+	session := rgSessions.find(token, "", "", now)
+	if session == nil || session.Limits.RealityCheck <= 0 || now.Sub(session.LastCheck) < session.Limits.RealityCheck {
+		return nil
+	}
+	session.LastCheck = now
+	return &RealityCheck{
+		Elapsed:     int64(now.Sub(session.Start) / time.Second),
+		Wagered:     session.Wagered,
+		Won:         session.Won,
+		NetPosition: session.NetPosition(),
+		Currency:    session.Currency,
+	}
+}
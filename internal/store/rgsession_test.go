@@ -0,0 +1,171 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+func rgTx(category Category, amount int) TransactionStore {
+	return TransactionStore{
+		PlayerId: "player-1",
+		Category: category,
+		Amount:   engine.Money{Amount: engine.NewFixedFromInt(amount), Currency: "USD"},
+	}
+}
+
+func rgSend(token Token) func() (BalanceStore, rgse.RGSErr) {
+	return func() (BalanceStore, rgse.RGSErr) {
+		return BalanceStore{PlayerId: "player-1", Token: token}, nil
+	}
+}
+
+func TestRGSessionTracker_Limits(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	tracker := newRGSessionTracker(config.RGSessionConfig{WagerLimit: map[string]float64{"USD": 10}, LossLimit: map[string]float64{"USD": 5}, Idle: 30})
+
+	// lose 5, the loss limit is reached
+	for i, token := range []Token{"t1", "t2", "t3", "t4", "t5"} {
+		_, err := tracker.transact(token, []TransactionStore{rgTx(CategoryWager, 1), rgTx(CategoryPayout, 0)}, rgSend(Token(string(token)+"'")))
+		if err != nil {
+			t.Fatalf("wager %v refused: %v", i, err.Error())
+		}
+		// the next call of the client uses the renewed token
+		tracker.follow(Token(string(token)+"'"), []Token{"t2", "t3", "t4", "t5", "t6"}[i], "player-1")
+	}
+	_, err := tracker.transact("t6", []TransactionStore{rgTx(CategoryWager, 1)}, rgSend("t7"))
+	if err == nil || err.(*rgse.RGSError).ErrCode != rgse.SpendingBudgetExceeded {
+		t.Fatalf("wager above the loss limit was not refused: %v", err)
+	}
+	// payouts are never refused
+	if _, err := tracker.transact("t6", []TransactionStore{rgTx(CategoryPayout, 4)}, rgSend("t7")); err != nil {
+		t.Fatalf("payout refused: %v", err.Error())
+	}
+	// the win brings the player back within the loss limit but the wager limit is reached
+	if _, err := tracker.transact("t7", []TransactionStore{rgTx(CategoryWager, 4)}, rgSend("t8")); err != nil {
+		t.Fatalf("wager refused: %v", err.Error())
+	}
+	_, err = tracker.transact("t8", []TransactionStore{rgTx(CategoryWager, 2)}, rgSend("t9"))
+	if err == nil || err.(*rgse.RGSError).ErrCode != rgse.SpendingBudgetExceeded {
+		t.Fatalf("wager above the wager limit was not refused: %v", err)
+	}
+	// free games are not paid by the player
+	free := rgTx(CategoryWager, 1)
+	free.FreeGames.CampaignRef = "campaign"
+	if _, err := tracker.transact("t8", []TransactionStore{free}, rgSend("t9")); err != nil {
+		t.Fatalf("free game refused: %v", err.Error())
+	}
+
+	session := tracker.find("t9", "", "", time.Now())
+	if session == nil {
+		t.Fatalf("session did not follow the renewed token")
+	}
+	if session.Wagered != engine.NewFixedFromInt(9) || session.Won != engine.NewFixedFromInt(4) {
+		t.Errorf("session wagered %v won %v, expected 9 and 4", session.Wagered, session.Won)
+	}
+}
+
+func TestRGSessionTracker_Currency(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	tracker := newRGSessionTracker(config.RGSessionConfig{WagerLimit: map[string]float64{"USD": 10}})
+
+	// the limit of 10 USD does not limit a session in another currency
+	eur := rgTx(CategoryWager, 50)
+	eur.Amount.Currency = "EUR"
+	if _, err := tracker.transact("t1", []TransactionStore{eur}, rgSend("t1")); err != nil {
+		t.Fatalf("wager in a currency without limits refused: %v", err.Error())
+	}
+	// the player plays a separate session in USD
+	if _, err := tracker.transact("t2", []TransactionStore{rgTx(CategoryWager, 10)}, rgSend("t2")); err != nil {
+		t.Fatalf("wager within the limit refused: %v", err.Error())
+	}
+	_, err := tracker.transact("t2", []TransactionStore{rgTx(CategoryWager, 1)}, rgSend("t2"))
+	if err == nil || err.(*rgse.RGSError).ErrCode != rgse.SpendingBudgetExceeded {
+		t.Fatalf("wager above the USD limit was not refused: %v", err)
+	}
+	if usd, eur := tracker.find("t2", "", "", time.Now()), tracker.find("t1", "", "", time.Now()); usd == eur || usd.Currency != "USD" || eur.Limits.WagerLimit != 0 {
+		t.Errorf("the sessions of the currencies are not separate")
+	}
+}
+
+func TestRGSessionTracker_TimeLimit(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	tracker := newRGSessionTracker(config.RGSessionConfig{TimeLimit: 60})
+	if _, err := tracker.transact("t1", []TransactionStore{rgTx(CategoryWager, 1)}, rgSend("t1")); err != nil {
+		t.Fatalf("wager refused: %v", err.Error())
+	}
+	session := tracker.find("t1", "", "", time.Now())
+	session.Start = session.Start.Add(-61 * time.Minute)
+	_, err := tracker.transact("t1", []TransactionStore{rgTx(CategoryWager, 1)}, rgSend("t1"))
+	if err == nil || err.(*rgse.RGSError).ErrCode != rgse.SessionTimeLimit {
+		t.Fatalf("wager after the time limit was not refused: %v", err)
+	}
+}
+
+func TestRGSessionTracker_Reserve(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	tracker := newRGSessionTracker(config.RGSessionConfig{WagerLimit: map[string]float64{"USD": 10}})
+
+	// a wager that is being sent holds its part of the limit
+	sending, sent, done := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		tracker.transact("t1", []TransactionStore{rgTx(CategoryWager, 8)}, func() (BalanceStore, rgse.RGSErr) {
+			close(sending)
+			<-sent
+			return BalanceStore{PlayerId: "player-1", Token: "t1"}, nil
+		})
+	}()
+	<-sending
+	_, err := tracker.transact("t1", []TransactionStore{rgTx(CategoryWager, 3)}, rgSend("t1"))
+	close(sent)
+	<-done
+	if err == nil || err.(*rgse.RGSError).ErrCode != rgse.SpendingBudgetExceeded {
+		t.Fatalf("wager above the limit with the wager being sent was not refused: %v", err)
+	}
+
+	// the wager of a refused transaction is released, the one of an unanswered transaction is kept
+	refuse := func(code int) func() (BalanceStore, rgse.RGSErr) {
+		return func() (BalanceStore, rgse.RGSErr) { return BalanceStore{}, rgse.Create(code) }
+	}
+	tracker.transact("t1", []TransactionStore{rgTx(CategoryWager, 1)}, refuse(rgse.InsufficientFundError))
+	tracker.transact("t1", []TransactionStore{rgTx(CategoryWager, 1)}, refuse(rgse.RequestTimeout))
+	session := tracker.find("t1", "", "", time.Now())
+	if session == nil || session.Wagered != engine.NewFixedFromInt(9) {
+		t.Errorf("session %#v, expected 9 wagered", session)
+	}
+}
+
+func TestDueRealityCheck(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	saved := rgSessions
+	defer func() { rgSessions = saved }()
+	rgSessions = newRGSessionTracker(config.RGSessionConfig{})
+
+	if err := StartRGSession("t1", "player-1", "USD", time.Hour); err != nil {
+		t.Fatalf("start session: %v", err.Error())
+	}
+	if check := DueRealityCheck("t1"); check != nil {
+		t.Fatalf("reality check is due at the start of the session")
+	}
+	session := rgSessions.find("t1", "", "", time.Now())
+	session.Start = session.Start.Add(-time.Hour)
+	session.LastCheck = session.Start
+	session.Wagered = engine.NewFixedFromInt(3)
+	session.Won = engine.NewFixedFromInt(1)
+
+	check := DueRealityCheck("t1")
+	if check == nil {
+		t.Fatalf("reality check is not due after the interval")
+	}
+	if check.Elapsed < 3600 || check.NetPosition != engine.NewFixedFromInt(-2) || check.Currency != "USD" {
+		t.Errorf("unexpected reality check %#v", check)
+	}
+	if check := DueRealityCheck("t1"); check != nil {
+		t.Errorf("reality check was returned twice in the interval")
+	}
+}
@@ -7,6 +7,11 @@ import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
+// GameCategoryRoulette is the game category of the roulette engines
+const GameCategoryRoulette = "roulette"
+
+var _ GameV3Factory = RegisterGameV3(GameCategoryRoulette, func() IGameV3 { return new(GameRouletteV3) })
+
 type GameRouletteV3 struct {
 	GameV3
 }
@@ -0,0 +1,282 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// GameCategoryScratch is the game category of the scratch card engines
+const GameCategoryScratch = "scratch"
+
+var _ GameV3Factory = RegisterGameV3(GameCategoryScratch, func() IGameV3 { return new(GameScratchV3) })
+
+type GameScratchV3 struct {
+	GameV3
+}
+
+func (g *GameScratchV3) Base() *GameV3 {
+	return &g.GameV3
+}
+
+func (g GameScratchV3) InitState() engine.IGameStateV3 {
+	scratchState := InitStateScratch(g.GameV3.Game, g.GameV3.Currency)
+	return &scratchState
+}
+
+func (g GameScratchV3) SerializeState(state engine.IGameStateV3) []byte {
+	return CompressState(state.Serialize(), COMPRESSION_LZW)
+}
+
+func (g GameScratchV3) DeserializeState(serialized []byte) (engine.IGameStateV3, rgse.RGSErr) {
+	state, err := g.DeserializeStateScratch(serialized)
+	return &state, err
+}
+
+func (g GameScratchV3) DeserializeStateScratch(serialized []byte) (state engine.GameStateScratch, rgserr rgse.RGSErr) {
+	var uncompressed []byte
+	uncompressed, rgserr = DecompressState(serialized)
+	if rgserr != nil {
+		return
+	}
+	rgserr = state.Deserialize(uncompressed)
+	return
+}
+
+func InitStateScratch(game string, currency string) engine.GameStateScratch {
+	gameState := engine.GameStateScratch{
+		GameStateV3: engine.GameStateV3{
+			Id:            rng.Uuid(),
+			NextGamestate: rng.Uuid(),
+			Game:          game,
+			Version:       "3",
+			Currency:      currency,
+		},
+	}
+	logger.Debugf("init state scratch %#v", gameState)
+	return gameState
+}
+
+// the ticket pools by game. With a pool directory the pools are kept in a file by game, the file holds the seed and the
+// cursor of the pool and every sale reads and writes the cursor under the lock of the game. The instances that mount
+// the same directory sell from the same pool and a restarted instance continues it, the tickets that a pool is shuffled
+// to are kept here so that a pool is shuffled once by every instance. Without a pool directory the pools are held here,
+// every instance then sells from a pool of its own that is lost when it stops.
+var scratchPools = struct {
+	sync.Mutex
+	pools map[string]*engine.ScratchPool
+}{pools: map[string]*engine.ScratchPool{}}
+
+// ScratchTicket is a ticket that is reserved for a round, it is sold once the wager of the round is taken and returned
+// to its pool otherwise
+type ScratchTicket struct {
+	Game   string
+	Pool   string
+	Number int
+	Prize  int
+}
+
+func scratchPoolPath(game string) string {
+	return filepath.Join(config.GlobalConfig.ScratchPools, game+".json")
+}
+
+// lockScratchPool takes the lock of the pool of the game that the instances share, the caller holds scratchPools
+func lockScratchPool(game string) (unlock func(), rgserr rgse.RGSErr) {
+	if config.GlobalConfig.ScratchPools == "" {
+		return func() {}, nil
+	}
+	unlock, err := lockFile(scratchPoolPath(game))
+	if err != nil {
+		return nil, scratchPoolError(game, err)
+	}
+	return unlock, nil
+}
+
+// loadScratchPool returns the pool of the game, nil if it has none, the caller holds the lock of the pool
+func loadScratchPool(game string) (*engine.ScratchPool, rgse.RGSErr) {
+	if config.GlobalConfig.ScratchPools == "" {
+		return scratchPools.pools[game], nil
+	}
+	b, err := ioutil.ReadFile(scratchPoolPath(game))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, scratchPoolError(game, err)
+	}
+	var pool engine.ScratchPool
+	if err := json.Unmarshal(b, &pool); err != nil {
+		return nil, scratchPoolError(game, err)
+	}
+	if cached := scratchPools.pools[game]; cached != nil && cached.Id == pool.Id && cached.Hash == pool.Hash {
+		pool.Tickets = cached.Tickets
+		return &pool, nil
+	}
+	// the pool was shuffled by another instance or before a restart
+	if !pool.Restore() {
+		return nil, scratchPoolError(game, fmt.Errorf("tickets of pool %v do not match its hash %v", pool.Id, pool.Hash))
+	}
+	scratchPools.pools[game] = &pool
+	return &pool, nil
+}
+
+// saveScratchPool writes the cursor of the pool of the game, the caller holds the lock of the pool
+func saveScratchPool(game string, pool *engine.ScratchPool) rgse.RGSErr {
+	scratchPools.pools[game] = pool
+	if config.GlobalConfig.ScratchPools == "" {
+		return nil
+	}
+	b, err := json.Marshal(pool)
+	if err != nil {
+		return scratchPoolError(game, err)
+	}
+	path := scratchPoolPath(game)
+	if err := ioutil.WriteFile(path+".tmp", b, 0644); err != nil {
+		return scratchPoolError(game, err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return scratchPoolError(game, err)
+	}
+	return nil
+}
+
+func scratchPoolError(game string, err error) rgse.RGSErr {
+	logger.Errorf("ticket pool of game %v: %v", game, err.Error())
+	rgserr := rgse.Create(rgse.InternalServerError)
+	rgserr.AppendErrorText(fmt.Sprintf("ticket pool of game %v is not available", game))
+	return rgserr
+}
+
+// withScratchPool runs the function on the pool of the game under its lock, nil if the game has none, and saves the
+// pool that the function returns unless it is nil
+func withScratchPool(game string, f func(pool *engine.ScratchPool) (*engine.ScratchPool, rgse.RGSErr)) rgse.RGSErr {
+	if filepath.Base(game) != game {
+		return scratchPoolError(game, fmt.Errorf("invalid game name"))
+	}
+	scratchPools.Lock()
+	defer scratchPools.Unlock()
+	unlock, rgserr := lockScratchPool(game)
+	if rgserr != nil {
+		return rgserr
+	}
+	defer unlock()
+	pool, rgserr := loadScratchPool(game)
+	if rgserr != nil {
+		return rgserr
+	}
+	if pool, rgserr = f(pool); rgserr != nil || pool == nil {
+		return rgserr
+	}
+	return saveScratchPool(game, pool)
+}
+
+// currentScratchPool returns the pool that the tickets of the game are sold from, a new pool is generated if the game
+// has none or if the prizes of the engine changed since its pool was generated. created is true for a new pool.
+func currentScratchPool(game string, conf engine.ScratchConfiguration, pool *engine.ScratchPool) (current *engine.ScratchPool, created bool) {
+	if pool != nil && pool.Holds(conf) {
+		return pool, false
+	}
+	return nextScratchPool(game, conf, pool), true
+}
+
+// nextScratchPool generates the pool that follows the pool of the game, the first pool if it is nil
+func nextScratchPool(game string, conf engine.ScratchConfiguration, previous *engine.ScratchPool) *engine.ScratchPool {
+	serial := 1
+	if previous != nil {
+		serial = previous.Serial + 1
+	}
+	pool := engine.NewScratchPool(conf, serial, nil)
+	logger.Infof("generated ticket pool %v #%v of %v tickets for game %v with hash %v", pool.Id, pool.Serial, pool.Size(), game, pool.Hash)
+	return pool
+}
+
+// ReserveScratchTicket reserves the next ticket of the pool of the game for a round, the ticket is sold or returned
+// once the wager of the round is settled. A sold out pool is replaced by a new pool unless the engine closes the sales
+// when its pool is sold out, the reserved tickets of the pool are then void.
+func ReserveScratchTicket(game string, conf engine.ScratchConfiguration) (ticket ScratchTicket, rgserr rgse.RGSErr) {
+	rgserr = withScratchPool(game, func(pool *engine.ScratchPool) (*engine.ScratchPool, rgse.RGSErr) {
+		pool, _ = currentScratchPool(game, conf, pool)
+		if pool.Remaining() == 0 {
+			if conf.CloseWhenSoldOut() {
+				rgserr := rgse.Create(rgse.TicketPoolSoldOut)
+				rgserr.AppendErrorText(fmt.Sprintf("ticket pool %v of game %v is sold out", pool.Id, game))
+				return nil, rgserr
+			}
+			logger.Infof("ticket pool %v #%v of game %v is sold out", pool.Id, pool.Serial, game)
+			if len(pool.Reserved) > 0 {
+				logger.Warnf("reserved tickets %v of pool %v of game %v are void", pool.Reserved, pool.Id, game)
+			}
+			pool = nextScratchPool(game, conf, pool)
+		}
+		prize, number, _ := pool.Next()
+		ticket = ScratchTicket{Game: game, Pool: pool.Id, Number: number, Prize: prize}
+		return pool, nil
+	})
+	return
+}
+
+// SellScratchTicket completes the sale of a reserved ticket
+func SellScratchTicket(ticket ScratchTicket) rgse.RGSErr {
+	return settleScratchTicket(ticket, (*engine.ScratchPool).Sell)
+}
+
+// ReturnScratchTicket returns a reserved ticket to its pool, it is sold again before the next tickets of the pool
+func ReturnScratchTicket(ticket ScratchTicket) rgse.RGSErr {
+	return settleScratchTicket(ticket, (*engine.ScratchPool).Return)
+}
+
+func settleScratchTicket(ticket ScratchTicket, settle func(pool *engine.ScratchPool, ticket int) bool) rgse.RGSErr {
+	return withScratchPool(ticket.Game, func(pool *engine.ScratchPool) (*engine.ScratchPool, rgse.RGSErr) {
+		if pool == nil || pool.Id != ticket.Pool || !settle(pool, ticket.Number) {
+			// the pool was replaced since the ticket was reserved
+			logger.Warnf("ticket %d of pool %s of game %s is no longer reserved", ticket.Number, ticket.Pool, ticket.Game)
+			return nil, nil
+		}
+		return pool, nil
+	})
+}
+
+// ScratchPoolStatus returns the pool that the tickets of the game are sold from, its size and its unsold tickets
+func ScratchPoolStatus(game string, conf engine.ScratchConfiguration) (id string, size int, remaining int, rgserr rgse.RGSErr) {
+	rgserr = withScratchPool(game, func(pool *engine.ScratchPool) (*engine.ScratchPool, rgse.RGSErr) {
+		pool, created := currentScratchPool(game, conf, pool)
+		id, size, remaining = pool.Id, pool.Size(), pool.Remaining()
+		if !created {
+			return nil, nil
+		}
+		return pool, nil
+	})
+	return
+}
+
+// settleReconciledScratchTicket settles the ticket of a scratch wager that the reconciliation worker resolved, the
+// ticket is sold if the wallet applied the wager and returned to its pool if the wager was rolled back or refunded.
+// Other transactions are ignored.
+func settleReconciledScratchTicket(tx TransactionStore, applied bool) {
+	if tx.Category != CategoryWager || len(tx.GameState) == 0 {
+		return
+	}
+	state, rgserr := GameScratchV3{}.DeserializeStateScratch(tx.GameState)
+	if rgserr != nil || state.Pool == "" {
+		// not a round of a ticket pool
+		return
+	}
+	ticket := ScratchTicket{Game: tx.GameId, Pool: state.Pool, Number: state.Ticket}
+	if applied {
+		rgserr = SellScratchTicket(ticket)
+	} else {
+		rgserr = ReturnScratchTicket(ticket)
+	}
+	if rgserr != nil {
+		logger.Errorf("ticket %d of pool %s of reconciled transaction %s was not settled: %v", ticket.Number, ticket.Pool, tx.TransactionId, rgserr.Error())
+	}
+}
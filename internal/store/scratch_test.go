@@ -0,0 +1,107 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+func testScratchPoolConfig() engine.ScratchConfiguration {
+	return engine.ScratchConfiguration{
+		Mode:   engine.ScratchModePool,
+		Layout: engine.ScratchLayout{Rows: 3, Columns: 3, Symbols: []int{1, 2, 3, 4, 5}},
+		Prizes: []engine.ScratchPrize{
+			{Multiplier: 10, Symbol: 1, Weight: 1, Count: 1},
+			{Multiplier: 2, Symbol: 2, Weight: 3, Count: 3},
+			{Multiplier: 0, Weight: 6, Count: 6},
+		},
+		Exhaustion: engine.ScratchPoolClose,
+	}
+}
+
+func TestScratchPoolShared(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	rng.Init()
+	dir, err := ioutil.TempDir("", "scratchpools")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	saved := config.GlobalConfig.ScratchPools
+	defer func() { config.GlobalConfig.ScratchPools = saved }()
+	config.GlobalConfig.ScratchPools = dir
+	conf := testScratchPoolConfig()
+
+	first, rgserr := ReserveScratchTicket("scratch-test", conf)
+	if rgserr != nil || first.Number != 1 {
+		t.Fatalf("reserved ticket %#v: %v", first, rgserr)
+	}
+	// the wager of the ticket was refused, the ticket is sold to the next round
+	if rgserr := ReturnScratchTicket(first); rgserr != nil {
+		t.Fatalf("return: %v", rgserr.Error())
+	}
+	again, rgserr := ReserveScratchTicket("scratch-test", conf)
+	if rgserr != nil || again != first {
+		t.Fatalf("returned ticket %#v reserved as %#v: %v", first, again, rgserr)
+	}
+	if rgserr := SellScratchTicket(again); rgserr != nil {
+		t.Fatalf("sell: %v", rgserr.Error())
+	}
+
+	// another instance or a restart continues the pool from the directory
+	if _, err := os.Stat(scratchPoolPath("scratch-test") + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock of the pool was not released")
+	}
+	// a restarted instance shuffles the tickets of the pool again from its seed
+	delete(scratchPools.pools, "scratch-test")
+	id, size, remaining, rgserr := ScratchPoolStatus("scratch-test", conf)
+	if rgserr != nil || id != first.Pool || size != 10 || remaining != 9 {
+		t.Fatalf("pool %v of %v tickets with %v remaining: %v", id, size, remaining, rgserr)
+	}
+	for n := 2; n <= 10; n++ {
+		ticket, rgserr := ReserveScratchTicket("scratch-test", conf)
+		if rgserr != nil || ticket.Number != n || ticket.Pool != first.Pool {
+			t.Fatalf("ticket %v reserved as %#v: %v", n, ticket, rgserr)
+		}
+		SellScratchTicket(ticket)
+	}
+	if _, rgserr := ReserveScratchTicket("scratch-test", conf); rgserr == nil {
+		t.Errorf("ticket reserved from a sold out pool")
+	}
+}
+
+func TestScratchTicketReconciled(t *testing.T) {
+	logger.NewLogger(logger.Configuration{})
+	rng.Init()
+	dir, err := ioutil.TempDir("", "scratchpools")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	saved := config.GlobalConfig.ScratchPools
+	defer func() { config.GlobalConfig.ScratchPools = saved }()
+	config.GlobalConfig.ScratchPools = dir
+	conf := testScratchPoolConfig()
+	wager := func(ticket ScratchTicket) TransactionStore {
+		state := engine.GameStateScratch{Pool: ticket.Pool, Ticket: ticket.Number}
+		return TransactionStore{TransactionId: "tx-1", Category: CategoryWager, GameId: ticket.Game, GameState: GameScratchV3{}.SerializeState(&state)}
+	}
+
+	// the wagers of both tickets were not answered, the wallet applied the first and rolled back the second
+	applied, _ := ReserveScratchTicket("scratch-test", conf)
+	refused, _ := ReserveScratchTicket("scratch-test", conf)
+	settleReconciledScratchTicket(wager(applied), true)
+	settleReconciledScratchTicket(wager(refused), false)
+	pool, rgserr := loadScratchPool("scratch-test")
+	if rgserr != nil || pool.Sold != 2 || len(pool.Reserved) != 0 || len(pool.Returned) != 1 {
+		t.Fatalf("tickets were not settled: %#v %v", pool, rgserr)
+	}
+	if next, rgserr := ReserveScratchTicket("scratch-test", conf); rgserr != nil || next != refused {
+		t.Errorf("returned ticket %#v reserved as %#v: %v", refused, next, rgserr)
+	}
+}
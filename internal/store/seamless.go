@@ -0,0 +1,437 @@
+package store
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/travelaudience/go-promhttp"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/seamless"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// SeamlessServiceImpl is the service of a wallet that speaks the generic seamless protocol.
+//
+// A call that was not answered, i.e. a network error, a timeout or a 5xx status, is resent with the same body until
+// it is answered or the retries or the time run out, the wallet answers a transaction that it already processed without
+// processing it again. A wager that stays unanswered is rolled back, and so are the transactions of a multi transaction
+// that were applied before one of them failed.
+type SeamlessServiceImpl struct {
+	wallet          config.Wallet
+	client          *http.Client
+	defaultPlatform string
+	defaultLanguage string
+	dataLimit       int
+}
+
+// NewSeamless returns the service of a seamless wallet of the operator registry
+func NewSeamless(wallet config.Wallet, c *config.Config) Service {
+	var timeout time.Duration
+	if wallet.TimeoutMs > 0 {
+		timeout = time.Duration(wallet.TimeoutMs) * time.Millisecond
+	}
+	client := &http.Client{Timeout: timeout}
+	promClient := &promhttp.Client{Client: client, Registerer: prometheus.DefaultRegisterer}
+	// the instrumented client is still returned when the metrics of the wallet are already registered
+	if instrumented, _ := promClient.ForRecipient(wallet.Name); instrumented != nil {
+		client = instrumented
+	}
+	return &SeamlessServiceImpl{
+		wallet:          wallet,
+		client:          client,
+		defaultPlatform: c.DefaultPlatform,
+		defaultLanguage: c.DefaultLanguage,
+		dataLimit:       c.DataLimit,
+	}
+}
+
+func (i *SeamlessServiceImpl) mode(mode Mode) string {
+	return strings.ToLower(string(mode))
+}
+
+// call posts a signed request to the endpoint of the wallet. answered is false if the wallet may or may not have
+// processed the request.
+func (i *SeamlessServiceImpl) call(endpoint string, rq interface{}) (response seamless.Response, answered bool, err rgse.RGSErr) {
+	body, jsonErr := json.Marshal(rq)
+	if jsonErr != nil {
+		return seamless.Response{}, true, rgse.Create(rgse.JsonError)
+	}
+	reqId := rng.Uuid()
+	signature := seamless.Sign(i.wallet.Secret, body)
+	start := time.Now()
+	var try int
+	for try = 0; try <= i.wallet.MaxRetries; try++ {
+		if i.wallet.TimeoutMs > 0 && time.Since(start).Milliseconds() > i.wallet.TimeoutMs {
+			logger.Errorf("%v call to wallet %v exceeded timeout after %v", endpoint, i.wallet.Name, time.Since(start).String())
+			break
+		}
+		logger.Debugf("%v request to wallet %v: %s", endpoint, i.wallet.Name, string(body))
+		req, _ := http.NewRequest("POST", strings.TrimRight(i.wallet.Url, "/")+"/"+endpoint, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(seamless.HeaderSignature, signature)
+		req.Header.Set(seamless.HeaderRequestId, reqId)
+		resp, httpErr := i.client.Do(req)
+		if httpErr != nil {
+			logger.Warnf("%v call to wallet %v failed: %v", endpoint, i.wallet.Name, httpErr)
+			err = rgse.Create(rgse.RestError)
+			continue
+		}
+		respBody, readErr := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			err = rgse.Create(rgse.RestError)
+			continue
+		}
+		if resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusGatewayTimeout {
+			err = rgse.Create(rgse.RequestTimeout)
+			continue
+		}
+		if resp.StatusCode >= 500 {
+			err = rgse.Create(rgse.GenericWalletError)
+			err.AppendErrorText(fmt.Sprintf("status %v", resp.StatusCode))
+			continue
+		}
+		logger.Debugf("%v response of wallet %v: %s", endpoint, i.wallet.Name, string(respBody))
+		if !seamless.Verify(i.wallet.Secret, respBody, resp.Header.Get(seamless.HeaderSignature)) {
+			logger.Errorf("%v response of wallet %v has an invalid signature", endpoint, i.wallet.Name)
+			return seamless.Response{}, true, rgse.Create(rgse.InvalidSignature)
+		}
+		if jsonErr = json.Unmarshal(respBody, &response); jsonErr != nil {
+			return seamless.Response{}, true, rgse.Create(rgse.JsonError)
+		}
+		if response.Error != nil {
+			return response, true, i.errorCode(*response.Error)
+		}
+		if resp.StatusCode != http.StatusOK {
+			err = rgse.Create(rgse.GenericWalletError)
+			err.AppendErrorText(fmt.Sprintf("status %v", resp.StatusCode))
+			return response, true, err
+		}
+		return response, true, nil
+	}
+	if try > i.wallet.MaxRetries {
+		logger.Errorf("%v call to wallet %v exceeded retry limit of %v", endpoint, i.wallet.Name, i.wallet.MaxRetries)
+	}
+	return seamless.Response{}, false, err
+}
+
+func (i *SeamlessServiceImpl) errorCode(e seamless.Error) rgse.RGSErr {
+	var err rgse.RGSErr
+	switch e.Code {
+	case seamless.ErrorInsufficientFunds:
+		err = rgse.Create(rgse.InsufficientFundError)
+	case seamless.ErrorTokenExpired:
+		err = rgse.Create(rgse.TokenExpired)
+	case seamless.ErrorPlayerNotFound:
+		err = rgse.Create(rgse.NoSuchPlayer)
+	case seamless.ErrorNotFound:
+		err = rgse.Create(rgse.EntityNotFound)
+	case seamless.ErrorLimitExceeded:
+		err = rgse.Create(rgse.SpendingBudgetExceeded)
+	case seamless.ErrorBlocked:
+		err = rgse.Create(rgse.BlockedFromProduct)
+	case seamless.ErrorBadRequest:
+		err = rgse.Create(rgse.BadRequest)
+	case seamless.ErrorInvalidSignature:
+		err = rgse.Create(rgse.InvalidSignature)
+	case seamless.ErrorRolledBack:
+		err = rgse.Create(rgse.TransactionRolledBack)
+	default:
+		err = rgse.Create(rgse.GenericWalletError)
+		err.AppendErrorText(e.Code)
+	}
+	if e.Message != "" {
+		err.AppendErrorText(e.Message)
+	}
+	return err
+}
+
+func (i *SeamlessServiceImpl) balance(token Token, mode Mode, response seamless.Response) (BalanceStore, rgse.RGSErr) {
+	amount, err := seamless.ParseAmount(response.Balance)
+	if err != nil {
+		return BalanceStore{}, rgse.Create(rgse.JsonError)
+	}
+	if response.Token != "" {
+		token = Token(response.Token)
+	}
+	return BalanceStore{
+		PlayerId: response.PlayerId,
+		Token:    token,
+		Mode:     mode,
+		Balance: engine.Money{
+			Currency: response.Currency,
+			Amount:   amount,
+		},
+		FreeGames: i.freeGames(response.FreeGames),
+	}, nil
+}
+
+func (i *SeamlessServiceImpl) freeGames(freeGames *seamless.FreeGames) FreeGamesStore {
+	if freeGames == nil {
+		return FreeGamesStore{}
+	}
+	wagerAmount, _ := seamless.ParseAmount(freeGames.WagerAmount)
+	return FreeGamesStore{
+		NoOfFreeSpins: freeGames.Count,
+		CampaignRef:   freeGames.CampaignRef,
+		TotalWagerAmt: wagerAmount,
+	}
+}
+
+func (i *SeamlessServiceImpl) gameState(gamestate []byte) string {
+	if len(gamestate) == 0 {
+		return ""
+	}
+	if i.dataLimit > 0 && len(gamestate) > i.dataLimit {
+		sentry.CaptureMessage(fmt.Sprintf("gamestate size exceeds store data limit of %d bytes", i.dataLimit))
+	}
+	return base64.StdEncoding.EncodeToString(gamestate)
+}
+
+func (i *SeamlessServiceImpl) PlayerByToken(token Token, mode Mode, gameId string) (PlayerStore, GameStateStore, rgse.RGSErr) {
+	logger.Debugf("SeamlessServiceImpl.PlayerByToken([%v], [%v])", token, mode)
+	response, _, err := i.call(seamless.EndpointAuthenticate, seamless.AuthenticateRequest{
+		Token:    string(token),
+		Game:     gameId,
+		Mode:     i.mode(mode),
+		Platform: i.defaultPlatform,
+		Language: i.defaultLanguage,
+	})
+	if err != nil {
+		return PlayerStore{}, GameStateStore{}, err
+	}
+	balance, err := i.balance(token, mode, response)
+	if err != nil {
+		return PlayerStore{}, GameStateStore{}, err
+	}
+	var gs GameStateStore
+	if response.LastTransaction != nil && response.LastTransaction.GameState != "" {
+		gameState, b64Err := base64.StdEncoding.DecodeString(response.LastTransaction.GameState)
+		if b64Err != nil {
+			return PlayerStore{}, GameStateStore{}, rgse.Create(rgse.B64Error)
+		}
+		gs = GameStateStore{GameState: gameState, WalletInternalStatus: 1}
+	}
+	return PlayerStore{
+		PlayerId:            response.PlayerId,
+		Token:               balance.Token,
+		Mode:                mode,
+		Username:            response.PlayerId,
+		Balance:             balance.Balance,
+		FreeGames:           balance.FreeGames,
+		BetLimitSettingCode: response.BetProfile,
+		CompanyId:           response.CompanyId,
+	}, gs, nil
+}
+
+func (i *SeamlessServiceImpl) BalanceByToken(token Token, mode Mode) (BalanceStore, rgse.RGSErr) {
+	response, _, err := i.call(seamless.EndpointBalance, seamless.BalanceRequest{
+		Token: string(token),
+		Mode:  i.mode(mode),
+	})
+	if err != nil {
+		return BalanceStore{}, err
+	}
+	return i.balance(token, mode, response)
+}
+
+func (i *SeamlessServiceImpl) Transaction(token Token, mode Mode, transaction TransactionStore) (BalanceStore, rgse.RGSErr) {
+	logger.Debugf("SeamlessServiceImpl.Transaction([%v], [%v], [%v])", token, mode, transaction.TransactionId)
+	balance, answered, err := i.transaction(token, mode, transaction)
+	if err != nil && !answered && transaction.Category == CategoryWager {
+		// the wager may have been taken without a round being played for it
+		logger.Warnf("rolling back unanswered wager %v of wallet %v", transaction.TransactionId, i.wallet.Name)
+		if _, rbErr := i.rollback(token, mode, transaction.GameId, transaction.RoundId, "", transaction.TransactionId); rbErr != nil {
+			logger.Errorf("rollback of wager %v failed, it should be settled manually: %v", transaction.TransactionId, rbErr.Error())
+		}
+	}
+	return balance, err
+}
+
+// transaction sends the transaction to the wallet, answered is false if the wallet may or may not have applied it
+func (i *SeamlessServiceImpl) transaction(token Token, mode Mode, transaction TransactionStore) (BalanceStore, bool, rgse.RGSErr) {
+	var txType string
+	switch transaction.Category {
+	case CategoryWager:
+		txType = seamless.TypeDebit
+	case CategoryPayout:
+		txType = seamless.TypeCredit
+	case CategoryClose:
+		txType = seamless.TypeEndRound
+	case CategoryRefund:
+		balance, err := i.rollback(token, mode, transaction.GameId, transaction.RoundId, transaction.TransactionId, transaction.ParentTransactionId)
+		return balance, !unanswered(err), err
+	default:
+		return BalanceStore{}, true, rgse.Create(rgse.UnexpectedTx)
+	}
+
+	response, answered, err := i.call(seamless.EndpointTransaction, seamless.Transaction{
+		Token:         string(token),
+		Game:          transaction.GameId,
+		Mode:          i.mode(mode),
+		Round:         transaction.RoundId,
+		TransactionId: transaction.TransactionId,
+		Type:          txType,
+		Amount:        seamless.FormatAmount(transaction.Amount.Amount),
+		Currency:      transaction.Amount.Currency,
+		CampaignRef:   transaction.FreeGames.CampaignRef,
+		CloseRound:    RoundStatusClose == transaction.RoundStatus,
+		GameState:     i.gameState(transaction.GameState),
+		Ttl:           transaction.Ttl,
+	})
+	if err != nil {
+		return BalanceStore{}, answered, err
+	}
+	balance, err := i.balance(token, mode, response)
+	return balance, true, err
+}
+
+func (i *SeamlessServiceImpl) rollback(token Token, mode Mode, gameId string, roundId string, transactionId string, originalTransactionId string) (BalanceStore, rgse.RGSErr) {
+	if transactionId == "" {
+		transactionId = "rollback-" + originalTransactionId
+	}
+	response, _, err := i.call(seamless.EndpointRollback, seamless.RollbackRequest{
+		Token:                 string(token),
+		Game:                  gameId,
+		Mode:                  i.mode(mode),
+		Round:                 roundId,
+		TransactionId:         transactionId,
+		OriginalTransactionId: originalTransactionId,
+	})
+	if err != nil {
+		return BalanceStore{}, err
+	}
+	return i.balance(token, mode, response)
+}
+
+func (i *SeamlessServiceImpl) MultiTransaction(token Token, mode Mode, transactions []TransactionStore) (BalanceStore, rgse.RGSErr) {
+	logger.Debugf("SeamlessServiceImpl.MultiTransaction([%v], [%v], [%v])", token, mode, len(transactions))
+	if len(transactions) == 0 {
+		panic("no transaction to send")
+	}
+	var balance BalanceStore
+	for n, transaction := range transactions {
+		bs, answered, err := i.transaction(token, mode, transaction)
+		if err != nil {
+			// the round is all or nothing, undo the transactions that were applied including the failed one if the
+			// wallet may have applied it, a rollback of a transaction that the wallet does not know is accepted
+			last := n - 1
+			if !answered {
+				last = n
+			}
+			for r := last; r >= 0; r-- {
+				if transactions[r].Category != CategoryWager && transactions[r].Category != CategoryPayout {
+					continue
+				}
+				if _, rbErr := i.rollback(token, mode, transactions[r].GameId, transactions[r].RoundId, "", transactions[r].TransactionId); rbErr != nil {
+					logger.Errorf("rollback of transaction %v failed, it should be settled manually: %v", transactions[r].TransactionId, rbErr.Error())
+				}
+			}
+			return BalanceStore{}, err
+		}
+		token = bs.Token
+		balance = bs
+	}
+	return balance, nil
+}
+
+func (i *SeamlessServiceImpl) TransactionByGameId(token Token, mode Mode, gameId string) (TransactionStore, rgse.RGSErr) {
+	response, _, err := i.call(seamless.EndpointLastTransaction, seamless.LastTransactionRequest{
+		Token: string(token),
+		Game:  gameId,
+		Mode:  i.mode(mode),
+	})
+	if err != nil {
+		return TransactionStore{}, err
+	}
+	if response.LastTransaction == nil {
+		return TransactionStore{}, rgse.Create(rgse.EntityNotFound)
+	}
+	balance, err := i.balance(token, mode, response)
+	if err != nil {
+		return TransactionStore{}, err
+	}
+	lastTx := *response.LastTransaction
+	var gameState []byte
+	if lastTx.GameState != "" {
+		var b64Err error
+		gameState, b64Err = base64.StdEncoding.DecodeString(lastTx.GameState)
+		if b64Err != nil {
+			return TransactionStore{}, rgse.Create(rgse.B64Error)
+		}
+	}
+	category := CategoryClose
+	switch lastTx.Type {
+	case seamless.TypeDebit:
+		category = CategoryWager
+	case seamless.TypeCredit:
+		category = CategoryPayout
+	}
+	roundStatus := RoundStatusOpen
+	if lastTx.CloseRound {
+		roundStatus = RoundStatusClose
+	}
+	var txTime time.Time
+	if lastTx.Time > 0 {
+		txTime = time.Unix(lastTx.Time, 0)
+	}
+	return TransactionStore{
+		TransactionId:       lastTx.TransactionId,
+		Token:               balance.Token,
+		Mode:                mode,
+		Category:            category,
+		RoundStatus:         roundStatus,
+		PlayerId:            response.PlayerId,
+		GameId:              lastTx.Game,
+		RoundId:             lastTx.Round,
+		Amount:              balance.Balance,
+		TxTime:              txTime,
+		GameState:           gameState,
+		BetLimitSettingCode: response.BetProfile,
+		CompanyId:           response.CompanyId,
+		FreeGames:           balance.FreeGames,
+		WalletStatus:        1,
+		Ttl:                 lastTx.Ttl,
+	}, nil
+}
+
+func (i *SeamlessServiceImpl) CloseRound(token Token, mode Mode, gameId string, roundId string, campaignRef string, gamestate []byte, ttl int64, _ *TransactionHistory) (BalanceStore, rgse.RGSErr) {
+	balance, err := i.Transaction(token, mode, TransactionStore{
+		TransactionId: "close-" + roundId,
+		Token:         token,
+		Mode:          mode,
+		Category:      CategoryClose,
+		RoundStatus:   RoundStatusClose,
+		GameId:        gameId,
+		RoundId:       roundId,
+		TxTime:        time.Now(),
+		GameState:     gamestate,
+		FreeGames:     FreeGamesStore{CampaignRef: campaignRef},
+		Ttl:           ttl,
+	})
+	if err != nil {
+		return BalanceStore{}, err
+	}
+	// the client cannot handle a token update on the clientstate save call
+	balance.Token = token
+	return balance, nil
+}
+
+func (i *SeamlessServiceImpl) Feed(token Token, mode Mode, gameId string, startTime string, endTime string, pageSize int, page int) ([]FeedRound, int, rgse.RGSErr) {
+	return []FeedRound{}, 0, rgse.Create(rgse.WalletNotSupported)
+}
+
+func (i *SeamlessServiceImpl) FeedRound(token Token, mode Mode, gameId string, roundId int64) ([]FeedTransaction, rgse.RGSErr) {
+	return []FeedTransaction{}, rgse.Create(rgse.WalletNotSupported)
+}
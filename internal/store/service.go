@@ -1049,6 +1049,17 @@ func (i *LocalServiceImpl) Transaction(token Token, mode Mode, transaction Trans
 	playerId, _ := i.getToken(token)
 	player, _ := i.getPlayer(playerId)
 
+	if tx, ok := i.getTransaction(transaction.TransactionId); ok && tx.PlayerId == transaction.PlayerId && tx.GameId == transaction.GameId {
+		// a resent transaction is answered with the balance without applying it again
+		logger.Debugf("repeated transaction %v", transaction.TransactionId)
+		return BalanceStore{
+			PlayerId:  player.PlayerId,
+			Token:     token,
+			Balance:   player.Balance,
+			FreeGames: FreeGamesStore{player.FreeGames.NoOfFreeSpins, player.FreeGames.CampaignRef, player.FreeGames.TotalWagerAmt},
+		}, nil
+	}
+
 	if transaction.Category == CategoryWager {
 		// process free game
 		if transaction.FreeGames.CampaignRef != "" && player.FreeGames.CampaignRef == transaction.FreeGames.CampaignRef && player.FreeGames.NoOfFreeSpins > 0 && transaction.RoundId == transaction.TransactionId {
@@ -1417,6 +1428,13 @@ func (i *RemoteServiceImpl) TransactionByGameId(token Token, mode Mode, gameId s
 	//if queryResp.PlayerId == i.logAccount {
 	//	logger.Infof("%v request took %v for account %v", ApiTypeBalance, time.Now().Sub(start).String(), balResp.PlayerId)
 	//}
+	// the time of the last tx is not returned, it is recovered from the ttl stamp that was sent with it. This relies on
+	// Dashur echoing back the exact ttlstamp and ttl of the tx, the min spin duration of a jurisdiction is enforced
+	// against this time and is not enforced if the ttlstamp is dropped or rewritten.
+	var txTime time.Time
+	if lastTx.TtlStamp > 0 {
+		txTime = time.Unix(lastTx.TtlStamp-lastTx.Ttl, 0)
+	}
 	return TransactionStore{
 		TransactionId:       lastTx.TxRef,
 		Token:               balance.Token, // the token returned in the queryResp is the token used to make the tx call, not a new token
@@ -1427,8 +1445,8 @@ func (i *RemoteServiceImpl) TransactionByGameId(token Token, mode Mode, gameId s
 		GameId:              lastTx.Game,
 		RoundId:             lastTx.Round,
 		Amount:              balance.Balance,
-		ParentTransactionId: "",         //TODO: fix this
-		TxTime:              time.Now(), //TODO: fix this
+		ParentTransactionId: "", //TODO: fix this
+		TxTime:              txTime,
 		GameState:           gameState,
 		BetLimitSettingCode: queryResp.BetLimit,
 		CompanyId:           fmt.Sprintf("%v", queryResp.CompanyId),
@@ -1588,10 +1606,7 @@ func (i *LocalServiceImpl) setToken(token Token, playerId string) {
 		panic(err)
 	}
 
-	ld.Lock.Lock()
-	defer ld.Lock.Unlock()
-
-	ld.Token[token] = NewGcString(playerId, 3600)
+	lds.SetToken(token, playerId, 3600)
 }
 
 func (i *LocalServiceImpl) deleteToken(token Token) {
@@ -1600,13 +1615,7 @@ func (i *LocalServiceImpl) deleteToken(token Token) {
 		panic(err)
 	}
 
-	ld.Lock.Lock()
-	defer ld.Lock.Unlock()
-	_, ok := ld.Token[token]
-	// don't delete the token if it matches the player id
-	if ok && string(token) != ld.Token[token].str {
-		delete(ld.Token, token)
-	}
+	lds.DeleteToken(token)
 }
 
 func (i *LocalServiceImpl) getToken(token Token) (string, bool) {
@@ -1615,14 +1624,7 @@ func (i *LocalServiceImpl) getToken(token Token) (string, bool) {
 		panic(err)
 	}
 
-	ld.Lock.RLock()
-	defer ld.Lock.RUnlock()
-
-	playerId, ok := ld.Token[token]
-	if ok {
-		return playerId.str, true
-	}
-	return "", false
+	return lds.GetToken(token)
 }
 
 func (i *LocalServiceImpl) setPlayer(playerId string, player PlayerStore) {
@@ -1631,10 +1633,7 @@ func (i *LocalServiceImpl) setPlayer(playerId string, player PlayerStore) {
 		panic(err)
 	}
 
-	ld.Lock.Lock()
-	defer ld.Lock.Unlock()
-
-	ld.Player[playerId] = NewGcPlayerStore(player, 3600)
+	lds.SetPlayer(playerId, player, 3600)
 }
 
 func (i *LocalServiceImpl) getPlayer(playerId string) (ps PlayerStore, ok bool) {
@@ -1643,16 +1642,7 @@ func (i *LocalServiceImpl) getPlayer(playerId string) (ps PlayerStore, ok bool)
 		panic(err)
 	}
 
-	ld.Lock.RLock()
-	defer ld.Lock.RUnlock()
-
-	var player gcPlayerStore
-	player, ok = ld.Player[playerId]
-	if ok {
-		ps = player.ps
-	}
-
-	return
+	return lds.GetPlayer(playerId)
 }
 
 func (i *LocalServiceImpl) SetMessage(playerId string, message string) rgse.RGSErr {
@@ -1662,10 +1652,7 @@ func (i *LocalServiceImpl) SetMessage(playerId string, message string) rgse.RGSE
 		return rgse.Create(rgse.InternalServerError)
 	}
 
-	ld.Lock.Lock()
-	defer ld.Lock.Unlock()
-
-	ld.Message[playerId] = NewGcString(message, 3600)
+	lds.SetMessage(playerId, message, 3600)
 	return nil
 }
 
@@ -1675,16 +1662,7 @@ func (i *LocalServiceImpl) getMessage(playerId string) (msg string, ok bool) {
 		panic(err)
 	}
 
-	ld.Lock.RLock()
-	defer ld.Lock.RUnlock()
-
-	var message gcstring
-	message, ok = ld.Message[playerId]
-	if ok {
-		msg = message.str
-	}
-
-	return
+	return lds.GetMessage(playerId)
 }
 
 func (i *LocalServiceImpl) setTransaction(transactionId string, transaction TransactionStore) {
@@ -1693,10 +1671,7 @@ func (i *LocalServiceImpl) setTransaction(transactionId string, transaction Tran
 		panic(err)
 	}
 
-	ld.Lock.Lock()
-	defer ld.Lock.Unlock()
-
-	ld.Transaction[transactionId] = NewGcTransactionStore(transaction, transaction.Ttl)
+	lds.SetTransaction(transactionId, transaction, transaction.Ttl)
 }
 
 func (i *LocalServiceImpl) getTransaction(transactionId string) (tx TransactionStore, ok bool) {
@@ -1705,16 +1680,7 @@ func (i *LocalServiceImpl) getTransaction(transactionId string) (tx TransactionS
 		panic(err)
 	}
 
-	ld.Lock.RLock()
-	defer ld.Lock.RUnlock()
-
-	var transaction gcTransactionStore
-	transaction, ok = ld.Transaction[transactionId]
-	if ok {
-		tx = transaction.ts
-	}
-
-	return
+	return lds.GetTransaction(transactionId)
 }
 
 func (i *LocalServiceImpl) setTransactionByPlayerGame(key string, transaction TransactionStore) {
@@ -1723,10 +1689,7 @@ func (i *LocalServiceImpl) setTransactionByPlayerGame(key string, transaction Tr
 		panic(err)
 	}
 
-	ld.Lock.Lock()
-	defer ld.Lock.Unlock()
-
-	ld.TransactionByPlayerGame[key] = NewGcTransactionStore(transaction, transaction.Ttl)
+	lds.SetTransactionByPlayerGame(key, transaction, transaction.Ttl)
 }
 
 func (i *LocalServiceImpl) getTransactionByPlayerGame(key string) (tx TransactionStore, ok bool) {
@@ -1735,15 +1698,7 @@ func (i *LocalServiceImpl) getTransactionByPlayerGame(key string) (tx Transactio
 		panic(err)
 	}
 
-	ld.Lock.RLock()
-	defer ld.Lock.RUnlock()
-
-	var transaction gcTransactionStore
-	transaction, ok = ld.TransactionByPlayerGame[key]
-	if ok {
-		tx = transaction.ts
-	}
-	return
+	return lds.GetTransactionByPlayerGame(key)
 }
 
 func (i *LocalServiceImpl) SetBalance(token Token, balance engine.Money) rgse.RGSErr {
@@ -2070,12 +2025,13 @@ func internalInit(c *config.Config) {
 
 	if c.DevMode {
 		if ld == nil {
-			ld = new(LocalData)
-			ld.Token = make(map[Token]gcstring)
-			ld.Player = make(map[string]gcPlayerStore)
-			ld.Message = make(map[string]gcstring)
-			ld.Transaction = make(map[string]gcTransactionStore)
-			ld.TransactionByPlayerGame = make(map[string]gcTransactionStore)
+			data := newLocalData()
+			store, err := NewLocalStore(c, data)
+			if err != nil {
+				logger.Errorf("falling back to in-memory local store: %s", err.Error())
+				store = data
+			}
+			ld, lds = data, store
 
 			go garbageCollector()
 		}
@@ -2093,7 +2049,7 @@ func internalCheck() rgse.RGSErr {
 		return rgse.Create(rgse.StoreInitError)
 	}
 
-	if ld.Player == nil {
+	if ld.Player == nil || lds == nil {
 		logger.Errorf("Local data is not initialized. Panic!!")
 		return rgse.Create(rgse.StoreInitError)
 	}
@@ -2125,7 +2081,9 @@ func New(c *config.Config) Service {
 
 func NewLocal() LocalService {
 	internalInit(&config.Config{
-		DevMode: true,
+		DevMode:        true,
+		LocalStore:     config.GlobalConfig.LocalStore,
+		LocalStorePath: config.GlobalConfig.LocalStorePath,
 	})
 
 	return &LocalServiceImpl{}
@@ -109,6 +109,10 @@ func TestRemoteServiceImpl_TransactionByGameId_1(t *testing.T) {
 	if txStore.Amount.Amount != engine.NewFixedFromFloat(float32(100/100)) {
 		t.Errorf("Found error, balance is not equal [%v] - [%v]", txStore.Amount.Amount, engine.NewFixedFromFloat(float32(100/100)))
 	}
+
+	if time.Since(txStore.TxTime) > time.Minute {
+		t.Errorf("Found error, transaction time is not recovered from the ttl stamp [%v]", txStore.TxTime)
+	}
 }
 
 func TestRemoteServiceImpl_TransactionByGameId_2(t *testing.T) {
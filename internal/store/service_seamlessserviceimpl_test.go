@@ -0,0 +1,175 @@
+package store
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
+	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/seamless"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+func testSeamlessService(secret string) (Service, *seamless.StandIn, *httptest.Server) {
+	logger.NewLogger(logger.Configuration{})
+	standIn := seamless.NewStandIn("secret")
+	standIn.AddPlayer("token-1", "player-1", "USD", engine.NewFixedFromInt(100), "company-1")
+	server := httptest.NewServer(standIn)
+	service := NewSeamless(config.Wallet{
+		Name:       "seamless-test",
+		Mode:       string(ModeReal),
+		Adapter:    config.WalletAdapterSeamless,
+		Url:        server.URL + "/wallet",
+		Secret:     secret,
+		MaxRetries: 2,
+		TimeoutMs:  1000,
+	}, &config.Config{DefaultPlatform: "html5", DefaultLanguage: "en"})
+	return service, standIn, server
+}
+
+func testSeamlessTransaction(id string, category Category, amount int) TransactionStore {
+	return TransactionStore{
+		TransactionId: id,
+		Token:         "token-1",
+		Mode:          ModeReal,
+		Category:      category,
+		RoundStatus:   RoundStatusOpen,
+		GameId:        "game-1",
+		RoundId:       "round-1",
+		Amount:        engine.Money{Amount: engine.NewFixedFromInt(amount), Currency: "USD"},
+		TxTime:        time.Now(),
+		GameState:     []byte("state of " + id),
+		Ttl:           3600,
+	}
+}
+
+func TestSeamlessServiceImpl_Transaction(t *testing.T) {
+	service, standIn, server := testSeamlessService("secret")
+	defer server.Close()
+
+	player, gs, err := service.PlayerByToken("token-1", ModeReal, "game-1")
+	if err != nil {
+		t.Fatalf("authenticate: %v", err.Error())
+	}
+	if player.PlayerId != "player-1" || player.CompanyId != "company-1" || player.Balance.Amount != engine.NewFixedFromInt(100) || len(gs.GameState) != 0 {
+		t.Fatalf("unexpected player %#v gamestate %v", player, gs)
+	}
+
+	balance, err := service.MultiTransaction("token-1", ModeReal, []TransactionStore{
+		testSeamlessTransaction("tx-1", CategoryWager, 10),
+		testSeamlessTransaction("tx-2", CategoryPayout, 4),
+	})
+	if err != nil {
+		t.Fatalf("transactions: %v", err.Error())
+	}
+	if balance.Balance.Amount != engine.NewFixedFromInt(94) || standIn.Balance("player-1") != engine.NewFixedFromInt(94) {
+		t.Errorf("balance %v, wallet balance %v, expected 94", balance.Balance.Amount, standIn.Balance("player-1"))
+	}
+
+	tx, err := service.TransactionByGameId("token-1", ModeReal, "game-1")
+	if err != nil {
+		t.Fatalf("last transaction: %v", err.Error())
+	}
+	if tx.TransactionId != "tx-2" || tx.Category != CategoryPayout || string(tx.GameState) != "state of tx-2" || tx.WalletStatus != 1 {
+		t.Errorf("unexpected last transaction %#v", tx)
+	}
+}
+
+func TestSeamlessServiceImpl_RetryLostResponse(t *testing.T) {
+	service, standIn, server := testSeamlessService("secret")
+	defer server.Close()
+
+	// the wallet debits the player but the answer is lost, the resent debit must not be applied again
+	standIn.Fail(seamless.EndpointTransaction, seamless.StandInFault{Status: http.StatusBadGateway, Process: true})
+	balance, err := service.Transaction("token-1", ModeReal, testSeamlessTransaction("tx-1", CategoryWager, 10))
+	if err != nil {
+		t.Fatalf("wager: %v", err.Error())
+	}
+	if standIn.Calls(seamless.EndpointTransaction) != 2 {
+		t.Errorf("wager was sent %v times, expected 2", standIn.Calls(seamless.EndpointTransaction))
+	}
+	if balance.Balance.Amount != engine.NewFixedFromInt(90) || standIn.Balance("player-1") != engine.NewFixedFromInt(90) {
+		t.Errorf("balance %v, wallet balance %v, expected 90", balance.Balance.Amount, standIn.Balance("player-1"))
+	}
+}
+
+func TestSeamlessServiceImpl_RollbackUnanswered(t *testing.T) {
+	service, standIn, server := testSeamlessService("secret")
+	defer server.Close()
+
+	// the wager is never answered, it is rolled back whether or not the wallet received it
+	fault := seamless.StandInFault{Status: http.StatusServiceUnavailable}
+	standIn.Fail(seamless.EndpointTransaction, seamless.StandInFault{Status: http.StatusServiceUnavailable, Process: true}, fault, fault)
+	_, err := service.Transaction("token-1", ModeReal, testSeamlessTransaction("tx-1", CategoryWager, 10))
+	if err == nil {
+		t.Fatalf("unanswered wager succeeded")
+	}
+	if standIn.Calls(seamless.EndpointRollback) != 1 || standIn.Processed("tx-1") {
+		t.Errorf("unanswered wager was not rolled back")
+	}
+	if standIn.Balance("player-1") != engine.NewFixedFromInt(100) {
+		t.Errorf("wallet balance %v, expected 100", standIn.Balance("player-1"))
+	}
+	// a late copy of the wager is refused
+	_, err = service.Transaction("token-1", ModeReal, testSeamlessTransaction("tx-1", CategoryWager, 10))
+	if err == nil || standIn.Balance("player-1") != engine.NewFixedFromInt(100) {
+		t.Errorf("rolled back wager was applied")
+	}
+}
+
+func TestSeamlessServiceImpl_MultiTransactionRollback(t *testing.T) {
+	service, standIn, server := testSeamlessService("secret")
+	defer server.Close()
+
+	// the wallet refuses the payout, the wager of the round is rolled back
+	payout := testSeamlessTransaction("tx-2", CategoryPayout, 4)
+	payout.Amount.Currency = "EUR"
+	_, err := service.MultiTransaction("token-1", ModeReal, []TransactionStore{
+		testSeamlessTransaction("tx-1", CategoryWager, 10),
+		payout,
+	})
+	if err == nil || err.(*rgse.RGSError).ErrCode != rgse.BadRequest {
+		t.Fatalf("refused payout did not fail the round: %v", err)
+	}
+	if standIn.Processed("tx-1") || standIn.Balance("player-1") != engine.NewFixedFromInt(100) {
+		t.Errorf("wager was not rolled back, wallet balance %v", standIn.Balance("player-1"))
+	}
+}
+
+func TestSeamlessServiceImpl_MultiTransactionUnanswered(t *testing.T) {
+	service, standIn, server := testSeamlessService("secret")
+	defer server.Close()
+
+	// the wager is answered, the payout is applied but never answered, both are rolled back
+	fault := seamless.StandInFault{Status: http.StatusServiceUnavailable}
+	standIn.Fail(seamless.EndpointTransaction, seamless.StandInFault{}, seamless.StandInFault{Status: http.StatusBadGateway, Process: true}, fault, fault)
+	_, err := service.MultiTransaction("token-1", ModeReal, []TransactionStore{
+		testSeamlessTransaction("tx-1", CategoryWager, 10),
+		testSeamlessTransaction("tx-2", CategoryPayout, 4),
+	})
+	if err == nil {
+		t.Fatalf("unanswered payout did not fail the round")
+	}
+	if standIn.Calls(seamless.EndpointRollback) != 2 || standIn.Processed("tx-1") || standIn.Processed("tx-2") {
+		t.Errorf("round was not rolled back")
+	}
+	if standIn.Balance("player-1") != engine.NewFixedFromInt(100) {
+		t.Errorf("wallet balance %v, expected 100", standIn.Balance("player-1"))
+	}
+}
+
+func TestSeamlessServiceImpl_InvalidSignature(t *testing.T) {
+	service, standIn, server := testSeamlessService("other secret")
+	defer server.Close()
+
+	_, err := service.BalanceByToken("token-1", ModeReal)
+	if err == nil || err.(*rgse.RGSError).ErrCode != rgse.InvalidSignature {
+		t.Fatalf("answer signed with another secret was accepted: %v", err)
+	}
+	if standIn.Calls(seamless.EndpointBalance) != 0 {
+		t.Errorf("wallet processed a request signed with another secret")
+	}
+}
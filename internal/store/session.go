@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
 	rgse "gitlab.maverick-ops.com/maverick/rgs-core-v2/errors"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/parameterSelector"
@@ -11,29 +12,33 @@ import (
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
-func InitPlayerGS(refreshToken string, playerID string, gameName string, currency string, wallet string) (latestGamestate engine.Gamestate, newPlayer PlayerStore, err rgse.RGSErr) {
+// InitPlayerGS returns the player and the latest gamestate of the game. New demo players get the bet profile and, if
+// the operator awards them, the free games of the demo wallet.
+func InitPlayerGS(refreshToken string, playerID string, gameName string, currency string, wallet string, betProfile string, freeGames bool) (latestGamestate engine.Gamestate, newPlayer PlayerStore, err rgse.RGSErr) {
 	logger.Debugf("init game %v for player %v", gameName, playerID)
 
-	err = ValidateWallet(wallet)
+	w, err := config.GetWallet(wallet)
 	if err != nil {
 		return
 	}
 
 	var latestGamestateStore GameStateStore
-	mode := WalletMode(wallet)
-	newPlayer, latestGamestateStore, err = GetService(mode).PlayerByToken(Token(refreshToken), mode, gameName)
+	service, mode := adapterService(w), Mode(w.Mode)
+	newPlayer, latestGamestateStore, err = service.PlayerByToken(Token(refreshToken), mode, gameName)
 	if err != nil && err.(*rgse.RGSError).ErrCode != rgse.NoSuchPlayer {
 		return
 	}
 
 	if len(latestGamestateStore.GameState) == 0 {
 		logger.Debugf("latest gamestate had length zero")
-		if wallet == "demo" {
-			// todo : allow setting of betlimitsettignscode
-			balance, ctFS, waFS, err := parameterSelector.GetDemoWalletDefaults(currency, gameName, "", playerID, newPlayer.BetSettingId)
+		if w.Adapter == config.WalletAdapterDemo {
+			balance, ctFS, waFS, err := parameterSelector.GetDemoWalletDefaults(currency, gameName, betProfile, playerID, newPlayer.BetSettingId)
 			if err != nil {
 				return engine.Gamestate{}, PlayerStore{}, err
 			}
+			if !freeGames {
+				ctFS, waFS = 0, 0
+			}
 			logger.Debugf("balance: %v, freespins: %v, wageramt: %v", balance, ctFS, waFS)
 
 			newPlayer = PlayerStore{
@@ -42,7 +47,7 @@ func InitPlayerGS(refreshToken string, playerID string, gameName string, currenc
 				Mode:                ModeDemo,
 				Username:            playerID,
 				Balance:             balance,
-				BetLimitSettingCode: "",
+				BetLimitSettingCode: betProfile,
 				CompanyId:           newPlayer.CompanyId,
 				FreeGames: FreeGamesStore{
 					NoOfFreeSpins: ctFS,
@@ -79,7 +84,7 @@ func InitPlayerGS(refreshToken string, playerID string, gameName string, currenc
 
 		// store the initial gamestate
 		var balanceStore BalanceStore
-		balanceStore, err = GetService(mode).Transaction(newPlayer.Token, mode, transaction)
+		balanceStore, err = service.Transaction(newPlayer.Token, mode, transaction)
 
 		if err != nil {
 			logger.Debugf("initial gamestate transaction failed")
@@ -126,39 +131,23 @@ func CreateInitGS(player PlayerStore, gameName string) (latestGamestate engine.G
 
 func PlayerBalance(token, wallet string) (BalanceStore, rgse.RGSErr) {
 	logger.Debugf("Token [%s] Wallet [%s]", token, wallet)
-	var balance BalanceStore
-	var err rgse.RGSErr
-	switch wallet {
-	case "dashur":
-		balance, err = Serv.BalanceByToken(Token(token), ModeReal)
-		if err != nil {
-			logger.Debugf("PlayerBalance Error: %v", &err)
-			return BalanceStore{}, err
-		}
-		return balance, nil
-	case "demo":
-		balance, err = ServLocal.BalanceByToken(Token(token), ModeDemo)
-		if err != nil {
-			logger.Debugf("PlayerBalance Error: %v", err)
-			return BalanceStore{}, err
-		}
-		return balance, nil
-	default:
-		logger.Debugf("PlayerBalance Error: %v", "No wallet specified")
+	service, mode, err := WalletService(wallet)
+	if err != nil {
+		logger.Debugf("PlayerBalance Error: %v", err)
 		return BalanceStore{}, err
 	}
+	balance, err := service.BalanceByToken(Token(token), mode)
+	if err != nil {
+		logger.Debugf("PlayerBalance Error: %v", err)
+		return BalanceStore{}, err
+	}
+	return balance, nil
 }
 
 func SetPlayerBalance(token string, wallet string, balance engine.Money) rgse.RGSErr {
-	switch wallet {
-	case "demo":
-		err := ServLocal.SetBalance(Token(token), balance)
-		if err != nil {
-			return err
-		}
-	default:
-		logger.Errorf("SetPlayerBalance is only available in demo mode")
+	if err := ValidateWallet(wallet); err != nil || WalletAdapter(wallet) != config.WalletAdapterDemo {
+		logger.Errorf("SetPlayerBalance is only available on the demo wallet")
 		return rgse.Create(rgse.InvalidWallet)
 	}
-	return nil
+	return ServLocal.SetBalance(Token(token), balance)
 }
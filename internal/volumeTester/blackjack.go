@@ -0,0 +1,259 @@
+package volumeTester
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// rounds played when the engine config has no volatility to size the test with
+const vtBlackjackRounds = 1000000
+
+// VTBlackjackReport holds the results of the blackjack rounds played with basic strategy
+type VTBlackjackReport struct {
+	Rules           engine.BlackjackConfiguration `json:"rules"`
+	Rounds          int                           `json:"rounds"`
+	Hands           int                           `json:"hands"`
+	HouseEdge       float64                       `json:"houseEdge"` // the loss as a share of the initial bets
+	HouseEdgeLow    float64                       `json:"houseEdgeCi95Low"`
+	HouseEdgeHigh   float64                       `json:"houseEdgeCi95High"`
+	AverageWager    float64                       `json:"averageWager"` // the wagers of a round as a multiple of the initial bet
+	Results         map[string]int                `json:"results"`      // the hands by their result
+	Actions         map[string]int                `json:"actions"`      // the actions taken by the player
+	InsuranceOffers int                           `json:"insuranceOffers"`
+}
+
+// blackjackStats accumulates the rounds of one blackjack worker, the return is the payout over all wagers and the edge
+// is the net result over the initial bets
+type blackjackStats struct {
+	ret     returnStats
+	edge    returnStats
+	hands   int
+	results map[string]int
+	actions map[string]int
+	offers  int
+}
+
+func newBlackjackStats() blackjackStats {
+	return blackjackStats{results: map[string]int{}, actions: map[string]int{}}
+}
+
+func (s *blackjackStats) merge(o blackjackStats) {
+	s.ret.merge(o.ret)
+	s.edge.merge(o.edge)
+	s.hands += o.hands
+	s.offers += o.offers
+	for k, n := range o.results {
+		s.results[k] += n
+	}
+	for k, n := range o.actions {
+		s.actions[k] += n
+	}
+}
+
+// playBlackjackRounds plays the rounds with basic strategy, the cards are drawn from the stream of the worker
+func playBlackjackRounds(stream *rand.Rand, conf engine.BlackjackConfiguration, numPlays int, stats *blackjackStats) {
+	// the wins are measured at the precision of the currency, a blackjack pays fractions of the bet
+	bet := vtPricedBetPerLine
+	round := rng.StreamRound(stream)
+	for j := 0; j < numPlays; j++ {
+		var state engine.GameStateBlackjack
+		var wagered engine.Fixed
+		action := engine.BlackjackDeal
+		for {
+			next, wager, err := engine.PlayBlackjack(round, state, action, bet, conf)
+			if err != nil {
+				logger.Errorf("blackjack vt: %v", err.Error())
+				return
+			}
+			state = next
+			wagered += wager
+			stats.actions[action]++
+			if !state.InProgress() {
+				break
+			}
+			if action == engine.BlackjackDeal && state.Actions[0] == engine.BlackjackInsurance {
+				stats.offers++
+			}
+			action = blackjackBasicStrategy(state, conf)
+		}
+		stats.ret.add(state.Win.ValueAsFloat64(), wagered.ValueAsFloat64())
+		stats.edge.add((wagered - state.Win).ValueAsFloat64(), bet.ValueAsFloat64())
+		stats.hands += len(state.Hands)
+		for _, h := range state.Hands {
+			stats.results[h.Result]++
+		}
+	}
+}
+
+// blackjackBasicStrategy returns the action of multi deck basic strategy for the active hand, insurance is declined
+func blackjackBasicStrategy(state engine.GameStateBlackjack, conf engine.BlackjackConfiguration) string {
+	offered := func(action string) bool {
+		for _, a := range state.Actions {
+			if a == action {
+				return true
+			}
+		}
+		return false
+	}
+	if offered(engine.BlackjackNoInsurance) {
+		return engine.BlackjackNoInsurance
+	}
+	hand := state.Hands[state.Active]
+	up := engine.BlackjackCardValue(state.Dealer[0])
+	if up == 1 {
+		up = 11
+	}
+	upIn := func(low int, high int) bool {
+		return up >= low && up <= high
+	}
+	doubleOr := func(otherwise string) string {
+		if offered(engine.BlackjackDouble) {
+			return engine.BlackjackDouble
+		}
+		return otherwise
+	}
+
+	if offered(engine.BlackjackSplit) {
+		split := false
+		switch engine.BlackjackCardValue(hand.Cards[0]) {
+		case 1, 8:
+			split = true
+		case 9:
+			split = upIn(2, 6) || upIn(8, 9)
+		case 7:
+			split = upIn(2, 7)
+		case 6:
+			split = upIn(2, 6) && conf.DoubleAfterSplit || upIn(3, 6)
+		case 4:
+			split = upIn(5, 6) && conf.DoubleAfterSplit
+		case 2, 3:
+			split = upIn(2, 7) && conf.DoubleAfterSplit || upIn(4, 7)
+		}
+		if split {
+			return engine.BlackjackSplit
+		}
+	}
+
+	value, soft := engine.BlackjackHandValue(hand.Cards)
+	if soft {
+		switch {
+		case value >= 19:
+			return engine.BlackjackStand
+		case value == 18:
+			if upIn(3, 6) {
+				return doubleOr(engine.BlackjackStand)
+			}
+			if upIn(2, 8) {
+				return engine.BlackjackStand
+			}
+			return engine.BlackjackHit
+		case value == 17 && upIn(3, 6), value >= 15 && upIn(4, 6), value >= 13 && upIn(5, 6):
+			return doubleOr(engine.BlackjackHit)
+		}
+		return engine.BlackjackHit
+	}
+	switch {
+	case value >= 17:
+		return engine.BlackjackStand
+	case value >= 13:
+		if upIn(2, 6) {
+			return engine.BlackjackStand
+		}
+	case value == 12:
+		if upIn(4, 6) {
+			return engine.BlackjackStand
+		}
+	case value == 11:
+		if upIn(2, 10) {
+			return doubleOr(engine.BlackjackHit)
+		}
+	case value == 10:
+		if upIn(2, 9) {
+			return doubleOr(engine.BlackjackHit)
+		}
+	case value == 9:
+		if upIn(3, 6) {
+			return doubleOr(engine.BlackjackHit)
+		}
+	}
+	return engine.BlackjackHit
+}
+
+// VolumeTestBlackjack plays numPlays rounds of the blackjack engine with basic strategy and measures the house edge,
+// the engine passes if the configured RTP lies within the 99.9% confidence interval of the measured RTP
+func VolumeTestBlackjack(engineID string, numPlays int, workers int) ([]string, VTReport) {
+	refTime := time.Now()
+	engineConf := engine.BuildEngineDefs(engineID)
+	conf := engineConf.EngineDefs[0].BlackjackConfig
+	if numPlays == 0 {
+		numPlays = vtBlackjackRounds
+		if engineConf.Volatility > 0 {
+			numPlays = engineConf.NumSpinsStat()
+		}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	logger.Infof("Running %v blackjack rounds for engine %v on %v workers", numPlays, engineID, workers)
+
+	shards := shardPlays(numPlays, workers)
+	results := make([]blackjackStats, workers)
+	var wg sync.WaitGroup
+	for w := range results {
+		results[w] = newBlackjackStats()
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			playBlackjackRounds(rng.Seeded(rng.NewSeed()), conf, shards[w], &results[w])
+		}(w)
+	}
+	wg.Wait()
+	total := newBlackjackStats()
+	for w := range results {
+		total.merge(results[w])
+	}
+
+	report := VTReport{
+		Engine:             engineID,
+		Spins:              total.ret.n,
+		Rounds:             total.edge.n,
+		Workers:            workers,
+		ExpectedRTP:        float64(engineConf.RTP),
+		RTP:                total.ret.ratio(),
+		RTPBase:            total.ret.ratio(),
+		RTPStdErr:          total.ret.stdErr(),
+		Variance:           total.ret.variance(),
+		ExpectedVolatility: engineConf.Volatility,
+		Defs:               []VTDefReport{},
+	}
+	report.RTPLow = report.RTP - ci95*report.RTPStdErr
+	report.RTPHigh = report.RTP + ci95*report.RTPStdErr
+	report.RTPDeviant, report.Passed = rtpVerdict(report.ExpectedRTP, report.RTP, report.RTPStdErr)
+	report.Blackjack = &VTBlackjackReport{
+		Rules:           conf,
+		Rounds:          total.edge.n,
+		Hands:           total.hands,
+		HouseEdge:       total.edge.ratio(),
+		HouseEdgeLow:    total.edge.ratio() - ci95*total.edge.stdErr(),
+		HouseEdgeHigh:   total.edge.ratio() + ci95*total.edge.stdErr(),
+		AverageWager:    total.ret.sumY / total.edge.sumY,
+		Results:         total.results,
+		Actions:         total.actions,
+		InsuranceOffers: total.offers,
+	}
+
+	b := report.Blackjack
+	info := fmt.Sprintf("Blackjack %v | Rounds: %v | Hands: %v | RTP: %.4f%% | 95%% confidence interval %.4f%% - %.4f%% | House edge: %.4f%% (%.4f%% - %.4f%%) | Average wager: %.4f\n", engineID, b.Rounds, b.Hands, report.RTP*100, report.RTPLow*100, report.RTPHigh*100, b.HouseEdge*100, b.HouseEdgeLow*100, b.HouseEdgeHigh*100, b.AverageWager)
+	if report.RTPDeviant {
+		logger.Warnf("WARNING : RTP DEVIANT (%.2f%%, 95%% confidence interval %.2f%% - %.2f%%)", report.RTP*100, report.RTPLow*100, report.RTPHigh*100)
+	}
+	logger.Infof(info)
+	logger.Infof("Blackjack results %v, actions %v, took %v", b.Results, b.Actions, time.Now().Sub(refTime))
+	return []string{info}, report
+}
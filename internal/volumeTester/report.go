@@ -0,0 +1,274 @@
+package volumeTester
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+)
+
+// z value of the two sided 95% confidence interval
+const ci95 = 1.959964
+
+// z value of the two sided 99.9% confidence interval
+const ci999 = 3.290527
+
+// prize wins are tracked as multipliers of the bet per line
+const vtBetPerLine = engine.Fixed(1000)
+
+// bet per line of the rounds that are played at a multiple of the stake
+const vtPricedBetPerLine = engine.Fixed(1000000)
+
+// VTReport is the machine readable result of the volume test of one engine
+type VTReport struct {
+	Engine             string             `json:"engine"`
+	BetMode            string             `json:"betMode,omitempty"`
+	Spins              int                `json:"spins"`
+	Rounds             int                `json:"rounds"` // finished rounds, a round spans its freespins and cascades
+	Workers            int                `json:"workers"`
+	ExpectedRTP        float64            `json:"expectedRtp"`
+	RTP                float64            `json:"rtp"`
+	RTPBase            float64            `json:"rtpBase"`
+	RTPFeature         float64            `json:"rtpFeature"`
+	RTPStdErr          float64            `json:"rtpStdErr"`
+	RTPLow             float64            `json:"rtpCi95Low"`
+	RTPHigh            float64            `json:"rtpCi95High"`
+	RTPDeviant         bool               `json:"rtpDeviant"` // the expected rtp lies outside the 95% confidence interval
+	Variance           float64            `json:"variance"`   // variance of the return of a round
+	ExpectedVolatility float64            `json:"expectedVolatility"`
+	Cascades           int                `json:"cascades"`
+	MaxWinMultiplier   int                `json:"maxWinMultiplier,omitempty"`
+	CappedRounds       int                `json:"cappedRounds"` // rounds whose win was truncated at the max win
+	Defs               []VTDefReport      `json:"defs"`
+	BuyFeature         *VTBuyReport       `json:"buyFeature,omitempty"`
+	Gamble             *VTGambleReport    `json:"gamble,omitempty"`
+	BetModes           []VTReport         `json:"betModes,omitempty"`  // the spins played in each bet mode
+	Blackjack          *VTBlackjackReport `json:"blackjack,omitempty"` // the rounds of a blackjack engine
+	Scratch            *VTScratchReport   `json:"scratch,omitempty"`   // the tickets of a scratch card engine
+	Passed             bool               `json:"passed"`
+}
+
+// VTBuyReport holds the results of the rounds that were started by buying the feature
+type VTBuyReport struct {
+	PriceMultiplier float64 `json:"priceMultiplier"`
+	Rounds          int     `json:"rounds"`
+	Spins           int     `json:"spins"`
+	ExpectedRTP     float64 `json:"expectedRtp"`
+	RTP             float64 `json:"rtp"`
+	RTPStdErr       float64 `json:"rtpStdErr"`
+	RTPLow          float64 `json:"rtpCi95Low"`
+	RTPHigh         float64 `json:"rtpCi95High"`
+	RTPDeviant      bool    `json:"rtpDeviant"`
+	Passed          bool    `json:"passed"`
+}
+
+// VTGambleReport holds the results of the gamble steps played on the round wins
+type VTGambleReport struct {
+	Mode        string  `json:"mode"`
+	Steps       int     `json:"steps"`
+	MaxStep     int     `json:"maxStep"`
+	ExpectedRTP float64 `json:"expectedRtp"`
+	RTP         float64 `json:"rtp"`
+	RTPStdErr   float64 `json:"rtpStdErr"`
+	RTPLow      float64 `json:"rtpCi95Low"`
+	RTPHigh     float64 `json:"rtpCi95High"`
+	RTPDeviant  bool    `json:"rtpDeviant"`
+	Passed      bool    `json:"passed"`
+}
+
+// VTDefReport holds the results of the spins played on one engine def, cascades are reported separately
+type VTDefReport struct {
+	DefID          int             `json:"defId"`
+	Cascade        bool            `json:"cascade"`
+	ExpectedRTP    float64         `json:"expectedRtp"`
+	RTP            float64         `json:"rtp"`
+	Plays          int             `json:"plays"`
+	PayoutPerRound float64         `json:"payoutPerRound"`
+	MaxWin         float64         `json:"maxWin"`
+	Prizes         []VTPrizeReport `json:"prizes"`
+}
+
+// VTPrizeReport holds the hit rate and return of one prize index
+type VTPrizeReport struct {
+	Index   string  `json:"index"`
+	Hits    int     `json:"hits"`
+	HitRate float64 `json:"hitRate"`
+	RTP     float64 `json:"rtp"`
+	MaxWin  int     `json:"maxWin"`
+}
+
+// rtpVerdict checks the expected rtp against the measured rtp. A correct engine misses the 95% confidence interval in
+// about one run in twenty, so a miss is only reported as deviant and the run fails outside the 99.9% interval.
+func rtpVerdict(expected float64, rtp float64, stdErr float64) (deviant bool, passed bool) {
+	// round the bounds outwards to the precision of Fixed so that an engine paying out exactly the expected value passes
+	deviation := math.Abs(expected - rtp)
+	return deviation > ci95*stdErr+1e-6, deviation <= ci999*stdErr+1e-6
+}
+
+func newVTReport(engineID string, engineConf engine.EngineConfig, stats vtStats, workers int) VTReport {
+	report := VTReport{
+		Engine:             engineID,
+		Spins:              stats.ret.n,
+		Rounds:             stats.rounds.n,
+		Workers:            workers,
+		ExpectedRTP:        float64(engineConf.RTP),
+		RTP:                stats.ret.ratio(),
+		RTPStdErr:          stats.rounds.stdErr(),
+		Variance:           stats.rounds.variance(),
+		ExpectedVolatility: engineConf.Volatility,
+		Cascades:           stats.ctCascades,
+		MaxWinMultiplier:   engineConf.MaxWinMultiplier,
+		CappedRounds:       stats.capped,
+		Defs:               []VTDefReport{},
+	}
+	totalBet := stats.totalBet.ValueAsFloat64()
+	betPerLine := stats.betPerLine
+	if betPerLine == 0 {
+		betPerLine = vtBetPerLine
+	}
+	if totalBet > 0 {
+		report.RTPFeature = stats.featureWin.ValueAsFloat64() / totalBet
+		report.RTPBase = report.RTP - report.RTPFeature
+	}
+	report.RTPLow = report.RTP - ci95*report.RTPStdErr
+	report.RTPHigh = report.RTP + ci95*report.RTPStdErr
+	report.RTPDeviant, report.Passed = rtpVerdict(report.ExpectedRTP, report.RTP, report.RTPStdErr)
+
+	for rsID, info := range stats.defs {
+		if info.totalPlays == 0 {
+			continue
+		}
+		defID := rsID
+		if rsID >= len(engineConf.EngineDefs) {
+			defID = rsID - len(engineConf.EngineDefs)
+		}
+		def := VTDefReport{
+			DefID:          defID,
+			Cascade:        rsID != defID,
+			ExpectedRTP:    float64(engineConf.EngineDefs[defID].RTP),
+			Plays:          info.totalPlays,
+			PayoutPerRound: info.totalWin.ValueAsFloat64() / float64(info.totalPlays),
+			MaxWin:         info.maxWin.ValueAsFloat64(),
+			Prizes:         []VTPrizeReport{},
+		}
+		if totalBet > 0 {
+			def.RTP = info.totalWin.ValueAsFloat64() / totalBet
+		}
+		for index, prize := range info.prizes {
+			p := VTPrizeReport{
+				Index:   index,
+				Hits:    prize.hits,
+				HitRate: float64(prize.hits) / float64(info.totalPlays),
+				MaxWin:  prize.maxWin,
+			}
+			if totalBet > 0 {
+				p.RTP = float64(prize.totalWin) * betPerLine.ValueAsFloat64() / totalBet
+			}
+			def.Prizes = append(def.Prizes, p)
+		}
+		sort.Slice(def.Prizes, func(i, j int) bool { return def.Prizes[i].Index < def.Prizes[j].Index })
+		report.Defs = append(report.Defs, def)
+	}
+	return report
+}
+
+func newVTBuyReport(engineConf engine.EngineConfig, stats vtStats) *VTBuyReport {
+	price, _ := engineConf.BuyFeaturePrice()
+	report := &VTBuyReport{
+		PriceMultiplier: price.ValueAsFloat64(),
+		Rounds:          stats.defs[engineConf.DefIdByName(engine.BuyFeatureAction)].totalPlays,
+		Spins:           stats.ret.n,
+		ExpectedRTP:     float64(engineConf.RTP),
+		RTP:             stats.ret.ratio(),
+		RTPStdErr:       stats.rounds.stdErr(),
+	}
+	report.RTPLow = report.RTP - ci95*report.RTPStdErr
+	report.RTPHigh = report.RTP + ci95*report.RTPStdErr
+	// the price is rounded up to the currency unit, so the bought rtp may lie slightly below the expected rtp
+	report.RTPDeviant, report.Passed = rtpVerdict(report.ExpectedRTP, report.RTP, report.RTPStdErr)
+	return report
+}
+
+func newVTGambleReport(engineConf engine.EngineConfig, stats vtStats) *VTGambleReport {
+	report := &VTGambleReport{
+		Mode:        engineConf.Gamble.Mode,
+		Steps:       stats.gamble.n,
+		MaxStep:     stats.gambleMaxStep,
+		ExpectedRTP: engineConf.Gamble.RTP,
+		RTP:         stats.gamble.ratio(),
+		RTPStdErr:   stats.gamble.stdErr(),
+	}
+	if report.ExpectedRTP == 0 {
+		report.ExpectedRTP = 1
+	}
+	report.RTPLow = report.RTP - ci95*report.RTPStdErr
+	report.RTPHigh = report.RTP + ci95*report.RTPStdErr
+	report.RTPDeviant, report.Passed = rtpVerdict(report.ExpectedRTP, report.RTP, report.RTPStdErr)
+	return report
+}
+
+// WriteVTReports writes the reports to path.json and path.csv
+func WriteVTReports(reports []VTReport, path string) error {
+	path = strings.TrimSuffix(strings.TrimSuffix(path, ".json"), ".csv")
+
+	jsonFile, err := os.Create(path + ".json")
+	if err != nil {
+		return fmt.Errorf("could not create vt report: %v", err)
+	}
+	defer jsonFile.Close()
+	encoder := json.NewEncoder(jsonFile)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(reports); err != nil {
+		return fmt.Errorf("could not write vt report: %v", err)
+	}
+
+	csvFile, err := os.Create(path + ".csv")
+	if err != nil {
+		return fmt.Errorf("could not create vt report: %v", err)
+	}
+	defer csvFile.Close()
+	writer := csv.NewWriter(csvFile)
+	err = writer.WriteAll(vtReportRecords(reports))
+	if err != nil {
+		return fmt.Errorf("could not write vt report: %v", err)
+	}
+	return nil
+}
+
+// vtReportRecords flattens the reports into one row per engine, def and prize so that runs can be diffed line by line
+func vtReportRecords(reports []VTReport) [][]string {
+	f := func(v float64) string {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'f', 6, 64)
+	}
+	records := [][]string{{"engine", "def", "cascade", "prize", "plays", "hits", "hit_rate", "expected_rtp", "rtp", "rtp_ci95_low", "rtp_ci95_high", "variance", "expected_volatility", "max_win", "passed", "rtp_deviant"}}
+	for _, r := range reports {
+		records = append(records, []string{r.Engine, "", "", "", strconv.Itoa(r.Spins), "", "", f(r.ExpectedRTP), f(r.RTP), f(r.RTPLow), f(r.RTPHigh), f(r.Variance), f(r.ExpectedVolatility), "", strconv.FormatBool(r.Passed), strconv.FormatBool(r.RTPDeviant)})
+		if b := r.BuyFeature; b != nil {
+			records = append(records, []string{r.Engine, engine.BuyFeatureAction, "", "", strconv.Itoa(b.Spins), strconv.Itoa(b.Rounds), "", f(b.ExpectedRTP), f(b.RTP), f(b.RTPLow), f(b.RTPHigh), "", "", "", strconv.FormatBool(b.Passed), strconv.FormatBool(b.RTPDeviant)})
+		}
+		if g := r.Gamble; g != nil {
+			records = append(records, []string{r.Engine, engine.GambleAction + ":" + g.Mode, "", "", strconv.Itoa(g.Steps), "", "", f(g.ExpectedRTP), f(g.RTP), f(g.RTPLow), f(g.RTPHigh), "", "", "", strconv.FormatBool(g.Passed), strconv.FormatBool(g.RTPDeviant)})
+		}
+		for _, m := range r.BetModes {
+			records = append(records, []string{r.Engine, "betMode:" + m.BetMode, "", "", strconv.Itoa(m.Spins), "", "", f(m.ExpectedRTP), f(m.RTP), f(m.RTPLow), f(m.RTPHigh), f(m.Variance), f(m.ExpectedVolatility), "", strconv.FormatBool(m.Passed), strconv.FormatBool(m.RTPDeviant)})
+		}
+		for _, d := range r.Defs {
+			def := strconv.Itoa(d.DefID)
+			cascade := strconv.FormatBool(d.Cascade)
+			records = append(records, []string{r.Engine, def, cascade, "", strconv.Itoa(d.Plays), "", "", f(d.ExpectedRTP), f(d.RTP), "", "", "", "", f(d.MaxWin), "", ""})
+			for _, p := range d.Prizes {
+				records = append(records, []string{r.Engine, def, cascade, p.Index, "", strconv.Itoa(p.Hits), f(p.HitRate), "", f(p.RTP), "", "", "", "", strconv.Itoa(p.MaxWin), "", ""})
+			}
+		}
+	}
+	return records
+}
@@ -0,0 +1,161 @@
+package volumeTester
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+)
+
+func testReportStats(win engine.Fixed) vtStats {
+	stats := testStats()
+	// stake 1 on every spin, every other spin pays out 2*win
+	for i := 0; i < 10000; i++ {
+		s := vtSample{defID: 0, stake: engine.Fixed(1000000)}
+		if i%2 == 0 {
+			s.win = 2 * win
+			s.prizes = []engine.Prize{{Payout: engine.Payout{Multiplier: 2}, Index: "1:3", Multiplier: 1}}
+		}
+		accumulate(&stats, []vtSample{s})
+	}
+	return stats
+}
+
+func TestVTReportVerdict(t *testing.T) {
+	conf := engine.EngineConfig{RTP: 0.95, Volatility: 1, EngineDefs: make([]engine.EngineDef, 3)}
+
+	report := newVTReport("test", conf, testReportStats(engine.NewFixedFromFloat(0.95)), 2)
+	if !report.Passed {
+		t.Errorf("expected report to pass: %#v", report)
+	}
+	if report.RTPLow >= report.RTP || report.RTPHigh <= report.RTP {
+		t.Errorf("rtp %v not inside the confidence interval %v - %v", report.RTP, report.RTPLow, report.RTPHigh)
+	}
+	if len(report.Defs) != 1 || len(report.Defs[0].Prizes) != 1 || report.Defs[0].Prizes[0].HitRate != 0.5 {
+		t.Errorf("unexpected def reports %#v", report.Defs)
+	}
+
+	// about 2.7 standard errors below the expected rtp: outside the 95% interval but not outside the 99.9% interval
+	report = newVTReport("test", conf, testReportStats(engine.NewFixedFromFloat(0.925)), 2)
+	if !report.Passed || !report.RTPDeviant {
+		t.Errorf("expected report with rtp %v (%v - %v) to pass as deviant", report.RTP, report.RTPLow, report.RTPHigh)
+	}
+
+	report = newVTReport("test", conf, testReportStats(engine.NewFixedFromFloat(0.9)), 2)
+	if report.Passed || !report.RTPDeviant {
+		t.Errorf("expected report with rtp %v (%v - %v) to fail", report.RTP, report.RTPLow, report.RTPHigh)
+	}
+}
+
+func TestVTReportRounds(t *testing.T) {
+	conf := engine.EngineConfig{RTP: 1, Volatility: 1, EngineDefs: make([]engine.EngineDef, 3)}
+	stats := testStats()
+	// every round is a paid spin winning nothing and a freespin winning 2 or 0, the spread of the rtp follows the rounds
+	for i := 0; i < 1000; i++ {
+		win := float64(2 * (i % 2))
+		stats.ret.add(0, 1)
+		stats.ret.add(win, 0)
+		stats.rounds.add(win, 1)
+	}
+	report := newVTReport("test", conf, stats, 1)
+	if report.Spins != 2000 || report.Rounds != 1000 || report.RTP != 1 {
+		t.Errorf("unexpected report of %v spins in %v rounds with rtp %v", report.Spins, report.Rounds, report.RTP)
+	}
+	if math.Abs(report.Variance-1000./999.) > 1e-9 || math.Abs(report.RTPStdErr-math.Sqrt(1./999.)) > 1e-9 {
+		t.Errorf("variance %v and standard error %v are not those of the rounds", report.Variance, report.RTPStdErr)
+	}
+}
+
+func TestWriteVTReports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vtreport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	conf := engine.EngineConfig{RTP: 0.95, Volatility: 1, EngineDefs: make([]engine.EngineDef, 3)}
+	reports := []VTReport{newVTReport("test", conf, testReportStats(engine.NewFixedFromFloat(0.95)), 1)}
+	path := filepath.Join(dir, "report")
+	if err := WriteVTReports(reports, path+".json"); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []VTReport
+	b, err := ioutil.ReadFile(path + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 1 || decoded[0].Engine != "test" || decoded[0].Passed != reports[0].Passed {
+		t.Errorf("unexpected json report %#v", decoded)
+	}
+
+	f, err := os.Open(path + ".csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header, engine, def and prize rows
+	if len(records) != 4 {
+		t.Errorf("expected 4 csv records, got %v", len(records))
+	}
+}
+
+func TestVTReportRecordsBetModes(t *testing.T) {
+	conf := engine.EngineConfig{RTP: 0.95, Volatility: 1, EngineDefs: make([]engine.EngineDef, 3)}
+	report := newVTReport("test", conf, testReportStats(engine.NewFixedFromFloat(0.95)), 1)
+	mode := newVTReport("test", conf, testReportStats(engine.NewFixedFromFloat(0.9)), 1)
+	mode.BetMode = "ante"
+	report.BetModes = []VTReport{mode}
+
+	records := vtReportRecords([]VTReport{report})
+	// header, engine, bet mode, def and prize rows
+	if len(records) != 5 {
+		t.Fatalf("expected 5 csv records, got %v", len(records))
+	}
+	if records[2][1] != "betMode:ante" || records[2][14] != strconv.FormatBool(mode.Passed) {
+		t.Errorf("unexpected bet mode record %v", records[2])
+	}
+}
+
+func TestVTGambleReport(t *testing.T) {
+	conf := engine.EngineConfig{RTP: 0.95, Volatility: 1, EngineDefs: make([]engine.EngineDef, 3), Gamble: engine.GambleConfiguration{Mode: "colour"}}
+	stats := newVtStats(conf)
+	// a fair double or nothing: every other step of 1.0 wins 2.0
+	for i := 0; i < 1000; i++ {
+		stats.gamble.add(float64(2*(i%2)), 1)
+	}
+	stats.gambleMaxStep = 3
+	gamble := newVTGambleReport(conf, stats)
+	if gamble.ExpectedRTP != 1 || gamble.RTP != 1 || !gamble.Passed || gamble.Steps != 1000 || gamble.MaxStep != 3 {
+		t.Errorf("unexpected gamble report %#v", gamble)
+	}
+
+	report := newVTReport("test", conf, testReportStats(engine.NewFixedFromFloat(0.95)), 1)
+	report.Gamble = gamble
+	records := vtReportRecords([]VTReport{report})
+	if len(records) != 5 || records[2][1] != "gamble:colour" || records[2][4] != "1000" {
+		t.Errorf("unexpected gamble record %v", records[2])
+	}
+}
+
+func TestVTReportCappedRounds(t *testing.T) {
+	conf := engine.EngineConfig{RTP: 0.95, Volatility: 1, MaxWinMultiplier: 5000, EngineDefs: make([]engine.EngineDef, 3)}
+	stats := testReportStats(engine.NewFixedFromFloat(0.95))
+	stats.capped = 2
+	if report := newVTReport("test", conf, stats, 1); report.MaxWinMultiplier != 5000 || report.CappedRounds != 2 {
+		t.Errorf("unexpected max win %v with %v capped rounds", report.MaxWinMultiplier, report.CappedRounds)
+	}
+}
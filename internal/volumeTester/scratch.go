@@ -0,0 +1,240 @@
+package volumeTester
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
+)
+
+// tickets sold when the engine config has no volatility to size the test with
+const vtScratchTickets = 1000000
+
+// VTScratchReport holds the results of the tickets of a scratch card engine
+type VTScratchReport struct {
+	Mode         string          `json:"mode"`
+	Tickets      int             `json:"tickets"`
+	ExactRTP     float64         `json:"exactRtp"` // the return of the odds of the prizes
+	HitRate      float64         `json:"hitRate"`
+	ExactHitRate float64         `json:"exactHitRate"`
+	Pools        int             `json:"pools,omitempty"` // the pools that were sold out
+	PoolErrors   int             `json:"poolErrors"`      // sold out pools whose tickets differ from the counts of the prizes
+	LayoutErrors int             `json:"layoutErrors"`    // tickets whose cells do not reveal exactly their prize
+	Prizes       []VTPrizeReport `json:"prizes"`
+}
+
+type scratchStats struct {
+	ret          returnStats
+	hits         int
+	prizes       []int
+	pools        int
+	poolErrors   int
+	layoutErrors int
+}
+
+func (s *scratchStats) merge(o scratchStats) {
+	s.ret.merge(o.ret)
+	s.hits += o.hits
+	for i, n := range o.prizes {
+		s.prizes[i] += n
+	}
+	s.pools += o.pools
+	s.poolErrors += o.poolErrors
+	s.layoutErrors += o.layoutErrors
+}
+
+// playScratchTickets sells the tickets of one worker, in pool mode the worker sells from its own run of pools
+func playScratchTickets(stream *rand.Rand, conf engine.ScratchConfiguration, numPlays int, stats *scratchStats) {
+	bet := vtPricedBetPerLine
+	var pool *engine.ScratchPool
+	var sold []int
+	soldOut := func() bool {
+		if pool == nil || pool.Remaining() > 0 {
+			return false
+		}
+		stats.pools++
+		if !scratchPoolExact(conf, sold) {
+			stats.poolErrors++
+		}
+		return true
+	}
+	round := rng.StreamRound(stream)
+	for j := 0; j < numPlays; j++ {
+		prize := 0
+		if conf.PoolMode() {
+			if pool == nil || soldOut() {
+				pool = engine.NewScratchPool(conf, stats.pools+1, rng.SeedFrom(stream))
+				sold = make([]int, len(conf.Prizes))
+			}
+			var ticket int
+			prize, ticket, _ = pool.Next()
+			pool.Sell(ticket)
+			sold[prize]++
+		}
+		if !conf.PoolMode() {
+			prize = conf.DrawPrize(round)
+		}
+		state := engine.PlayScratch(round, conf, prize, bet)
+
+		stats.ret.add(state.Win.ValueAsFloat64(), bet.ValueAsFloat64())
+		stats.prizes[prize]++
+		if state.Win > 0 {
+			stats.hits++
+		}
+		if !scratchTicketValid(conf, state) {
+			stats.layoutErrors++
+		}
+	}
+	soldOut()
+}
+
+// scratchPoolExact checks that a sold out pool sold the configured tickets of every prize
+func scratchPoolExact(conf engine.ScratchConfiguration, sold []int) bool {
+	for i, p := range conf.Prizes {
+		if sold[i] != p.Count {
+			return false
+		}
+	}
+	return true
+}
+
+// scratchTicketValid checks that the cells of the ticket show its symbol exactly Match times at its positions and
+// show every other symbol of the layout fewer times
+func scratchTicketValid(conf engine.ScratchConfiguration, state engine.GameStateScratch) bool {
+	match := conf.MatchCount()
+	if len(state.Layout) != conf.Layout.Rows*conf.Layout.Columns {
+		return false
+	}
+	counts := map[int]int{}
+	for _, s := range state.Layout {
+		counts[s]++
+	}
+	for s, n := range counts {
+		known := false
+		for _, l := range conf.Layout.Symbols {
+			known = known || l == s
+		}
+		if !known {
+			return false
+		}
+		if n >= match && (state.Multiplier == 0 || s != state.Symbol) {
+			return false
+		}
+	}
+	if state.Multiplier == 0 {
+		return len(state.Positions) == 0
+	}
+	if counts[state.Symbol] != match || len(state.Positions) != match {
+		return false
+	}
+	for _, p := range state.Positions {
+		if state.Layout[p] != state.Symbol {
+			return false
+		}
+	}
+	return true
+}
+
+// VolumeTestScratch sells numPlays tickets of the scratch card engine and compares their return with the exact return
+// of the prizes, the engine passes if the configured RTP lies within the 99.9% confidence interval of the measured RTP,
+// every ticket reveals its prize and every sold out pool paid its prizes exactly
+func VolumeTestScratch(engineID string, numPlays int, workers int) ([]string, VTReport) {
+	refTime := time.Now()
+	engineConf := engine.BuildEngineDefs(engineID)
+	def := engineConf.EngineDefs[0]
+	conf := def.ScratchConfig
+	if numPlays == 0 {
+		numPlays = vtScratchTickets
+		if engineConf.Volatility > 0 {
+			numPlays = engineConf.NumSpinsStat()
+		}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	logger.Infof("Running %v scratch tickets for engine %v on %v workers", numPlays, engineID, workers)
+
+	// the tickets pay their prizes in full
+	exact, err := conf.ExactRTP(0)
+	if err != nil {
+		logger.Errorf("scratch vt: %v", err.Error())
+		return []string{err.Error()}, VTReport{Engine: engineID}
+	}
+
+	shards := shardPlays(numPlays, workers)
+	results := make([]scratchStats, workers)
+	var wg sync.WaitGroup
+	for w := range results {
+		results[w].prizes = make([]int, len(conf.Prizes))
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			playScratchTickets(rng.Seeded(rng.NewSeed()), conf, shards[w], &results[w])
+		}(w)
+	}
+	wg.Wait()
+	total := scratchStats{prizes: make([]int, len(conf.Prizes))}
+	for w := range results {
+		total.merge(results[w])
+	}
+
+	report := VTReport{
+		Engine:             engineID,
+		Spins:              total.ret.n,
+		Rounds:             total.ret.n,
+		Workers:            workers,
+		ExpectedRTP:        float64(engineConf.RTP),
+		RTP:                total.ret.ratio(),
+		RTPBase:            total.ret.ratio(),
+		RTPStdErr:          total.ret.stdErr(),
+		Variance:           total.ret.variance(),
+		ExpectedVolatility: engineConf.Volatility,
+		Defs:               []VTDefReport{},
+	}
+	report.RTPLow = report.RTP - ci95*report.RTPStdErr
+	report.RTPHigh = report.RTP + ci95*report.RTPStdErr
+	s := &VTScratchReport{
+		Mode:         conf.Mode,
+		Tickets:      total.ret.n,
+		ExactRTP:     exact.RTP,
+		ExactHitRate: exact.HitFrequency,
+		Pools:        total.pools,
+		PoolErrors:   total.poolErrors,
+		LayoutErrors: total.layoutErrors,
+		Prizes:       []VTPrizeReport{},
+	}
+	if s.Mode == "" {
+		s.Mode = engine.ScratchModeTable
+	}
+	if total.ret.n > 0 {
+		s.HitRate = float64(total.hits) / float64(total.ret.n)
+		for i, p := range conf.Prizes {
+			s.Prizes = append(s.Prizes, VTPrizeReport{
+				Index:   strconv.Itoa(i),
+				Hits:    total.prizes[i],
+				HitRate: float64(total.prizes[i]) / float64(total.ret.n),
+				RTP:     float64(total.prizes[i]*p.Multiplier) / float64(total.ret.n),
+				MaxWin:  p.Multiplier,
+			})
+		}
+	}
+	report.Scratch = s
+	report.RTPDeviant, report.Passed = rtpVerdict(report.ExpectedRTP, report.RTP, report.RTPStdErr)
+	report.Passed = report.Passed && s.LayoutErrors == 0 && s.PoolErrors == 0
+
+	info := fmt.Sprintf("Scratch %v | Mode: %v | Tickets: %v | RTP: %.4f%% | 95%% confidence interval %.4f%% - %.4f%% | Exact RTP: %.4f%% | Hit rate: %.4f%% (exact %.4f%%) | Pools sold out: %v\n", engineID, s.Mode, s.Tickets, report.RTP*100, report.RTPLow*100, report.RTPHigh*100, s.ExactRTP*100, s.HitRate*100, s.ExactHitRate*100, s.Pools)
+	if report.RTPDeviant {
+		logger.Warnf("WARNING : RTP DEVIANT (%.2f%%, 95%% confidence interval %.2f%% - %.2f%%)", report.RTP*100, report.RTPLow*100, report.RTPHigh*100)
+	}
+	if s.LayoutErrors > 0 || s.PoolErrors > 0 {
+		logger.Warnf("WARNING : %v tickets do not reveal their prize, %v pools did not sell their prizes", s.LayoutErrors, s.PoolErrors)
+	}
+	logger.Infof(info)
+	logger.Infof("Scratch took %v", time.Now().Sub(refTime))
+	return []string{info}, report
+}
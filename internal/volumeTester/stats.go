@@ -0,0 +1,210 @@
+package volumeTester
+
+import (
+	"math"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+)
+
+type defInfo struct {
+	totalWin   engine.Fixed
+	totalPlays int
+	prizes     map[string]*prizeInfo
+	maxWin     engine.Fixed
+}
+
+type prizeInfo struct {
+	totalWin int
+	hits     int
+	maxWin   int
+}
+
+func (d *defInfo) addPlay(win engine.Fixed) bool {
+	d.totalWin += win
+	d.totalPlays++
+	if win > d.maxWin {
+		d.maxWin = win
+		return true
+	}
+	return false
+}
+
+func (d *defInfo) addWins(p []engine.Prize, mul int) {
+	if d.prizes == nil {
+		d.prizes = make(map[string]*prizeInfo)
+	}
+
+	for i := 0; i < len(p); i++ {
+		if d.prizes[p[i].Index] == nil {
+			d.prizes[p[i].Index] = &prizeInfo{}
+		}
+		win := p[i].Payout.Multiplier * p[i].Multiplier
+		d.prizes[p[i].Index].addWin(win * mul)
+	}
+}
+
+func (p *prizeInfo) addWin(win int) {
+	p.hits++
+	p.totalWin += win
+	if win > p.maxWin {
+		p.maxWin = win
+	}
+}
+
+type variance struct {
+	xBar    float64
+	sumDist float64
+	n       int
+	betMult float64
+}
+
+func (v *variance) addSample(val float64) {
+	val = val / v.betMult
+	v.sumDist += math.Pow(val-v.xBar, 2)
+	v.n++
+}
+
+func (v *variance) getS2() float64 {
+	if v.n < 2 {
+		return 0
+	}
+	return v.sumDist / float64(v.n)
+}
+
+func (d *defInfo) merge(o defInfo) {
+	d.totalWin += o.totalWin
+	d.totalPlays += o.totalPlays
+	if o.maxWin > d.maxWin {
+		d.maxWin = o.maxWin
+	}
+	if len(o.prizes) > 0 && d.prizes == nil {
+		d.prizes = make(map[string]*prizeInfo)
+	}
+	for k, p := range o.prizes {
+		if d.prizes[k] == nil {
+			d.prizes[k] = &prizeInfo{}
+		}
+		d.prizes[k].merge(*p)
+	}
+}
+
+func (p *prizeInfo) merge(o prizeInfo) {
+	p.hits += o.hits
+	p.totalWin += o.totalWin
+	if o.maxWin > p.maxWin {
+		p.maxWin = o.maxWin
+	}
+}
+
+// merge requires that both samples were taken around the same mean
+func (v *variance) merge(o variance) {
+	v.sumDist += o.sumDist
+	v.n += o.n
+}
+
+// returnStats keeps the sums needed to estimate the spread of win / stake over all spins, the spins with zero stake
+// (freespins, cascades) are part of the round they were triggered in so the ratio of the totals is used rather
+// than the mean of the per spin ratios
+type returnStats struct {
+	n     int
+	sumX  float64
+	sumY  float64
+	sumXX float64
+	sumYY float64
+	sumXY float64
+}
+
+func (r *returnStats) add(win float64, stake float64) {
+	r.n++
+	r.sumX += win
+	r.sumY += stake
+	r.sumXX += win * win
+	r.sumYY += stake * stake
+	r.sumXY += win * stake
+}
+
+func (r *returnStats) merge(o returnStats) {
+	r.n += o.n
+	r.sumX += o.sumX
+	r.sumY += o.sumY
+	r.sumXX += o.sumXX
+	r.sumYY += o.sumYY
+	r.sumXY += o.sumXY
+}
+
+func (r returnStats) ratio() float64 {
+	if r.sumY == 0 {
+		return 0
+	}
+	return r.sumX / r.sumY
+}
+
+// variance of the return of a single spin in multiples of the average stake
+func (r returnStats) variance() float64 {
+	if r.n < 2 || r.sumY == 0 {
+		return 0
+	}
+	R := r.ratio()
+	meanY := r.sumY / float64(r.n)
+	ss := r.sumXX - 2*R*r.sumXY + R*R*r.sumYY
+	if ss < 0 {
+		ss = 0
+	}
+	return ss / float64(r.n-1) / (meanY * meanY)
+}
+
+// standard error of the ratio estimate
+func (r returnStats) stdErr() float64 {
+	if r.n < 2 {
+		return 0
+	}
+	return math.Sqrt(r.variance() / float64(r.n))
+}
+
+// vtStats accumulates the results of the spins of one volume test worker
+type vtStats struct {
+	totalWin      engine.Fixed
+	totalBet      engine.Fixed
+	featureWin    engine.Fixed
+	respinWin     engine.Fixed
+	respinBet     engine.Fixed
+	ctCascades    int
+	defs          []defInfo
+	s2            variance
+	ret           returnStats
+	rounds        returnStats  // win and stake of the finished rounds, the spread of the rtp is estimated from them
+	betPerLine    engine.Fixed // bet per line the spins were played at
+	gamble        returnStats  // win and stake of the gamble steps
+	gambleMaxStep int
+	capped        int // rounds finished early because their win reached the max win
+}
+
+func newVtStats(engineConf engine.EngineConfig) vtStats {
+	return vtStats{
+		defs: make([]defInfo, len(engineConf.EngineDefs)*2),
+		s2:   variance{xBar: float64(engineConf.RTP), betMult: float64(engineConf.EngineDefs[0].StakeDivisor)},
+	}
+}
+
+func (s *vtStats) merge(o vtStats) {
+	s.totalWin += o.totalWin
+	s.totalBet += o.totalBet
+	s.featureWin += o.featureWin
+	s.respinWin += o.respinWin
+	s.respinBet += o.respinBet
+	s.ctCascades += o.ctCascades
+	s.capped += o.capped
+	if s.betPerLine == 0 {
+		s.betPerLine = o.betPerLine
+	}
+	for i := range o.defs {
+		s.defs[i].merge(o.defs[i])
+	}
+	s.s2.merge(o.s2)
+	s.ret.merge(o.ret)
+	s.rounds.merge(o.rounds)
+	s.gamble.merge(o.gamble)
+	if o.gambleMaxStep > s.gambleMaxStep {
+		s.gambleMaxStep = o.gambleMaxStep
+	}
+}
@@ -0,0 +1,114 @@
+package volumeTester
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
+)
+
+type vtSample struct {
+	defID  int
+	win    engine.Fixed
+	stake  engine.Fixed
+	prizes []engine.Prize
+}
+
+func testSamples() []vtSample {
+	samples := []vtSample{}
+	for i := 0; i < 101; i++ {
+		s := vtSample{defID: i % 3, stake: engine.Fixed(1000000)}
+		if i%4 == 0 {
+			s.prizes = []engine.Prize{{Payout: engine.Payout{Symbol: i % 5, Count: 3, Multiplier: i % 7}, Index: []string{"a:3", "b:4", "c:5"}[i%3], Multiplier: 1}}
+			s.win = engine.Fixed(100000 * (i % 7))
+		}
+		samples = append(samples, s)
+	}
+	return samples
+}
+
+func accumulate(stats *vtStats, samples []vtSample) {
+	for _, s := range samples {
+		stats.totalWin += s.win
+		stats.totalBet += s.stake
+		stats.s2.addSample(float64(s.win.ValueAsFloat()))
+		stats.ret.add(s.win.ValueAsFloat64(), s.stake.ValueAsFloat64())
+		stats.rounds.add(s.win.ValueAsFloat64(), s.stake.ValueAsFloat64())
+		stats.defs[s.defID].addPlay(s.win)
+		stats.defs[s.defID].addWins(s.prizes, 1)
+		if s.defID != 0 {
+			stats.featureWin += s.win
+		}
+	}
+}
+
+func testStats() vtStats {
+	return vtStats{
+		defs: make([]defInfo, 3),
+		s2:   variance{xBar: 0.95, betMult: 1},
+	}
+}
+
+func TestVtStatsMerge(t *testing.T) {
+	samples := testSamples()
+	for _, workers := range []int{1, 2, 3, 8} {
+		single := testStats()
+		accumulate(&single, samples)
+		merged := testStats()
+		offset := 0
+		for _, n := range shardPlays(len(samples), workers) {
+			part := testStats()
+			accumulate(&part, samples[offset:offset+n])
+			merged.merge(part)
+			offset += n
+		}
+		if offset != len(samples) {
+			t.Fatalf("%v workers: sharded %v plays, expected %v", workers, offset, len(samples))
+		}
+		if math.Abs(merged.s2.getS2()-single.s2.getS2()) > 1e-9 {
+			t.Errorf("%v workers: variance %v, expected %v", workers, merged.s2.getS2(), single.s2.getS2())
+		}
+		if merged.ret.n != single.ret.n || math.Abs(merged.ret.variance()-single.ret.variance()) > 1e-9 {
+			t.Errorf("%v workers: return variance %v, expected %v", workers, merged.ret.variance(), single.ret.variance())
+		}
+		merged.s2, single.s2 = variance{}, variance{}
+		merged.ret, single.ret = returnStats{}, returnStats{}
+		merged.rounds, single.rounds = returnStats{}, returnStats{}
+		if !reflect.DeepEqual(merged, single) {
+			t.Errorf("%v workers: merged stats %#v differ from single pass %#v", workers, merged, single)
+		}
+	}
+}
+
+func TestPrizeInfoMerge(t *testing.T) {
+	p := prizeInfo{totalWin: 10, hits: 2, maxWin: 8}
+	p.merge(prizeInfo{totalWin: 20, hits: 3, maxWin: 12})
+	if p != (prizeInfo{totalWin: 30, hits: 5, maxWin: 12}) {
+		t.Errorf("unexpected merge result %#v", p)
+	}
+}
+
+func TestReturnStats(t *testing.T) {
+	var r returnStats
+	// constant stake of 1, wins 0 and 2 alternate: rtp 1, variance of a spin 1
+	for i := 0; i < 1000; i++ {
+		r.add(float64(2*(i%2)), 1)
+	}
+	if r.ratio() != 1 {
+		t.Errorf("ratio %v, expected 1", r.ratio())
+	}
+	if math.Abs(r.variance()-1000./999.) > 1e-9 {
+		t.Errorf("variance %v, expected %v", r.variance(), 1000./999.)
+	}
+	if math.Abs(r.stdErr()-math.Sqrt(1./999.)) > 1e-9 {
+		t.Errorf("standard error %v, expected %v", r.stdErr(), math.Sqrt(1./999.))
+	}
+}
+
+func TestShardPlays(t *testing.T) {
+	shards := shardPlays(10, 4)
+	if !reflect.DeepEqual(shards, []int{3, 3, 2, 2}) {
+		t.Errorf("unexpected shards %v", shards)
+	}
+}
@@ -2,192 +2,282 @@ package volumeTester
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/config"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/engine"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/rng"
+	"gitlab.maverick-ops.com/maverick/rgs-core-v2/internal/store"
 	"gitlab.maverick-ops.com/maverick/rgs-core-v2/utils/logger"
 )
 
-type defInfo struct {
-	totalWin   engine.Fixed
-	totalPlays int
-	prizes     map[string]*prizeInfo
-	maxWin     engine.Fixed
+func GetMaxes(engineID string) {
+	refTime := time.Now()
+	engineConf := engine.BuildEngineDefs(engineID)
+	logger.Infof("finding max values for engine %v", engineID)
+	engine.GetMaxWin(engineConf)
+	logger.Infof("took %v", time.Now().Sub(refTime))
 }
 
-type prizeInfo struct {
-	totalWin int
-	hits     int
-	maxWin   int
+// ExactRTPReport holds the enumerated results of every engine def of an engine that supports it
+type ExactRTPReport struct {
+	Engine string                  `json:"engine"`
+	Defs   []engine.ExactRTPResult `json:"defs"`
+	Errors map[int]string          `json:"errors,omitempty"`
 }
 
-func (d *defInfo) addPlay(win engine.Fixed) bool {
-	d.totalWin += win
-	d.totalPlays++
-	if win > d.maxWin {
-		d.maxWin = win
-		return true
+// RunExactRTP enumerates the reels of every def of the engine, or of all engines if engineID is blank
+func RunExactRTP(engineID string, reportPath string) (failed bool) {
+	engineIDs := []string{engineID}
+	if engineID == "" {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			logger.Errorf("Failed opening current directory")
+			return true
+		}
+		files, err := ioutil.ReadDir(filepath.Join(currentDir, "internal/engine/engineConfigs"))
+		if err != nil {
+			logger.Errorf("Failed reading engineDefs")
+			return true
+		}
+		engineIDs = []string{}
+		for _, f := range files {
+			engineIDs = append(engineIDs, strings.Split(f.Name(), ".")[0])
+		}
 	}
-	return false
-}
 
-func (d *defInfo) addWins(p []engine.Prize, mul int) {
-	if d.prizes == nil {
-		d.prizes = make(map[string]*prizeInfo)
+	var reports []ExactRTPReport
+	for _, id := range engineIDs {
+		refTime := time.Now()
+		engineConf := engine.BuildEngineDefs(id)
+		report := ExactRTPReport{Engine: id, Defs: []engine.ExactRTPResult{}, Errors: map[int]string{}}
+		for i, ed := range engineConf.EngineDefs {
+			result, err := ed.ExactRTPWithMaxWin(engineConf.DefMaxWinMultiplier(i))
+			if err != nil {
+				logger.Warnf("engine %v def %v: %v", id, i, err.Error())
+				report.Errors[i] = err.Error()
+				continue
+			}
+			logger.Infof("engine %v def %v (%v): rtp %.6f%% | hit frequency %.4f%% | multiplier %.4f | cap rate %.6f%% | triggers %v", id, i, ed.Function, result.RTP*100, result.HitFrequency*100, result.Multiplier, result.CapRate*100, result.Triggers)
+			report.Defs = append(report.Defs, result)
+		}
+		logger.Infof("engine %v took %v", id, time.Now().Sub(refTime))
+		reports = append(reports, report)
 	}
 
-	for i := 0; i < len(p); i++ {
-		if d.prizes[p[i].Index] == nil {
-			d.prizes[p[i].Index] = &prizeInfo{}
+	if reportPath != "" {
+		path := strings.TrimSuffix(strings.TrimSuffix(reportPath, ".json"), ".csv") + "_exact.json"
+		b, err := json.MarshalIndent(reports, "", "  ")
+		if err == nil {
+			err = ioutil.WriteFile(path, b, 0644)
+		}
+		if err != nil {
+			logger.Errorf("Error writing exact rtp report: %v", err)
+			return true
 		}
-		win := p[i].Payout.Multiplier * p[i].Multiplier
-		d.prizes[p[i].Index].addWin(win * mul)
 	}
+	return false
+}
+
+// vtWorker plays a sequence of spins on its own rng stream, the rounds draw from the stream one after the other
+type vtWorker struct {
+	stream            *rand.Rand
+	round             *rng.Round // the rng of the rounds of the worker, it draws from stream
+	previousGamestate engine.Gamestate
+	action            string // action of new rounds, base if empty
+	betMode           string // bet mode of new rounds
+	betPerLine        engine.Fixed
+	gamble            bool // gamble every win that the engine offers to gamble
+	roundWin          float64
+	roundStake        float64 // win and stake of the spins of the round in play, it may continue in the next chunk
+}
+
+type vtSpinWriter struct {
+	lock    sync.Mutex
+	writer  *csv.Writer
+	enabled bool
 }
 
-func (p *prizeInfo) addWin(win int) {
-	p.hits++
-	p.totalWin += win
-	if win > p.maxWin {
-		p.maxWin = win
+func (w *vtSpinWriter) write(record string) {
+	if w == nil {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if !w.enabled {
+		return
+	}
+	err := w.writer.Write([]string{record})
+	if err != nil {
+		logger.Errorf("error writing to csv: %v", err)
+		w.enabled = false
+		logger.Errorf("stoping perspin results")
 	}
 }
 
-func GetMaxes(engineID string) {
-	refTime := time.Now()
-	engineConf := engine.BuildEngineDefs(engineID)
-	logger.Infof("finding max values for engine %v", engineID)
-	engine.GetMaxWin(engineConf)
-	logger.Infof("took %v", time.Now().Sub(refTime))
+func (w *vtWorker) run(engineConf engine.EngineConfig, numPlays int, stats *vtStats, spinWriter *vtSpinWriter) {
+	stats.betPerLine = w.betPerLine
+	for j := 0; j < numPlays; j++ {
+		var params engine.GameParams
+		//if strings.Contains(previousGamestate.Action,"freespin") || (j == 0 && i == 0) {
+		params.Action = "base" // change this to maxBase or to any other special function for a particular wallet to see special RTP
+		if w.action != "" {
+			params.Action = w.action
+		}
+		params.BetMode = w.betMode
+		//} else {
+		//	params.Action = "respin"
+		//	params.RespinReel = rng.RandFromRange(5)
+		//	logger.Warnf("Need to uncomment the next line and the SetPG function for this to work for respin engine")
+		//	//params.SetPG(previousGamestate)
+		//}
+		if w.previousGamestate.NextActions[0] == "cascade" {
+			params.Action = "cascade"
+		}
+		if w.gamble {
+			if _, err := engineConf.GambleOffer(w.previousGamestate); err == nil {
+				// the choice and the collect half option are drawn so that every option is played
+				params.Action = engine.GambleAction
+				if choices := engineConf.Gamble.Choices(); len(choices) > 0 {
+					params.Selection = choices[w.stream.Intn(len(choices))]
+				}
+				params.GambleHalf = engineConf.Gamble.CollectHalf && w.stream.Intn(2) == 0
+			}
+		}
+		if w.previousGamestate.NextActions[0] == "pickSpins" {
+			// user action is required (we are assuming here this is engine II, update later if more choice engines added)
+			params.Selection = []string{"freespin25:25", "freespin10:10", "freespin5:5"}[engine.SelectFromWeightedOptions(w.round, []int{0, 1, 2}, []int{1, 1, 1})]
+			// we do not add any selected win lines, always assume all lines. NB: ENGINE X has variable RTP based on selected win lines
+		}
+		params.Rng = w.round
+		gamestate, _, _ := engine.Play(w.previousGamestate, w.betPerLine, "BTC", params)
+		currentWinnings, currentStake := engine.GetCurrentWinAndStake(gamestate)
+		if gamestate.Gamble != nil {
+			// gamble steps are reported on their own, they are not part of the rtp of the rounds
+			stats.gamble.add(currentWinnings.ValueAsFloat64(), currentStake.ValueAsFloat64())
+			if gamestate.Gamble.Step > stats.gambleMaxStep {
+				stats.gambleMaxStep = gamestate.Gamble.Step
+			}
+			w.previousGamestate = gamestate
+			continue
+		}
+		stats.totalWin += currentWinnings
+		stats.totalBet += currentStake
+		if gamestate.Capped {
+			stats.capped++
+		}
+		stats.s2.addSample(float64(currentWinnings.ValueAsFloat()))
+		stats.ret.add(currentWinnings.ValueAsFloat64(), currentStake.ValueAsFloat64())
+		// the spins of a round are not independent, a round is counted once its freespins and cascades are played
+		w.roundWin += currentWinnings.ValueAsFloat64()
+		w.roundStake += currentStake.ValueAsFloat64()
+		if len(gamestate.NextActions) == 1 && gamestate.NextActions[0] == "finish" {
+			stats.rounds.add(w.roundWin, w.roundStake)
+			w.roundWin, w.roundStake = 0, 0
+		}
+		// compile hit frequencies
+		defID := gamestate.DefID
+		if gamestate.Action == "cascade" {
+			defID += len(engineConf.EngineDefs)
+		}
+		newmax := stats.defs[defID].addPlay(currentWinnings)
+		if newmax {
+			logger.Debugf("new max for engine %v: %#v", defID, gamestate)
+		}
+		stats.defs[defID].addWins(gamestate.Prizes, gamestate.Multiplier)
+
+		if gamestate.Action != "base" {
+			if gamestate.Action == "cascade" {
+				stats.ctCascades++
+			}
+			stats.featureWin = stats.featureWin.Add(currentWinnings)
+			if gamestate.Action == "respin" {
+				stats.respinBet += currentStake
+				stats.respinWin += currentWinnings
+			}
+		}
+		if spinWriter != nil {
+			spinWriter.write(fmt.Sprintf("%v,%v,%v,%v,%v,%v", defID, gamestate.Action, time.Now().Format("02 Jan 06 15:04 MST"), currentStake, currentWinnings, gamestate.StopList))
+		}
+
+		w.previousGamestate = gamestate
+	}
 }
 
-func VolumeTestEngine(engineID string, numPlays int, chunks int, perSpin bool) ([]string, bool) {
+// shardPlays splits the plays of a chunk over the workers
+func shardPlays(numPlays int, workers int) []int {
+	shards := make([]int, workers)
+	for i := range shards {
+		shards[i] = numPlays / workers
+		if i < numPlays%workers {
+			shards[i]++
+		}
+	}
+	return shards
+}
+
+// VolumeTestEngine plays numPlays spins of the engine and reports the results, the engine passes if the configured
+// RTP lies within the 99.9% confidence interval of the measured RTP and is reported deviant outside the 95% interval
+func VolumeTestEngine(engineID string, numPlays int, chunks int, perSpin bool, workers int) ([]string, VTReport) {
+	switch config.GetCategoryFromEngine(engineID) {
+	case store.GameCategoryBlackjack:
+		// the rounds of blackjack are played step by step with basic strategy
+		return VolumeTestBlackjack(engineID, numPlays, workers)
+	case store.GameCategoryScratch:
+		return VolumeTestScratch(engineID, numPlays, workers)
+	}
 	refTime := time.Now()
-	failed := true
-	totalWin := engine.Fixed(0)
-	totalBet := engine.Fixed(0)
-	var writer *csv.Writer
+	var report VTReport
+	var spinWriter *vtSpinWriter
 	if perSpin == true {
 		outFile := fmt.Sprintf("%v_%v.csv", engineID, time.Now().Format("2006-01-02"))
 		file, err := os.Create(outFile)
 		if err != nil {
-			perSpin = false
 			logger.Errorf("Could not open file %v -- not saving perSpin results", outFile)
 		} else {
 			defer file.Close()
-			writer = csv.NewWriter(file)
+			spinWriter = &vtSpinWriter{writer: csv.NewWriter(file), enabled: true}
+			defer spinWriter.writer.Flush()
 		}
-		defer writer.Flush()
 	}
 	engineConf := engine.BuildEngineDefs(engineID)
 	if numPlays == 0 {
 		numPlays = engineConf.NumSpinsStat()
 	}
+	if workers < 1 {
+		workers = 1
+	}
 
-	s2 := variance{xBar: float64(engineConf.RTP), betMult: float64(engineConf.EngineDefs[0].StakeDivisor)}
-
-	logger.Infof("Running %v spins for engine %v", numPlays, engineID)
+	logger.Infof("Running %v spins for engine %v on %v workers", numPlays, engineID, workers)
 
 	chunkSize := numPlays / chunks
-	infoStructs := make([]defInfo, len(engineConf.EngineDefs)*2)
+	total := newVtStats(engineConf)
 
 	initString := fmt.Sprintf("Running %v spins in %v chunks for %v \n Expected RTP: %v \n Volatility: %v\n", numPlays, chunks, engineID, engineConf.RTP, engineConf.Volatility)
 	vtInfo := []string{initString, "Chunk || RTP || RTP Feature || RTP base \n"}
-	featureWin := engine.Fixed(0)
-	respinWin := engine.Fixed(0)
-	respinBet := engine.Fixed(0)
 	featureHits := 0
-	ctCascades := 0
-	previousGamestate := engine.Gamestate{NextActions: []string{"finish"}, Game: getMatchingGame(engineID), DefID: 0, NextGamestate: "FirstSpinVT" + engineID}
+	vtWorkers := newVtWorkers(engineID, workers, "", "")
 	for i := 0; i < chunks; i++ {
+		total.merge(runVtWorkers(vtWorkers, engineConf, chunkSize, spinWriter))
 
-		for j := 0; j < chunkSize; j++ {
-			var params engine.GameParams
-			//if strings.Contains(previousGamestate.Action,"freespin") || (j == 0 && i == 0) {
-			params.Action = "base" // change this to maxBase or to any other special function for a particular wallet to see special RTP
-			//} else {
-			//	params.Action = "respin"
-			//	params.RespinReel = rng.RandFromRange(5)
-			//	logger.Warnf("Need to uncomment the next line and the SetPG function for this to work for respin engine")
-			//	//params.SetPG(previousGamestate)
-			//}
-			if previousGamestate.NextActions[0] == "cascade" {
-				params.Action = "cascade"
-			}
-			if previousGamestate.NextActions[0] == "pickSpins" {
-				// user action is required (we are assuming here this is engine II, update later if more choice engines added)
-				params.Selection = []string{"freespin25:25", "freespin10:10", "freespin5:5"}[engine.SelectFromWeightedOptions([]int{0, 1, 2}, []int{1, 1, 1})]
-				// we do not add any selected win lines, always assume all lines. NB: ENGINE X has variable RTP based on selected win lines
-			}
-			gamestate, _, _ := engine.Play(previousGamestate, engine.Fixed(1000), "BTC", params)
-			currentWinnings, currentStake := engine.GetCurrentWinAndStake(gamestate)
-			//if strings.Contains(gamestate.Action, "freespin") {
-			//	logger.Warnf("winnings: %v, gamesate: %v", currentWinnings, gamestate)
-			//
-			//}
-			totalWin += currentWinnings
-			totalBet += currentStake
-			s2.addSample(float64(currentWinnings.ValueAsFloat()))
-			// compile hit frequencies
-			defID := gamestate.DefID
-			if gamestate.Action == "cascade" {
-				defID += len(engineConf.EngineDefs)
-			}
-			newmax := infoStructs[defID].addPlay(currentWinnings)
-			if newmax {
-				logger.Debugf("new max for engine %v: %#v", defID, gamestate)
-			}
-			infoStructs[defID].addWins(gamestate.Prizes, gamestate.Multiplier)
-
-			if gamestate.Action != "base" {
-				if gamestate.Action == "cascade" {
-					ctCascades++
-				}
-				featureWin = featureWin.Add(currentWinnings)
-				if gamestate.Action == "respin" {
-					respinBet += currentStake
-					respinWin += currentWinnings
-				}
-			}
-			if perSpin == true {
-				err := writer.Write([]string{fmt.Sprintf("%v,%v,%v,%v,%v,%v", defID, gamestate.Action, time.Now().Format("02 Jan 06 15:04 MST"), currentStake, currentWinnings, gamestate.StopList)})
-				if err != nil {
-					logger.Errorf("error writing to csv: %v", err)
-					perSpin = false
-					logger.Errorf("stoping perspin results")
-				}
-				//if len(gamestate.RecoveryGrid) > 0 {
-				//	for r:=0; r<len(gamestate.RecoveryGrid); r++{
-				//
-				//		err = writer.Write([]string{fmt.Sprintf("%v,%v,%v,%v -- DISCARDED", defID, gamestate.Action, time.Now().Format("02 Jan 06 15:04 MST"), gamestate.RecoveryGrid[r])})
-				//		if err != nil {
-				//			logger.Errorf("error writing to csv: %v", err)
-				//			perSpin = false
-				//			logger.Errorf("stoping perspin results")
-				//		}
-				//	}
-				//}
-			}
-
-			previousGamestate = gamestate
-		}
-		RTP := totalWin.Div(totalBet)
-		RTPBase := totalWin.Sub(featureWin).Div(totalBet)
-		RTPFeature := featureWin.Div(totalBet)
-		//RTPRespin := respinWin.Div(respinBet)
+		totalBet := total.totalBet
+		RTP := total.totalWin.Div(totalBet)
+		RTPBase := total.totalWin.Sub(total.featureWin).Div(totalBet)
+		RTPFeature := total.featureWin.Div(totalBet)
+		//RTPRespin := total.respinWin.Div(total.respinBet)
 		// fsPct := float64(fsTriggers) / (float64(chunkSize) * float64(i+1))
 		ftInfo := ""
 		//logger.Infof("avg feature multiplier: %v%%", float64(featureMultiplier)/float64(ftTriggers[1]["rounds"])*100)
-		for rsID, reelsetInfo := range infoStructs {
+		for rsID, reelsetInfo := range total.defs {
 			//logger.Infof("info: %v", reelsetInfo)
 			if reelsetInfo.totalPlays == 0 {
 				continue
@@ -201,64 +291,140 @@ func VolumeTestEngine(engineID string, numPlays int, chunks int, perSpin bool) (
 				ftInfo += fmt.Sprintf("%v ==  %.2f%% | RTP %.2f%% | max win: %v\n", x, float64(prizeInfo.hits)/float64(reelsetInfo.totalPlays)*100, engine.Fixed(prizeInfo.totalWin).Div(totalBet).ValueAsFloat()*100, prizeInfo.maxWin)
 			}
 		}
-		logger.Infof("FS HIT RATE: %v in %v", featureHits, infoStructs[0].totalPlays)
+		logger.Infof("FS HIT RATE: %v in %v", featureHits, total.defs[0].totalPlays)
 
-		chunkInfo := fmt.Sprintf(" %v | RTP: %v%% | Feature: %v%% | Base: %v%% | Variance: %.5f | \n %v \n", i+1, RTP.ValueAsFloat()*100., RTPFeature.ValueAsFloat()*100., RTPBase.ValueAsFloat()*100., s2.getS2(), ftInfo)
+		chunkInfo := fmt.Sprintf(" %v | RTP: %v%% | Feature: %v%% | Base: %v%% | Variance: %.5f | \n %v \n", i+1, RTP.ValueAsFloat()*100., RTPFeature.ValueAsFloat()*100., RTPBase.ValueAsFloat()*100., total.s2.getS2(), ftInfo)
 		vtInfo = append(vtInfo, chunkInfo)
 		//float64(featureMultiplier)/float64(ftTriggersFeature["rounds"]), float64(wildCounts)/float64(ftTriggersFeature["rounds"])
 
-		if math.Abs(float64(RTP.Sub(engine.NewFixedFromFloat(engineConf.RTP)).ValueAsFloat())) > 0.01 {
-			logger.Warnf("WARNING : RTP DEVIANT (%.2f%%)", RTP.ValueAsFloat()*100)
+		report = newVTReport(engineID, engineConf, total, workers)
+		if report.RTPDeviant {
+			logger.Warnf("WARNING : RTP DEVIANT (%.2f%%, 95%% confidence interval %.2f%% - %.2f%%)", report.RTP*100, report.RTPLow*100, report.RTPHigh*100)
+			logger.Infof(chunkInfo)
+		} else if i == chunks-1 {
 			logger.Infof(chunkInfo)
-		} else {
-			failed = false
-			if i == chunks-1 {
-				logger.Infof(chunkInfo)
-			}
 		}
 		//logger.Warnf("Respin RTP: %v", RTPRespin.ValueAsFloat()*100)
 
-		//logger.Warnf("%v cascades total", ctCascades)
+		//logger.Warnf("%v cascades total", total.ctCascades)
 		logger.Infof("Chunk %v done in %v", i+1, time.Now().Sub(refTime))
 		refTime = time.Now()
 	}
-	return vtInfo, failed
-}
 
-func getMatchingGame(engineID string) string {
-	// function to get a game name that matches the given engine
-	for i := 0; i < len(config.GlobalGameConfig); i++ {
-		if config.GlobalGameConfig[i].EngineID == engineID {
-			return config.GlobalGameConfig[i].Games[0].Name
+	if !report.Passed {
+		logger.Errorf("expected RTP of engine %v lies outside the 99.9%% confidence interval of the measured RTP %.2f%%", engineID, report.RTP*100)
+	}
+	if engineConf.MaxWinMultiplier > 0 {
+		capInfo := fmt.Sprintf("Max win %vx | Capped rounds: %v in %v spins\n", engineConf.MaxWinMultiplier, report.CappedRounds, report.Spins)
+		logger.Infof(capInfo)
+		vtInfo = append(vtInfo, capInfo)
+	}
+	if engineConf.HasBuyFeature() {
+		// rounds started by buying the feature are played separately so that their rtp can be reported on its own
+		logger.Infof("Running %v spins of bought features for engine %v", numPlays, engineID)
+		buyStats := runVtWorkers(newVtWorkers(engineID, workers, engine.BuyFeatureAction, ""), engineConf, numPlays, spinWriter)
+		report.BuyFeature = newVTBuyReport(engineConf, buyStats)
+		report.Passed = report.Passed && report.BuyFeature.Passed
+		buyInfo := fmt.Sprintf("Buy feature | Price: %.4f | Rounds: %v | RTP: %.4f%% | 95%% confidence interval %.4f%% - %.4f%%\n", report.BuyFeature.PriceMultiplier, report.BuyFeature.Rounds, report.BuyFeature.RTP*100, report.BuyFeature.RTPLow*100, report.BuyFeature.RTPHigh*100)
+		if report.BuyFeature.RTPDeviant {
+			logger.Warnf("WARNING : BUY FEATURE RTP DEVIANT")
 		}
+		logger.Infof(buyInfo)
+		vtInfo = append(vtInfo, buyInfo)
 	}
-	return ""
+	if engineConf.HasGamble() {
+		// the wins of the rounds are gambled in a separate pass, the rtp of the gamble steps is measured against their stakes
+		logger.Infof("Running %v spins gambling the wins for engine %v", numPlays, engineID)
+		gambleWorkers := newVtWorkers(engineID, workers, "", "")
+		for w := range gambleWorkers {
+			gambleWorkers[w].gamble = true
+			gambleWorkers[w].betPerLine = vtPricedBetPerLine
+		}
+		gambleStats := runVtWorkers(gambleWorkers, engineConf, numPlays, spinWriter)
+		report.Gamble = newVTGambleReport(engineConf, gambleStats)
+		report.Passed = report.Passed && report.Gamble.Passed
+		gambleInfo := fmt.Sprintf("Gamble %v | Steps: %v | Max step: %v | RTP: %.4f%% | 95%% confidence interval %.4f%% - %.4f%%\n", report.Gamble.Mode, report.Gamble.Steps, report.Gamble.MaxStep, report.Gamble.RTP*100, report.Gamble.RTPLow*100, report.Gamble.RTPHigh*100)
+		if report.Gamble.RTPDeviant {
+			logger.Warnf("WARNING : GAMBLE RTP DEVIANT")
+		}
+		logger.Infof(gambleInfo)
+		vtInfo = append(vtInfo, gambleInfo)
+	}
+	for _, mode := range engineConf.BetModes {
+		// every bet mode is played separately, its rtp is measured against the cost of its rounds
+		logger.Infof("Running %v spins in bet mode %v for engine %v", numPlays, mode.Name, engineID)
+		modeStats := runVtWorkers(newVtWorkers(engineID, workers, "", mode.Name), engineConf, numPlays, spinWriter)
+		modeReport := newVTReport(engineID, engineConf, modeStats, workers)
+		modeReport.BetMode = mode.Name
+		report.BetModes = append(report.BetModes, modeReport)
+		report.Passed = report.Passed && modeReport.Passed
+		modeInfo := fmt.Sprintf("Bet mode %v | Cost: %.4f | RTP: %.4f%% | Feature: %.4f%% | 95%% confidence interval %.4f%% - %.4f%%\n", mode.Name, mode.Cost, modeReport.RTP*100, modeReport.RTPFeature*100, modeReport.RTPLow*100, modeReport.RTPHigh*100)
+		if modeReport.RTPDeviant {
+			logger.Warnf("WARNING : BET MODE %v RTP DEVIANT", mode.Name)
+		}
+		logger.Infof(modeInfo)
+		vtInfo = append(vtInfo, modeInfo)
+	}
+	return vtInfo, report
 }
 
-type variance struct {
-	xBar    float64
-	sumDist float64
-	n       int
-	betMult float64
+func newVtWorkers(engineID string, workers int, action string, betMode string) []vtWorker {
+	// rounds with a price are played at a larger bet so that rounding the price up to the currency unit does not bias the rtp
+	betPerLine := vtBetPerLine
+	if action != "" || betMode != "" {
+		betPerLine = vtPricedBetPerLine
+	}
+	vtWorkers := make([]vtWorker, workers)
+	for w := range vtWorkers {
+		stream := rng.Seeded(rng.NewSeed())
+		vtWorkers[w] = vtWorker{
+			stream:            stream,
+			round:             rng.StreamRound(stream),
+			previousGamestate: engine.Gamestate{NextActions: []string{"finish"}, Game: getMatchingGame(engineID), DefID: 0, NextGamestate: fmt.Sprintf("FirstSpinVT%v_%v", engineID, w)},
+			action:            action,
+			betMode:           betMode,
+			betPerLine:        betPerLine,
+		}
+	}
+	return vtWorkers
 }
 
-func (v *variance) addSample(val float64) {
-	val = val / v.betMult
-	v.sumDist += math.Pow(val-v.xBar, 2)
-	v.n++
+// runVtWorkers shares numPlays over the workers and merges their stats
+func runVtWorkers(vtWorkers []vtWorker, engineConf engine.EngineConfig, numPlays int, spinWriter *vtSpinWriter) vtStats {
+	shards := shardPlays(numPlays, len(vtWorkers))
+	results := make([]vtStats, len(vtWorkers))
+	var wg sync.WaitGroup
+	for w := range vtWorkers {
+		results[w] = newVtStats(engineConf)
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			vtWorkers[w].run(engineConf, shards[w], &results[w], spinWriter)
+		}(w)
+	}
+	wg.Wait()
+	total := newVtStats(engineConf)
+	for w := range results {
+		total.merge(results[w])
+	}
+	return total
 }
 
-func (v *variance) getS2() float64 {
-	if v.n < 2 {
-		return 0
+func getMatchingGame(engineID string) string {
+	// function to get a game name that matches the given engine
+	for i := 0; i < len(config.GlobalGameConfig); i++ {
+		if config.GlobalGameConfig[i].EngineID == engineID {
+			return config.GlobalGameConfig[i].Games[0].Name
+		}
 	}
-	return v.sumDist / float64(v.n)
+	return ""
 }
 
-func RunVT(engineID string, spins int, chunks int, perSpin bool, maxes bool) (failed bool) {
+func RunVT(engineID string, spins int, chunks int, perSpin bool, maxes bool, workers int, reportPath string) (failed bool) {
 	// Run VT from command line
 
 	var results []string
+	var reports []VTReport
 	failed = false
 	if engineID != "" {
 		// run VT on one engine
@@ -273,7 +439,10 @@ func RunVT(engineID string, spins int, chunks int, perSpin bool, maxes bool) (fa
 		if maxes {
 			GetMaxes(engineID)
 		} else {
-			results, failed = VolumeTestEngine(engineID, spins, chunks, perSpin)
+			var report VTReport
+			results, report = VolumeTestEngine(engineID, spins, chunks, perSpin, workers)
+			failed = !report.Passed
+			reports = append(reports, report)
 		}
 	} else {
 		currentDir, err := os.Getwd()
@@ -293,14 +462,22 @@ func RunVT(engineID string, spins int, chunks int, perSpin bool, maxes bool) (fa
 			if maxes {
 				GetMaxes(strings.Split(engines.Name(), ".")[0])
 			} else {
-				newResults, newFail := VolumeTestEngine(strings.Split(engines.Name(), ".")[0], spins, chunks, perSpin)
-				failed = failed || newFail
+				newResults, report := VolumeTestEngine(strings.Split(engines.Name(), ".")[0], spins, chunks, perSpin, workers)
+				failed = failed || !report.Passed
 				results = append(results, newResults...)
+				reports = append(reports, report)
 			}
 
 		}
 	}
 	//fmt.Print(results)
+	if reportPath != "" && len(reports) > 0 {
+		err := WriteVTReports(reports, reportPath)
+		if err != nil {
+			logger.Errorf("Error writing VT report: %v", err)
+			return true
+		}
+	}
 	return
 }
 